@@ -0,0 +1,46 @@
+package v2action
+
+import (
+	"time"
+
+	"code.cloudfoundry.org/cli/api/uaa"
+)
+
+// AccessTokenInfo is the decoded content of an access token.
+type AccessTokenInfo struct {
+	UserName  string
+	ClientID  string
+	GrantType string
+	Issuer    string
+	Scopes    []string
+	IssuedAt  time.Time
+	ExpiresAt time.Time
+}
+
+// RefreshAccessToken refreshes the current user's access token and returns
+// the new token.
+func (actor Actor) RefreshAccessToken() (string, error) {
+	err := actor.UAAClient.RefreshToken()
+	if err != nil {
+		return "", err
+	}
+	return actor.UAAClient.AccessToken(), nil
+}
+
+// GetAccessTokenInfo decodes the claims of the given access token.
+func (actor Actor) GetAccessTokenInfo(accessToken string) (AccessTokenInfo, error) {
+	claims, err := uaa.ParseAccessToken(accessToken)
+	if err != nil {
+		return AccessTokenInfo{}, err
+	}
+
+	return AccessTokenInfo{
+		UserName:  claims.UserName,
+		ClientID:  claims.ClientID,
+		GrantType: claims.GrantType,
+		Issuer:    claims.Issuer,
+		Scopes:    claims.Scopes,
+		IssuedAt:  claims.IssuedAtTime(),
+		ExpiresAt: claims.ExpiresAt(),
+	}, nil
+}
@@ -0,0 +1,86 @@
+package v2action_test
+
+import (
+	"encoding/base64"
+	"errors"
+	"time"
+
+	. "code.cloudfoundry.org/cli/actor/v2action"
+	"code.cloudfoundry.org/cli/actor/v2action/v2actionfakes"
+	"code.cloudfoundry.org/cli/api/uaa"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Access Token Actions", func() {
+	var (
+		actor         Actor
+		fakeUAAClient *v2actionfakes.FakeUAAClient
+	)
+
+	BeforeEach(func() {
+		fakeUAAClient = new(v2actionfakes.FakeUAAClient)
+		actor = NewActor(nil, fakeUAAClient)
+	})
+
+	Describe("RefreshAccessToken", func() {
+		Context("when the token is refreshed", func() {
+			BeforeEach(func() {
+				fakeUAAClient.AccessTokenReturns("bearer some-new-token")
+			})
+
+			It("returns the new token", func() {
+				token, err := actor.RefreshAccessToken()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(token).To(Equal("bearer some-new-token"))
+				Expect(fakeUAAClient.RefreshTokenCallCount()).To(Equal(1))
+			})
+		})
+
+		Context("when the refresh fails", func() {
+			var expectedErr error
+
+			BeforeEach(func() {
+				expectedErr = errors.New("some-error")
+				fakeUAAClient.RefreshTokenReturns(expectedErr)
+			})
+
+			It("returns the error", func() {
+				_, err := actor.RefreshAccessToken()
+				Expect(err).To(MatchError(expectedErr))
+			})
+		})
+	})
+
+	Describe("GetAccessTokenInfo", func() {
+		Context("when the token can be decoded", func() {
+			var token string
+
+			BeforeEach(func() {
+				claims := `{"user_name":"some-user","client_id":"cf","grant_type":"password","iss":"some-issuer","scope":["openid"],"iat":1500000000,"exp":1500000600}`
+				token = "bearer header." + base64.RawURLEncoding.EncodeToString([]byte(claims)) + ".signature"
+			})
+
+			It("returns the token info", func() {
+				info, err := actor.GetAccessTokenInfo(token)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(info).To(Equal(AccessTokenInfo{
+					UserName:  "some-user",
+					ClientID:  "cf",
+					GrantType: "password",
+					Issuer:    "some-issuer",
+					Scopes:    []string{"openid"},
+					IssuedAt:  time.Unix(1500000000, 0),
+					ExpiresAt: time.Unix(1500000600, 0),
+				}))
+			})
+		})
+
+		Context("when the token cannot be decoded", func() {
+			It("returns an InvalidAccessTokenError", func() {
+				_, err := actor.GetAccessTokenInfo("bearer some-opaque-token")
+				Expect(err).To(MatchError(uaa.InvalidAccessTokenError{}))
+			})
+		})
+	})
+})
@@ -0,0 +1,70 @@
+package v2action
+
+import (
+	"time"
+
+	"code.cloudfoundry.org/cli/api/uaa"
+)
+
+// Authenticate logs the user in with their username and password. The
+// previous user's tokens and targeted organization and space are removed
+// first.
+func (actor Actor) Authenticate(config Config, username string, password string) error {
+	config.UnsetUserInformation()
+
+	accessToken, refreshToken, err := actor.UAAClient.Authenticate(username, password)
+	if err != nil {
+		return err
+	}
+
+	config.SetAccessToken(accessToken)
+	config.SetRefreshToken(refreshToken)
+	return nil
+}
+
+// AuthenticateClientCredentials logs in as the given client with the
+// client_credentials grant. The client credentials are stored so that the
+// access token can be renewed once it expires.
+func (actor Actor) AuthenticateClientCredentials(config Config, clientID string, clientSecret string) error {
+	config.UnsetUserInformation()
+
+	accessToken, refreshToken, err := actor.UAAClient.AuthenticateWithClientCredentials(clientID, clientSecret)
+	if err != nil {
+		return err
+	}
+
+	config.SetUAAGrantType(uaa.GrantTypeClientCredentials)
+	config.SetUAAClientCredentials(clientID, clientSecret)
+	config.SetAccessToken(accessToken)
+	config.SetRefreshToken(refreshToken)
+	return nil
+}
+
+// AuthenticatePasscode logs the user in with a one-time passcode.
+func (actor Actor) AuthenticatePasscode(config Config, passcode string) error {
+	config.UnsetUserInformation()
+
+	accessToken, refreshToken, err := actor.UAAClient.AuthenticateWithPasscode(passcode)
+	if err != nil {
+		return err
+	}
+
+	config.SetAccessToken(accessToken)
+	config.SetRefreshToken(refreshToken)
+	return nil
+}
+
+// AuthenticateWithBrowser logs the user in by having them authorize the CLI
+// in a browser. openURL is given the URL the user needs to visit.
+func (actor Actor) AuthenticateWithBrowser(config Config, authorizationEndpoint string, timeout time.Duration, openURL func(authorizationURL string) error) error {
+	config.UnsetUserInformation()
+
+	accessToken, refreshToken, err := actor.UAAClient.AuthenticateWithBrowser(authorizationEndpoint, timeout, openURL)
+	if err != nil {
+		return err
+	}
+
+	config.SetAccessToken(accessToken)
+	config.SetRefreshToken(refreshToken)
+	return nil
+}
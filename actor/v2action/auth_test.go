@@ -0,0 +1,137 @@
+package v2action_test
+
+import (
+	"errors"
+	"time"
+
+	. "code.cloudfoundry.org/cli/actor/v2action"
+	"code.cloudfoundry.org/cli/actor/v2action/v2actionfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Auth Actions", func() {
+	var (
+		actor         Actor
+		fakeUAAClient *v2actionfakes.FakeUAAClient
+		fakeConfig    *v2actionfakes.FakeConfig
+		expectedErr   error
+	)
+
+	BeforeEach(func() {
+		fakeUAAClient = new(v2actionfakes.FakeUAAClient)
+		fakeConfig = new(v2actionfakes.FakeConfig)
+		actor = NewActor(nil, fakeUAAClient)
+		expectedErr = errors.New("some-error")
+	})
+
+	Describe("Authenticate", func() {
+		Context("when the credentials are valid", func() {
+			BeforeEach(func() {
+				fakeUAAClient.AuthenticateReturns("bearer some-access-token", "some-refresh-token", nil)
+			})
+
+			It("stores the tokens after removing the previous user", func() {
+				err := actor.Authenticate(fakeConfig, "some-user", "some-password")
+				Expect(err).ToNot(HaveOccurred())
+
+				username, password := fakeUAAClient.AuthenticateArgsForCall(0)
+				Expect(username).To(Equal("some-user"))
+				Expect(password).To(Equal("some-password"))
+
+				Expect(fakeConfig.UnsetUserInformationCallCount()).To(Equal(1))
+				Expect(fakeConfig.SetAccessTokenArgsForCall(0)).To(Equal("bearer some-access-token"))
+				Expect(fakeConfig.SetRefreshTokenArgsForCall(0)).To(Equal("some-refresh-token"))
+				Expect(fakeConfig.SetUAAGrantTypeCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when authentication fails", func() {
+			BeforeEach(func() {
+				fakeUAAClient.AuthenticateReturns("", "", expectedErr)
+			})
+
+			It("returns the error without storing tokens", func() {
+				err := actor.Authenticate(fakeConfig, "some-user", "some-password")
+				Expect(err).To(MatchError(expectedErr))
+				Expect(fakeConfig.UnsetUserInformationCallCount()).To(Equal(1))
+				Expect(fakeConfig.SetAccessTokenCallCount()).To(Equal(0))
+			})
+		})
+	})
+
+	Describe("AuthenticateClientCredentials", func() {
+		Context("when the credentials are valid", func() {
+			BeforeEach(func() {
+				fakeUAAClient.AuthenticateWithClientCredentialsReturns("bearer some-access-token", "", nil)
+			})
+
+			It("stores the token, the grant type and the client credentials", func() {
+				err := actor.AuthenticateClientCredentials(fakeConfig, "some-client", "some-secret")
+				Expect(err).ToNot(HaveOccurred())
+
+				clientID, clientSecret := fakeUAAClient.AuthenticateWithClientCredentialsArgsForCall(0)
+				Expect(clientID).To(Equal("some-client"))
+				Expect(clientSecret).To(Equal("some-secret"))
+
+				Expect(fakeConfig.SetUAAGrantTypeArgsForCall(0)).To(Equal("client_credentials"))
+				storedClient, storedSecret := fakeConfig.SetUAAClientCredentialsArgsForCall(0)
+				Expect(storedClient).To(Equal("some-client"))
+				Expect(storedSecret).To(Equal("some-secret"))
+				Expect(fakeConfig.SetAccessTokenArgsForCall(0)).To(Equal("bearer some-access-token"))
+			})
+		})
+
+		Context("when authentication fails", func() {
+			BeforeEach(func() {
+				fakeUAAClient.AuthenticateWithClientCredentialsReturns("", "", expectedErr)
+			})
+
+			It("returns the error without storing the client credentials", func() {
+				err := actor.AuthenticateClientCredentials(fakeConfig, "some-client", "some-secret")
+				Expect(err).To(MatchError(expectedErr))
+				Expect(fakeConfig.SetUAAClientCredentialsCallCount()).To(Equal(0))
+			})
+		})
+	})
+
+	Describe("AuthenticatePasscode", func() {
+		BeforeEach(func() {
+			fakeUAAClient.AuthenticateWithPasscodeReturns("bearer some-access-token", "some-refresh-token", nil)
+		})
+
+		It("stores the tokens", func() {
+			err := actor.AuthenticatePasscode(fakeConfig, "some-passcode")
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(fakeUAAClient.AuthenticateWithPasscodeArgsForCall(0)).To(Equal("some-passcode"))
+			Expect(fakeConfig.SetAccessTokenArgsForCall(0)).To(Equal("bearer some-access-token"))
+			Expect(fakeConfig.SetRefreshTokenArgsForCall(0)).To(Equal("some-refresh-token"))
+		})
+	})
+
+	Describe("AuthenticateWithBrowser", func() {
+		BeforeEach(func() {
+			fakeUAAClient.AuthenticateWithBrowserStub = func(_ string, _ time.Duration, openURL func(string) error) (string, string, error) {
+				return "bearer some-access-token", "some-refresh-token", openURL("https://login.example.com/oauth/authorize")
+			}
+		})
+
+		It("passes the URL to open and stores the tokens", func() {
+			var openedURL string
+			err := actor.AuthenticateWithBrowser(fakeConfig, "https://login.example.com", time.Minute, func(url string) error {
+				openedURL = url
+				return nil
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(openedURL).To(Equal("https://login.example.com/oauth/authorize"))
+
+			endpoint, timeout, _ := fakeUAAClient.AuthenticateWithBrowserArgsForCall(0)
+			Expect(endpoint).To(Equal("https://login.example.com"))
+			Expect(timeout).To(Equal(time.Minute))
+
+			Expect(fakeConfig.SetAccessTokenArgsForCall(0)).To(Equal("bearer some-access-token"))
+			Expect(fakeConfig.SetRefreshTokenArgsForCall(0)).To(Equal("some-refresh-token"))
+		})
+	})
+})
@@ -0,0 +1,12 @@
+package v2action
+
+//go:generate counterfeiter . Config
+
+// Config a way of getting and setting the authentication information
+type Config interface {
+	SetAccessToken(token string)
+	SetRefreshToken(token string)
+	SetUAAClientCredentials(client string, clientSecret string)
+	SetUAAGrantType(uaaGrantType string)
+	UnsetUserInformation()
+}
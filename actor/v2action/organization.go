@@ -0,0 +1,40 @@
+package v2action
+
+import (
+	"fmt"
+
+	"code.cloudfoundry.org/cli/api/cloudcontroller/ccv2"
+)
+
+type Organization ccv2.Organization
+
+type OrganizationNotFoundError struct {
+	Name string
+}
+
+func (e OrganizationNotFoundError) Error() string {
+	return fmt.Sprintf("Organization '%s' not found.", e.Name)
+}
+
+// GetOrganizationByName returns the organization with the provided name.
+func (actor Actor) GetOrganizationByName(name string) (Organization, Warnings, error) {
+	orgs, warnings, err := actor.CloudControllerClient.GetOrganizations([]ccv2.Query{
+		ccv2.Query{
+			Filter:   ccv2.NameFilter,
+			Operator: ccv2.EqualOperator,
+			Value:    name,
+		},
+	})
+
+	if err != nil {
+		return Organization{}, Warnings(warnings), err
+	}
+
+	if len(orgs) == 0 {
+		return Organization{}, Warnings(warnings), OrganizationNotFoundError{
+			Name: name,
+		}
+	}
+
+	return Organization(orgs[0]), Warnings(warnings), nil
+}
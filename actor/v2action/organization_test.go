@@ -0,0 +1,78 @@
+package v2action_test
+
+import (
+	"errors"
+
+	. "code.cloudfoundry.org/cli/actor/v2action"
+	"code.cloudfoundry.org/cli/actor/v2action/v2actionfakes"
+	"code.cloudfoundry.org/cli/api/cloudcontroller/ccv2"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Organization Actions", func() {
+	var (
+		actor                     Actor
+		fakeCloudControllerClient *v2actionfakes.FakeCloudControllerClient
+	)
+
+	BeforeEach(func() {
+		fakeCloudControllerClient = new(v2actionfakes.FakeCloudControllerClient)
+		actor = NewActor(fakeCloudControllerClient, nil)
+	})
+
+	Describe("GetOrganizationByName", func() {
+		Context("when the organization exists", func() {
+			BeforeEach(func() {
+				fakeCloudControllerClient.GetOrganizationsReturns(
+					[]ccv2.Organization{{GUID: "some-org-guid", Name: "some-org"}},
+					ccv2.Warnings{"foo"},
+					nil,
+				)
+			})
+
+			It("returns the organization and warnings", func() {
+				org, warnings, err := actor.GetOrganizationByName("some-org")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(org).To(Equal(Organization{GUID: "some-org-guid", Name: "some-org"}))
+				Expect(warnings).To(Equal(Warnings{"foo"}))
+
+				Expect(fakeCloudControllerClient.GetOrganizationsCallCount()).To(Equal(1))
+				Expect(fakeCloudControllerClient.GetOrganizationsArgsForCall(0)).To(ConsistOf([]ccv2.Query{
+					ccv2.Query{
+						Filter:   ccv2.NameFilter,
+						Operator: ccv2.EqualOperator,
+						Value:    "some-org",
+					},
+				}))
+			})
+		})
+
+		Context("when the organization does not exist", func() {
+			BeforeEach(func() {
+				fakeCloudControllerClient.GetOrganizationsReturns([]ccv2.Organization{}, nil, nil)
+			})
+
+			It("returns an OrganizationNotFoundError", func() {
+				_, _, err := actor.GetOrganizationByName("some-org")
+				Expect(err).To(MatchError(OrganizationNotFoundError{Name: "some-org"}))
+			})
+		})
+
+		Context("when the cloud controller client returns an error", func() {
+			var expectedError error
+
+			BeforeEach(func() {
+				expectedError = errors.New("I am a CloudControllerClient Error")
+				fakeCloudControllerClient.GetOrganizationsReturns(nil, ccv2.Warnings{"foo"}, expectedError)
+			})
+
+			It("returns the error and warnings", func() {
+				_, warnings, err := actor.GetOrganizationByName("some-org")
+				Expect(err).To(MatchError(expectedError))
+				Expect(warnings).To(Equal(Warnings{"foo"}))
+			})
+		})
+	})
+})
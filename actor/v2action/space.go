@@ -0,0 +1,46 @@
+package v2action
+
+import (
+	"fmt"
+
+	"code.cloudfoundry.org/cli/api/cloudcontroller/ccv2"
+)
+
+type Space ccv2.Space
+
+type SpaceNotFoundError struct {
+	Name string
+}
+
+func (e SpaceNotFoundError) Error() string {
+	return fmt.Sprintf("Space '%s' not found.", e.Name)
+}
+
+// GetSpaceByOrganizationAndName returns the space with the provided name in
+// the organization.
+func (actor Actor) GetSpaceByOrganizationAndName(orgGUID string, name string) (Space, Warnings, error) {
+	spaces, warnings, err := actor.CloudControllerClient.GetSpaces([]ccv2.Query{
+		ccv2.Query{
+			Filter:   ccv2.NameFilter,
+			Operator: ccv2.EqualOperator,
+			Value:    name,
+		},
+		ccv2.Query{
+			Filter:   ccv2.OrganizationGUIDFilter,
+			Operator: ccv2.EqualOperator,
+			Value:    orgGUID,
+		},
+	})
+
+	if err != nil {
+		return Space{}, Warnings(warnings), err
+	}
+
+	if len(spaces) == 0 {
+		return Space{}, Warnings(warnings), SpaceNotFoundError{
+			Name: name,
+		}
+	}
+
+	return Space(spaces[0]), Warnings(warnings), nil
+}
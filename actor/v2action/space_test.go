@@ -0,0 +1,83 @@
+package v2action_test
+
+import (
+	"errors"
+
+	. "code.cloudfoundry.org/cli/actor/v2action"
+	"code.cloudfoundry.org/cli/actor/v2action/v2actionfakes"
+	"code.cloudfoundry.org/cli/api/cloudcontroller/ccv2"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Space Actions", func() {
+	var (
+		actor                     Actor
+		fakeCloudControllerClient *v2actionfakes.FakeCloudControllerClient
+	)
+
+	BeforeEach(func() {
+		fakeCloudControllerClient = new(v2actionfakes.FakeCloudControllerClient)
+		actor = NewActor(fakeCloudControllerClient, nil)
+	})
+
+	Describe("GetSpaceByOrganizationAndName", func() {
+		Context("when the space exists", func() {
+			BeforeEach(func() {
+				fakeCloudControllerClient.GetSpacesReturns(
+					[]ccv2.Space{{GUID: "some-space-guid", Name: "some-space", AllowSSH: true}},
+					ccv2.Warnings{"foo"},
+					nil,
+				)
+			})
+
+			It("returns the space and warnings", func() {
+				space, warnings, err := actor.GetSpaceByOrganizationAndName("some-org-guid", "some-space")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(space).To(Equal(Space{GUID: "some-space-guid", Name: "some-space", AllowSSH: true}))
+				Expect(warnings).To(Equal(Warnings{"foo"}))
+
+				Expect(fakeCloudControllerClient.GetSpacesCallCount()).To(Equal(1))
+				Expect(fakeCloudControllerClient.GetSpacesArgsForCall(0)).To(ConsistOf([]ccv2.Query{
+					ccv2.Query{
+						Filter:   ccv2.NameFilter,
+						Operator: ccv2.EqualOperator,
+						Value:    "some-space",
+					},
+					ccv2.Query{
+						Filter:   ccv2.OrganizationGUIDFilter,
+						Operator: ccv2.EqualOperator,
+						Value:    "some-org-guid",
+					},
+				}))
+			})
+		})
+
+		Context("when the space does not exist", func() {
+			BeforeEach(func() {
+				fakeCloudControllerClient.GetSpacesReturns([]ccv2.Space{}, nil, nil)
+			})
+
+			It("returns a SpaceNotFoundError", func() {
+				_, _, err := actor.GetSpaceByOrganizationAndName("some-org-guid", "some-space")
+				Expect(err).To(MatchError(SpaceNotFoundError{Name: "some-space"}))
+			})
+		})
+
+		Context("when the cloud controller client returns an error", func() {
+			var expectedError error
+
+			BeforeEach(func() {
+				expectedError = errors.New("I am a CloudControllerClient Error")
+				fakeCloudControllerClient.GetSpacesReturns(nil, ccv2.Warnings{"foo"}, expectedError)
+			})
+
+			It("returns the error and warnings", func() {
+				_, warnings, err := actor.GetSpaceByOrganizationAndName("some-org-guid", "some-space")
+				Expect(err).To(MatchError(expectedError))
+				Expect(warnings).To(Equal(Warnings{"foo"}))
+			})
+		})
+	})
+})
@@ -0,0 +1,14 @@
+package v2action
+
+import "time"
+
+//go:generate counterfeiter . UAAClient
+
+type UAAClient interface {
+	AccessToken() string
+	Authenticate(username string, password string) (string, string, error)
+	AuthenticateWithBrowser(authorizationEndpoint string, timeout time.Duration, openURL func(authorizationURL string) error) (string, string, error)
+	AuthenticateWithClientCredentials(clientID string, clientSecret string) (string, string, error)
+	AuthenticateWithPasscode(passcode string) (string, string, error)
+	RefreshToken() error
+}
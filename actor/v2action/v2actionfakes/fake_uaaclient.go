@@ -0,0 +1,296 @@
+// This file was generated by counterfeiter
+package v2actionfakes
+
+import (
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/cli/actor/v2action"
+)
+
+type FakeUAAClient struct {
+	AccessTokenStub        func() string
+	accessTokenMutex       sync.RWMutex
+	accessTokenArgsForCall []struct{}
+	accessTokenReturns     struct {
+		result1 string
+	}
+	AuthenticateStub        func(username string, password string) (string, string, error)
+	authenticateMutex       sync.RWMutex
+	authenticateArgsForCall []struct {
+		username string
+		password string
+	}
+	authenticateReturns struct {
+		result1 string
+		result2 string
+		result3 error
+	}
+	AuthenticateWithBrowserStub        func(authorizationEndpoint string, timeout time.Duration, openURL func(authorizationURL string) error) (string, string, error)
+	authenticateWithBrowserMutex       sync.RWMutex
+	authenticateWithBrowserArgsForCall []struct {
+		authorizationEndpoint string
+		timeout               time.Duration
+		openURL               func(authorizationURL string) error
+	}
+	authenticateWithBrowserReturns struct {
+		result1 string
+		result2 string
+		result3 error
+	}
+	AuthenticateWithClientCredentialsStub        func(clientID string, clientSecret string) (string, string, error)
+	authenticateWithClientCredentialsMutex       sync.RWMutex
+	authenticateWithClientCredentialsArgsForCall []struct {
+		clientID     string
+		clientSecret string
+	}
+	authenticateWithClientCredentialsReturns struct {
+		result1 string
+		result2 string
+		result3 error
+	}
+	AuthenticateWithPasscodeStub        func(passcode string) (string, string, error)
+	authenticateWithPasscodeMutex       sync.RWMutex
+	authenticateWithPasscodeArgsForCall []struct {
+		passcode string
+	}
+	authenticateWithPasscodeReturns struct {
+		result1 string
+		result2 string
+		result3 error
+	}
+	RefreshTokenStub        func() error
+	refreshTokenMutex       sync.RWMutex
+	refreshTokenArgsForCall []struct{}
+	refreshTokenReturns     struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeUAAClient) AccessToken() string {
+	fake.accessTokenMutex.Lock()
+	fake.accessTokenArgsForCall = append(fake.accessTokenArgsForCall, struct{}{})
+	fake.recordInvocation("AccessToken", []interface{}{})
+	fake.accessTokenMutex.Unlock()
+	if fake.AccessTokenStub != nil {
+		return fake.AccessTokenStub()
+	} else {
+		return fake.accessTokenReturns.result1
+	}
+}
+
+func (fake *FakeUAAClient) AccessTokenCallCount() int {
+	fake.accessTokenMutex.RLock()
+	defer fake.accessTokenMutex.RUnlock()
+	return len(fake.accessTokenArgsForCall)
+}
+
+func (fake *FakeUAAClient) AccessTokenReturns(result1 string) {
+	fake.AccessTokenStub = nil
+	fake.accessTokenReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeUAAClient) Authenticate(username string, password string) (string, string, error) {
+	fake.authenticateMutex.Lock()
+	fake.authenticateArgsForCall = append(fake.authenticateArgsForCall, struct {
+		username string
+		password string
+	}{username, password})
+	fake.recordInvocation("Authenticate", []interface{}{username, password})
+	fake.authenticateMutex.Unlock()
+	if fake.AuthenticateStub != nil {
+		return fake.AuthenticateStub(username, password)
+	} else {
+		return fake.authenticateReturns.result1, fake.authenticateReturns.result2, fake.authenticateReturns.result3
+	}
+}
+
+func (fake *FakeUAAClient) AuthenticateCallCount() int {
+	fake.authenticateMutex.RLock()
+	defer fake.authenticateMutex.RUnlock()
+	return len(fake.authenticateArgsForCall)
+}
+
+func (fake *FakeUAAClient) AuthenticateArgsForCall(i int) (string, string) {
+	fake.authenticateMutex.RLock()
+	defer fake.authenticateMutex.RUnlock()
+	return fake.authenticateArgsForCall[i].username, fake.authenticateArgsForCall[i].password
+}
+
+func (fake *FakeUAAClient) AuthenticateReturns(result1 string, result2 string, result3 error) {
+	fake.AuthenticateStub = nil
+	fake.authenticateReturns = struct {
+		result1 string
+		result2 string
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeUAAClient) AuthenticateWithBrowser(authorizationEndpoint string, timeout time.Duration, openURL func(authorizationURL string) error) (string, string, error) {
+	fake.authenticateWithBrowserMutex.Lock()
+	fake.authenticateWithBrowserArgsForCall = append(fake.authenticateWithBrowserArgsForCall, struct {
+		authorizationEndpoint string
+		timeout               time.Duration
+		openURL               func(authorizationURL string) error
+	}{authorizationEndpoint, timeout, openURL})
+	fake.recordInvocation("AuthenticateWithBrowser", []interface{}{authorizationEndpoint, timeout, openURL})
+	fake.authenticateWithBrowserMutex.Unlock()
+	if fake.AuthenticateWithBrowserStub != nil {
+		return fake.AuthenticateWithBrowserStub(authorizationEndpoint, timeout, openURL)
+	} else {
+		return fake.authenticateWithBrowserReturns.result1, fake.authenticateWithBrowserReturns.result2, fake.authenticateWithBrowserReturns.result3
+	}
+}
+
+func (fake *FakeUAAClient) AuthenticateWithBrowserCallCount() int {
+	fake.authenticateWithBrowserMutex.RLock()
+	defer fake.authenticateWithBrowserMutex.RUnlock()
+	return len(fake.authenticateWithBrowserArgsForCall)
+}
+
+func (fake *FakeUAAClient) AuthenticateWithBrowserArgsForCall(i int) (string, time.Duration, func(authorizationURL string) error) {
+	fake.authenticateWithBrowserMutex.RLock()
+	defer fake.authenticateWithBrowserMutex.RUnlock()
+	return fake.authenticateWithBrowserArgsForCall[i].authorizationEndpoint, fake.authenticateWithBrowserArgsForCall[i].timeout, fake.authenticateWithBrowserArgsForCall[i].openURL
+}
+
+func (fake *FakeUAAClient) AuthenticateWithBrowserReturns(result1 string, result2 string, result3 error) {
+	fake.AuthenticateWithBrowserStub = nil
+	fake.authenticateWithBrowserReturns = struct {
+		result1 string
+		result2 string
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeUAAClient) AuthenticateWithClientCredentials(clientID string, clientSecret string) (string, string, error) {
+	fake.authenticateWithClientCredentialsMutex.Lock()
+	fake.authenticateWithClientCredentialsArgsForCall = append(fake.authenticateWithClientCredentialsArgsForCall, struct {
+		clientID     string
+		clientSecret string
+	}{clientID, clientSecret})
+	fake.recordInvocation("AuthenticateWithClientCredentials", []interface{}{clientID, clientSecret})
+	fake.authenticateWithClientCredentialsMutex.Unlock()
+	if fake.AuthenticateWithClientCredentialsStub != nil {
+		return fake.AuthenticateWithClientCredentialsStub(clientID, clientSecret)
+	} else {
+		return fake.authenticateWithClientCredentialsReturns.result1, fake.authenticateWithClientCredentialsReturns.result2, fake.authenticateWithClientCredentialsReturns.result3
+	}
+}
+
+func (fake *FakeUAAClient) AuthenticateWithClientCredentialsCallCount() int {
+	fake.authenticateWithClientCredentialsMutex.RLock()
+	defer fake.authenticateWithClientCredentialsMutex.RUnlock()
+	return len(fake.authenticateWithClientCredentialsArgsForCall)
+}
+
+func (fake *FakeUAAClient) AuthenticateWithClientCredentialsArgsForCall(i int) (string, string) {
+	fake.authenticateWithClientCredentialsMutex.RLock()
+	defer fake.authenticateWithClientCredentialsMutex.RUnlock()
+	return fake.authenticateWithClientCredentialsArgsForCall[i].clientID, fake.authenticateWithClientCredentialsArgsForCall[i].clientSecret
+}
+
+func (fake *FakeUAAClient) AuthenticateWithClientCredentialsReturns(result1 string, result2 string, result3 error) {
+	fake.AuthenticateWithClientCredentialsStub = nil
+	fake.authenticateWithClientCredentialsReturns = struct {
+		result1 string
+		result2 string
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeUAAClient) AuthenticateWithPasscode(passcode string) (string, string, error) {
+	fake.authenticateWithPasscodeMutex.Lock()
+	fake.authenticateWithPasscodeArgsForCall = append(fake.authenticateWithPasscodeArgsForCall, struct {
+		passcode string
+	}{passcode})
+	fake.recordInvocation("AuthenticateWithPasscode", []interface{}{passcode})
+	fake.authenticateWithPasscodeMutex.Unlock()
+	if fake.AuthenticateWithPasscodeStub != nil {
+		return fake.AuthenticateWithPasscodeStub(passcode)
+	} else {
+		return fake.authenticateWithPasscodeReturns.result1, fake.authenticateWithPasscodeReturns.result2, fake.authenticateWithPasscodeReturns.result3
+	}
+}
+
+func (fake *FakeUAAClient) AuthenticateWithPasscodeCallCount() int {
+	fake.authenticateWithPasscodeMutex.RLock()
+	defer fake.authenticateWithPasscodeMutex.RUnlock()
+	return len(fake.authenticateWithPasscodeArgsForCall)
+}
+
+func (fake *FakeUAAClient) AuthenticateWithPasscodeArgsForCall(i int) string {
+	fake.authenticateWithPasscodeMutex.RLock()
+	defer fake.authenticateWithPasscodeMutex.RUnlock()
+	return fake.authenticateWithPasscodeArgsForCall[i].passcode
+}
+
+func (fake *FakeUAAClient) AuthenticateWithPasscodeReturns(result1 string, result2 string, result3 error) {
+	fake.AuthenticateWithPasscodeStub = nil
+	fake.authenticateWithPasscodeReturns = struct {
+		result1 string
+		result2 string
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeUAAClient) RefreshToken() error {
+	fake.refreshTokenMutex.Lock()
+	fake.refreshTokenArgsForCall = append(fake.refreshTokenArgsForCall, struct{}{})
+	fake.recordInvocation("RefreshToken", []interface{}{})
+	fake.refreshTokenMutex.Unlock()
+	if fake.RefreshTokenStub != nil {
+		return fake.RefreshTokenStub()
+	} else {
+		return fake.refreshTokenReturns.result1
+	}
+}
+
+func (fake *FakeUAAClient) RefreshTokenCallCount() int {
+	fake.refreshTokenMutex.RLock()
+	defer fake.refreshTokenMutex.RUnlock()
+	return len(fake.refreshTokenArgsForCall)
+}
+
+func (fake *FakeUAAClient) RefreshTokenReturns(result1 error) {
+	fake.RefreshTokenStub = nil
+	fake.refreshTokenReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeUAAClient) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.accessTokenMutex.RLock()
+	defer fake.accessTokenMutex.RUnlock()
+	fake.authenticateMutex.RLock()
+	defer fake.authenticateMutex.RUnlock()
+	fake.authenticateWithBrowserMutex.RLock()
+	defer fake.authenticateWithBrowserMutex.RUnlock()
+	fake.authenticateWithClientCredentialsMutex.RLock()
+	defer fake.authenticateWithClientCredentialsMutex.RUnlock()
+	fake.authenticateWithPasscodeMutex.RLock()
+	defer fake.authenticateWithPasscodeMutex.RUnlock()
+	fake.refreshTokenMutex.RLock()
+	defer fake.refreshTokenMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeUAAClient) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ v2action.UAAClient = new(FakeUAAClient)
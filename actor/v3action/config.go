@@ -0,0 +1,9 @@
+package v3action
+
+//go:generate counterfeiter . Config
+
+// Config is the interface for getting the information the V3 actor needs
+// from the CLI configuration.
+type Config interface {
+	AccessToken() string
+}
@@ -0,0 +1,69 @@
+package v3action
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/cloudfoundry/sonde-go/events"
+)
+
+// LogMessage represents a single log line emitted by an application.
+type LogMessage struct {
+	Message        string
+	Type           string
+	Timestamp      time.Time
+	SourceType     string
+	SourceInstance string
+}
+
+// newLogMessage converts a NOAA log message into a LogMessage.
+func newLogMessage(message *events.LogMessage) LogMessage {
+	messageType := "OUT"
+	if message.GetMessageType() == events.LogMessage_ERR {
+		messageType = "ERR"
+	}
+
+	return LogMessage{
+		Message:        string(message.GetMessage()),
+		Type:           messageType,
+		Timestamp:      time.Unix(0, message.GetTimestamp()),
+		SourceType:     message.GetSourceType(),
+		SourceInstance: message.GetSourceInstance(),
+	}
+}
+
+// GetStreamingTaskLogs streams the logs of the task with the provided name
+// running in the environment of the provided application. Log lines from the
+// application or any other task are dropped. Both returned channels are
+// closed once the client is closed.
+func (actor Actor) GetStreamingTaskLogs(appGUID string, taskName string, client NOAAClient, config Config) (<-chan LogMessage, <-chan error) {
+	outgoingLogStream := make(chan LogMessage)
+	outgoingErrStream := make(chan error)
+
+	taskSourceType := fmt.Sprintf("APP/TASK/%s", taskName)
+
+	go func() {
+		defer close(outgoingLogStream)
+		defer close(outgoingErrStream)
+
+		incomingLogStream, incomingErrStream := client.TailingLogs(appGUID, config.AccessToken())
+		for {
+			select {
+			case message, ok := <-incomingLogStream:
+				if !ok {
+					return
+				}
+				if message.GetSourceType() == taskSourceType {
+					outgoingLogStream <- newLogMessage(message)
+				}
+			case err, ok := <-incomingErrStream:
+				if !ok {
+					return
+				}
+				outgoingErrStream <- err
+			}
+		}
+	}()
+
+	return outgoingLogStream, outgoingErrStream
+}
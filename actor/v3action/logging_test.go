@@ -0,0 +1,87 @@
+package v3action_test
+
+import (
+	"errors"
+	"time"
+
+	. "code.cloudfoundry.org/cli/actor/v3action"
+	"code.cloudfoundry.org/cli/actor/v3action/v3actionfakes"
+	"github.com/cloudfoundry/sonde-go/events"
+	"github.com/golang/protobuf/proto"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Logging Actions", func() {
+	var (
+		actor          Actor
+		fakeNOAAClient *v3actionfakes.FakeNOAAClient
+		fakeConfig     *v3actionfakes.FakeConfig
+	)
+
+	BeforeEach(func() {
+		actor = NewActor(new(v3actionfakes.FakeCloudControllerClient))
+		fakeNOAAClient = new(v3actionfakes.FakeNOAAClient)
+		fakeConfig = new(v3actionfakes.FakeConfig)
+		fakeConfig.AccessTokenReturns("bearer some-access-token")
+	})
+
+	Describe("GetStreamingTaskLogs", func() {
+		var (
+			incomingLogs chan *events.LogMessage
+			incomingErrs chan error
+		)
+
+		BeforeEach(func() {
+			incomingLogs = make(chan *events.LogMessage)
+			incomingErrs = make(chan error)
+			fakeNOAAClient.TailingLogsReturns(incomingLogs, incomingErrs)
+
+			go func() {
+				incomingLogs <- &events.LogMessage{
+					Message:        []byte("app message"),
+					MessageType:    events.LogMessage_OUT.Enum(),
+					Timestamp:      proto.Int64(1),
+					SourceType:     proto.String("APP"),
+					SourceInstance: proto.String("0"),
+				}
+				incomingLogs <- &events.LogMessage{
+					Message:        []byte("other task message"),
+					MessageType:    events.LogMessage_OUT.Enum(),
+					Timestamp:      proto.Int64(2),
+					SourceType:     proto.String("APP/TASK/other-task"),
+					SourceInstance: proto.String("0"),
+				}
+				incomingLogs <- &events.LogMessage{
+					Message:        []byte("task message"),
+					MessageType:    events.LogMessage_ERR.Enum(),
+					Timestamp:      proto.Int64(3),
+					SourceType:     proto.String("APP/TASK/some-task"),
+					SourceInstance: proto.String("0"),
+				}
+				incomingErrs <- errors.New("some-log-error")
+				close(incomingLogs)
+			}()
+		})
+
+		It("streams only the task's log messages and all errors", func() {
+			messages, errs := actor.GetStreamingTaskLogs("some-app-guid", "some-task", fakeNOAAClient, fakeConfig)
+
+			Eventually(messages).Should(Receive(Equal(LogMessage{
+				Message:        "task message",
+				Type:           "ERR",
+				Timestamp:      time.Unix(0, 3),
+				SourceType:     "APP/TASK/some-task",
+				SourceInstance: "0",
+			})))
+			Eventually(errs).Should(Receive(MatchError("some-log-error")))
+			Eventually(messages).Should(BeClosed())
+			Eventually(errs).Should(BeClosed())
+
+			Expect(fakeNOAAClient.TailingLogsCallCount()).To(Equal(1))
+			appGUID, authToken := fakeNOAAClient.TailingLogsArgsForCall(0)
+			Expect(appGUID).To(Equal("some-app-guid"))
+			Expect(authToken).To(Equal("bearer some-access-token"))
+		})
+	})
+})
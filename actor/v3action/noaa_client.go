@@ -0,0 +1,11 @@
+package v3action
+
+import "github.com/cloudfoundry/sonde-go/events"
+
+//go:generate counterfeiter . NOAAClient
+
+// NOAAClient is the interface to the Doppler/Loggregator log streaming API.
+type NOAAClient interface {
+	Close() error
+	TailingLogs(appGUID string, authToken string) (<-chan *events.LogMessage, <-chan error)
+}
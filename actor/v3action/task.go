@@ -2,16 +2,29 @@ package v3action
 
 import (
 	"fmt"
-	"net/url"
 	"strconv"
+	"time"
 
 	"code.cloudfoundry.org/cli/api/cloudcontroller"
 	"code.cloudfoundry.org/cli/api/cloudcontroller/ccv3"
 )
 
+const (
+	// TaskSucceeded is the state of a task that has completed successfully.
+	TaskSucceeded = "SUCCEEDED"
+
+	// TaskFailed is the state of a task that has failed or was terminated.
+	TaskFailed = "FAILED"
+)
+
 // Task represents a V3 actor Task.
 type Task ccv3.Task
 
+// Finished returns true if the task has either succeeded or failed.
+func (task Task) Finished() bool {
+	return task.State == TaskSucceeded || task.State == TaskFailed
+}
+
 // TaskWorkersUnavailableError is returned when there are no workers to run a
 // given task.
 type TaskWorkersUnavailableError struct {
@@ -44,15 +57,59 @@ func (actor Actor) RunTask(appGUID string, command string, name string) (Task, W
 	return Task(task), Warnings(warnings), err
 }
 
+// TaskFilter narrows down the tasks returned by GetApplicationTasks. Zero
+// values are ignored.
+type TaskFilter struct {
+	// States only includes tasks in any of the given states.
+	States []string
+	// Names only includes tasks with any of the given names.
+	Names []string
+	// CreatedAfter only includes tasks created after the given time.
+	CreatedAfter time.Time
+	// CreatedBefore only includes tasks created before the given time.
+	CreatedBefore time.Time
+	// Limit is the maximum number of tasks returned.
+	Limit int
+}
+
+func (filter TaskFilter) queries() []ccv3.Query {
+	var queries []ccv3.Query
+	if len(filter.States) > 0 {
+		queries = append(queries, ccv3.Query{Key: ccv3.StateFilter, Values: filter.States})
+	}
+	if len(filter.Names) > 0 {
+		queries = append(queries, ccv3.Query{Key: ccv3.NameFilter, Values: filter.Names})
+	}
+	if !filter.CreatedAfter.IsZero() {
+		queries = append(queries, ccv3.Query{
+			Key:    ccv3.CreatedAfterFilter,
+			Values: []string{filter.CreatedAfter.UTC().Format(time.RFC3339)},
+		})
+	}
+	if !filter.CreatedBefore.IsZero() {
+		queries = append(queries, ccv3.Query{
+			Key:    ccv3.CreatedBeforeFilter,
+			Values: []string{filter.CreatedBefore.UTC().Format(time.RFC3339)},
+		})
+	}
+	if filter.Limit > 0 && filter.Limit < ccv3.MaxPerPage {
+		queries = append(queries, ccv3.Query{
+			Key:    ccv3.PerPage,
+			Values: []string{strconv.Itoa(filter.Limit)},
+		})
+	}
+	return queries
+}
+
 // GetApplicationTasks returns a list of tasks associated with the provided
-// appplication GUID.
-func (actor Actor) GetApplicationTasks(appGUID string, sortOrder SortOrder) ([]Task, Warnings, error) {
-	query := url.Values{}
+// appplication GUID, narrowed down by the provided filter.
+func (actor Actor) GetApplicationTasks(appGUID string, sortOrder SortOrder, filter TaskFilter) ([]Task, Warnings, error) {
+	queries := filter.queries()
 	if sortOrder == Descending {
-		query.Add("order_by", "-created_at")
+		queries = append(queries, ccv3.Query{Key: ccv3.OrderBy, Values: []string{ccv3.CreatedAtDescending}})
 	}
 
-	tasks, warnings, err := actor.CloudControllerClient.GetApplicationTasks(appGUID, query)
+	tasks, warnings, err := actor.CloudControllerClient.GetApplicationTasks(appGUID, queries, filter.Limit)
 	actorWarnings := Warnings(warnings)
 	if err != nil {
 		return nil, actorWarnings, err
@@ -67,11 +124,9 @@ func (actor Actor) GetApplicationTasks(appGUID string, sortOrder SortOrder) ([]T
 }
 
 func (actor Actor) GetTaskBySequenceIDAndApplication(sequenceID int, appGUID string) (Task, Warnings, error) {
-	query := url.Values{
-		"sequence_ids": []string{strconv.Itoa(sequenceID)},
-	}
-
-	tasks, warnings, err := actor.CloudControllerClient.GetApplicationTasks(appGUID, query)
+	tasks, warnings, err := actor.CloudControllerClient.GetApplicationTasks(appGUID, []ccv3.Query{
+		{Key: ccv3.SequenceIDFilter, Values: []string{strconv.Itoa(sequenceID)}},
+	}, 0)
 	if err != nil {
 		return Task{}, Warnings(warnings), err
 	}
@@ -2,7 +2,7 @@ package v3action_test
 
 import (
 	"errors"
-	"net/url"
+	"time"
 
 	. "code.cloudfoundry.org/cli/actor/v3action"
 	"code.cloudfoundry.org/cli/actor/v3action/v3actionfakes"
@@ -154,20 +154,63 @@ var _ = Describe("Task Actions", func() {
 				})
 
 				It("returns all tasks associated with the application and all warnings", func() {
-					tasks, warnings, err := actor.GetApplicationTasks("some-app-guid", Descending)
+					tasks, warnings, err := actor.GetApplicationTasks("some-app-guid", Descending, TaskFilter{})
 					Expect(err).ToNot(HaveOccurred())
 
 					Expect(tasks).To(ConsistOf(Task(task1), Task(task2), Task(task3)))
 					Expect(warnings).To(ConsistOf("warning-1", "warning-2"))
 
 					Expect(fakeCloudControllerClient.GetApplicationTasksCallCount()).To(Equal(1))
-					appGUID, query := fakeCloudControllerClient.GetApplicationTasksArgsForCall(0)
+					appGUID, queries, limit := fakeCloudControllerClient.GetApplicationTasksArgsForCall(0)
 					Expect(appGUID).To(Equal("some-app-guid"))
-					Expect(query).To(Equal(
-						url.Values{
-							"order_by": []string{"-created_at"},
-						},
-					))
+					Expect(queries).To(Equal([]ccv3.Query{
+						{Key: ccv3.OrderBy, Values: []string{"-created_at"}},
+					}))
+					Expect(limit).To(BeZero())
+				})
+			})
+
+			Context("when a filter is provided", func() {
+				BeforeEach(func() {
+					fakeCloudControllerClient.GetApplicationTasksReturns(
+						[]ccv3.Task{},
+						ccv3.Warnings{"warning-1"},
+						nil,
+					)
+				})
+
+				It("passes the filter to the cloud controller client", func() {
+					_, warnings, err := actor.GetApplicationTasks("some-app-guid", Ascending, TaskFilter{
+						States:        []string{"RUNNING", "FAILED"},
+						Names:         []string{"some-task"},
+						CreatedAfter:  time.Date(2017, 1, 2, 3, 4, 5, 0, time.UTC),
+						CreatedBefore: time.Date(2017, 2, 3, 4, 5, 6, 0, time.FixedZone("", 3600)),
+						Limit:         10,
+					})
+					Expect(err).ToNot(HaveOccurred())
+					Expect(warnings).To(ConsistOf("warning-1"))
+
+					Expect(fakeCloudControllerClient.GetApplicationTasksCallCount()).To(Equal(1))
+					_, queries, limit := fakeCloudControllerClient.GetApplicationTasksArgsForCall(0)
+					Expect(queries).To(Equal([]ccv3.Query{
+						{Key: ccv3.StateFilter, Values: []string{"RUNNING", "FAILED"}},
+						{Key: ccv3.NameFilter, Values: []string{"some-task"}},
+						{Key: ccv3.CreatedAfterFilter, Values: []string{"2017-01-02T03:04:05Z"}},
+						{Key: ccv3.CreatedBeforeFilter, Values: []string{"2017-02-03T03:05:06Z"}},
+						{Key: ccv3.PerPage, Values: []string{"10"}},
+					}))
+					Expect(limit).To(Equal(10))
+				})
+
+				Context("when the limit is larger than the maximum page size", func() {
+					It("does not set the page size", func() {
+						_, _, err := actor.GetApplicationTasks("some-app-guid", Ascending, TaskFilter{Limit: 6000})
+						Expect(err).ToNot(HaveOccurred())
+
+						_, queries, limit := fakeCloudControllerClient.GetApplicationTasksArgsForCall(0)
+						Expect(queries).To(BeEmpty())
+						Expect(limit).To(Equal(6000))
+					})
 				})
 			})
 
@@ -181,7 +224,7 @@ var _ = Describe("Task Actions", func() {
 				})
 
 				It("returns an empty list of tasks", func() {
-					tasks, _, err := actor.GetApplicationTasks("some-app-guid", Descending)
+					tasks, _, err := actor.GetApplicationTasks("some-app-guid", Descending, TaskFilter{})
 					Expect(err).ToNot(HaveOccurred())
 					Expect(tasks).To(BeEmpty())
 				})
@@ -201,7 +244,7 @@ var _ = Describe("Task Actions", func() {
 			})
 
 			It("returns the same error and all warnings", func() {
-				_, warnings, err := actor.GetApplicationTasks("some-app-guid", Descending)
+				_, warnings, err := actor.GetApplicationTasks("some-app-guid", Descending, TaskFilter{})
 				Expect(err).To(MatchError(expectedErr))
 				Expect(warnings).To(ConsistOf("warning-1", "warning-2"))
 			})
@@ -230,6 +273,14 @@ var _ = Describe("Task Actions", func() {
 					Expect(err).ToNot(HaveOccurred())
 					Expect(task).To(Equal(Task(task1)))
 					Expect(warnings).To(ConsistOf("get-task-warning-1"))
+
+					Expect(fakeCloudControllerClient.GetApplicationTasksCallCount()).To(Equal(1))
+					appGUID, queries, limit := fakeCloudControllerClient.GetApplicationTasksArgsForCall(0)
+					Expect(appGUID).To(Equal("some-app-guid"))
+					Expect(queries).To(Equal([]ccv3.Query{
+						{Key: ccv3.SequenceIDFilter, Values: []string{"1"}},
+					}))
+					Expect(limit).To(BeZero())
 				})
 			})
 
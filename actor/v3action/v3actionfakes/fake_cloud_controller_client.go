@@ -10,11 +10,12 @@ import (
 )
 
 type FakeCloudControllerClient struct {
-	GetApplicationTasksStub        func(appGUID string, query url.Values) ([]ccv3.Task, ccv3.Warnings, error)
+	GetApplicationTasksStub        func(appGUID string, queries []ccv3.Query, limit int) ([]ccv3.Task, ccv3.Warnings, error)
 	getApplicationTasksMutex       sync.RWMutex
 	getApplicationTasksArgsForCall []struct {
 		appGUID string
-		query   url.Values
+		queries []ccv3.Query
+		limit   int
 	}
 	getApplicationTasksReturns struct {
 		result1 []ccv3.Task
@@ -57,16 +58,22 @@ type FakeCloudControllerClient struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeCloudControllerClient) GetApplicationTasks(appGUID string, query url.Values) ([]ccv3.Task, ccv3.Warnings, error) {
+func (fake *FakeCloudControllerClient) GetApplicationTasks(appGUID string, queries []ccv3.Query, limit int) ([]ccv3.Task, ccv3.Warnings, error) {
+	var queriesCopy []ccv3.Query
+	if queries != nil {
+		queriesCopy = make([]ccv3.Query, len(queries))
+		copy(queriesCopy, queries)
+	}
 	fake.getApplicationTasksMutex.Lock()
 	fake.getApplicationTasksArgsForCall = append(fake.getApplicationTasksArgsForCall, struct {
 		appGUID string
-		query   url.Values
-	}{appGUID, query})
-	fake.recordInvocation("GetApplicationTasks", []interface{}{appGUID, query})
+		queries []ccv3.Query
+		limit   int
+	}{appGUID, queriesCopy, limit})
+	fake.recordInvocation("GetApplicationTasks", []interface{}{appGUID, queriesCopy, limit})
 	fake.getApplicationTasksMutex.Unlock()
 	if fake.GetApplicationTasksStub != nil {
-		return fake.GetApplicationTasksStub(appGUID, query)
+		return fake.GetApplicationTasksStub(appGUID, queries, limit)
 	} else {
 		return fake.getApplicationTasksReturns.result1, fake.getApplicationTasksReturns.result2, fake.getApplicationTasksReturns.result3
 	}
@@ -78,10 +85,10 @@ func (fake *FakeCloudControllerClient) GetApplicationTasksCallCount() int {
 	return len(fake.getApplicationTasksArgsForCall)
 }
 
-func (fake *FakeCloudControllerClient) GetApplicationTasksArgsForCall(i int) (string, url.Values) {
+func (fake *FakeCloudControllerClient) GetApplicationTasksArgsForCall(i int) (string, []ccv3.Query, int) {
 	fake.getApplicationTasksMutex.RLock()
 	defer fake.getApplicationTasksMutex.RUnlock()
-	return fake.getApplicationTasksArgsForCall[i].appGUID, fake.getApplicationTasksArgsForCall[i].query
+	return fake.getApplicationTasksArgsForCall[i].appGUID, fake.getApplicationTasksArgsForCall[i].queries, fake.getApplicationTasksArgsForCall[i].limit
 }
 
 func (fake *FakeCloudControllerClient) GetApplicationTasksReturns(result1 []ccv3.Task, result2 ccv3.Warnings, result3 error) {
@@ -12,6 +12,7 @@ const (
 	DeleteRouteRequest            = "DeleteRoute"
 	DeleteServiceBindingRequest   = "DeleteServiceBinding"
 	InfoRequest                   = "Info"
+	OrganizationsRequest          = "Organizations"
 	PrivateDomainRequest          = "PrivateDomain"
 	RouteMappingsFromRouteRequest = "RouteMappingsFromRoute"
 	RoutesFromSpaceRequest        = "RoutesFromSpace"
@@ -19,11 +20,13 @@ const (
 	ServiceInstancesRequest       = "ServiceInstances"
 	SharedDomainRequest           = "SharedDomain"
 	SpaceServiceInstancesRequest  = "SpaceServiceInstances"
+	SpacesRequest                 = "Spaces"
 )
 
 var APIRoutes = rata.Routes{
 	{Path: "/v2/apps", Method: http.MethodGet, Name: AppsRequest},
 	{Path: "/v2/info", Method: http.MethodGet, Name: InfoRequest},
+	{Path: "/v2/organizations", Method: http.MethodGet, Name: OrganizationsRequest},
 	{Path: "/v2/private_domains/:private_domain_guid", Method: http.MethodGet, Name: PrivateDomainRequest},
 	{Path: "/v2/routes/:route_guid", Method: http.MethodDelete, Name: DeleteRouteRequest},
 	{Path: "/v2/routes/:route_guid/apps", Method: http.MethodGet, Name: AppsFromRouteRequest},
@@ -32,6 +35,7 @@ var APIRoutes = rata.Routes{
 	{Path: "/v2/service_bindings/:service_binding_guid", Method: http.MethodDelete, Name: DeleteServiceBindingRequest},
 	{Path: "/v2/service_instances", Method: http.MethodGet, Name: ServiceInstancesRequest},
 	{Path: "/v2/shared_domains/:shared_domain_guid", Method: http.MethodGet, Name: SharedDomainRequest},
+	{Path: "/v2/spaces", Method: http.MethodGet, Name: SpacesRequest},
 	{Path: "/v2/spaces/:guid/service_instances", Method: http.MethodGet, Name: SpaceServiceInstancesRequest},
 	{Path: "/v2/spaces/:space_guid/routes", Method: http.MethodGet, Name: RoutesFromSpaceRequest},
 }
@@ -0,0 +1,77 @@
+package ccv2
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"code.cloudfoundry.org/cli/api/cloudcontroller"
+	"code.cloudfoundry.org/cli/api/cloudcontroller/ccv2/internal"
+)
+
+// Organization represents a Cloud Controller Organization.
+type Organization struct {
+	GUID string
+	Name string
+}
+
+// UnmarshalJSON helps unmarshal a Cloud Controller Organization response.
+func (org *Organization) UnmarshalJSON(data []byte) error {
+	var ccOrg struct {
+		Metadata internal.Metadata `json:"metadata"`
+		Entity   struct {
+			Name string `json:"name"`
+		} `json:"entity"`
+	}
+	if err := json.Unmarshal(data, &ccOrg); err != nil {
+		return err
+	}
+
+	org.GUID = ccOrg.Metadata.GUID
+	org.Name = ccOrg.Entity.Name
+	return nil
+}
+
+// GetOrganizations returns back a list of Organizations based off of the
+// provided queries.
+func (client *Client) GetOrganizations(queries []Query) ([]Organization, Warnings, error) {
+	request, err := client.newHTTPRequest(requestOptions{
+		RequestName: internal.OrganizationsRequest,
+		Query:       FormatQueryParameters(queries),
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	fullOrgsList := []Organization{}
+	fullWarningsList := Warnings{}
+
+	for {
+		var orgs []Organization
+		wrapper := PaginatedWrapper{
+			Resources: &orgs,
+		}
+		response := cloudcontroller.Response{
+			Result: &wrapper,
+		}
+
+		err := client.connection.Make(request, &response)
+		fullWarningsList = append(fullWarningsList, response.Warnings...)
+		if err != nil {
+			return nil, fullWarningsList, err
+		}
+		fullOrgsList = append(fullOrgsList, orgs...)
+
+		if wrapper.NextURL == "" {
+			break
+		}
+		request, err = client.newHTTPRequest(requestOptions{
+			URI:    wrapper.NextURL,
+			Method: http.MethodGet,
+		})
+		if err != nil {
+			return nil, fullWarningsList, err
+		}
+	}
+
+	return fullOrgsList, fullWarningsList, nil
+}
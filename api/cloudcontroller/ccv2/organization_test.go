@@ -0,0 +1,73 @@
+package ccv2_test
+
+import (
+	"net/http"
+
+	. "code.cloudfoundry.org/cli/api/cloudcontroller/ccv2"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Organization", func() {
+	var client *Client
+
+	BeforeEach(func() {
+		client = NewTestClient()
+	})
+
+	Describe("GetOrganizations", func() {
+		BeforeEach(func() {
+			response1 := `{
+				"next_url": "/v2/organizations?q=name:some-org&page=2",
+				"resources": [
+					{
+						"metadata": {
+							"guid": "org-guid-1"
+						},
+						"entity": {
+							"name": "org-name-1"
+						}
+					}
+				]
+			}`
+			response2 := `{
+				"next_url": null,
+				"resources": [
+					{
+						"metadata": {
+							"guid": "org-guid-2"
+						},
+						"entity": {
+							"name": "org-name-2"
+						}
+					}
+				]
+			}`
+			server.AppendHandlers(
+				CombineHandlers(
+					VerifyRequest(http.MethodGet, "/v2/organizations", "q=name:some-org"),
+					RespondWith(http.StatusOK, response1, http.Header{"X-Cf-Warnings": {"this is a warning"}}),
+				),
+				CombineHandlers(
+					VerifyRequest(http.MethodGet, "/v2/organizations", "q=name:some-org&page=2"),
+					RespondWith(http.StatusOK, response2, http.Header{"X-Cf-Warnings": {"this is another warning"}}),
+				),
+			)
+		})
+
+		It("returns all the queried organizations", func() {
+			orgs, warnings, err := client.GetOrganizations([]Query{{
+				Filter:   NameFilter,
+				Operator: EqualOperator,
+				Value:    "some-org",
+			}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(orgs).To(ConsistOf([]Organization{
+				{Name: "org-name-1", GUID: "org-guid-1"},
+				{Name: "org-name-2", GUID: "org-guid-2"},
+			}))
+			Expect(warnings).To(ConsistOf(Warnings{"this is a warning", "this is another warning"}))
+		})
+	})
+})
@@ -13,6 +13,7 @@ type QueryOperator string
 
 const (
 	AppGUIDFilter             QueryFilter = "app_guid"
+	OrganizationGUIDFilter    QueryFilter = "organization_guid"
 	ServiceInstanceGUIDFilter QueryFilter = "service_instance_guid"
 	SpaceGUIDFilter           QueryFilter = "space_guid"
 
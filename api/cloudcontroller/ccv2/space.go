@@ -0,0 +1,79 @@
+package ccv2
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"code.cloudfoundry.org/cli/api/cloudcontroller"
+	"code.cloudfoundry.org/cli/api/cloudcontroller/ccv2/internal"
+)
+
+// Space represents a Cloud Controller Space.
+type Space struct {
+	GUID     string
+	Name     string
+	AllowSSH bool
+}
+
+// UnmarshalJSON helps unmarshal a Cloud Controller Space response.
+func (space *Space) UnmarshalJSON(data []byte) error {
+	var ccSpace struct {
+		Metadata internal.Metadata `json:"metadata"`
+		Entity   struct {
+			Name     string `json:"name"`
+			AllowSSH bool   `json:"allow_ssh"`
+		} `json:"entity"`
+	}
+	if err := json.Unmarshal(data, &ccSpace); err != nil {
+		return err
+	}
+
+	space.GUID = ccSpace.Metadata.GUID
+	space.Name = ccSpace.Entity.Name
+	space.AllowSSH = ccSpace.Entity.AllowSSH
+	return nil
+}
+
+// GetSpaces returns back a list of Spaces based off of the provided queries.
+func (client *Client) GetSpaces(queries []Query) ([]Space, Warnings, error) {
+	request, err := client.newHTTPRequest(requestOptions{
+		RequestName: internal.SpacesRequest,
+		Query:       FormatQueryParameters(queries),
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	fullSpacesList := []Space{}
+	fullWarningsList := Warnings{}
+
+	for {
+		var spaces []Space
+		wrapper := PaginatedWrapper{
+			Resources: &spaces,
+		}
+		response := cloudcontroller.Response{
+			Result: &wrapper,
+		}
+
+		err := client.connection.Make(request, &response)
+		fullWarningsList = append(fullWarningsList, response.Warnings...)
+		if err != nil {
+			return nil, fullWarningsList, err
+		}
+		fullSpacesList = append(fullSpacesList, spaces...)
+
+		if wrapper.NextURL == "" {
+			break
+		}
+		request, err = client.newHTTPRequest(requestOptions{
+			URI:    wrapper.NextURL,
+			Method: http.MethodGet,
+		})
+		if err != nil {
+			return nil, fullWarningsList, err
+		}
+	}
+
+	return fullSpacesList, fullWarningsList, nil
+}
@@ -0,0 +1,66 @@
+package ccv2_test
+
+import (
+	"net/http"
+
+	. "code.cloudfoundry.org/cli/api/cloudcontroller/ccv2"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Space", func() {
+	var client *Client
+
+	BeforeEach(func() {
+		client = NewTestClient()
+	})
+
+	Describe("GetSpaces", func() {
+		BeforeEach(func() {
+			response := `{
+				"next_url": null,
+				"resources": [
+					{
+						"metadata": {
+							"guid": "space-guid-1"
+						},
+						"entity": {
+							"name": "space-name-1",
+							"allow_ssh": true
+						}
+					},
+					{
+						"metadata": {
+							"guid": "space-guid-2"
+						},
+						"entity": {
+							"name": "space-name-2",
+							"allow_ssh": false
+						}
+					}
+				]
+			}`
+			server.AppendHandlers(
+				CombineHandlers(
+					VerifyRequest(http.MethodGet, "/v2/spaces", "q=organization_guid:some-org-guid"),
+					RespondWith(http.StatusOK, response, http.Header{"X-Cf-Warnings": {"this is a warning"}}),
+				),
+			)
+		})
+
+		It("returns all the queried spaces", func() {
+			spaces, warnings, err := client.GetSpaces([]Query{{
+				Filter:   OrganizationGUIDFilter,
+				Operator: EqualOperator,
+				Value:    "some-org-guid",
+			}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(spaces).To(ConsistOf([]Space{
+				{Name: "space-name-1", GUID: "space-guid-1", AllowSSH: true},
+				{Name: "space-name-2", GUID: "space-guid-2", AllowSSH: false},
+			}))
+			Expect(warnings).To(ConsistOf(Warnings{"this is a warning"}))
+		})
+	})
+})
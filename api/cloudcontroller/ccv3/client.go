@@ -62,6 +62,7 @@ type Warnings []string
 type Client struct {
 	cloudControllerURL string
 	uaaLink            string
+	loggingLink        string
 
 	connection cloudcontroller.Connection
 	userAgent  string
@@ -22,6 +22,9 @@ type RootResponse struct {
 
 		// UAA is the link to the UAA API
 		UAA APILink `json:"uaa"`
+
+		// Logging is the link to the Doppler/Loggregator websocket endpoint
+		Logging APILink `json:"logging"`
 	} `json:"links"`
 }
 
@@ -30,6 +33,11 @@ func (root RootResponse) UAA() string {
 	return root.Links.UAA.HREF
 }
 
+// Logging returns the HREF for Doppler.
+func (root RootResponse) Logging() string {
+	return root.Links.Logging.HREF
+}
+
 func (r RootResponse) ccV3Link() string {
 	return r.Links.CCV3.HREF
 }
@@ -101,3 +109,8 @@ func (client *Client) rootResponse() (RootResponse, Warnings, error) {
 func (client *Client) UAA() string {
 	return client.uaaLink
 }
+
+// Logging returns back the location of the Doppler Endpoint
+func (client *Client) Logging() string {
+	return client.loggingLink
+}
@@ -54,6 +54,9 @@ var _ = Describe("Info", func() {
 					},
 					"uaa": {
 						"href": "https://uaa.bosh-lite.com"
+					},
+					"logging": {
+						"href": "wss://doppler.bosh-lite.com:443"
 					}
 				}
 			}
@@ -86,6 +89,7 @@ var _ = Describe("Info", func() {
 			apis, _, _, err := client.Info()
 			Expect(err).NotTo(HaveOccurred())
 			Expect(apis.UAA()).To(Equal("https://uaa.bosh-lite.com"))
+			Expect(apis.Logging()).To(Equal("wss://doppler.bosh-lite.com:443"))
 		})
 
 		It("returns all warnings", func() {
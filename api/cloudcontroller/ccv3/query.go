@@ -0,0 +1,57 @@
+package ccv3
+
+import (
+	"net/url"
+	"strings"
+)
+
+// QueryKey is the type of query that is being selected on.
+type QueryKey string
+
+const (
+	// NameFilter is a query parameter for listing objects by name.
+	NameFilter QueryKey = "names"
+	// StateFilter is a query parameter for listing objects by state.
+	StateFilter QueryKey = "states"
+	// SequenceIDFilter is a query parameter for listing tasks by sequence ID.
+	SequenceIDFilter QueryKey = "sequence_ids"
+	// CreatedAfterFilter is a query parameter for listing objects created
+	// after the given timestamp.
+	CreatedAfterFilter QueryKey = "created_ats[gt]"
+	// CreatedBeforeFilter is a query parameter for listing objects created
+	// before the given timestamp.
+	CreatedBeforeFilter QueryKey = "created_ats[lt]"
+
+	// OrderBy is a query parameter for the sort order of the returned objects.
+	OrderBy QueryKey = "order_by"
+	// PerPage is a query parameter for the number of objects returned per
+	// page.
+	PerPage QueryKey = "per_page"
+)
+
+const (
+	// MaxPerPage is the largest page size the Cloud Controller accepts.
+	MaxPerPage = 5000
+
+	// CreatedAtDescending orders objects from newest to oldest.
+	CreatedAtDescending = "-created_at"
+)
+
+// Query is a type of filter that can be passed to specific request to narrow
+// down the return set.
+type Query struct {
+	Key    QueryKey
+	Values []string
+}
+
+// FormatQueryParameters converts a Query object into a collection that
+// cloudcontroller.Request can accept. Multiple values for the same key are
+// joined with commas.
+func FormatQueryParameters(queries []Query) url.Values {
+	params := url.Values{}
+	for _, query := range queries {
+		params.Add(string(query.Key), strings.Join(query.Values, ","))
+	}
+
+	return params
+}
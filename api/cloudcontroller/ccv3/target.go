@@ -36,6 +36,7 @@ func (client *Client) TargetCF(settings TargetSettings) (Warnings, error) {
 	}
 
 	client.uaaLink = apis.UAA()
+	client.loggingLink = apis.Logging()
 
 	return warnings, nil
 }
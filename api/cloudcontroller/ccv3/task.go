@@ -5,19 +5,25 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
-	"net/url"
 
 	"code.cloudfoundry.org/cli/api/cloudcontroller"
 )
 
 // Task represents a Cloud Controller V3 Task.
 type Task struct {
-	GUID       string `json:"guid"`
-	SequenceID int    `json:"sequence_id"`
-	Name       string `json:"name"`
-	Command    string `json:"command"`
-	State      string `json:"state"`
-	CreatedAt  string `json:"created_at"`
+	GUID       string     `json:"guid"`
+	SequenceID int        `json:"sequence_id"`
+	Name       string     `json:"name"`
+	Command    string     `json:"command"`
+	State      string     `json:"state"`
+	CreatedAt  string     `json:"created_at"`
+	Result     TaskResult `json:"result"`
+}
+
+// TaskResult represents the outcome of a Cloud Controller V3 Task.
+type TaskResult struct {
+	// FailureReason is set when the task has FAILED.
+	FailureReason string `json:"failure_reason"`
 }
 
 // NewTaskBody represents the body of the request to create a Task.
@@ -60,12 +66,14 @@ func (client *Client) NewTask(appGUID string, command string, name string) (Task
 }
 
 // GetApplicationTasks returns a list of tasks associated with the provided
-// application GUID. Results can be filtered by providing URL queries.
-func (client *Client) GetApplicationTasks(appGUID string, query url.Values) ([]Task, Warnings, error) {
+// application GUID. Results can be filtered by providing queries. When limit
+// is greater than zero, no more than limit tasks are returned and no further
+// pages are requested once it has been reached.
+func (client *Client) GetApplicationTasks(appGUID string, queries []Query, limit int) ([]Task, Warnings, error) {
 	request, err := client.newHTTPRequest(requestOptions{
 		URL:    fmt.Sprintf("%s/v3/apps/%s/tasks", client.cloudControllerURL, appGUID),
 		Method: http.MethodGet,
-		Query:  query,
+		Query:  FormatQueryParameters(queries),
 	})
 	if err != nil {
 		return nil, nil, err
@@ -90,6 +98,11 @@ func (client *Client) GetApplicationTasks(appGUID string, query url.Values) ([]T
 		}
 		allTasks = append(allTasks, tasks...)
 
+		if limit > 0 && len(allTasks) >= limit {
+			allTasks = allTasks[:limit]
+			break
+		}
+
 		if wrapper.Pagination.Next.HREF == "" {
 			break
 		}
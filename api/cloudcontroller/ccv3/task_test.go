@@ -3,7 +3,6 @@ package ccv3_test
 import (
 	"fmt"
 	"net/http"
-	"net/url"
 
 	"code.cloudfoundry.org/cli/api/cloudcontroller"
 	. "code.cloudfoundry.org/cli/api/cloudcontroller/ccv3"
@@ -168,7 +167,10 @@ var _ = Describe("Task", func() {
       "name": "task-2",
       "command": "some-command",
       "state": "FAILED",
-      "created_at": "2016-11-07T06:59:01Z"
+      "created_at": "2016-11-07T06:59:01Z",
+      "result": {
+        "failure_reason": "Exited with status 1"
+      }
     }
   ]
 }`, server.URL())
@@ -202,7 +204,7 @@ var _ = Describe("Task", func() {
 			})
 
 			It("returns a list of tasks associated with the application and all warnings", func() {
-				tasks, warnings, err := client.GetApplicationTasks("some-app-guid", url.Values{"per_page": []string{"2"}})
+				tasks, warnings, err := client.GetApplicationTasks("some-app-guid", []Query{{Key: PerPage, Values: []string{"2"}}}, 0)
 				Expect(err).ToNot(HaveOccurred())
 
 				Expect(tasks).To(ConsistOf(
@@ -221,6 +223,9 @@ var _ = Describe("Task", func() {
 						State:      "FAILED",
 						CreatedAt:  "2016-11-07T06:59:01Z",
 						Command:    "some-command",
+						Result: TaskResult{
+							FailureReason: "Exited with status 1",
+						},
 					},
 					Task{
 						GUID:       "task-3-guid",
@@ -235,6 +240,109 @@ var _ = Describe("Task", func() {
 			})
 		})
 
+		Context("when filters are provided", func() {
+			BeforeEach(func() {
+				response := `{
+  "pagination": {
+    "next": null
+  },
+  "resources": [
+    {
+      "guid": "task-1-guid",
+      "sequence_id": 1,
+      "name": "some-task",
+      "command": "some-command",
+      "state": "RUNNING",
+      "created_at": "2016-11-07T05:59:01Z"
+    }
+  ]
+}`
+				server.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodGet, "/v3/apps/some-app-guid/tasks", "created_ats%5Bgt%5D=2016-11-07T00%3A00%3A00Z&names=some-task&order_by=-created_at&states=RUNNING%2CFAILED"),
+						RespondWith(http.StatusOK, response, http.Header{"X-Cf-Warnings": {"warning-1"}}),
+					),
+				)
+			})
+
+			It("passes them to the cloud controller as query parameters", func() {
+				tasks, warnings, err := client.GetApplicationTasks("some-app-guid", []Query{
+					{Key: StateFilter, Values: []string{"RUNNING", "FAILED"}},
+					{Key: NameFilter, Values: []string{"some-task"}},
+					{Key: CreatedAfterFilter, Values: []string{"2016-11-07T00:00:00Z"}},
+					{Key: OrderBy, Values: []string{CreatedAtDescending}},
+				}, 0)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(tasks).To(ConsistOf(Task{
+					GUID:       "task-1-guid",
+					SequenceID: 1,
+					Name:       "some-task",
+					State:      "RUNNING",
+					CreatedAt:  "2016-11-07T05:59:01Z",
+					Command:    "some-command",
+				}))
+				Expect(warnings).To(ConsistOf("warning-1"))
+			})
+		})
+
+		Context("when a limit is provided", func() {
+			BeforeEach(func() {
+				response := fmt.Sprintf(`{
+  "pagination": {
+    "next": {
+      "href": "%s/v3/apps/some-app-guid/tasks?per_page=2&page=2"
+    }
+  },
+  "resources": [
+    {
+      "guid": "task-1-guid",
+      "sequence_id": 1,
+      "name": "task-1",
+      "command": "some-command",
+      "state": "SUCCEEDED",
+      "created_at": "2016-11-07T05:59:01Z"
+    },
+    {
+      "guid": "task-2-guid",
+      "sequence_id": 2,
+      "name": "task-2",
+      "command": "some-command",
+      "state": "RUNNING",
+      "created_at": "2016-11-07T06:59:01Z"
+    }
+  ]
+}`, server.URL())
+				server.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodGet, "/v3/apps/some-app-guid/tasks", "per_page=2"),
+						RespondWith(http.StatusOK, response, http.Header{"X-Cf-Warnings": {"warning-1"}}),
+					),
+				)
+			})
+
+			Context("when the limit is reached before the last page", func() {
+				It("returns at most limit tasks without requesting further pages", func() {
+					requestCount := len(server.ReceivedRequests())
+					tasks, warnings, err := client.GetApplicationTasks("some-app-guid", []Query{{Key: PerPage, Values: []string{"2"}}}, 1)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(tasks).To(HaveLen(1))
+					Expect(tasks[0].GUID).To(Equal("task-1-guid"))
+					Expect(warnings).To(ConsistOf("warning-1"))
+					Expect(server.ReceivedRequests()).To(HaveLen(requestCount + 1))
+				})
+			})
+
+			Context("when the limit is reached at the end of a page", func() {
+				It("does not request further pages", func() {
+					requestCount := len(server.ReceivedRequests())
+					tasks, _, err := client.GetApplicationTasks("some-app-guid", []Query{{Key: PerPage, Values: []string{"2"}}}, 2)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(tasks).To(HaveLen(2))
+					Expect(server.ReceivedRequests()).To(HaveLen(requestCount + 1))
+				})
+			})
+		})
+
 		Context("when the application does not exist", func() {
 			BeforeEach(func() {
 				response := `{
@@ -255,7 +363,7 @@ var _ = Describe("Task", func() {
 			})
 
 			It("returns a ResourceNotFoundError", func() {
-				_, _, err := client.GetApplicationTasks("some-app-guid", nil)
+				_, _, err := client.GetApplicationTasks("some-app-guid", nil, 0)
 				Expect(err).To(MatchError(cloudcontroller.ResourceNotFoundError{Message: "App not found"}))
 			})
 		})
@@ -285,7 +393,7 @@ var _ = Describe("Task", func() {
 			})
 
 			It("returns the errors and all warnings", func() {
-				_, warnings, err := client.GetApplicationTasks("some-app-guid", nil)
+				_, warnings, err := client.GetApplicationTasks("some-app-guid", nil, 0)
 				Expect(err).To(MatchError(UnexpectedResponseError{
 					ResponseCode: http.StatusTeapot,
 					CCErrorResponse: CCErrorResponse{
@@ -11,6 +11,8 @@ import (
 	"net/url"
 	"strings"
 	"time"
+
+	"code.cloudfoundry.org/cli/util/cassette"
 )
 
 type CloudControllerConnection struct {
@@ -36,7 +38,7 @@ func NewConnection(config Config) *CloudControllerConnection {
 	}
 
 	return &CloudControllerConnection{
-		HTTPClient: &http.Client{Transport: tr},
+		HTTPClient: &http.Client{Transport: cassette.WrapTransport(tr)},
 	}
 }
 
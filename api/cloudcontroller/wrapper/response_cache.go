@@ -0,0 +1,98 @@
+package wrapper
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"code.cloudfoundry.org/cli/api/cloudcontroller"
+	"code.cloudfoundry.org/cli/util/responsecache"
+)
+
+// ResponseCache is a wrapper that stores GET responses on disk and
+// revalidates them with the Cloud Controller using ETag and Last-Modified.
+// Any other request invalidates the cached responses.
+type ResponseCache struct {
+	cache      *responsecache.Cache
+	connection cloudcontroller.Connection
+}
+
+// NewResponseCache returns a pointer to a ResponseCache wrapper that stores
+// responses in dir. Responses for the root and info documents are reused for
+// ttl without revalidation.
+func NewResponseCache(dir string, ttl time.Duration) *ResponseCache {
+	return &ResponseCache{
+		cache: responsecache.New(dir, ttl),
+	}
+}
+
+// Wrap sets the connection in the ResponseCache and returns itself.
+func (cache *ResponseCache) Wrap(innerconnection cloudcontroller.Connection) cloudcontroller.Connection {
+	cache.connection = innerconnection
+	return cache
+}
+
+// Make serves GET requests from the cache when possible and stores
+// cacheable responses. Other requests are passed through and invalidate the
+// cache.
+func (cache *ResponseCache) Make(request *http.Request, passedResponse *cloudcontroller.Response) error {
+	if request.Method != http.MethodGet {
+		err := cache.connection.Make(request, passedResponse)
+		cache.cache.Invalidate(request.URL)
+		return err
+	}
+
+	entry, found, fresh := cache.cache.Load(request.URL)
+	if fresh {
+		passedResponse.Warnings = nil
+		return cache.respond(request, entry, passedResponse)
+	}
+
+	if found {
+		entry.SetConditions(request.Header)
+	}
+
+	// The inner connection is given a response without a Result so that a
+	// body-less 304 does not fail to decode.
+	var response cloudcontroller.Response
+	err := cache.connection.Make(request, &response)
+	passedResponse.HTTPResponse = response.HTTPResponse
+	passedResponse.RawResponse = response.RawResponse
+	passedResponse.Warnings = response.Warnings
+	if err != nil {
+		return err
+	}
+
+	if found && response.HTTPResponse.StatusCode == http.StatusNotModified {
+		cache.cache.Refresh(entry)
+		return cache.respond(request, entry, passedResponse)
+	}
+
+	cache.cache.Store(request.URL, response.HTTPResponse, response.RawResponse)
+	return decodeResult(passedResponse)
+}
+
+func (cache *ResponseCache) respond(request *http.Request, entry responsecache.Entry, passedResponse *cloudcontroller.Response) error {
+	passedResponse.HTTPResponse = &http.Response{
+		StatusCode: http.StatusOK,
+		Status:     "200 OK",
+		Proto:      "HTTP/1.1",
+		ProtoMajor: 1,
+		ProtoMinor: 1,
+		Header:     entry.Header,
+		Request:    request,
+	}
+	passedResponse.RawResponse = entry.Body
+	return decodeResult(passedResponse)
+}
+
+func decodeResult(passedResponse *cloudcontroller.Response) error {
+	if passedResponse.Result == nil || len(passedResponse.RawResponse) == 0 {
+		return nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewBuffer(passedResponse.RawResponse))
+	decoder.UseNumber()
+	return decoder.Decode(passedResponse.Result)
+}
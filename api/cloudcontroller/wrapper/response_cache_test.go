@@ -0,0 +1,226 @@
+package wrapper_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"time"
+
+	"code.cloudfoundry.org/cli/api/cloudcontroller"
+	"code.cloudfoundry.org/cli/api/cloudcontroller/cloudcontrollerfakes"
+	. "code.cloudfoundry.org/cli/api/cloudcontroller/wrapper"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Response Cache", func() {
+	var (
+		cacheDir       string
+		fakeConnection *cloudcontrollerfakes.FakeConnection
+		wrapper        cloudcontroller.Connection
+
+		responses []*http.Response
+		bodies    []string
+	)
+
+	makeRequest := func(method string, url string) (map[string]interface{}, *cloudcontroller.Response, error) {
+		request, err := http.NewRequest(method, url, nil)
+		Expect(err).ToNot(HaveOccurred())
+
+		var result map[string]interface{}
+		response := &cloudcontroller.Response{Result: &result}
+		err = wrapper.Make(request, response)
+		return result, response, err
+	}
+
+	BeforeEach(func() {
+		var err error
+		cacheDir, err = ioutil.TempDir("", "cf-response-cache")
+		Expect(err).ToNot(HaveOccurred())
+
+		responses = nil
+		bodies = nil
+
+		fakeConnection = new(cloudcontrollerfakes.FakeConnection)
+		fakeConnection.MakeStub = func(request *http.Request, passedResponse *cloudcontroller.Response) error {
+			call := fakeConnection.MakeCallCount() - 1
+			passedResponse.HTTPResponse = responses[call]
+			passedResponse.RawResponse = []byte(bodies[call])
+			passedResponse.Warnings = []string{"warning"}
+			if request.Method == http.MethodGet && passedResponse.Result != nil {
+				return errors.New("the cache must decode the result itself")
+			}
+			return nil
+		}
+
+		wrapper = NewResponseCache(cacheDir, time.Minute).Wrap(fakeConnection)
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(cacheDir)
+	})
+
+	Context("when the response has an ETag", func() {
+		BeforeEach(func() {
+			responses = []*http.Response{
+				{StatusCode: http.StatusOK, Header: http.Header{"Etag": {`"some-etag"`}}},
+				{StatusCode: http.StatusNotModified, Header: http.Header{}},
+			}
+			bodies = []string{`{"name":"some-app"}`, ""}
+		})
+
+		It("revalidates the cached response with If-None-Match", func() {
+			result, _, err := makeRequest(http.MethodGet, "https://api.example.com/v2/apps/some-app-guid")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result).To(HaveKeyWithValue("name", "some-app"))
+
+			result, response, err := makeRequest(http.MethodGet, "https://api.example.com/v2/apps/some-app-guid")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result).To(HaveKeyWithValue("name", "some-app"))
+			Expect(response.HTTPResponse.StatusCode).To(Equal(http.StatusOK))
+			Expect(response.Warnings).To(ConsistOf("warning"))
+
+			Expect(fakeConnection.MakeCallCount()).To(Equal(2))
+			request, _ := fakeConnection.MakeArgsForCall(1)
+			Expect(request.Header.Get("If-None-Match")).To(Equal(`"some-etag"`))
+		})
+	})
+
+	Context("when the response has a Last-Modified date", func() {
+		BeforeEach(func() {
+			responses = []*http.Response{
+				{StatusCode: http.StatusOK, Header: http.Header{"Last-Modified": {"Wed, 21 Oct 2015 07:28:00 GMT"}}},
+				{StatusCode: http.StatusOK, Header: http.Header{}},
+			}
+			bodies = []string{`{"name":"old-name"}`, `{"name":"new-name"}`}
+		})
+
+		It("revalidates with If-Modified-Since and uses the new response when it changed", func() {
+			_, _, err := makeRequest(http.MethodGet, "https://api.example.com/v2/apps/some-app-guid")
+			Expect(err).ToNot(HaveOccurred())
+
+			result, _, err := makeRequest(http.MethodGet, "https://api.example.com/v2/apps/some-app-guid")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result).To(HaveKeyWithValue("name", "new-name"))
+
+			request, _ := fakeConnection.MakeArgsForCall(1)
+			Expect(request.Header.Get("If-Modified-Since")).To(Equal("Wed, 21 Oct 2015 07:28:00 GMT"))
+		})
+	})
+
+	Context("when the response has no validators", func() {
+		BeforeEach(func() {
+			responses = []*http.Response{
+				{StatusCode: http.StatusOK, Header: http.Header{}},
+				{StatusCode: http.StatusOK, Header: http.Header{}},
+			}
+			bodies = []string{`{"name":"some-app"}`, `{"name":"some-app"}`}
+		})
+
+		It("does not cache it", func() {
+			_, _, err := makeRequest(http.MethodGet, "https://api.example.com/v2/apps/some-app-guid")
+			Expect(err).ToNot(HaveOccurred())
+			_, _, err = makeRequest(http.MethodGet, "https://api.example.com/v2/apps/some-app-guid")
+			Expect(err).ToNot(HaveOccurred())
+
+			request, _ := fakeConnection.MakeArgsForCall(1)
+			Expect(request.Header.Get("If-None-Match")).To(BeEmpty())
+			Expect(request.Header.Get("If-Modified-Since")).To(BeEmpty())
+		})
+	})
+
+	Context("when requesting the info document", func() {
+		BeforeEach(func() {
+			responses = []*http.Response{
+				{StatusCode: http.StatusOK, Header: http.Header{}},
+				{StatusCode: http.StatusOK, Header: http.Header{}},
+			}
+			bodies = []string{`{"api_version":"2.69.0"}`, `{"api_version":"2.70.0"}`}
+		})
+
+		It("serves it from the cache until the TTL expires", func() {
+			_, _, err := makeRequest(http.MethodGet, "https://api.example.com/v2/info")
+			Expect(err).ToNot(HaveOccurred())
+
+			result, response, err := makeRequest(http.MethodGet, "https://api.example.com/v2/info")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result).To(HaveKeyWithValue("api_version", "2.69.0"))
+			Expect(response.Warnings).To(BeEmpty())
+			Expect(fakeConnection.MakeCallCount()).To(Equal(1))
+		})
+
+		Context("when the TTL has expired", func() {
+			BeforeEach(func() {
+				wrapper = NewResponseCache(cacheDir, 0).Wrap(fakeConnection)
+			})
+
+			It("fetches it again", func() {
+				_, _, err := makeRequest(http.MethodGet, "https://api.example.com/v2/info")
+				Expect(err).ToNot(HaveOccurred())
+
+				result, _, err := makeRequest(http.MethodGet, "https://api.example.com/v2/info")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result).To(HaveKeyWithValue("api_version", "2.70.0"))
+				Expect(fakeConnection.MakeCallCount()).To(Equal(2))
+			})
+		})
+	})
+
+	Context("when a non-GET request is made", func() {
+		BeforeEach(func() {
+			responses = []*http.Response{
+				{StatusCode: http.StatusOK, Header: http.Header{"Etag": {`"app-etag"`}}},
+				{StatusCode: http.StatusOK, Header: http.Header{"Etag": {`"apps-etag"`}}},
+				{StatusCode: http.StatusOK, Header: http.Header{"Etag": {`"other-etag"`}}},
+				{StatusCode: http.StatusCreated, Header: http.Header{}},
+				{StatusCode: http.StatusOK, Header: http.Header{}},
+				{StatusCode: http.StatusOK, Header: http.Header{}},
+				{StatusCode: http.StatusOK, Header: http.Header{}},
+			}
+			bodies = []string{`{}`, `{}`, `{}`, `{}`, `{}`, `{}`, `{}`}
+		})
+
+		It("invalidates every cached response", func() {
+			_, _, err := makeRequest(http.MethodGet, "https://api.example.com/v2/apps/some-app-guid")
+			Expect(err).ToNot(HaveOccurred())
+			_, _, err = makeRequest(http.MethodGet, "https://api.example.com/v2/apps?q=name:some-app")
+			Expect(err).ToNot(HaveOccurred())
+			_, _, err = makeRequest(http.MethodGet, "https://api.example.com/v2/spaces/some-space-guid")
+			Expect(err).ToNot(HaveOccurred())
+
+			_, _, err = makeRequest(http.MethodPut, "https://api.example.com/v2/apps/some-app-guid")
+			Expect(err).ToNot(HaveOccurred())
+
+			_, _, err = makeRequest(http.MethodGet, "https://api.example.com/v2/apps/some-app-guid")
+			Expect(err).ToNot(HaveOccurred())
+			request, _ := fakeConnection.MakeArgsForCall(4)
+			Expect(request.Header.Get("If-None-Match")).To(BeEmpty())
+
+			_, _, err = makeRequest(http.MethodGet, "https://api.example.com/v2/apps?q=name:some-app")
+			Expect(err).ToNot(HaveOccurred())
+			request, _ = fakeConnection.MakeArgsForCall(5)
+			Expect(request.Header.Get("If-None-Match")).To(BeEmpty())
+
+			_, _, err = makeRequest(http.MethodGet, "https://api.example.com/v2/spaces/some-space-guid")
+			Expect(err).ToNot(HaveOccurred())
+			request, _ = fakeConnection.MakeArgsForCall(6)
+			Expect(request.Header.Get("If-None-Match")).To(BeEmpty())
+		})
+	})
+
+	Context("when the connection returns an error", func() {
+		var expectedErr error
+
+		BeforeEach(func() {
+			expectedErr = errors.New("some-error")
+			fakeConnection.MakeStub = nil
+			fakeConnection.MakeReturns(expectedErr)
+		})
+
+		It("returns the error", func() {
+			_, _, err := makeRequest(http.MethodGet, "https://api.example.com/v2/apps/some-app-guid")
+			Expect(err).To(MatchError(expectedErr))
+		})
+	})
+})
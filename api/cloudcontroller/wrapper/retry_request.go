@@ -2,23 +2,70 @@ package wrapper
 
 import (
 	"bytes"
+	"io"
 	"io/ioutil"
+	"math/rand"
+	"net"
 	"net/http"
+	"net/url"
+	"strconv"
+	"time"
 
 	"code.cloudfoundry.org/cli/api/cloudcontroller"
 )
 
-// RetryRequest is a wrapper that retries failed requests if they contain a 5XX
-// status code.
+const (
+	// DefaultRetryInitialBackoff is the time waited before the first retry
+	// when the policy does not specify one.
+	DefaultRetryInitialBackoff = 500 * time.Millisecond
+
+	// DefaultRetryMaxBackoff is the longest time waited between two attempts
+	// when the policy does not specify one. It does not limit Retry-After.
+	DefaultRetryMaxBackoff = 10 * time.Second
+)
+
+// RetryPolicy determines how many times and for how long RetryRequest retries
+// a failed request.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of times a request is made, including
+	// the first attempt.
+	MaxAttempts int
+
+	// MaxElapsedTime is the maximum time spent on a request across all
+	// attempts. A retry is not made if waiting for it would exceed this time.
+	// Zero means no limit.
+	MaxElapsedTime time.Duration
+
+	// InitialBackoff is the time waited before the first retry. It doubles
+	// with every following retry.
+	InitialBackoff time.Duration
+
+	// MaxBackoff caps the time waited between two attempts.
+	MaxBackoff time.Duration
+}
+
+// RetryRequest is a wrapper that retries failed requests with exponential
+// backoff. Requests are retried if they:
+//   - come back with a 429, or a 5XX status code for non-POST requests
+//   - fail with a transient network error for idempotent requests
+//
+// A Retry-After header on a 429 or 503 response is honored.
 type RetryRequest struct {
-	maxRetries int
+	policy     RetryPolicy
 	connection cloudcontroller.Connection
 }
 
 // NewRetryRequest returns a pointer to a RetryRequest wrapper.
-func NewRetryRequest(maxRetries int) *RetryRequest {
+func NewRetryRequest(policy RetryPolicy) *RetryRequest {
+	if policy.InitialBackoff <= 0 {
+		policy.InitialBackoff = DefaultRetryInitialBackoff
+	}
+	if policy.MaxBackoff <= 0 {
+		policy.MaxBackoff = DefaultRetryMaxBackoff
+	}
+
 	return &RetryRequest{
-		maxRetries: maxRetries,
+		policy: policy,
 	}
 }
 
@@ -28,7 +75,8 @@ func (retry *RetryRequest) Wrap(innerconnection cloudcontroller.Connection) clou
 	return retry
 }
 
-// Make retries the request if it comes back with a 5XX status code.
+// Make retries the request according to the RetryPolicy if it fails with a
+// retryable error.
 func (retry *RetryRequest) Make(request *http.Request, passedResponse *cloudcontroller.Response) error {
 	var err error
 	var rawRequestBody []byte
@@ -41,7 +89,12 @@ func (retry *RetryRequest) Make(request *http.Request, passedResponse *cloudcont
 		}
 	}
 
-	for i := 0; i < retry.maxRetries+1; i += 1 {
+	startTime := time.Now()
+	for attempt := 1; ; attempt++ {
+		// The response of a previous attempt must not decide whether this one
+		// is retried, in case it fails before getting a response.
+		passedResponse.HTTPResponse = nil
+
 		if rawRequestBody != nil {
 			request.Body = ioutil.NopCloser(bytes.NewBuffer(rawRequestBody))
 		}
@@ -50,14 +103,122 @@ func (retry *RetryRequest) Make(request *http.Request, passedResponse *cloudcont
 			return nil
 		}
 
-		if request.Method == http.MethodPost ||
-			passedResponse.HTTPResponse != nil &&
-				passedResponse.HTTPResponse.StatusCode != http.StatusInternalServerError &&
-				passedResponse.HTTPResponse.StatusCode != http.StatusBadGateway &&
-				passedResponse.HTTPResponse.StatusCode != http.StatusServiceUnavailable &&
-				passedResponse.HTTPResponse.StatusCode != http.StatusGatewayTimeout {
+		if attempt >= retry.policy.MaxAttempts || !shouldRetry(request, passedResponse, err) {
+			break
+		}
+
+		wait := retry.backoff(attempt)
+		if retryAfter, ok := retryAfterDuration(passedResponse); ok && retryAfter > wait {
+			wait = retryAfter
+		}
+
+		if retry.policy.MaxElapsedTime > 0 && time.Since(startTime)+wait > retry.policy.MaxElapsedTime {
 			break
 		}
+		time.Sleep(wait)
 	}
 	return err
 }
+
+// backoff returns the time to wait after the given attempt. Half of the
+// exponential backoff is randomized so that clients failing at the same time
+// do not retry in lockstep.
+func (retry *RetryRequest) backoff(attempt int) time.Duration {
+	backoff := retry.policy.InitialBackoff
+	for i := 1; i < attempt && backoff < retry.policy.MaxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > retry.policy.MaxBackoff {
+		backoff = retry.policy.MaxBackoff
+	}
+
+	half := backoff / 2
+	return half + time.Duration(rand.Int63n(int64(backoff-half)+1))
+}
+
+func shouldRetry(request *http.Request, response *cloudcontroller.Response, err error) bool {
+	if response.HTTPResponse == nil {
+		return isIdempotent(request.Method) && isTransientError(err)
+	}
+
+	switch response.HTTPResponse.StatusCode {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return request.Method != http.MethodPost
+	default:
+		return false
+	}
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// isTransientError returns true if the request failed because the connection
+// to the server could not be made, was reset or timed out.
+func isTransientError(err error) bool {
+	requestErr, ok := err.(cloudcontroller.RequestError)
+	if !ok {
+		return false
+	}
+
+	err = requestErr.Err
+	if urlErr, ok := err.(*url.Error); ok {
+		err = urlErr.Err
+	}
+
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		return true
+	}
+	if netErr, ok := err.(net.Error); ok && netErr.Timeout() {
+		return true
+	}
+	if opErr, ok := err.(*net.OpError); ok {
+		if dnsErr, isDNSErr := opErr.Err.(*net.DNSError); isDNSErr {
+			return dnsErr.Temporary()
+		}
+		return true
+	}
+	return false
+}
+
+// retryAfterDuration returns the time the server asked to wait before
+// retrying a 429 or 503 response. The Retry-After header can either be a
+// number of seconds or an HTTP date.
+func retryAfterDuration(response *cloudcontroller.Response) (time.Duration, bool) {
+	if response.HTTPResponse == nil {
+		return 0, false
+	}
+	if response.HTTPResponse.StatusCode != http.StatusTooManyRequests &&
+		response.HTTPResponse.StatusCode != http.StatusServiceUnavailable {
+		return 0, false
+	}
+
+	value := response.HTTPResponse.Header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		wait := date.Sub(time.Now())
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+
+	return 0, false
+}
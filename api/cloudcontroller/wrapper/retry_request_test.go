@@ -1,9 +1,14 @@
 package wrapper_test
 
 import (
+	"errors"
+	"io"
 	"io/ioutil"
+	"net"
 	"net/http"
+	"net/url"
 	"strings"
+	"time"
 
 	"code.cloudfoundry.org/cli/api/cloudcontroller"
 	"code.cloudfoundry.org/cli/api/cloudcontroller/cloudcontrollerfakes"
@@ -14,6 +19,16 @@ import (
 )
 
 var _ = Describe("Retry Request", func() {
+	var policy RetryPolicy
+
+	BeforeEach(func() {
+		policy = RetryPolicy{
+			MaxAttempts:    3,
+			InitialBackoff: time.Millisecond,
+			MaxBackoff:     2 * time.Millisecond,
+		}
+	})
+
 	DescribeTable("number of retries",
 		func(requestMethod string, responseStatusCode int, expectedNumberOfRetries int) {
 			request, err := http.NewRequest(requestMethod, "https://foo.bar.com/banana", nil)
@@ -22,11 +37,7 @@ var _ = Describe("Retry Request", func() {
 			rawRequestBody := "banana pants"
 			request.Body = ioutil.NopCloser(strings.NewReader(rawRequestBody))
 
-			response := &cloudcontroller.Response{
-				HTTPResponse: &http.Response{
-					StatusCode: responseStatusCode,
-				},
-			}
+			response := &cloudcontroller.Response{}
 
 			fakeConnection := new(cloudcontrollerfakes.FakeConnection)
 			expectedErr := cloudcontroller.RawHTTPStatusError{
@@ -37,10 +48,11 @@ var _ = Describe("Retry Request", func() {
 				body, err := ioutil.ReadAll(request.Body)
 				Expect(err).ToNot(HaveOccurred())
 				Expect(string(body)).To(Equal(rawRequestBody))
+				passedResponse.HTTPResponse = &http.Response{StatusCode: responseStatusCode}
 				return expectedErr
 			}
 
-			wrapper := NewRetryRequest(2).Wrap(fakeConnection)
+			wrapper := NewRetryRequest(policy).Wrap(fakeConnection)
 			err = wrapper.Make(request, response)
 			Expect(err).To(MatchError(expectedErr))
 			Expect(fakeConnection.MakeCallCount()).To(Equal(expectedNumberOfRetries))
@@ -50,11 +62,13 @@ var _ = Describe("Retry Request", func() {
 		Entry("maxRetries for Non-Post (502) Bad Gateway", http.MethodGet, http.StatusBadGateway, 3),
 		Entry("maxRetries for Non-Post (503) Service Unavailable", http.MethodGet, http.StatusServiceUnavailable, 3),
 		Entry("maxRetries for Non-Post (504) Gateway Timeout", http.MethodGet, http.StatusGatewayTimeout, 3),
+		Entry("maxRetries for Non-Post (429) Too Many Requests", http.MethodGet, http.StatusTooManyRequests, 3),
 
 		Entry("1 for Post (500) Internal Server Error", http.MethodPost, http.StatusInternalServerError, 1),
 		Entry("1 for Post (502) Bad Gateway", http.MethodPost, http.StatusBadGateway, 1),
 		Entry("1 for Post (503) Service Unavailable", http.MethodPost, http.StatusServiceUnavailable, 1),
 		Entry("1 for Post (504) Gateway Timeout", http.MethodPost, http.StatusGatewayTimeout, 1),
+		Entry("maxRetries for Post (429) Too Many Requests", http.MethodPost, http.StatusTooManyRequests, 3),
 
 		Entry("1 for Post 4XX Errors", http.MethodGet, http.StatusNotFound, 1),
 	)
@@ -69,10 +83,193 @@ var _ = Describe("Retry Request", func() {
 		}
 
 		fakeConnection := new(cloudcontrollerfakes.FakeConnection)
-		wrapper := NewRetryRequest(2).Wrap(fakeConnection)
+		wrapper := NewRetryRequest(policy).Wrap(fakeConnection)
 
 		err = wrapper.Make(request, response)
 		Expect(err).ToNot(HaveOccurred())
 		Expect(fakeConnection.MakeCallCount()).To(Equal(1))
 	})
+
+	It("backs off exponentially between attempts", func() {
+		policy.InitialBackoff = 20 * time.Millisecond
+		policy.MaxBackoff = time.Second
+
+		request, err := http.NewRequest(http.MethodGet, "https://foo.bar.com/banana", nil)
+		Expect(err).NotTo(HaveOccurred())
+		response := &cloudcontroller.Response{}
+
+		fakeConnection := new(cloudcontrollerfakes.FakeConnection)
+		fakeConnection.MakeStub = respondWith(&http.Response{StatusCode: http.StatusBadGateway}, cloudcontroller.RawHTTPStatusError{StatusCode: http.StatusBadGateway})
+		wrapper := NewRetryRequest(policy).Wrap(fakeConnection)
+
+		startTime := time.Now()
+		err = wrapper.Make(request, response)
+		Expect(err).To(HaveOccurred())
+		Expect(fakeConnection.MakeCallCount()).To(Equal(3))
+		// At least half of 20ms and 40ms are waited between the attempts.
+		Expect(time.Since(startTime)).To(BeNumerically(">=", 30*time.Millisecond))
+	})
+
+	Describe("Retry-After", func() {
+		var (
+			request        *http.Request
+			response       *cloudcontroller.Response
+			httpResponse   *http.Response
+			fakeConnection *cloudcontrollerfakes.FakeConnection
+		)
+
+		BeforeEach(func() {
+			var err error
+			request, err = http.NewRequest(http.MethodGet, "https://foo.bar.com/banana", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response = &cloudcontroller.Response{}
+			httpResponse = &http.Response{
+				StatusCode: http.StatusServiceUnavailable,
+				Header:     http.Header{},
+			}
+
+			fakeConnection = new(cloudcontrollerfakes.FakeConnection)
+			fakeConnection.MakeStub = respondWith(httpResponse, cloudcontroller.RawHTTPStatusError{StatusCode: http.StatusServiceUnavailable})
+		})
+
+		Context("when waiting for Retry-After would exceed the max elapsed time", func() {
+			BeforeEach(func() {
+				policy.MaxElapsedTime = 500 * time.Millisecond
+			})
+
+			It("does not retry before the server asked to when given seconds", func() {
+				httpResponse.Header.Set("Retry-After", "2")
+
+				err := NewRetryRequest(policy).Wrap(fakeConnection).Make(request, response)
+				Expect(err).To(HaveOccurred())
+				Expect(fakeConnection.MakeCallCount()).To(Equal(1))
+			})
+
+			It("does not retry before the server asked to when given a date", func() {
+				httpResponse.Header.Set("Retry-After", time.Now().Add(time.Minute).UTC().Format(http.TimeFormat))
+
+				err := NewRetryRequest(policy).Wrap(fakeConnection).Make(request, response)
+				Expect(err).To(HaveOccurred())
+				Expect(fakeConnection.MakeCallCount()).To(Equal(1))
+			})
+		})
+
+		Context("when the server asks to retry immediately", func() {
+			It("retries up to the max attempts", func() {
+				httpResponse.Header.Set("Retry-After", "0")
+
+				err := NewRetryRequest(policy).Wrap(fakeConnection).Make(request, response)
+				Expect(err).To(HaveOccurred())
+				Expect(fakeConnection.MakeCallCount()).To(Equal(3))
+			})
+		})
+	})
+
+	Describe("transport errors", func() {
+		var fakeConnection *cloudcontrollerfakes.FakeConnection
+
+		BeforeEach(func() {
+			fakeConnection = new(cloudcontrollerfakes.FakeConnection)
+		})
+
+		DescribeTable("retrying requests that did not get a response",
+			func(requestMethod string, requestErr error, expectedNumberOfAttempts int) {
+				fakeConnection.MakeReturns(requestErr)
+
+				request, err := http.NewRequest(requestMethod, "https://foo.bar.com/banana", nil)
+				Expect(err).NotTo(HaveOccurred())
+
+				err = NewRetryRequest(policy).Wrap(fakeConnection).Make(request, &cloudcontroller.Response{})
+				Expect(err).To(MatchError(requestErr))
+				Expect(fakeConnection.MakeCallCount()).To(Equal(expectedNumberOfAttempts))
+			},
+
+			Entry("retries GET on connection reset", http.MethodGet, resetError(), 3),
+			Entry("retries PUT on connection reset", http.MethodPut, resetError(), 3),
+			Entry("retries DELETE on connection reset", http.MethodDelete, resetError(), 3),
+			Entry("retries GET on a timeout", http.MethodGet, timeoutError(), 3),
+			Entry("retries GET on an unexpected EOF", http.MethodGet, cloudcontroller.RequestError{Err: &url.Error{Op: "Get", URL: "https://foo.bar.com/banana", Err: io.EOF}}, 3),
+
+			Entry("does not retry POST on connection reset", http.MethodPost, resetError(), 1),
+			Entry("does not retry PATCH on connection reset", http.MethodPatch, resetError(), 1),
+			Entry("does not retry GET when the host cannot be found", http.MethodGet, cloudcontroller.RequestError{Err: &url.Error{Op: "Get", URL: "https://foo.bar.com/banana", Err: &net.OpError{Op: "dial", Err: &net.DNSError{Err: "no such host", Name: "foo.bar.com"}}}}, 1),
+			Entry("does not retry GET on other errors", http.MethodGet, errors.New("some-error"), 1),
+		)
+
+		Context("when a POST gets a 429 and then a transport error", func() {
+			It("does not retry the transport error because of the earlier response", func() {
+				tooManyRequests := &http.Response{
+					StatusCode: http.StatusTooManyRequests,
+					Header:     http.Header{"Retry-After": []string{"0"}},
+				}
+				fakeConnection.MakeStub = func(_ *http.Request, passedResponse *cloudcontroller.Response) error {
+					if fakeConnection.MakeCallCount() == 1 {
+						passedResponse.HTTPResponse = tooManyRequests
+						return cloudcontroller.RawHTTPStatusError{StatusCode: http.StatusTooManyRequests}
+					}
+					return resetError()
+				}
+
+				request, err := http.NewRequest(http.MethodPost, "https://foo.bar.com/banana", nil)
+				Expect(err).NotTo(HaveOccurred())
+
+				response := &cloudcontroller.Response{}
+				err = NewRetryRequest(policy).Wrap(fakeConnection).Make(request, response)
+				Expect(err).To(MatchError(resetError()))
+				Expect(fakeConnection.MakeCallCount()).To(Equal(2))
+				Expect(response.HTTPResponse).To(BeNil())
+			})
+		})
+	})
+
+	Context("when MaxElapsedTime has passed", func() {
+		It("stops retrying", func() {
+			policy.MaxAttempts = 100
+			policy.InitialBackoff = 20 * time.Millisecond
+			policy.MaxBackoff = 20 * time.Millisecond
+			policy.MaxElapsedTime = 50 * time.Millisecond
+
+			fakeConnection := new(cloudcontrollerfakes.FakeConnection)
+			fakeConnection.MakeReturns(resetError())
+
+			request, err := http.NewRequest(http.MethodGet, "https://foo.bar.com/banana", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = NewRetryRequest(policy).Wrap(fakeConnection).Make(request, &cloudcontroller.Response{})
+			Expect(err).To(HaveOccurred())
+			Expect(fakeConnection.MakeCallCount()).To(BeNumerically("<=", 5))
+		})
+	})
 })
+
+// respondWith returns a Make stub that sets the HTTP response the way the
+// cloud controller connection does, and returns err.
+func respondWith(httpResponse *http.Response, err error) func(*http.Request, *cloudcontroller.Response) error {
+	return func(_ *http.Request, passedResponse *cloudcontroller.Response) error {
+		passedResponse.HTTPResponse = httpResponse
+		return err
+	}
+}
+
+func resetError() error {
+	return cloudcontroller.RequestError{
+		Err: &url.Error{
+			Op:  "Get",
+			URL: "https://foo.bar.com/banana",
+			Err: &net.OpError{Op: "read", Net: "tcp", Err: errors.New("connection reset by peer")},
+		},
+	}
+}
+
+type fakeTimeoutError struct{}
+
+func (fakeTimeoutError) Error() string   { return "i/o timeout" }
+func (fakeTimeoutError) Timeout() bool   { return true }
+func (fakeTimeoutError) Temporary() bool { return true }
+
+func timeoutError() error {
+	return cloudcontroller.RequestError{
+		Err: &url.Error{Op: "Get", URL: "https://foo.bar.com/banana", Err: fakeTimeoutError{}},
+	}
+}
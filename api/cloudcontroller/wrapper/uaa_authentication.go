@@ -4,10 +4,17 @@ import (
 	"bytes"
 	"io/ioutil"
 	"net/http"
+	"sync"
+	"time"
 
 	"code.cloudfoundry.org/cli/api/cloudcontroller"
+	"code.cloudfoundry.org/cli/api/uaa"
 )
 
+// TokenRefreshMargin is how long before its expiry an access token is
+// refreshed, so that it does not expire while a request is in flight.
+const TokenRefreshMargin = time.Minute
+
 //go:generate counterfeiter . UAAClient
 
 type UAAClient interface {
@@ -16,10 +23,14 @@ type UAAClient interface {
 }
 
 // UAAAuthentication wraps connections and adds authentication headers to all
-// requests
+// requests. Access tokens are refreshed shortly before they expire, or when
+// the Cloud Controller rejects them. Refreshes are serialized so that parallel
+// requests share a single refreshed token.
 type UAAAuthentication struct {
 	connection cloudcontroller.Connection
 	client     UAAClient
+
+	refreshLock sync.Mutex
 }
 
 // NewUAAAuthentication returns a pointer to a UAAAuthentication wrapper with
@@ -53,11 +64,15 @@ func (t *UAAAuthentication) Make(request *http.Request, passedResponse *cloudcon
 		request.Body = ioutil.NopCloser(bytes.NewBuffer(rawRequestBody))
 	}
 
-	request.Header.Set("Authorization", t.client.AccessToken())
+	token, err := t.accessToken()
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Authorization", token)
 
 	err = t.connection.Make(request, passedResponse)
 	if _, ok := err.(cloudcontroller.InvalidAuthTokenError); ok {
-		err = t.client.RefreshToken()
+		token, err = t.refreshToken(token)
 		if err != nil {
 			return err
 		}
@@ -65,9 +80,55 @@ func (t *UAAAuthentication) Make(request *http.Request, passedResponse *cloudcon
 		if rawRequestBody != nil {
 			request.Body = ioutil.NopCloser(bytes.NewBuffer(rawRequestBody))
 		}
-		request.Header.Set("Authorization", t.client.AccessToken())
+		request.Header.Set("Authorization", token)
 		err = t.connection.Make(request, passedResponse)
 	}
 
 	return err
 }
+
+// accessToken returns the current access token, refreshing it first if it
+// expires within the TokenRefreshMargin. Tokens that cannot be decoded are
+// used as is. If the refresh fails while the token is still valid, the
+// current token is used.
+func (t *UAAAuthentication) accessToken() (string, error) {
+	t.refreshLock.Lock()
+	defer t.refreshLock.Unlock()
+
+	token := t.client.AccessToken()
+	claims, err := uaa.ParseAccessToken(token)
+	if err != nil || claims.ExpiresAt().IsZero() {
+		return token, nil
+	}
+
+	expiresAt := claims.ExpiresAt()
+	if time.Now().Add(TokenRefreshMargin).Before(expiresAt) {
+		return token, nil
+	}
+
+	err = t.client.RefreshToken()
+	if err != nil {
+		if time.Now().Before(expiresAt) {
+			return token, nil
+		}
+		return "", err
+	}
+	return t.client.AccessToken(), nil
+}
+
+// refreshToken refreshes the rejected token. If another request has already
+// replaced it, the replacement is returned without refreshing again.
+func (t *UAAAuthentication) refreshToken(rejectedToken string) (string, error) {
+	t.refreshLock.Lock()
+	defer t.refreshLock.Unlock()
+
+	if token := t.client.AccessToken(); token != rejectedToken {
+		return token, nil
+	}
+
+	err := t.client.RefreshToken()
+	if err != nil {
+		return "", err
+	}
+	return t.client.AccessToken(), nil
+}
@@ -1,11 +1,14 @@
 package wrapper_test
 
 import (
+	"encoding/base64"
 	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
 	"strings"
+	"sync"
+	"time"
 
 	"code.cloudfoundry.org/cli/api/cloudcontroller"
 	"code.cloudfoundry.org/cli/api/cloudcontroller/cloudcontrollerfakes"
@@ -102,11 +105,14 @@ var _ = Describe("UAA Authentication", func() {
 					}
 				}
 
-				count := 0
+				count := 1
 				fakeClient.AccessTokenStub = func() string {
-					count = count + 1
 					return fmt.Sprintf("foobar-%d", count)
 				}
+				fakeClient.RefreshTokenStub = func() error {
+					count = count + 1
+					return nil
+				}
 
 				err := wrapper.Make(request, nil)
 				Expect(err).ToNot(HaveOccurred())
@@ -123,5 +129,136 @@ var _ = Describe("UAA Authentication", func() {
 				Expect(request.Header.Get("Authorization")).To(Equal("foobar-2"))
 			})
 		})
+
+		Context("when the token expires soon", func() {
+			var (
+				token string
+				lock  sync.Mutex
+			)
+
+			BeforeEach(func() {
+				token = jwtExpiringAt(time.Now().Add(TokenRefreshMargin / 2))
+				fakeClient.AccessTokenStub = func() string {
+					lock.Lock()
+					defer lock.Unlock()
+					return token
+				}
+				fakeClient.RefreshTokenStub = func() error {
+					lock.Lock()
+					defer lock.Unlock()
+					token = "bearer refreshed-token"
+					return nil
+				}
+			})
+
+			It("refreshes the token before making the request", func() {
+				err := wrapper.Make(request, nil)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(fakeClient.RefreshTokenCallCount()).To(Equal(1))
+				Expect(fakeConnection.MakeCallCount()).To(Equal(1))
+				authenticatedRequest, _ := fakeConnection.MakeArgsForCall(0)
+				Expect(authenticatedRequest.Header.Get("Authorization")).To(Equal("bearer refreshed-token"))
+			})
+
+			Context("when requests are made in parallel", func() {
+				It("refreshes the token once", func() {
+					var wg sync.WaitGroup
+					for i := 0; i < 10; i++ {
+						wg.Add(1)
+						go func() {
+							defer GinkgoRecover()
+							defer wg.Done()
+							err := wrapper.Make(&http.Request{Header: http.Header{}}, nil)
+							Expect(err).ToNot(HaveOccurred())
+						}()
+					}
+					wg.Wait()
+
+					Expect(fakeClient.RefreshTokenCallCount()).To(Equal(1))
+					Expect(fakeConnection.MakeCallCount()).To(Equal(10))
+				})
+			})
+
+			Context("when the refresh fails", func() {
+				var expectedErr error
+
+				BeforeEach(func() {
+					expectedErr = errors.New("refresh error")
+					fakeClient.RefreshTokenStub = nil
+					fakeClient.RefreshTokenReturns(expectedErr)
+				})
+
+				It("makes the request with the current token", func() {
+					err := wrapper.Make(request, nil)
+					Expect(err).ToNot(HaveOccurred())
+
+					authenticatedRequest, _ := fakeConnection.MakeArgsForCall(0)
+					Expect(authenticatedRequest.Header.Get("Authorization")).To(Equal(token))
+				})
+			})
+		})
+
+		Context("when the token has expired and cannot be refreshed", func() {
+			var expectedErr error
+
+			BeforeEach(func() {
+				expectedErr = errors.New("refresh error")
+				fakeClient.AccessTokenReturns(jwtExpiringAt(time.Now().Add(-time.Minute)))
+				fakeClient.RefreshTokenReturns(expectedErr)
+			})
+
+			It("returns the error without making the request", func() {
+				err := wrapper.Make(request, nil)
+				Expect(err).To(MatchError(expectedErr))
+				Expect(fakeConnection.MakeCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the token does not expire soon", func() {
+			BeforeEach(func() {
+				fakeClient.AccessTokenReturns(jwtExpiringAt(time.Now().Add(time.Hour)))
+			})
+
+			It("does not refresh the token", func() {
+				err := wrapper.Make(request, nil)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(fakeClient.RefreshTokenCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when another request has already refreshed a rejected token", func() {
+			BeforeEach(func() {
+				accessTokenCount := 0
+				fakeClient.AccessTokenStub = func() string {
+					accessTokenCount++
+					if accessTokenCount == 1 {
+						return "bearer rejected-token"
+					}
+					return "bearer new-token"
+				}
+				fakeConnection.MakeStub = func(request *http.Request, _ *cloudcontroller.Response) error {
+					if request.Header.Get("Authorization") == "bearer rejected-token" {
+						return cloudcontroller.InvalidAuthTokenError{}
+					}
+					return nil
+				}
+			})
+
+			It("resends the request with the new token without refreshing", func() {
+				err := wrapper.Make(request, nil)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(fakeClient.RefreshTokenCallCount()).To(Equal(0))
+				Expect(fakeConnection.MakeCallCount()).To(Equal(2))
+				resentRequest, _ := fakeConnection.MakeArgsForCall(1)
+				Expect(resentRequest.Header.Get("Authorization")).To(Equal("bearer new-token"))
+			})
+		})
 	})
 })
+
+func jwtExpiringAt(expiry time.Time) string {
+	claims := fmt.Sprintf(`{"user_name":"some-user","exp":%d}`, expiry.Unix())
+	return "bearer header." + base64.RawURLEncoding.EncodeToString([]byte(claims)) + ".signature"
+}
@@ -1,5 +1,65 @@
 package uaa
 
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+// AccessTokenClaims are the claims of a UAA issued JSON Web Token.
+type AccessTokenClaims struct {
+	UserName  string   `json:"user_name"`
+	UserID    string   `json:"user_id"`
+	Email     string   `json:"email"`
+	ClientID  string   `json:"client_id"`
+	GrantType string   `json:"grant_type"`
+	Issuer    string   `json:"iss"`
+	Scopes    []string `json:"scope"`
+	IssuedAt  int64    `json:"iat"`
+	Expiry    int64    `json:"exp"`
+}
+
+// IssuedAtTime returns the time the token was issued.
+func (claims AccessTokenClaims) IssuedAtTime() time.Time {
+	return time.Unix(claims.IssuedAt, 0)
+}
+
+// ExpiresAt returns the time the token expires. It is the zero time if the
+// token does not expire.
+func (claims AccessTokenClaims) ExpiresAt() time.Time {
+	if claims.Expiry == 0 {
+		return time.Time{}
+	}
+	return time.Unix(claims.Expiry, 0)
+}
+
+// ParseAccessToken decodes the claims of an access token, with or without
+// its "bearer" prefix. The signature is not verified; the claims are only
+// meant to be informational.
+func ParseAccessToken(token string) (AccessTokenClaims, error) {
+	if index := strings.Index(token, " "); index != -1 {
+		token = token[index+1:]
+	}
+
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return AccessTokenClaims{}, InvalidAccessTokenError{}
+	}
+
+	rawClaims, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return AccessTokenClaims{}, InvalidAccessTokenError{}
+	}
+
+	var claims AccessTokenClaims
+	err = json.Unmarshal(rawClaims, &claims)
+	if err != nil {
+		return AccessTokenClaims{}, InvalidAccessTokenError{}
+	}
+	return claims, nil
+}
+
 // AccessToken returns the implicit grant access token
 func (client *Client) AccessToken() string {
 	return client.store.AccessToken()
@@ -1,6 +1,9 @@
 package uaa_test
 
 import (
+	"encoding/base64"
+	"time"
+
 	. "code.cloudfoundry.org/cli/api/uaa"
 	"code.cloudfoundry.org/cli/api/uaa/uaafakes"
 
@@ -23,3 +26,74 @@ var _ = Describe("AccessToken", func() {
 		Expect(client.AccessToken()).To(Equal("access-token"))
 	})
 })
+
+var _ = Describe("ParseAccessToken", func() {
+	var (
+		token      string
+		claims     AccessTokenClaims
+		executeErr error
+	)
+
+	JustBeforeEach(func() {
+		claims, executeErr = ParseAccessToken(token)
+	})
+
+	Context("when the token is a JSON Web Token", func() {
+		BeforeEach(func() {
+			payload := base64.RawURLEncoding.EncodeToString([]byte(`{
+				"user_name": "some-user",
+				"user_id": "some-user-guid",
+				"client_id": "cf",
+				"grant_type": "password",
+				"iss": "https://uaa.example.com/oauth/token",
+				"scope": ["cloud_controller.read", "openid"],
+				"iat": 1500000000,
+				"exp": 1500000600
+			}`))
+			token = "bearer header." + payload + ".signature"
+		})
+
+		It("returns the claims", func() {
+			Expect(executeErr).ToNot(HaveOccurred())
+			Expect(claims.UserName).To(Equal("some-user"))
+			Expect(claims.UserID).To(Equal("some-user-guid"))
+			Expect(claims.ClientID).To(Equal("cf"))
+			Expect(claims.GrantType).To(Equal("password"))
+			Expect(claims.Issuer).To(Equal("https://uaa.example.com/oauth/token"))
+			Expect(claims.Scopes).To(ConsistOf("cloud_controller.read", "openid"))
+			Expect(claims.IssuedAtTime()).To(Equal(time.Unix(1500000000, 0)))
+			Expect(claims.ExpiresAt()).To(Equal(time.Unix(1500000600, 0)))
+		})
+	})
+
+	Context("when the token does not expire", func() {
+		BeforeEach(func() {
+			token = "header." + base64.RawURLEncoding.EncodeToString([]byte(`{"client_id": "cf"}`)) + ".signature"
+		})
+
+		It("returns a zero expiry", func() {
+			Expect(executeErr).ToNot(HaveOccurred())
+			Expect(claims.ExpiresAt().IsZero()).To(BeTrue())
+		})
+	})
+
+	Context("when the token is not a JSON Web Token", func() {
+		BeforeEach(func() {
+			token = "bearer some-opaque-token"
+		})
+
+		It("returns an InvalidAccessTokenError", func() {
+			Expect(executeErr).To(MatchError(InvalidAccessTokenError{}))
+		})
+	})
+
+	Context("when the payload is not JSON", func() {
+		BeforeEach(func() {
+			token = "header." + base64.RawURLEncoding.EncodeToString([]byte("not-json")) + ".signature"
+		})
+
+		It("returns an InvalidAccessTokenError", func() {
+			Expect(executeErr).To(MatchError(InvalidAccessTokenError{}))
+		})
+	})
+})
@@ -0,0 +1,128 @@
+package uaa
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+
+	"code.cloudfoundry.org/cli/api/uaa/internal"
+)
+
+const (
+	// GrantTypeClientCredentials is the grant type used by clients that
+	// authenticate as themselves instead of on behalf of a user.
+	GrantTypeClientCredentials = "client_credentials"
+
+	// GrantTypePassword is the grant type used to authenticate users with a
+	// password or a one-time passcode.
+	GrantTypePassword = "password"
+
+	// GrantTypeAuthorizationCode is the grant type used to exchange the code
+	// returned by the authorization endpoint for tokens.
+	GrantTypeAuthorizationCode = "authorization_code"
+)
+
+// AuthResponse represents the UAA response to a token request.
+type AuthResponse struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	TokenType    string `json:"token_type"`
+}
+
+// AuthorizationToken returns the access token formatted for the Authorization
+// header.
+func (authResponse AuthResponse) AuthorizationToken() string {
+	return RefreshTokenResponse{
+		AccessToken: authResponse.AccessToken,
+		TokenType:   authResponse.TokenType,
+	}.AuthorizationToken()
+}
+
+// Authenticate uses the password grant to authenticate the user with the
+// UAA. It returns the access token, formatted for the Authorization header,
+// and the refresh token.
+func (client *Client) Authenticate(username string, password string) (string, string, error) {
+	return client.requestToken(url.Values{
+		"client_id":     {client.store.UAAOAuthClient()},
+		"client_secret": {client.store.UAAOAuthClientSecret()},
+		"grant_type":    {GrantTypePassword},
+		"username":      {username},
+		"password":      {password},
+	})
+}
+
+// AuthenticateWithClientCredentials uses the client_credentials grant to
+// authenticate the given client with the UAA. The UAA does not issue refresh
+// tokens for this grant, so the returned refresh token is usually empty.
+func (client *Client) AuthenticateWithClientCredentials(clientID string, clientSecret string) (string, string, error) {
+	return client.requestToken(url.Values{
+		"client_id":     {clientID},
+		"client_secret": {clientSecret},
+		"grant_type":    {GrantTypeClientCredentials},
+	})
+}
+
+// AuthenticateWithPasscode authenticates the user with a one-time passcode
+// obtained from the login server's /passcode page.
+func (client *Client) AuthenticateWithPasscode(passcode string) (string, string, error) {
+	return client.requestToken(url.Values{
+		"client_id":     {client.store.UAAOAuthClient()},
+		"client_secret": {client.store.UAAOAuthClientSecret()},
+		"grant_type":    {GrantTypePassword},
+		"passcode":      {passcode},
+	})
+}
+
+// AuthenticateWithAuthorizationCode exchanges an authorization code, and the
+// PKCE code verifier it was requested with, for tokens. redirectURI must be
+// the redirect URI that was used to request the code.
+func (client *Client) AuthenticateWithAuthorizationCode(code string, codeVerifier string, redirectURI string) (string, string, error) {
+	return client.requestToken(url.Values{
+		"client_id":     {client.store.UAAOAuthClient()},
+		"client_secret": {client.store.UAAOAuthClientSecret()},
+		"grant_type":    {GrantTypeAuthorizationCode},
+		"code":          {code},
+		"code_verifier": {codeVerifier},
+		"redirect_uri":  {redirectURI},
+	})
+}
+
+func (client *Client) requestToken(values url.Values) (string, string, error) {
+	request, err := client.newRequest(requestOptions{
+		RequestName: internal.PostOAuthTokenRequest,
+		Header: http.Header{
+			"Content-Type": {"application/x-www-form-urlencoded"},
+		},
+		Body: strings.NewReader(values.Encode()),
+	})
+	if err != nil {
+		return "", "", err
+	}
+
+	var authResponse AuthResponse
+	response := Response{
+		Result: &authResponse,
+	}
+	err = client.connection.Make(request, &response)
+	if err != nil {
+		return "", "", convertTokenError(err)
+	}
+
+	return authResponse.AuthorizationToken(), authResponse.RefreshToken, nil
+}
+
+// convertTokenError turns the errors the UAA returns for rejected
+// credentials, passcodes and codes into a BadCredentialsError.
+func convertTokenError(err error) error {
+	uaaErr, ok := err.(Error)
+	if !ok {
+		return err
+	}
+
+	switch uaaErr.Type {
+	case "unauthorized", "invalid_grant", "invalid_client":
+		return BadCredentialsError{Message: uaaErr.Description}
+	default:
+		return err
+	}
+}
@@ -0,0 +1,140 @@
+package uaa_test
+
+import (
+	"net/http"
+
+	. "code.cloudfoundry.org/cli/api/uaa"
+	"code.cloudfoundry.org/cli/api/uaa/uaafakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Auth", func() {
+	var (
+		client    *Client
+		fakeStore *uaafakes.FakeAuthenticationStore
+
+		accessToken  string
+		refreshToken string
+		err          error
+	)
+
+	BeforeEach(func() {
+		client, fakeStore = NewTestUAAClientAndStore()
+		fakeStore.UAAOAuthClientReturns("client-id")
+		fakeStore.UAAOAuthClientSecretReturns("client-secret")
+	})
+
+	tokenResponse := `{
+		"access_token": "access-token",
+		"token_type": "bearer",
+		"refresh_token": "refresh-token"
+	}`
+
+	Describe("Authenticate", func() {
+		Context("when the credentials are valid", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodPost, "/oauth/token"),
+						VerifyHeaderKV("Content-Type", "application/x-www-form-urlencoded"),
+						VerifyBody([]byte("client_id=client-id&client_secret=client-secret&grant_type=password&password=some-password&username=some-user")),
+						RespondWith(http.StatusOK, tokenResponse),
+					))
+			})
+
+			It("returns the access and refresh tokens", func() {
+				accessToken, refreshToken, err = client.Authenticate("some-user", "some-password")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(accessToken).To(Equal("bearer access-token"))
+				Expect(refreshToken).To(Equal("refresh-token"))
+			})
+		})
+
+		Context("when the credentials are rejected", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodPost, "/oauth/token"),
+						RespondWith(http.StatusUnauthorized, `{"error":"unauthorized","error_description":"Bad credentials"}`),
+					))
+			})
+
+			It("returns a BadCredentialsError", func() {
+				_, _, err = client.Authenticate("some-user", "some-password")
+				Expect(err).To(MatchError(BadCredentialsError{Message: "Bad credentials"}))
+			})
+		})
+
+		Context("when the UAA returns any other error", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodPost, "/oauth/token"),
+						RespondWith(http.StatusBadRequest, `{"error":"invalid_scope","error_description":"some description"}`),
+					))
+			})
+
+			It("returns the UAA error", func() {
+				_, _, err = client.Authenticate("some-user", "some-password")
+				Expect(err).To(MatchError(Error{Type: "invalid_scope", Description: "some description"}))
+			})
+		})
+	})
+
+	Describe("AuthenticateWithClientCredentials", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(
+				CombineHandlers(
+					VerifyRequest(http.MethodPost, "/oauth/token"),
+					VerifyBody([]byte("client_id=some-client&client_secret=some-secret&grant_type=client_credentials")),
+					RespondWith(http.StatusOK, `{"access_token":"access-token","token_type":"bearer"}`),
+				))
+		})
+
+		It("authenticates the passed client", func() {
+			accessToken, refreshToken, err = client.AuthenticateWithClientCredentials("some-client", "some-secret")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(accessToken).To(Equal("bearer access-token"))
+			Expect(refreshToken).To(BeEmpty())
+		})
+	})
+
+	Describe("AuthenticateWithPasscode", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(
+				CombineHandlers(
+					VerifyRequest(http.MethodPost, "/oauth/token"),
+					VerifyBody([]byte("client_id=client-id&client_secret=client-secret&grant_type=password&passcode=some-passcode")),
+					RespondWith(http.StatusOK, tokenResponse),
+				))
+		})
+
+		It("returns the access and refresh tokens", func() {
+			accessToken, refreshToken, err = client.AuthenticateWithPasscode("some-passcode")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(accessToken).To(Equal("bearer access-token"))
+			Expect(refreshToken).To(Equal("refresh-token"))
+		})
+	})
+
+	Describe("AuthenticateWithAuthorizationCode", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(
+				CombineHandlers(
+					VerifyRequest(http.MethodPost, "/oauth/token"),
+					VerifyBody([]byte("client_id=client-id&client_secret=client-secret&code=some-code&code_verifier=some-verifier&grant_type=authorization_code&redirect_uri=http%3A%2F%2F127.0.0.1%3A1234%2Fcallback")),
+					RespondWith(http.StatusOK, tokenResponse),
+				))
+		})
+
+		It("exchanges the code for tokens", func() {
+			accessToken, refreshToken, err = client.AuthenticateWithAuthorizationCode("some-code", "some-verifier", "http://127.0.0.1:1234/callback")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(accessToken).To(Equal("bearer access-token"))
+			Expect(refreshToken).To(Equal("refresh-token"))
+		})
+	})
+})
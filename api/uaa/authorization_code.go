@@ -0,0 +1,155 @@
+package uaa
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// AuthorizationCallbackPath is the path of the loopback listener the browser
+// is redirected to after the user authorizes the CLI.
+const AuthorizationCallbackPath = "/callback"
+
+type authorizationResult struct {
+	code string
+	err  error
+}
+
+// AuthorizationCode is a code returned by the authorization endpoint, with
+// the PKCE code verifier and redirect URI it was requested with. All three
+// are needed to exchange it for tokens.
+type AuthorizationCode struct {
+	Code         string
+	CodeVerifier string
+	RedirectURI  string
+}
+
+// AuthenticateWithBrowser authenticates the user with the authorization code
+// grant and PKCE. See RequestAuthorizationCode for how the code is obtained.
+func (client *Client) AuthenticateWithBrowser(authorizationEndpoint string, timeout time.Duration, openURL func(authorizationURL string) error) (string, string, error) {
+	code, err := RequestAuthorizationCode(authorizationEndpoint, client.store.UAAOAuthClient(), timeout, openURL)
+	if err != nil {
+		return "", "", err
+	}
+	return client.AuthenticateWithAuthorizationCode(code.Code, code.CodeVerifier, code.RedirectURI)
+}
+
+// RequestAuthorizationCode has the user authorize clientID in a browser. A
+// listener is started on a random port of the loopback interface, and
+// openURL is given the authorization URL the user needs to visit. Once the
+// user authorizes the client, the browser is redirected to the listener with
+// the code.
+//
+// An AuthorizationTimeoutError is returned if the browser does not redirect
+// back within timeout.
+func RequestAuthorizationCode(authorizationEndpoint string, clientID string, timeout time.Duration, openURL func(authorizationURL string) error) (AuthorizationCode, error) {
+	codeVerifier, err := randomString(32)
+	if err != nil {
+		return AuthorizationCode{}, err
+	}
+	state, err := randomString(16)
+	if err != nil {
+		return AuthorizationCode{}, err
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return AuthorizationCode{}, err
+	}
+	defer listener.Close()
+
+	redirectURI := fmt.Sprintf("http://%s%s", listener.Addr().String(), AuthorizationCallbackPath)
+
+	results := make(chan authorizationResult, 1)
+	server := &http.Server{Handler: authorizationCallbackHandler(state, results)}
+	go server.Serve(listener)
+	defer server.Close()
+
+	err = openURL(authorizationURL(authorizationEndpoint, clientID, redirectURI, state, codeVerifier))
+	if err != nil {
+		return AuthorizationCode{}, err
+	}
+
+	select {
+	case result := <-results:
+		if result.err != nil {
+			return AuthorizationCode{}, result.err
+		}
+		return AuthorizationCode{
+			Code:         result.code,
+			CodeVerifier: codeVerifier,
+			RedirectURI:  redirectURI,
+		}, nil
+	case <-time.After(timeout):
+		return AuthorizationCode{}, AuthorizationTimeoutError{Timeout: timeout}
+	}
+}
+
+func authorizationURL(authorizationEndpoint string, clientID string, redirectURI string, state string, codeVerifier string) string {
+	challenge := sha256.Sum256([]byte(codeVerifier))
+
+	query := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {clientID},
+		"redirect_uri":          {redirectURI},
+		"state":                 {state},
+		"code_challenge":        {base64.RawURLEncoding.EncodeToString(challenge[:])},
+		"code_challenge_method": {"S256"},
+	}
+
+	return fmt.Sprintf("%s/oauth/authorize?%s", strings.TrimSuffix(authorizationEndpoint, "/"), query.Encode())
+}
+
+// authorizationCallbackHandler handles the redirect from the authorization
+// endpoint. Only the first redirect with a matching state is reported;
+// anything else is rejected so that other local processes cannot inject a
+// code.
+func authorizationCallbackHandler(state string, results chan<- authorizationResult) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != AuthorizationCallbackPath {
+			http.NotFound(w, r)
+			return
+		}
+
+		query := r.URL.Query()
+		if query.Get("state") != state {
+			http.Error(w, AuthorizationStateMismatchError{}.Error(), http.StatusBadRequest)
+			sendAuthorizationResult(results, authorizationResult{err: AuthorizationStateMismatchError{}})
+			return
+		}
+
+		if errType := query.Get("error"); errType != "" {
+			http.Error(w, "Authorization failed. You can close this window.", http.StatusUnauthorized)
+			sendAuthorizationResult(results, authorizationResult{err: convertTokenError(Error{
+				Type:        errType,
+				Description: query.Get("error_description"),
+			})})
+			return
+		}
+
+		fmt.Fprintln(w, "Authorization complete. You can close this window and return to the CLI.")
+		sendAuthorizationResult(results, authorizationResult{code: query.Get("code")})
+	})
+}
+
+func sendAuthorizationResult(results chan<- authorizationResult, result authorizationResult) {
+	select {
+	case results <- result:
+	default:
+	}
+}
+
+func randomString(size int) (string, error) {
+	raw := make([]byte, size)
+	_, err := rand.Read(raw)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
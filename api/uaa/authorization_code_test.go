@@ -0,0 +1,148 @@
+package uaa_test
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"net/url"
+	"time"
+
+	. "code.cloudfoundry.org/cli/api/uaa"
+	"code.cloudfoundry.org/cli/api/uaa/uaafakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("AuthenticateWithBrowser", func() {
+	var (
+		client    *Client
+		fakeStore *uaafakes.FakeAuthenticationStore
+
+		authorizationURL *url.URL
+		redirect         func(authorizationURL *url.URL) url.Values
+
+		accessToken  string
+		refreshToken string
+		err          error
+	)
+
+	BeforeEach(func() {
+		client, fakeStore = NewTestUAAClientAndStore()
+		fakeStore.UAAOAuthClientReturns("client-id")
+	})
+
+	Context("when the browser is redirected back", func() {
+		JustBeforeEach(func() {
+			accessToken, refreshToken, err = client.AuthenticateWithBrowser("https://login.example.com", time.Second, func(rawURL string) error {
+				var parseErr error
+				authorizationURL, parseErr = url.Parse(rawURL)
+				Expect(parseErr).ToNot(HaveOccurred())
+
+				query := authorizationURL.Query()
+				go func() {
+					defer GinkgoRecover()
+					_, getErr := http.Get(query.Get("redirect_uri") + "?" + redirect(authorizationURL).Encode())
+					Expect(getErr).ToNot(HaveOccurred())
+				}()
+				return nil
+			})
+		})
+
+		Context("when the user authorizes the CLI", func() {
+			var codeVerifier string
+
+			BeforeEach(func() {
+				redirect = func(authorizationURL *url.URL) url.Values {
+					return url.Values{
+						"code":  {"some-code"},
+						"state": {authorizationURL.Query().Get("state")},
+					}
+				}
+
+				server.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodPost, "/oauth/token"),
+						func(w http.ResponseWriter, r *http.Request) {
+							Expect(r.ParseForm()).To(Succeed())
+							Expect(r.PostForm.Get("grant_type")).To(Equal("authorization_code"))
+							Expect(r.PostForm.Get("code")).To(Equal("some-code"))
+							codeVerifier = r.PostForm.Get("code_verifier")
+						},
+						RespondWith(http.StatusOK, `{"access_token":"access-token","token_type":"bearer","refresh_token":"refresh-token"}`),
+					))
+			})
+
+			It("sends the user to the authorization endpoint with a PKCE challenge", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(authorizationURL.Host).To(Equal("login.example.com"))
+				Expect(authorizationURL.Path).To(Equal("/oauth/authorize"))
+
+				query := authorizationURL.Query()
+				Expect(query.Get("response_type")).To(Equal("code"))
+				Expect(query.Get("client_id")).To(Equal("client-id"))
+				Expect(query.Get("redirect_uri")).To(MatchRegexp(`^http://127\.0\.0\.1:\d+/callback$`))
+				Expect(query.Get("code_challenge_method")).To(Equal("S256"))
+
+				challenge := sha256.Sum256([]byte(codeVerifier))
+				Expect(query.Get("code_challenge")).To(Equal(base64.RawURLEncoding.EncodeToString(challenge[:])))
+			})
+
+			It("exchanges the code for tokens", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(accessToken).To(Equal("bearer access-token"))
+				Expect(refreshToken).To(Equal("refresh-token"))
+			})
+		})
+
+		Context("when the state does not match", func() {
+			BeforeEach(func() {
+				redirect = func(*url.URL) url.Values {
+					return url.Values{"code": {"some-code"}, "state": {"some-other-state"}}
+				}
+			})
+
+			It("returns an AuthorizationStateMismatchError", func() {
+				Expect(err).To(MatchError(AuthorizationStateMismatchError{}))
+				Expect(server.ReceivedRequests()).To(BeEmpty())
+			})
+		})
+
+		Context("when the user denies access", func() {
+			BeforeEach(func() {
+				redirect = func(authorizationURL *url.URL) url.Values {
+					return url.Values{
+						"error":             {"access_denied"},
+						"error_description": {"User denied access"},
+						"state":             {authorizationURL.Query().Get("state")},
+					}
+				}
+			})
+
+			It("returns the error", func() {
+				Expect(err).To(MatchError(Error{Type: "access_denied", Description: "User denied access"}))
+			})
+		})
+	})
+
+	Context("when the URL cannot be opened", func() {
+		It("returns the error", func() {
+			expectedErr := errors.New("some-error")
+			_, _, err = client.AuthenticateWithBrowser("https://login.example.com", time.Second, func(string) error {
+				return expectedErr
+			})
+			Expect(err).To(MatchError(expectedErr))
+		})
+	})
+
+	Context("when the browser does not redirect back in time", func() {
+		It("returns an AuthorizationTimeoutError", func() {
+			_, _, err = client.AuthenticateWithBrowser("https://login.example.com", 10*time.Millisecond, func(string) error {
+				return nil
+			})
+			Expect(err).To(MatchError(AuthorizationTimeoutError{Timeout: 10 * time.Millisecond}))
+		})
+	})
+})
@@ -20,6 +20,7 @@ import (
 type AuthenticationStore interface {
 	UAAOAuthClient() string
 	UAAOAuthClientSecret() string
+	UAAGrantType() string
 
 	AccessToken() string
 	RefreshToken() string
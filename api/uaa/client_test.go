@@ -6,7 +6,6 @@ import (
 	"runtime"
 
 	. "code.cloudfoundry.org/cli/api/uaa"
-	"code.cloudfoundry.org/cli/api/uaa/uaafakes"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -14,13 +13,10 @@ import (
 )
 
 var _ = Describe("UAA Client", func() {
-	var (
-		client    *Client
-		fakeStore *uaafakes.FakeAuthenticationStore
-	)
+	var client *Client
 
 	BeforeEach(func() {
-		client, fakeStore = NewTestUAAClientAndStore()
+		client, _ = NewTestUAAClientAndStore()
 	})
 
 	Describe("Request Headers", func() {
@@ -1,6 +1,9 @@
 package uaa
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+)
 
 // UnverifiedServerError replaces x509.UnknownAuthorityError when the server
 // has SSL but the client is unable to verify it's certificate
@@ -30,3 +33,40 @@ type Error struct {
 func (r Error) Error() string {
 	return fmt.Sprintf("Error Type: %s\nDescription: %s", r.Type, r.Description)
 }
+
+// BadCredentialsError is returned when the UAA rejects the credentials,
+// passcode or authorization code used to request a token.
+type BadCredentialsError struct {
+	Message string
+}
+
+func (e BadCredentialsError) Error() string {
+	return e.Message
+}
+
+// AuthorizationTimeoutError is returned when the user does not authorize the
+// CLI in the browser within the time allowed.
+type AuthorizationTimeoutError struct {
+	Timeout time.Duration
+}
+
+func (e AuthorizationTimeoutError) Error() string {
+	return fmt.Sprintf("timed out after %s waiting for the browser to authorize the CLI", e.Timeout)
+}
+
+// AuthorizationStateMismatchError is returned when the state returned to the
+// loopback listener does not match the state sent to the authorization
+// endpoint, which indicates the redirect was not initiated by this CLI.
+type AuthorizationStateMismatchError struct{}
+
+func (e AuthorizationStateMismatchError) Error() string {
+	return "the authorization response state does not match the request"
+}
+
+// InvalidAccessTokenError is returned when an access token is not a JSON Web
+// Token.
+type InvalidAccessTokenError struct{}
+
+func (e InvalidAccessTokenError) Error() string {
+	return "the access token is not a valid JSON Web Token"
+}
@@ -7,9 +7,11 @@ import (
 )
 
 const (
-	RefreshTokenRequest = "RefreshToken"
+	PostOAuthTokenRequest = "PostOAuthToken"
+	RefreshTokenRequest   = "RefreshToken"
 )
 
 var Routes = rata.Routes{
+	{Path: "/oauth/token", Method: http.MethodPost, Name: PostOAuthTokenRequest},
 	{Path: "/oauth/token", Method: http.MethodPost, Name: RefreshTokenRequest},
 }
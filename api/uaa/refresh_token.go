@@ -20,8 +20,19 @@ func (refreshTokenResponse RefreshTokenResponse) AuthorizationToken() string {
 	return fmt.Sprintf("%s %s", refreshTokenResponse.TokenType, refreshTokenResponse.AccessToken)
 }
 
-// RefreshToken refreshes the current access token
+// RefreshToken refreshes the current access token. Clients authenticated
+// with the client_credentials grant are not given a refresh token, so they
+// authenticate again instead.
 func (client *Client) RefreshToken() error {
+	if client.store.UAAGrantType() == GrantTypeClientCredentials {
+		accessToken, _, err := client.AuthenticateWithClientCredentials(client.store.UAAOAuthClient(), client.store.UAAOAuthClientSecret())
+		if err != nil {
+			return err
+		}
+		client.store.SetAccessToken(accessToken)
+		return nil
+	}
+
 	body := strings.NewReader(url.Values{
 		"client_id":     {client.store.UAAOAuthClient()},
 		"client_secret": {client.store.UAAOAuthClientSecret()},
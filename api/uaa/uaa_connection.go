@@ -10,6 +10,8 @@ import (
 	"net/http"
 	"net/url"
 	"time"
+
+	"code.cloudfoundry.org/cli/util/cassette"
 )
 
 // UAAConnection represents the connection to UAA
@@ -31,7 +33,7 @@ func NewConnection(skipSSLValidation bool, dialTimeout time.Duration) *UAAConnec
 	}
 
 	return &UAAConnection{
-		HTTPClient: &http.Client{Transport: tr},
+		HTTPClient: &http.Client{Transport: cassette.WrapTransport(tr)},
 	}
 }
 
@@ -2,20 +2,20 @@
 package actorsfakes
 
 import (
-	"os"
 	"sync"
 
 	"code.cloudfoundry.org/cli/cf/actors"
+	"code.cloudfoundry.org/cli/cf/api/applicationbits"
 	"code.cloudfoundry.org/cli/cf/api/resources"
 	"code.cloudfoundry.org/cli/cf/models"
 )
 
 type FakePushActor struct {
-	UploadAppStub        func(appGUID string, zipFile *os.File, presentFiles []resources.AppFileResource) error
+	UploadAppStub        func(appGUID string, writeZip applicationbits.ZipWriter, presentFiles []resources.AppFileResource) error
 	uploadAppMutex       sync.RWMutex
 	uploadAppArgsForCall []struct {
 		appGUID      string
-		zipFile      *os.File
+		writeZip     applicationbits.ZipWriter
 		presentFiles []resources.AppFileResource
 	}
 	uploadAppReturns struct {
@@ -30,16 +30,15 @@ type FakePushActor struct {
 	processPathReturns struct {
 		result1 error
 	}
-	GatherFilesStub        func(localFiles []models.AppFileFields, appDir string, uploadDir string) ([]resources.AppFileResource, bool, error)
+	GatherFilesStub        func(localFiles []models.AppFileFields, appDir string) ([]resources.AppFileResource, []models.AppFileFields, error)
 	gatherFilesMutex       sync.RWMutex
 	gatherFilesArgsForCall []struct {
 		localFiles []models.AppFileFields
 		appDir     string
-		uploadDir  string
 	}
 	gatherFilesReturns struct {
 		result1 []resources.AppFileResource
-		result2 bool
+		result2 []models.AppFileFields
 		result3 error
 	}
 	ValidateAppParamsStub        func(apps []models.AppParams) []error
@@ -64,7 +63,7 @@ type FakePushActor struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakePushActor) UploadApp(appGUID string, zipFile *os.File, presentFiles []resources.AppFileResource) error {
+func (fake *FakePushActor) UploadApp(appGUID string, writeZip applicationbits.ZipWriter, presentFiles []resources.AppFileResource) error {
 	var presentFilesCopy []resources.AppFileResource
 	if presentFiles != nil {
 		presentFilesCopy = make([]resources.AppFileResource, len(presentFiles))
@@ -73,13 +72,13 @@ func (fake *FakePushActor) UploadApp(appGUID string, zipFile *os.File, presentFi
 	fake.uploadAppMutex.Lock()
 	fake.uploadAppArgsForCall = append(fake.uploadAppArgsForCall, struct {
 		appGUID      string
-		zipFile      *os.File
+		writeZip     applicationbits.ZipWriter
 		presentFiles []resources.AppFileResource
-	}{appGUID, zipFile, presentFilesCopy})
-	fake.recordInvocation("UploadApp", []interface{}{appGUID, zipFile, presentFilesCopy})
+	}{appGUID, writeZip, presentFilesCopy})
+	fake.recordInvocation("UploadApp", []interface{}{appGUID, writeZip, presentFilesCopy})
 	fake.uploadAppMutex.Unlock()
 	if fake.UploadAppStub != nil {
-		return fake.UploadAppStub(appGUID, zipFile, presentFiles)
+		return fake.UploadAppStub(appGUID, writeZip, presentFiles)
 	} else {
 		return fake.uploadAppReturns.result1
 	}
@@ -91,10 +90,10 @@ func (fake *FakePushActor) UploadAppCallCount() int {
 	return len(fake.uploadAppArgsForCall)
 }
 
-func (fake *FakePushActor) UploadAppArgsForCall(i int) (string, *os.File, []resources.AppFileResource) {
+func (fake *FakePushActor) UploadAppArgsForCall(i int) (string, applicationbits.ZipWriter, []resources.AppFileResource) {
 	fake.uploadAppMutex.RLock()
 	defer fake.uploadAppMutex.RUnlock()
-	return fake.uploadAppArgsForCall[i].appGUID, fake.uploadAppArgsForCall[i].zipFile, fake.uploadAppArgsForCall[i].presentFiles
+	return fake.uploadAppArgsForCall[i].appGUID, fake.uploadAppArgsForCall[i].writeZip, fake.uploadAppArgsForCall[i].presentFiles
 }
 
 func (fake *FakePushActor) UploadAppReturns(result1 error) {
@@ -138,7 +137,7 @@ func (fake *FakePushActor) ProcessPathReturns(result1 error) {
 	}{result1}
 }
 
-func (fake *FakePushActor) GatherFiles(localFiles []models.AppFileFields, appDir string, uploadDir string) ([]resources.AppFileResource, bool, error) {
+func (fake *FakePushActor) GatherFiles(localFiles []models.AppFileFields, appDir string) ([]resources.AppFileResource, []models.AppFileFields, error) {
 	var localFilesCopy []models.AppFileFields
 	if localFiles != nil {
 		localFilesCopy = make([]models.AppFileFields, len(localFiles))
@@ -148,12 +147,11 @@ func (fake *FakePushActor) GatherFiles(localFiles []models.AppFileFields, appDir
 	fake.gatherFilesArgsForCall = append(fake.gatherFilesArgsForCall, struct {
 		localFiles []models.AppFileFields
 		appDir     string
-		uploadDir  string
-	}{localFilesCopy, appDir, uploadDir})
-	fake.recordInvocation("GatherFiles", []interface{}{localFilesCopy, appDir, uploadDir})
+	}{localFilesCopy, appDir})
+	fake.recordInvocation("GatherFiles", []interface{}{localFilesCopy, appDir})
 	fake.gatherFilesMutex.Unlock()
 	if fake.GatherFilesStub != nil {
-		return fake.GatherFilesStub(localFiles, appDir, uploadDir)
+		return fake.GatherFilesStub(localFiles, appDir)
 	} else {
 		return fake.gatherFilesReturns.result1, fake.gatherFilesReturns.result2, fake.gatherFilesReturns.result3
 	}
@@ -165,17 +163,17 @@ func (fake *FakePushActor) GatherFilesCallCount() int {
 	return len(fake.gatherFilesArgsForCall)
 }
 
-func (fake *FakePushActor) GatherFilesArgsForCall(i int) ([]models.AppFileFields, string, string) {
+func (fake *FakePushActor) GatherFilesArgsForCall(i int) ([]models.AppFileFields, string) {
 	fake.gatherFilesMutex.RLock()
 	defer fake.gatherFilesMutex.RUnlock()
-	return fake.gatherFilesArgsForCall[i].localFiles, fake.gatherFilesArgsForCall[i].appDir, fake.gatherFilesArgsForCall[i].uploadDir
+	return fake.gatherFilesArgsForCall[i].localFiles, fake.gatherFilesArgsForCall[i].appDir
 }
 
-func (fake *FakePushActor) GatherFilesReturns(result1 []resources.AppFileResource, result2 bool, result3 error) {
+func (fake *FakePushActor) GatherFilesReturns(result1 []resources.AppFileResource, result2 []models.AppFileFields, result3 error) {
 	fake.GatherFilesStub = nil
 	fake.gatherFilesReturns = struct {
 		result1 []resources.AppFileResource
-		result2 bool
+		result2 []models.AppFileFields
 		result3 error
 	}{result1, result2, result3}
 }
@@ -3,16 +3,20 @@ package actors
 import (
 	"fmt"
 	"io/ioutil"
+	"net/url"
 	"os"
 	"path/filepath"
 	"runtime"
+	"strings"
 
 	"code.cloudfoundry.org/cli/cf/api/applicationbits"
 	"code.cloudfoundry.org/cli/cf/api/resources"
 	"code.cloudfoundry.org/cli/cf/appfiles"
+	"code.cloudfoundry.org/cli/cf/errors"
 	. "code.cloudfoundry.org/cli/cf/i18n"
 	"code.cloudfoundry.org/cli/cf/models"
-	"code.cloudfoundry.org/gofileutils/fileutils"
+	"code.cloudfoundry.org/cli/util"
+	"code.cloudfoundry.org/cli/util/downloader"
 )
 
 const windowsPathPrefix = `\\?\`
@@ -20,9 +24,9 @@ const windowsPathPrefix = `\\?\`
 //go:generate counterfeiter . PushActor
 
 type PushActor interface {
-	UploadApp(appGUID string, zipFile *os.File, presentFiles []resources.AppFileResource) error
+	UploadApp(appGUID string, writeZip applicationbits.ZipWriter, presentFiles []resources.AppFileResource) error
 	ProcessPath(dirOrZipFile string, f func(string) error) error
-	GatherFiles(localFiles []models.AppFileFields, appDir string, uploadDir string) ([]resources.AppFileResource, bool, error)
+	GatherFiles(localFiles []models.AppFileFields, appDir string) ([]resources.AppFileResource, []models.AppFileFields, error)
 	ValidateAppParams(apps []models.AppParams) []error
 	MapManifestRoute(routeName string, app models.Application, appParamsFromContext models.AppParams) error
 }
@@ -43,51 +47,71 @@ func NewPushActor(appBitsRepo applicationbits.Repository, zipper appfiles.Zipper
 	}
 }
 
-// ProcessPath takes in a director of app files or a zip file which contains
-// the app files. If given a zip file, it will extract the zip to a temporary
-// location, call the provided callback with that location, and then clean up
-// the location after the callback has been executed.
+// ProcessPath takes in a director of app files, a zip or tar file which
+// contains the app files, or the http(s) URL of such a file. If given an
+// archive, it will extract it to a temporary location, call the provided
+// callback with that location, and then clean up the location after the
+// callback has been executed. A URL can end with #sha1=CHECKSUM, in which case
+// the downloaded file must have that SHA-1 checksum.
 //
 // This was done so that the caller of ProcessPath wouldn't need to know if it
-// was a zip file or an app dir that it was given, and the caller would not be
+// was an archive or an app dir that it was given, and the caller would not be
 // responsible for cleaning up the temporary directory ProcessPath creates when
-// given a zip.
+// given an archive.
 func (actor PushActorImpl) ProcessPath(dirOrZipFile string, f func(string) error) error {
-	if !actor.zipper.IsZipFile(dirOrZipFile) {
-		if filepath.IsAbs(dirOrZipFile) {
-			appDir, err := filepath.EvalSymlinks(dirOrZipFile)
-			if err != nil {
-				return err
-			}
-			err = f(appDir)
-			if err != nil {
-				return err
-			}
-		} else {
-			absPath, err := filepath.Abs(dirOrZipFile)
-			if err != nil {
-				return err
-			}
-			appDir, err := filepath.EvalSymlinks(absPath)
-			if err != nil {
-				return err
-			}
+	if IsRemoteAppPath(dirOrZipFile) {
+		return actor.processRemotePath(dirOrZipFile, f)
+	}
 
-			err = f(appDir)
-			if err != nil {
-				return err
-			}
+	if actor.zipper.IsZipFile(dirOrZipFile) {
+		return actor.processArchive(dirOrZipFile, actor.zipper.Unzip, f)
+	}
+
+	if actor.zipper.IsTarFile(dirOrZipFile) {
+		return actor.processArchive(dirOrZipFile, actor.zipper.Untar, f)
+	}
+
+	if filepath.IsAbs(dirOrZipFile) {
+		appDir, err := filepath.EvalSymlinks(dirOrZipFile)
+		if err != nil {
+			return err
+		}
+		err = f(appDir)
+		if err != nil {
+			return err
+		}
+	} else {
+		absPath, err := filepath.Abs(dirOrZipFile)
+		if err != nil {
+			return err
+		}
+		appDir, err := filepath.EvalSymlinks(absPath)
+		if err != nil {
+			return err
 		}
 
-		return nil
+		err = f(appDir)
+		if err != nil {
+			return err
+		}
 	}
 
-	tempDir, err := ioutil.TempDir("", "unzipped-app")
+	return nil
+}
+
+// IsRemoteAppPath reports whether the app path is an http(s) URL rather than
+// a local file or directory.
+func IsRemoteAppPath(appPath string) bool {
+	return strings.HasPrefix(appPath, "http://") || strings.HasPrefix(appPath, "https://")
+}
+
+func (actor PushActorImpl) processArchive(archive string, extract func(string, string) error, f func(string) error) error {
+	tempDir, err := ioutil.TempDir("", appfiles.ExtractedAppDirPrefix)
 	if err != nil {
 		return err
 	}
 
-	err = actor.zipper.Unzip(dirOrZipFile, tempDir)
+	err = extract(archive, tempDir)
 	if err != nil {
 		return err
 	}
@@ -105,7 +129,53 @@ func (actor PushActorImpl) ProcessPath(dirOrZipFile string, f func(string) error
 	return nil
 }
 
-func (actor PushActorImpl) GatherFiles(localFiles []models.AppFileFields, appDir string, uploadDir string) ([]resources.AppFileResource, bool, error) {
+func (actor PushActorImpl) processRemotePath(appURL string, f func(string) error) error {
+	parsedURL, err := url.Parse(appURL)
+	if err != nil {
+		return err
+	}
+
+	var checksum string
+	if parsedURL.Fragment != "" {
+		if !strings.HasPrefix(parsedURL.Fragment, "sha1=") {
+			return errors.New(T("Unsupported fragment in app URL {{.URL}}: use #sha1=CHECKSUM to verify the download",
+				map[string]interface{}{"URL": appURL}))
+		}
+		checksum = strings.ToLower(strings.TrimPrefix(parsedURL.Fragment, "sha1="))
+		parsedURL.Fragment = ""
+	}
+
+	downloadDir, err := ioutil.TempDir("", "downloaded-app")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(downloadDir)
+
+	_, filename, err := downloader.NewDownloader(downloadDir).DownloadFile(parsedURL.String())
+	if err != nil {
+		return err
+	}
+	archive := filepath.Join(downloadDir, filename)
+
+	if checksum != "" && !util.NewSha1Checksum(archive).CheckSha1(checksum) {
+		return errors.New(T("The SHA-1 checksum of the app downloaded from {{.URL}} does not match {{.Checksum}}",
+			map[string]interface{}{"URL": parsedURL.String(), "Checksum": checksum}))
+	}
+
+	switch {
+	case actor.zipper.IsZipFile(archive):
+		return actor.processArchive(archive, actor.zipper.Unzip, f)
+	case actor.zipper.IsTarFile(archive):
+		return actor.processArchive(archive, actor.zipper.Untar, f)
+	default:
+		return errors.New(T("The app downloaded from {{.URL}} is not a zip or tar archive",
+			map[string]interface{}{"URL": parsedURL.String()}))
+	}
+}
+
+// GatherFiles returns the files of the app that Cloud Controller already has,
+// which do not need to be uploaded, and the files that do.
+func (actor PushActorImpl) GatherFiles(localFiles []models.AppFileFields, appDir string) ([]resources.AppFileResource, []models.AppFileFields, error) {
 	appFileResource := []resources.AppFileResource{}
 	for _, file := range localFiles {
 		appFileResource = append(appFileResource, resources.AppFileResource{
@@ -117,37 +187,25 @@ func (actor PushActorImpl) GatherFiles(localFiles []models.AppFileFields, appDir
 
 	remoteFiles, err := actor.appBitsRepo.GetApplicationFiles(appFileResource)
 	if err != nil {
-		return []resources.AppFileResource{}, false, err
+		return []resources.AppFileResource{}, nil, err
 	}
 
-	filesToUpload := make([]models.AppFileFields, len(localFiles), len(localFiles))
-	copy(filesToUpload, localFiles)
-
+	remotePaths := make(map[string]bool, len(remoteFiles))
 	for _, remoteFile := range remoteFiles {
-		for i, fileToUpload := range filesToUpload {
-			if remoteFile.Path == fileToUpload.Path {
-				filesToUpload = append(filesToUpload[:i], filesToUpload[i+1:]...)
-			}
-		}
-	}
-
-	err = actor.appfiles.CopyFiles(filesToUpload, appDir, uploadDir)
-	if err != nil {
-		return []resources.AppFileResource{}, false, err
+		remotePaths[remoteFile.Path] = true
 	}
 
-	_, err = os.Stat(filepath.Join(appDir, ".cfignore"))
-	if err == nil {
-		err = fileutils.CopyPathToPath(filepath.Join(appDir, ".cfignore"), filepath.Join(uploadDir, ".cfignore"))
-		if err != nil {
-			return []resources.AppFileResource{}, false, err
+	filesToUpload := []models.AppFileFields{}
+	for _, localFile := range localFiles {
+		if !remotePaths[localFile.Path] {
+			filesToUpload = append(filesToUpload, localFile)
 		}
 	}
 
 	for i := range remoteFiles {
 		fullPath, err := filepath.Abs(filepath.Join(appDir, remoteFiles[i].Path))
 		if err != nil {
-			return []resources.AppFileResource{}, false, err
+			return []resources.AppFileResource{}, nil, err
 		}
 
 		if runtime.GOOS == "windows" {
@@ -155,7 +213,7 @@ func (actor PushActorImpl) GatherFiles(localFiles []models.AppFileFields, appDir
 		}
 		fileInfo, err := os.Lstat(fullPath)
 		if err != nil {
-			return []resources.AppFileResource{}, false, err
+			return []resources.AppFileResource{}, nil, err
 		}
 		fileMode := fileInfo.Mode()
 
@@ -166,11 +224,11 @@ func (actor PushActorImpl) GatherFiles(localFiles []models.AppFileFields, appDir
 		remoteFiles[i].Mode = fmt.Sprintf("%#o", fileMode)
 	}
 
-	return remoteFiles, len(filesToUpload) > 0, nil
+	return remoteFiles, filesToUpload, nil
 }
 
-func (actor PushActorImpl) UploadApp(appGUID string, zipFile *os.File, presentFiles []resources.AppFileResource) error {
-	return actor.appBitsRepo.UploadBits(appGUID, zipFile, presentFiles)
+func (actor PushActorImpl) UploadApp(appGUID string, writeZip applicationbits.ZipWriter, presentFiles []resources.AppFileResource) error {
+	return actor.appBitsRepo.UploadBits(appGUID, writeZip, presentFiles)
 }
 
 func (actor PushActorImpl) ValidateAppParams(apps []models.AppParams) []error {
@@ -1,9 +1,11 @@
 package actors_test
 
 import (
+	"crypto/sha1"
 	"errors"
 	"fmt"
 	"io/ioutil"
+	"net/http"
 	"os"
 	"path/filepath"
 	"runtime"
@@ -17,6 +19,7 @@ import (
 	"code.cloudfoundry.org/cli/cf/models"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
 )
 
 var _ = Describe("Push Actor", func() {
@@ -51,8 +54,6 @@ var _ = Describe("Push Actor", func() {
 	})
 
 	Describe("GatherFiles", func() {
-		var tmpDir string
-
 		BeforeEach(func() {
 			presentFiles = []resources.AppFileResource{
 				{Path: "example-app/ignore-me"},
@@ -60,13 +61,6 @@ var _ = Describe("Push Actor", func() {
 
 			appDir = filepath.Join(fixturesDir, "example-app.zip")
 			appBitsRepo.GetApplicationFilesReturns(presentFiles, nil)
-			var err error
-			tmpDir, err = ioutil.TempDir("", "gather-files")
-			Expect(err).NotTo(HaveOccurred())
-		})
-
-		AfterEach(func() {
-			os.RemoveAll(tmpDir)
 		})
 
 		Context("when we cannot reach CC", func() {
@@ -78,43 +72,12 @@ var _ = Describe("Push Actor", func() {
 			})
 
 			It("returns an error if we cannot reach the cc", func() {
-				_, _, err := actor.GatherFiles(allFiles, appDir, tmpDir)
+				_, _, err := actor.GatherFiles(allFiles, appDir)
 				Expect(err).To(HaveOccurred())
 				Expect(err).To(Equal(expectedErr))
 			})
 		})
 
-		Context("when we cannot copy the app files", func() {
-			var expectedErr error
-
-			BeforeEach(func() {
-				expectedErr = errors.New("error")
-				appFiles.CopyFilesReturns(expectedErr)
-			})
-
-			It("returns an error", func() {
-				_, _, err := actor.GatherFiles(allFiles, appDir, tmpDir)
-				Expect(err).To(HaveOccurred())
-				Expect(err).To(Equal(expectedErr))
-			})
-		})
-
-		Context("when using .cfignore", func() {
-			BeforeEach(func() {
-				appDir = filepath.Join(fixturesDir, "exclude-a-default-cfignore")
-				// Ignore app files for this test as .cfignore is not one of them
-				appBitsRepo.GetApplicationFilesReturns(nil, nil)
-			})
-
-			It("copies the .cfignore file to the upload directory", func() {
-				_, _, err := actor.GatherFiles(allFiles, appDir, tmpDir)
-				Expect(err).NotTo(HaveOccurred())
-
-				_, err = os.Stat(filepath.Join(tmpDir, ".cfignore"))
-				Expect(os.IsNotExist(err)).To(BeFalse())
-			})
-		})
-
 		It("returns files to upload with file mode unchanged on non-Windows platforms", func() {
 			if runtime.GOOS == "windows" {
 				Skip("This does not run on windows")
@@ -125,7 +88,7 @@ var _ = Describe("Push Actor", func() {
 
 			expectedFileMode := fmt.Sprintf("%#o", info.Mode())
 
-			actualFiles, _, err := actor.GatherFiles(allFiles, fixturesDir, tmpDir)
+			actualFiles, _, err := actor.GatherFiles(allFiles, fixturesDir)
 			Expect(err).NotTo(HaveOccurred())
 
 			expectedFiles := []resources.AppFileResource{
@@ -148,7 +111,7 @@ var _ = Describe("Push Actor", func() {
 
 			expectedFileMode := fmt.Sprintf("%#o", info.Mode()|0700)
 
-			actualFiles, _, err := actor.GatherFiles(allFiles, fixturesDir, tmpDir)
+			actualFiles, _, err := actor.GatherFiles(allFiles, fixturesDir)
 			Expect(err).NotTo(HaveOccurred())
 
 			expectedFiles := []resources.AppFileResource{
@@ -166,13 +129,7 @@ var _ = Describe("Push Actor", func() {
 				appBitsRepo.GetApplicationFilesReturns([]resources.AppFileResource{}, nil)
 			})
 
-			It("returns true for hasFileToUpload", func() {
-				_, hasFileToUpload, err := actor.GatherFiles(allFiles, fixturesDir, tmpDir)
-				Expect(err).NotTo(HaveOccurred())
-				Expect(hasFileToUpload).To(BeTrue())
-			})
-
-			It("copies all local files to the upload dir", func() {
+			It("returns all local files to upload", func() {
 				expectedFiles := []models.AppFileFields{
 					{Path: "example-app/.cfignore"},
 					{Path: "example-app/app.rb"},
@@ -182,14 +139,10 @@ var _ = Describe("Push Actor", func() {
 					{Path: "example-app/ignore-me"},
 					{Path: "example-app/manifest.yml"},
 				}
-				_, _, err := actor.GatherFiles(allFiles, fixturesDir, tmpDir)
+				_, filesToUpload, err := actor.GatherFiles(allFiles, fixturesDir)
 				Expect(err).NotTo(HaveOccurred())
 
-				Expect(appFiles.CopyFilesCallCount()).To(Equal(1))
-				filesToUpload, fromDir, toDir := appFiles.CopyFilesArgsForCall(0)
 				Expect(filesToUpload).To(Equal(expectedFiles))
-				Expect(fromDir).To(Equal(fixturesDir))
-				Expect(toDir).To(Equal(tmpDir))
 			})
 		})
 
@@ -204,13 +157,7 @@ var _ = Describe("Push Actor", func() {
 				appBitsRepo.GetApplicationFilesReturns(remoteFiles, nil)
 			})
 
-			It("returns true for hasFileToUpload", func() {
-				_, hasFileToUpload, err := actor.GatherFiles(allFiles, fixturesDir, tmpDir)
-				Expect(err).NotTo(HaveOccurred())
-				Expect(hasFileToUpload).To(BeTrue())
-			})
-
-			It("copies unmatched local files to the upload dir", func() {
+			It("returns unmatched local files to upload", func() {
 				expectedFiles := []models.AppFileFields{
 					{Path: "example-app/.cfignore"},
 					{Path: "example-app/app.rb"},
@@ -219,14 +166,10 @@ var _ = Describe("Push Actor", func() {
 					{Path: "example-app/Gemfile.lock"},
 					{Path: "example-app/ignore-me"},
 				}
-				_, _, err := actor.GatherFiles(allFiles, fixturesDir, tmpDir)
+				_, filesToUpload, err := actor.GatherFiles(allFiles, fixturesDir)
 				Expect(err).NotTo(HaveOccurred())
 
-				Expect(appFiles.CopyFilesCallCount()).To(Equal(1))
-				filesToUpload, fromDir, toDir := appFiles.CopyFilesArgsForCall(0)
 				Expect(filesToUpload).To(Equal(expectedFiles))
-				Expect(fromDir).To(Equal(fixturesDir))
-				Expect(toDir).To(Equal(tmpDir))
 			})
 		})
 
@@ -245,21 +188,11 @@ var _ = Describe("Push Actor", func() {
 				appBitsRepo.GetApplicationFilesReturns(remoteFiles, nil)
 			})
 
-			It("returns false for hasFileToUpload", func() {
-				_, hasFileToUpload, err := actor.GatherFiles(allFiles, fixturesDir, tmpDir)
-				Expect(err).NotTo(HaveOccurred())
-				Expect(hasFileToUpload).To(BeFalse())
-			})
-
-			It("copies nothing to the upload dir", func() {
-				_, _, err := actor.GatherFiles(allFiles, fixturesDir, tmpDir)
+			It("returns no files to upload", func() {
+				_, filesToUpload, err := actor.GatherFiles(allFiles, fixturesDir)
 				Expect(err).NotTo(HaveOccurred())
 
-				Expect(appFiles.CopyFilesCallCount()).To(Equal(1))
-				filesToUpload, fromDir, toDir := appFiles.CopyFilesArgsForCall(0)
 				Expect(filesToUpload).To(BeEmpty())
-				Expect(fromDir).To(Equal(fixturesDir))
-				Expect(toDir).To(Equal(tmpDir))
 			})
 		})
 	})
@@ -337,6 +270,114 @@ var _ = Describe("Push Actor", func() {
 			})
 		})
 
+		Context("when given a tar file", func() {
+			BeforeEach(func() {
+				fakezipper.IsTarFileReturns(true)
+				actor = actors.NewPushActor(appBitsRepo, fakezipper, appFiles, routeActor)
+			})
+
+			It("extracts the tar to a directory, calls the provided function with it and cleans it up", func() {
+				f := func(tempDir string) error {
+					wasCalled = true
+					wasCalledWith = tempDir
+					return nil
+				}
+				err := actor.ProcessPath("/some/app.tgz", f)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakezipper.UntarCallCount()).To(Equal(1))
+				tarFile, destDir := fakezipper.UntarArgsForCall(0)
+				Expect(tarFile).To(Equal("/some/app.tgz"))
+				Expect(wasCalled).To(BeTrue())
+				Expect(wasCalledWith).To(Equal(destDir))
+
+				_, err = os.Stat(destDir)
+				Expect(os.IsNotExist(err)).To(BeTrue())
+			})
+
+			It("returns an error if the extraction fails", func() {
+				fakezipper.UntarReturns(errors.New("untar-error"))
+				err := actor.ProcessPath("/some/app.tgz", func(_ string) error { return nil })
+				Expect(err).To(MatchError("untar-error"))
+			})
+		})
+
+		Context("when given an http(s) URL", func() {
+			var (
+				server   *ghttp.Server
+				zipBytes []byte
+				zipSha1  string
+			)
+
+			BeforeEach(func() {
+				wasCalled = false
+
+				var err error
+				zipBytes, err = ioutil.ReadFile(filepath.Join(fixturesDir, "example-app.zip"))
+				Expect(err).NotTo(HaveOccurred())
+				zipSha1 = fmt.Sprintf("%x", sha1.Sum(zipBytes))
+
+				server = ghttp.NewServer()
+				server.AllowUnhandledRequests = true
+				server.UnhandledRequestStatusCode = http.StatusNotFound
+				server.RouteToHandler("GET", "/app.zip", ghttp.RespondWith(http.StatusOK, zipBytes))
+				server.RouteToHandler("GET", "/readme.txt", ghttp.RespondWith(http.StatusOK, "not an archive"))
+			})
+
+			AfterEach(func() {
+				server.Close()
+			})
+
+			It("downloads and extracts the archive", func() {
+				f := func(tempDir string) error {
+					wasCalledWith = tempDir
+					for _, file := range allFiles {
+						_, err := os.Stat(filepath.Join(tempDir, file.Path))
+						Expect(err).NotTo(HaveOccurred())
+					}
+					return nil
+				}
+				err := actor.ProcessPath(server.URL()+"/app.zip", f)
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = os.Stat(wasCalledWith)
+				Expect(os.IsNotExist(err)).To(BeTrue())
+			})
+
+			It("verifies the checksum given in the URL", func() {
+				err := actor.ProcessPath(server.URL()+"/app.zip#sha1="+zipSha1, func(_ string) error {
+					wasCalled = true
+					return nil
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(wasCalled).To(BeTrue())
+			})
+
+			It("returns an error if the checksum does not match", func() {
+				err := actor.ProcessPath(server.URL()+"/app.zip#sha1=0123456789abcdef", func(_ string) error {
+					wasCalled = true
+					return nil
+				})
+				Expect(err).To(MatchError(ContainSubstring("does not match 0123456789abcdef")))
+				Expect(wasCalled).To(BeFalse())
+			})
+
+			It("returns an error if the URL has some other fragment", func() {
+				err := actor.ProcessPath(server.URL()+"/app.zip#md5=abc", func(_ string) error { return nil })
+				Expect(err).To(MatchError(ContainSubstring("Unsupported fragment")))
+			})
+
+			It("returns an error if the download is not an archive", func() {
+				err := actor.ProcessPath(server.URL()+"/readme.txt", func(_ string) error { return nil })
+				Expect(err).To(MatchError(ContainSubstring("is not a zip or tar archive")))
+			})
+
+			It("returns an error if the download fails", func() {
+				err := actor.ProcessPath(server.URL()+"/missing.zip", func(_ string) error { return nil })
+				Expect(err).To(HaveOccurred())
+			})
+		})
+
 		It("calls the provided function with the provided directory", func() {
 			appDir = filepath.Join(fixturesDir, "example-app")
 			f := func(tempDir string) error {
@@ -22,27 +22,30 @@ func (resource ApplicationSummaries) ToModels() (apps []models.ApplicationFields
 }
 
 type ApplicationFromSummary struct {
-	GUID                 string
-	Name                 string
-	Routes               []RouteSummary
-	Services             []ServicePlanSummary
-	Diego                bool `json:"diego,omitempty"`
-	RunningInstances     int  `json:"running_instances"`
-	Memory               int64
-	Instances            int
-	DiskQuota            int64 `json:"disk_quota"`
-	AppPorts             []int `json:"ports"`
-	URLs                 []string
-	EnvironmentVars      map[string]interface{} `json:"environment_json,omitempty"`
-	HealthCheckTimeout   int                    `json:"health_check_timeout"`
-	State                string
-	DetectedStartCommand string     `json:"detected_start_command"`
-	SpaceGUID            string     `json:"space_guid"`
-	StackGUID            string     `json:"stack_guid"`
-	Command              string     `json:"command"`
-	PackageState         string     `json:"package_state"`
-	PackageUpdatedAt     *time.Time `json:"package_updated_at"`
-	Buildpack            string
+	GUID                    string
+	Name                    string
+	Routes                  []RouteSummary
+	Services                []ServicePlanSummary
+	Diego                   bool `json:"diego,omitempty"`
+	RunningInstances        int  `json:"running_instances"`
+	Memory                  int64
+	Instances               int
+	DiskQuota               int64 `json:"disk_quota"`
+	AppPorts                []int `json:"ports"`
+	URLs                    []string
+	EnvironmentVars         map[string]interface{} `json:"environment_json,omitempty"`
+	HealthCheckType         string                 `json:"health_check_type"`
+	HealthCheckTimeout      int                    `json:"health_check_timeout"`
+	HealthCheckHTTPEndpoint string                 `json:"health_check_http_endpoint"`
+	State                   string
+	DetectedStartCommand    string     `json:"detected_start_command"`
+	SpaceGUID               string     `json:"space_guid"`
+	StackGUID               string     `json:"stack_guid"`
+	Command                 string     `json:"command"`
+	PackageState            string     `json:"package_state"`
+	PackageUpdatedAt        *time.Time `json:"package_updated_at"`
+	Buildpack               string
+	DockerImage             string `json:"docker_image"`
 }
 
 func (resource ApplicationFromSummary) ToFields() (app models.ApplicationFields) {
@@ -60,9 +63,12 @@ func (resource ApplicationFromSummary) ToFields() (app models.ApplicationFields)
 	app.PackageUpdatedAt = resource.PackageUpdatedAt
 	app.PackageState = resource.PackageState
 	app.DetectedStartCommand = resource.DetectedStartCommand
+	app.HealthCheckType = resource.HealthCheckType
 	app.HealthCheckTimeout = resource.HealthCheckTimeout
+	app.HealthCheckHTTPEndpoint = resource.HealthCheckHTTPEndpoint
 	app.BuildpackURL = resource.Buildpack
 	app.Command = resource.Command
+	app.DockerImage = resource.DockerImage
 	app.AppPorts = resource.AppPorts
 	app.EnvironmentVars = resource.EnvironmentVars
 
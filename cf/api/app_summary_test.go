@@ -129,6 +129,9 @@ var _ = Describe("AppSummaryRepository", func() {
 			Expect(app.Memory).To(Equal(int64(128)))
 			Expect(app.PackageUpdatedAt.Format("2006-01-02T15:04:05Z07:00")).To(Equal("2014-10-24T19:54:00Z"))
 			Expect(app.StackGUID).To(Equal("the-stack-guid"))
+			Expect(app.DockerImage).To(Equal("user/docker-image"))
+			Expect(app.HealthCheckType).To(Equal("http"))
+			Expect(app.HealthCheckHTTPEndpoint).To(Equal("/health"))
 		})
 	})
 
@@ -240,6 +243,9 @@ const getAppSummaryResponseBody string = `
 		"command": "start_command",
 		"instances":1,
 		"buildpack":"go_buildpack",
+		"docker_image":"user/docker-image",
+		"health_check_type":"http",
+		"health_check_http_endpoint":"/health",
 		"state":"STARTED",
 		"service_names":[
 			"my-service-instance"
@@ -5,16 +5,17 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"mime/multipart"
 	"net/textproto"
-	"os"
+	"sync"
 	"time"
 
 	"code.cloudfoundry.org/cli/cf/api/resources"
 	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/errors"
 	. "code.cloudfoundry.org/cli/cf/i18n"
 	"code.cloudfoundry.org/cli/cf/net"
-	"code.cloudfoundry.org/gofileutils/fileutils"
 )
 
 const (
@@ -23,9 +24,14 @@ const (
 
 //go:generate counterfeiter . Repository
 
+// ZipWriter writes the zip of the application files to upload. It is called
+// again each time the upload is retried, and must write the same zip each
+// time.
+type ZipWriter func(io.Writer) error
+
 type Repository interface {
 	GetApplicationFiles(appFilesRequest []resources.AppFileResource) ([]resources.AppFileResource, error)
-	UploadBits(appGUID string, zipFile *os.File, presentFiles []resources.AppFileResource) (apiErr error)
+	UploadBits(appGUID string, writeZip ZipWriter, presentFiles []resources.AppFileResource) error
 }
 
 type CloudControllerApplicationBitsRepository struct {
@@ -39,52 +45,103 @@ func NewCloudControllerApplicationBitsRepository(config coreconfig.Reader, gatew
 	return
 }
 
-func (repo CloudControllerApplicationBitsRepository) UploadBits(appGUID string, zipFile *os.File, presentFiles []resources.AppFileResource) (apiErr error) {
+func (repo CloudControllerApplicationBitsRepository) UploadBits(appGUID string, writeZip ZipWriter, presentFiles []resources.AppFileResource) error {
 	apiURL := fmt.Sprintf("/v2/apps/%s/bits", appGUID)
-	fileutils.TempFile("requests", func(requestFile *os.File, err error) {
-		if err != nil {
-			apiErr = fmt.Errorf("%s: %s", T("Error creating tmp file: {{.Err}}", map[string]interface{}{"Err": err}), err.Error())
-			return
-		}
 
-		// json.Marshal represents a nil value as "null" instead of an empty slice "[]"
-		if presentFiles == nil {
-			presentFiles = []resources.AppFileResource{}
-		}
+	// json.Marshal represents a nil value as "null" instead of an empty slice "[]"
+	if presentFiles == nil {
+		presentFiles = []resources.AppFileResource{}
+	}
 
-		presentFilesJSON, err := json.Marshal(presentFiles)
-		if err != nil {
-			apiErr = fmt.Errorf("%s: %s", T("Error marshaling JSON"), err.Error())
-			return
+	presentFilesJSON, err := json.Marshal(presentFiles)
+	if err != nil {
+		return fmt.Errorf("%s: %s", T("Error marshaling JSON"), err.Error())
+	}
+
+	// Every attempt to send the request must use the same boundary, since it
+	// is part of the Content-Type header.
+	boundary := multipart.NewWriter(ioutil.Discard).Boundary()
+
+	var (
+		zipErrMutex sync.Mutex
+		zipErr      error
+	)
+	newBody := func() (io.ReadCloser, error) {
+		// Zipping the same files again would fail in the same way, so there is
+		// no point retrying the request.
+		zipErrMutex.Lock()
+		defer zipErrMutex.Unlock()
+		if zipErr != nil {
+			return nil, zipErr
 		}
 
-		boundary, err := repo.writeUploadBody(zipFile, requestFile, presentFilesJSON)
-		if err != nil {
-			apiErr = fmt.Errorf("%s: %s", T("Error writing to tmp file: {{.Err}}", map[string]interface{}{"Err": err}), err.Error())
-			return
+		reader, writer := io.Pipe()
+		go func() {
+			err := writeUploadBody(writer, boundary, presentFilesJSON, writeZip)
+			if err != nil && err != io.ErrClosedPipe {
+				zipErrMutex.Lock()
+				zipErr = err
+				zipErrMutex.Unlock()
+			}
+			_ = writer.CloseWithError(err)
+		}()
+		return reader, nil
+	}
+
+	request, err := repo.gateway.NewRequestForStream("PUT", repo.config.APIEndpoint()+apiURL, repo.config.AccessToken(), newBody)
+	if err != nil {
+		return err
+	}
+
+	contentType := fmt.Sprintf("multipart/form-data; boundary=%s", boundary)
+	request.HTTPReq.Header.Set("Content-Type", contentType)
+
+	response := &resources.Resource{}
+	_, err = repo.gateway.PerformPollingRequestForJSONResponse(repo.config.APIEndpoint(), request, response, DefaultAppUploadBitsTimeout)
+	if err != nil {
+		zipErrMutex.Lock()
+		defer zipErrMutex.Unlock()
+		if emptyDirErr, ok := zipErr.(*errors.EmptyDirError); ok {
+			return emptyDirErr
+		}
+		if zipErr != nil {
+			return fmt.Errorf("%s: %s", T("Error zipping application"), zipErr.Error())
 		}
+		return err
+	}
+
+	return nil
+}
+
+// ResourceMatchBatchSize is the largest number of files that are checked in
+// a single /v2/resource_match request.
+var ResourceMatchBatchSize = 1000
 
-		var request *net.Request
-		request, apiErr = repo.gateway.NewRequestForFile("PUT", repo.config.APIEndpoint()+apiURL, repo.config.AccessToken(), requestFile)
-		if apiErr != nil {
-			return
+func (repo CloudControllerApplicationBitsRepository) GetApplicationFiles(appFilesToCheck []resources.AppFileResource) ([]resources.AppFileResource, error) {
+	integrityFields := mapAppFilesToIntegrityFields(appFilesToCheck)
+
+	matchedShas := map[string]bool{}
+	for start := 0; start < len(integrityFields); start += ResourceMatchBatchSize {
+		end := start + ResourceMatchBatchSize
+		if end > len(integrityFields) {
+			end = len(integrityFields)
 		}
 
-		contentType := fmt.Sprintf("multipart/form-data; boundary=%s", boundary)
-		request.HTTPReq.Header.Set("Content-Type", contentType)
+		responseFieldsColl, err := repo.matchResources(integrityFields[start:end])
+		if err != nil {
+			return nil, err
+		}
 
-		response := &resources.Resource{}
-		_, apiErr = repo.gateway.PerformPollingRequestForJSONResponse(repo.config.APIEndpoint(), request, response, DefaultAppUploadBitsTimeout)
-		if apiErr != nil {
-			return
+		for _, responseFields := range responseFieldsColl {
+			matchedShas[responseFields.Sha1] = true
 		}
-	})
+	}
 
-	return
+	return filterAppFilesBySha(appFilesToCheck, matchedShas), nil
 }
 
-func (repo CloudControllerApplicationBitsRepository) GetApplicationFiles(appFilesToCheck []resources.AppFileResource) ([]resources.AppFileResource, error) {
-	integrityFieldsJSON, err := json.Marshal(mapAppFilesToIntegrityFields(appFilesToCheck))
+func (repo CloudControllerApplicationBitsRepository) matchResources(integrityFields []resources.IntegrityFields) ([]resources.IntegrityFields, error) {
+	integrityFieldsJSON, err := json.Marshal(integrityFields)
 	if err != nil {
 		apiErr := fmt.Errorf("%s: %s", T("Failed to create json for resource_match request"), err.Error())
 		return nil, apiErr
@@ -101,83 +158,75 @@ func (repo CloudControllerApplicationBitsRepository) GetApplicationFiles(appFile
 		return nil, apiErr
 	}
 
-	return intersectAppFilesIntegrityFields(appFilesToCheck, responseFieldsColl), nil
+	return responseFieldsColl, nil
 }
 
+// mapAppFilesToIntegrityFields returns the integrity fields of every file that
+// the Cloud Controller could already have. Directories are left out and files
+// with the same contents are only checked once.
 func mapAppFilesToIntegrityFields(in []resources.AppFileResource) (out []resources.IntegrityFields) {
+	seen := map[resources.IntegrityFields]bool{}
 	for _, appFile := range in {
-		out = append(out, appFile.ToIntegrityFields())
-	}
-	return out
-}
+		if appFile.Sha1 == "0" {
+			continue
+		}
 
-func intersectAppFilesIntegrityFields(
-	appFiles []resources.AppFileResource,
-	integrityFields []resources.IntegrityFields,
-) (out []resources.AppFileResource) {
-	inputFiles := appFilesBySha(appFiles)
-	for _, responseFields := range integrityFields {
-		item, found := inputFiles[responseFields.Sha1]
-		if found {
-			out = append(out, item)
+		integrityFields := appFile.ToIntegrityFields()
+		if !seen[integrityFields] {
+			seen[integrityFields] = true
+			out = append(out, integrityFields)
 		}
 	}
 	return out
 }
 
-func appFilesBySha(in []resources.AppFileResource) (out map[string]resources.AppFileResource) {
-	out = map[string]resources.AppFileResource{}
-	for _, inputFileResource := range in {
-		out[inputFileResource.Sha1] = inputFileResource
+func filterAppFilesBySha(appFiles []resources.AppFileResource, shas map[string]bool) (out []resources.AppFileResource) {
+	for _, appFile := range appFiles {
+		if shas[appFile.Sha1] {
+			out = append(out, appFile)
+		}
 	}
 	return out
 }
 
-func (repo CloudControllerApplicationBitsRepository) writeUploadBody(zipFile *os.File, body *os.File, presentResourcesJSON []byte) (boundary string, err error) {
+// writeUploadBody writes the multipart body of an upload, with the files the
+// Cloud Controller already has and the zip of the files it does not.
+func writeUploadBody(body io.Writer, boundary string, presentResourcesJSON []byte, writeZip ZipWriter) error {
 	writer := multipart.NewWriter(body)
-	defer writer.Close()
-
-	boundary = writer.Boundary()
+	err := writer.SetBoundary(boundary)
+	if err != nil {
+		return err
+	}
 
 	part, err := writer.CreateFormField("resources")
 	if err != nil {
-		return
+		return err
 	}
 
-	_, err = io.Copy(part, bytes.NewBuffer(presentResourcesJSON))
+	_, err = part.Write(presentResourcesJSON)
 	if err != nil {
-		return
+		return err
 	}
 
-	if zipFile != nil {
-		zipStats, zipErr := zipFile.Stat()
-		if zipErr != nil {
-			return
-		}
-
-		if zipStats.Size() == 0 {
-			return
-		}
-
-		part, zipErr = createZipPartWriter(zipStats, writer)
-		if zipErr != nil {
-			return
+	if writeZip != nil {
+		part, err = createZipPartWriter(writer)
+		if err != nil {
+			return err
 		}
 
-		_, zipErr = io.Copy(part, zipFile)
-		if zipErr != nil {
-			return
+		err = writeZip(part)
+		if err != nil {
+			return err
 		}
 	}
 
-	return
+	return writer.Close()
 }
 
-func createZipPartWriter(zipStats os.FileInfo, writer *multipart.Writer) (io.Writer, error) {
+func createZipPartWriter(writer *multipart.Writer) (io.Writer, error) {
 	h := make(textproto.MIMEHeader)
 	h.Set("Content-Disposition", `form-data; name="application"; filename="application.zip"`)
 	h.Set("Content-Type", "application/zip")
-	h.Set("Content-Length", fmt.Sprintf("%d", zipStats.Size()))
 	h.Set("Content-Transfer-Encoding", "binary")
 	return writer.CreatePart(h)
 }
@@ -3,19 +3,20 @@ package applicationbits_test
 import (
 	"archive/zip"
 	"fmt"
-	"log"
+	"io"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"runtime"
-	"strconv"
 	"strings"
 	"time"
 
 	testapi "code.cloudfoundry.org/cli/cf/api/apifakes"
 	"code.cloudfoundry.org/cli/cf/api/resources"
 	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/errors"
 	"code.cloudfoundry.org/cli/cf/net"
 	"code.cloudfoundry.org/cli/cf/terminal/terminalfakes"
 	testconfig "code.cloudfoundry.org/cli/util/testhelpers/configuration"
@@ -63,13 +64,18 @@ var _ = Describe("CloudControllerApplicationBitsRepository", func() {
 	}
 
 	Describe(".UploadBits", func() {
-		var uploadFile *os.File
-		var err error
+		var writeZip ZipWriter
 
 		BeforeEach(func() {
-			uploadFile, err = os.Open(filepath.Join(fixturesDir, "ignored_and_resource_matched_example_app.zip"))
-			if err != nil {
-				log.Fatal(err)
+			writeZip = func(writer io.Writer) error {
+				uploadFile, err := os.Open(filepath.Join(fixturesDir, "ignored_and_resource_matched_example_app.zip"))
+				if err != nil {
+					return err
+				}
+				defer uploadFile.Close()
+
+				_, err = io.Copy(writer, uploadFile)
+				return err
 			}
 		})
 
@@ -97,7 +103,7 @@ var _ = Describe("CloudControllerApplicationBitsRepository", func() {
 				createProgressEndpoint("finished"),
 			)
 
-			apiErr := repo.UploadBits("my-cool-app-guid", uploadFile, []resources.AppFileResource{file1, file2})
+			apiErr := repo.UploadBits("my-cool-app-guid", writeZip, []resources.AppFileResource{file1, file2})
 			Expect(apiErr).NotTo(HaveOccurred())
 		})
 
@@ -120,11 +126,71 @@ var _ = Describe("CloudControllerApplicationBitsRepository", func() {
 				createProgressEndpoint("running"),
 				createProgressEndpoint("failed"),
 			)
-			apiErr := repo.UploadBits("my-cool-app-guid", uploadFile, []resources.AppFileResource{file1, file2})
+			apiErr := repo.UploadBits("my-cool-app-guid", writeZip, []resources.AppFileResource{file1, file2})
 
 			Expect(apiErr).To(HaveOccurred())
 		})
 
+		It("streams the body without a content length", func() {
+			setupTestServer(testapi.NewCloudControllerTestRequest(testnet.TestRequest{
+				Method: "PUT",
+				Path:   "/v2/apps/my-cool-app-guid/bits",
+				Matcher: func(request *http.Request) {
+					Expect(request.ContentLength).To(Equal(int64(-1)))
+					Expect(request.TransferEncoding).To(Equal([]string{"chunked"}))
+					uploadBodyMatcher(defaultZipCheck)(request)
+				},
+				Response: testnet.TestResponse{
+					Status: http.StatusCreated,
+					Body: `
+					{
+						"metadata":{
+							"guid": "my-job-guid",
+							"url": "/v2/jobs/my-job-guid"
+						}
+					}`,
+				},
+			}),
+				createProgressEndpoint("finished"),
+			)
+
+			apiErr := repo.UploadBits("my-cool-app-guid", writeZip, []resources.AppFileResource{file1, file2})
+			Expect(apiErr).NotTo(HaveOccurred())
+		})
+
+		Context("when zipping the application fails", func() {
+			BeforeEach(func() {
+				setupTestServer(testapi.NewCloudControllerTestRequest(testnet.TestRequest{
+					Method: "PUT",
+					Path:   "/v2/apps/my-cool-app-guid/bits",
+					Matcher: func(request *http.Request) {
+						_, err := ioutil.ReadAll(request.Body)
+						Expect(err).To(HaveOccurred())
+					},
+					Response: testnet.TestResponse{Status: http.StatusBadRequest},
+				}))
+			})
+
+			It("returns the zip error", func() {
+				apiErr := repo.UploadBits("my-cool-app-guid", func(io.Writer) error {
+					return errors.New("zip-error")
+				}, []resources.AppFileResource{file1, file2})
+
+				Expect(apiErr).To(HaveOccurred())
+				Expect(apiErr.Error()).To(ContainSubstring("Error zipping application"))
+				Expect(apiErr.Error()).To(ContainSubstring("zip-error"))
+			})
+
+			It("returns an empty directory error as it is", func() {
+				emptyDirErr := errors.NewEmptyDirError("some-dir")
+				apiErr := repo.UploadBits("my-cool-app-guid", func(io.Writer) error {
+					return emptyDirErr
+				}, []resources.AppFileResource{file1, file2})
+
+				Expect(apiErr).To(Equal(emptyDirErr))
+			})
+		})
+
 		Context("when there are no files to upload", func() {
 			It("makes a request without a zipfile", func() {
 				setupTestServer(
@@ -214,6 +280,56 @@ var _ = Describe("CloudControllerApplicationBitsRepository", func() {
 			Expect(matchedFiles).To(Equal([]resources.AppFileResource{file4}))
 			Expect(err).NotTo(HaveOccurred())
 		})
+
+		It("does not check directories and checks files with the same contents once", func() {
+			setupTestServer(matchResourceRequestImbalanced)
+			dir := resources.AppFileResource{Path: "lib", Sha1: "0"}
+			copyOfFile4 := resources.AppFileResource{Path: "lib/Gemfile.lock", Sha1: file4.Sha1, Size: file4.Size}
+
+			matchedFiles, err := repo.GetApplicationFiles([]resources.AppFileResource{dir, file1, file4, copyOfFile4})
+			Expect(matchedFiles).To(Equal([]resources.AppFileResource{file4, copyOfFile4}))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when there are more files than fit in one request", func() {
+			var originalBatchSize int
+
+			BeforeEach(func() {
+				originalBatchSize = ResourceMatchBatchSize
+				ResourceMatchBatchSize = 2
+			})
+
+			AfterEach(func() {
+				ResourceMatchBatchSize = originalBatchSize
+			})
+
+			It("checks the files in batches", func() {
+				setupTestServer(
+					testnet.TestRequest{
+						Method:  "PUT",
+						Path:    "/v2/resource_match",
+						Matcher: testnet.RequestBodyMatcher(`[{"sha1":"2474735f5163ba7612ef641f438f4b5bee00127b","size":51},{"sha1":"f097424ce1fa66c6cb9f5e8a18c317376ec12e05","size":70}]`),
+						Response: testnet.TestResponse{
+							Status: http.StatusOK,
+							Body:   `[{"sha1":"f097424ce1fa66c6cb9f5e8a18c317376ec12e05","size":70}]`,
+						},
+					},
+					testnet.TestRequest{
+						Method:  "PUT",
+						Path:    "/v2/resource_match",
+						Matcher: testnet.RequestBodyMatcher(`[{"sha1":"d9c3a51de5c89c11331d3b90b972789f1a14699a","size":59},{"sha1":"345f999aef9070fb9a608e65cf221b7038156b6d","size":229}]`),
+						Response: testnet.TestResponse{
+							Status: http.StatusOK,
+							Body:   `[{"sha1":"345f999aef9070fb9a608e65cf221b7038156b6d","size":229}]`,
+						},
+					},
+				)
+
+				matchedFiles, err := repo.GetApplicationFiles([]resources.AppFileResource{file1, file2, file3, file4})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(matchedFiles).To(Equal([]resources.AppFileResource{file2, file4}))
+			})
+		})
 	})
 })
 
@@ -375,14 +491,8 @@ func uploadBodyMatcher(zipChecks func(zipReader *zip.Reader)) func(*http.Request
 			return
 		}
 
-		length, err := strconv.ParseInt(applicationFile.Header.Get("content-length"), 10, 64)
-		if err != nil {
-			Fail(fmt.Sprintf("Cannot convert content-length to int %v", err.Error()))
-			return
-		}
-
 		if zipChecks != nil {
-			zipReader, err := zip.NewReader(file, length)
+			zipReader, err := zip.NewReader(file, applicationFile.Size)
 			if err != nil {
 				Fail(fmt.Sprintf("Error reading zip content %v", err.Error()))
 				return
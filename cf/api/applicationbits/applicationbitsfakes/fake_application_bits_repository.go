@@ -2,7 +2,6 @@
 package applicationbitsfakes
 
 import (
-	"os"
 	"sync"
 
 	"code.cloudfoundry.org/cli/cf/api/applicationbits"
@@ -19,11 +18,11 @@ type FakeApplicationBitsRepository struct {
 		result1 []resources.AppFileResource
 		result2 error
 	}
-	UploadBitsStub        func(appGUID string, zipFile *os.File, presentFiles []resources.AppFileResource) (apiErr error)
+	UploadBitsStub        func(appGUID string, writeZip applicationbits.ZipWriter, presentFiles []resources.AppFileResource) error
 	uploadBitsMutex       sync.RWMutex
 	uploadBitsArgsForCall []struct {
 		appGUID      string
-		zipFile      *os.File
+		writeZip     applicationbits.ZipWriter
 		presentFiles []resources.AppFileResource
 	}
 	uploadBitsReturns struct {
@@ -69,7 +68,7 @@ func (fake *FakeApplicationBitsRepository) GetApplicationFilesReturns(result1 []
 	}{result1, result2}
 }
 
-func (fake *FakeApplicationBitsRepository) UploadBits(appGUID string, zipFile *os.File, presentFiles []resources.AppFileResource) (apiErr error) {
+func (fake *FakeApplicationBitsRepository) UploadBits(appGUID string, writeZip applicationbits.ZipWriter, presentFiles []resources.AppFileResource) error {
 	var presentFilesCopy []resources.AppFileResource
 	if presentFiles != nil {
 		presentFilesCopy = make([]resources.AppFileResource, len(presentFiles))
@@ -78,12 +77,12 @@ func (fake *FakeApplicationBitsRepository) UploadBits(appGUID string, zipFile *o
 	fake.uploadBitsMutex.Lock()
 	fake.uploadBitsArgsForCall = append(fake.uploadBitsArgsForCall, struct {
 		appGUID      string
-		zipFile      *os.File
+		writeZip     applicationbits.ZipWriter
 		presentFiles []resources.AppFileResource
-	}{appGUID, zipFile, presentFilesCopy})
+	}{appGUID, writeZip, presentFilesCopy})
 	fake.uploadBitsMutex.Unlock()
 	if fake.UploadBitsStub != nil {
-		return fake.UploadBitsStub(appGUID, zipFile, presentFiles)
+		return fake.UploadBitsStub(appGUID, writeZip, presentFiles)
 	} else {
 		return fake.uploadBitsReturns.result1
 	}
@@ -95,10 +94,10 @@ func (fake *FakeApplicationBitsRepository) UploadBitsCallCount() int {
 	return len(fake.uploadBitsArgsForCall)
 }
 
-func (fake *FakeApplicationBitsRepository) UploadBitsArgsForCall(i int) (string, *os.File, []resources.AppFileResource) {
+func (fake *FakeApplicationBitsRepository) UploadBitsArgsForCall(i int) (string, applicationbits.ZipWriter, []resources.AppFileResource) {
 	fake.uploadBitsMutex.RLock()
 	defer fake.uploadBitsMutex.RUnlock()
-	return fake.uploadBitsArgsForCall[i].appGUID, fake.uploadBitsArgsForCall[i].zipFile, fake.uploadBitsArgsForCall[i].presentFiles
+	return fake.uploadBitsArgsForCall[i].appGUID, fake.uploadBitsArgsForCall[i].writeZip, fake.uploadBitsArgsForCall[i].presentFiles
 }
 
 func (fake *FakeApplicationBitsRepository) UploadBitsReturns(result1 error) {
@@ -7,12 +7,15 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"sync"
 	"time"
 
+	uaaapi "code.cloudfoundry.org/cli/api/uaa"
 	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
 	"code.cloudfoundry.org/cli/cf/errors"
 	. "code.cloudfoundry.org/cli/cf/i18n"
 	"code.cloudfoundry.org/cli/cf/net"
+	"code.cloudfoundry.org/cli/util/cassette"
 )
 
 //go:generate counterfeiter . TokenRefresher
@@ -28,6 +31,8 @@ type Repository interface {
 
 	RefreshAuthToken() (updatedToken string, apiErr error)
 	Authenticate(credentials map[string]string) (apiErr error)
+	AuthenticateClientCredentials(clientID string, clientSecret string) (apiErr error)
+	AuthenticateWithBrowser(timeout time.Duration, openURL func(authorizationURL string) error) (apiErr error)
 	Authorize(token string) (string, error)
 	GetLoginPromptsAndSaveUAAServerURL() (map[string]coreconfig.AuthPrompt, error)
 }
@@ -55,14 +60,14 @@ func (uaa UAARepository) Authorize(token string) (string, error) {
 			return ErrPreventRedirect
 		},
 		Timeout: 30 * time.Second,
-		Transport: &http.Transport{
+		Transport: cassette.WrapTransport(&http.Transport{
 			DisableKeepAlives: true,
 			TLSClientConfig: &tls.Config{
 				InsecureSkipVerify: uaa.config.IsSSLDisabled(),
 			},
 			Proxy:               http.ProxyFromEnvironment,
 			TLSHandshakeTimeout: 10 * time.Second,
-		},
+		}),
 	}
 
 	authorizeURL, err := url.Parse(uaa.config.UaaEndpoint())
@@ -119,22 +124,55 @@ func (uaa UAARepository) Authenticate(credentials map[string]string) error {
 		data[key] = []string{val}
 	}
 
-	err := uaa.getAuthToken(data)
+	return translateAuthError(uaa.getAuthToken(data))
+}
+
+// AuthenticateClientCredentials logs in as the given client with the
+// client_credentials grant. The client credentials are kept in the config so
+// that RefreshAuthToken can authenticate again once the token expires.
+func (uaa UAARepository) AuthenticateClientCredentials(clientID string, clientSecret string) error {
+	uaa.config.SetUAAOAuthClient(clientID)
+	uaa.config.SetUAAOAuthClientSecret(clientSecret)
+	uaa.config.SetUAAGrantType(uaaapi.GrantTypeClientCredentials)
+
+	err := uaa.getAuthToken(url.Values{
+		"grant_type": {uaaapi.GrantTypeClientCredentials},
+	})
 	if err != nil {
-		httpError, ok := err.(errors.HTTPError)
-		if ok {
-			switch {
-			case httpError.StatusCode() == http.StatusUnauthorized:
-				return errors.New(T("Credentials were rejected, please try again."))
-			case httpError.StatusCode() >= http.StatusInternalServerError:
-				return errors.New(T("The targeted API endpoint could not be reached."))
-			}
-		}
+		uaa.config.ClearSession()
+		return translateAuthError(err)
+	}
+
+	return nil
+}
 
+// AuthenticateWithBrowser logs the user in by having them authorize the CLI
+// in a browser. openURL is given the URL the user needs to visit.
+func (uaa UAARepository) AuthenticateWithBrowser(timeout time.Duration, openURL func(authorizationURL string) error) error {
+	code, err := uaaapi.RequestAuthorizationCode(uaa.config.AuthenticationEndpoint(), uaa.config.UAAOAuthClient(), timeout, openURL)
+	if err != nil {
 		return err
 	}
 
-	return nil
+	return translateAuthError(uaa.getAuthToken(url.Values{
+		"grant_type":    {uaaapi.GrantTypeAuthorizationCode},
+		"code":          {code.Code},
+		"code_verifier": {code.CodeVerifier},
+		"redirect_uri":  {code.RedirectURI},
+	}))
+}
+
+func translateAuthError(err error) error {
+	if httpError, ok := err.(errors.HTTPError); ok {
+		switch {
+		case httpError.StatusCode() == http.StatusUnauthorized:
+			return errors.New(T("Credentials were rejected, please try again."))
+		case httpError.StatusCode() >= http.StatusInternalServerError:
+			return errors.New(T("The targeted API endpoint could not be reached."))
+		}
+	}
+
+	return err
 }
 
 func (uaa UAARepository) DumpRequest(req *http.Request) {
@@ -180,13 +218,36 @@ func (uaa UAARepository) GetLoginPromptsAndSaveUAAServerURL() (prompts map[strin
 	return
 }
 
+// refreshLock serializes token refreshes, so that callers that find the token
+// expired at the same time do not each ask the UAA for a new one.
+var refreshLock sync.Mutex
+
+// RefreshAuthToken gets a new access token from the UAA and saves it in the
+// config. If the token was refreshed by someone else while waiting to refresh
+// it, that token is returned instead.
 func (uaa UAARepository) RefreshAuthToken() (string, error) {
+	staleToken := uaa.config.AccessToken()
+
+	refreshLock.Lock()
+	defer refreshLock.Unlock()
+
+	if token := uaa.config.AccessToken(); token != staleToken {
+		return token, nil
+	}
+
 	data := url.Values{
 		"refresh_token": {uaa.config.RefreshToken()},
 		"grant_type":    {"refresh_token"},
 		"scope":         {""},
 	}
 
+	// Clients are not given a refresh token and authenticate again instead.
+	if uaa.config.UAAGrantType() == uaaapi.GrantTypeClientCredentials {
+		data = url.Values{
+			"grant_type": {uaaapi.GrantTypeClientCredentials},
+		}
+	}
+
 	apiErr := uaa.getAuthToken(data)
 	updatedToken := uaa.config.AccessToken()
 
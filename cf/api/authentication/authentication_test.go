@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"time"
 
 	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
 	"code.cloudfoundry.org/cli/cf/net"
@@ -137,6 +139,105 @@ var _ = Describe("AuthenticationRepository", func() {
 			})
 		})
 
+		Describe("authenticating with client credentials", func() {
+			var err error
+
+			JustBeforeEach(func() {
+				err = auth.AuthenticateClientCredentials("some-client", "some-secret")
+			})
+
+			Context("when the client is authenticated", func() {
+				BeforeEach(func() {
+					setupTestServer(testnet.TestRequest{
+						Method: "POST",
+						Path:   "/oauth/token",
+						Header: http.Header{
+							"authorization": {"Basic " + base64.StdEncoding.EncodeToString([]byte("some-client:some-secret"))},
+						},
+						Matcher: func(request *http.Request) {
+							Expect(request.ParseForm()).To(Succeed())
+							Expect(request.Form.Get("grant_type")).To(Equal("client_credentials"))
+						},
+						Response: testnet.TestResponse{
+							Status: http.StatusOK,
+							Body:   `{"access_token": "my_access_token", "token_type": "BEARER"}`,
+						},
+					})
+				})
+
+				It("stores the token and the client credentials in the config", func() {
+					Expect(handler).To(HaveAllRequestsCalled())
+					Expect(err).NotTo(HaveOccurred())
+					Expect(config.AccessToken()).To(Equal("BEARER my_access_token"))
+					Expect(config.UAAOAuthClient()).To(Equal("some-client"))
+					Expect(config.UAAOAuthClientSecret()).To(Equal("some-secret"))
+					Expect(config.UAAGrantType()).To(Equal("client_credentials"))
+				})
+			})
+
+			Context("when the client credentials are rejected", func() {
+				BeforeEach(func() {
+					setupTestServer(unsuccessfulLoginRequest)
+				})
+
+				It("returns an error and restores the default client", func() {
+					Expect(handler).To(HaveAllRequestsCalled())
+					Expect(err).To(MatchError("Credentials were rejected, please try again."))
+					Expect(config.UAAOAuthClient()).To(Equal("cf"))
+					Expect(config.UAAOAuthClientSecret()).To(BeEmpty())
+					Expect(config.UAAGrantType()).To(BeEmpty())
+				})
+			})
+		})
+
+		Describe("authenticating with a browser", func() {
+			var err error
+
+			BeforeEach(func() {
+				setupTestServer(testnet.TestRequest{
+					Method: "POST",
+					Path:   "/oauth/token",
+					Header: authHeaders,
+					Matcher: func(request *http.Request) {
+						Expect(request.ParseForm()).To(Succeed())
+						Expect(request.Form.Get("grant_type")).To(Equal("authorization_code"))
+						Expect(request.Form.Get("code")).To(Equal("some-code"))
+						Expect(request.Form.Get("code_verifier")).ToNot(BeEmpty())
+						Expect(request.Form.Get("redirect_uri")).To(HavePrefix("http://127.0.0.1:"))
+					},
+					Response: testnet.TestResponse{
+						Status: http.StatusOK,
+						Body:   `{"access_token": "my_access_token", "token_type": "BEARER", "refresh_token": "my_refresh_token"}`,
+					},
+				})
+			})
+
+			JustBeforeEach(func() {
+				err = auth.AuthenticateWithBrowser(time.Second, func(rawURL string) error {
+					authorizationURL, parseErr := url.Parse(rawURL)
+					Expect(parseErr).NotTo(HaveOccurred())
+					Expect(authorizationURL.Path).To(Equal("/oauth/authorize"))
+					query := authorizationURL.Query()
+					Expect(query.Get("client_id")).To(Equal("cf"))
+
+					go func() {
+						defer GinkgoRecover()
+						response, getErr := http.Get(query.Get("redirect_uri") + "?code=some-code&state=" + query.Get("state"))
+						Expect(getErr).NotTo(HaveOccurred())
+						response.Body.Close()
+					}()
+					return nil
+				})
+			})
+
+			It("exchanges the authorization code for tokens", func() {
+				Expect(handler).To(HaveAllRequestsCalled())
+				Expect(err).NotTo(HaveOccurred())
+				Expect(config.AccessToken()).To(Equal("BEARER my_access_token"))
+				Expect(config.RefreshToken()).To(Equal("my_refresh_token"))
+			})
+		})
+
 		Describe("getting login info", func() {
 			var (
 				apiErr  error
@@ -212,6 +313,31 @@ var _ = Describe("AuthenticationRepository", func() {
 					Expect(apiErr.Error()).To(Equal("Authentication has expired.  Please log back in to re-authenticate.\n\nTIP: Use `cf login -a <endpoint> -u <user> -o <org> -s <space>` to log back in and re-authenticate."))
 				})
 			})
+			Context("when authenticated with client credentials", func() {
+				BeforeEach(func() {
+					config.SetUAAGrantType("client_credentials")
+					setupTestServer(testnet.TestRequest{
+						Method: "POST",
+						Path:   "/oauth/token",
+						Matcher: func(request *http.Request) {
+							Expect(request.ParseForm()).To(Succeed())
+							Expect(request.Form.Get("grant_type")).To(Equal("client_credentials"))
+							Expect(request.Form.Get("refresh_token")).To(BeEmpty())
+						},
+						Response: testnet.TestResponse{
+							Status: http.StatusOK,
+							Body:   `{"access_token": "my_access_token", "token_type": "BEARER"}`,
+						},
+					})
+				})
+
+				It("authenticates the client again", func() {
+					Expect(handler).To(HaveAllRequestsCalled())
+					Expect(apiErr).NotTo(HaveOccurred())
+					Expect(config.AccessToken()).To(Equal("BEARER my_access_token"))
+				})
+			})
+
 			Context("when there is a UAA error", func() {
 				BeforeEach(func() {
 					setupTestServer(errorLoginRequest)
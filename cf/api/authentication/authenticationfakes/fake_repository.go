@@ -4,6 +4,7 @@ package authenticationfakes
 import (
 	"net/http"
 	"sync"
+	"time"
 
 	"code.cloudfoundry.org/cli/cf/api/authentication"
 	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
@@ -35,6 +36,24 @@ type FakeRepository struct {
 	authenticateReturns struct {
 		result1 error
 	}
+	AuthenticateClientCredentialsStub        func(clientID string, clientSecret string) (apiErr error)
+	authenticateClientCredentialsMutex       sync.RWMutex
+	authenticateClientCredentialsArgsForCall []struct {
+		clientID     string
+		clientSecret string
+	}
+	authenticateClientCredentialsReturns struct {
+		result1 error
+	}
+	AuthenticateWithBrowserStub        func(timeout time.Duration, openURL func(authorizationURL string) error) (apiErr error)
+	authenticateWithBrowserMutex       sync.RWMutex
+	authenticateWithBrowserArgsForCall []struct {
+		timeout time.Duration
+		openURL func(authorizationURL string) error
+	}
+	authenticateWithBrowserReturns struct {
+		result1 error
+	}
 	AuthorizeStub        func(token string) (string, error)
 	authorizeMutex       sync.RWMutex
 	authorizeArgsForCall []struct {
@@ -103,7 +122,7 @@ func (fake *FakeRepository) DumpResponseArgsForCall(i int) *http.Response {
 	return fake.dumpResponseArgsForCall[i].arg1
 }
 
-func (fake *FakeRepository) RefreshAuthToken() (updatedToken string, apiErr error) {
+func (fake *FakeRepository) RefreshAuthToken() (string, error) {
 	fake.refreshAuthTokenMutex.Lock()
 	fake.refreshAuthTokenArgsForCall = append(fake.refreshAuthTokenArgsForCall, struct{}{})
 	fake.recordInvocation("RefreshAuthToken", []interface{}{})
@@ -129,7 +148,7 @@ func (fake *FakeRepository) RefreshAuthTokenReturns(result1 string, result2 erro
 	}{result1, result2}
 }
 
-func (fake *FakeRepository) Authenticate(credentials map[string]string) (apiErr error) {
+func (fake *FakeRepository) Authenticate(credentials map[string]string) error {
 	fake.authenticateMutex.Lock()
 	fake.authenticateArgsForCall = append(fake.authenticateArgsForCall, struct {
 		credentials map[string]string
@@ -162,6 +181,74 @@ func (fake *FakeRepository) AuthenticateReturns(result1 error) {
 	}{result1}
 }
 
+func (fake *FakeRepository) AuthenticateClientCredentials(clientID string, clientSecret string) error {
+	fake.authenticateClientCredentialsMutex.Lock()
+	fake.authenticateClientCredentialsArgsForCall = append(fake.authenticateClientCredentialsArgsForCall, struct {
+		clientID     string
+		clientSecret string
+	}{clientID, clientSecret})
+	fake.recordInvocation("AuthenticateClientCredentials", []interface{}{clientID, clientSecret})
+	fake.authenticateClientCredentialsMutex.Unlock()
+	if fake.AuthenticateClientCredentialsStub != nil {
+		return fake.AuthenticateClientCredentialsStub(clientID, clientSecret)
+	} else {
+		return fake.authenticateClientCredentialsReturns.result1
+	}
+}
+
+func (fake *FakeRepository) AuthenticateClientCredentialsCallCount() int {
+	fake.authenticateClientCredentialsMutex.RLock()
+	defer fake.authenticateClientCredentialsMutex.RUnlock()
+	return len(fake.authenticateClientCredentialsArgsForCall)
+}
+
+func (fake *FakeRepository) AuthenticateClientCredentialsArgsForCall(i int) (string, string) {
+	fake.authenticateClientCredentialsMutex.RLock()
+	defer fake.authenticateClientCredentialsMutex.RUnlock()
+	return fake.authenticateClientCredentialsArgsForCall[i].clientID, fake.authenticateClientCredentialsArgsForCall[i].clientSecret
+}
+
+func (fake *FakeRepository) AuthenticateClientCredentialsReturns(result1 error) {
+	fake.AuthenticateClientCredentialsStub = nil
+	fake.authenticateClientCredentialsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeRepository) AuthenticateWithBrowser(timeout time.Duration, openURL func(authorizationURL string) error) error {
+	fake.authenticateWithBrowserMutex.Lock()
+	fake.authenticateWithBrowserArgsForCall = append(fake.authenticateWithBrowserArgsForCall, struct {
+		timeout time.Duration
+		openURL func(authorizationURL string) error
+	}{timeout, openURL})
+	fake.recordInvocation("AuthenticateWithBrowser", []interface{}{timeout, openURL})
+	fake.authenticateWithBrowserMutex.Unlock()
+	if fake.AuthenticateWithBrowserStub != nil {
+		return fake.AuthenticateWithBrowserStub(timeout, openURL)
+	} else {
+		return fake.authenticateWithBrowserReturns.result1
+	}
+}
+
+func (fake *FakeRepository) AuthenticateWithBrowserCallCount() int {
+	fake.authenticateWithBrowserMutex.RLock()
+	defer fake.authenticateWithBrowserMutex.RUnlock()
+	return len(fake.authenticateWithBrowserArgsForCall)
+}
+
+func (fake *FakeRepository) AuthenticateWithBrowserArgsForCall(i int) (time.Duration, func(authorizationURL string) error) {
+	fake.authenticateWithBrowserMutex.RLock()
+	defer fake.authenticateWithBrowserMutex.RUnlock()
+	return fake.authenticateWithBrowserArgsForCall[i].timeout, fake.authenticateWithBrowserArgsForCall[i].openURL
+}
+
+func (fake *FakeRepository) AuthenticateWithBrowserReturns(result1 error) {
+	fake.AuthenticateWithBrowserStub = nil
+	fake.authenticateWithBrowserReturns = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeRepository) Authorize(token string) (string, error) {
 	fake.authorizeMutex.Lock()
 	fake.authorizeArgsForCall = append(fake.authorizeArgsForCall, struct {
@@ -233,6 +320,10 @@ func (fake *FakeRepository) Invocations() map[string][][]interface{} {
 	defer fake.refreshAuthTokenMutex.RUnlock()
 	fake.authenticateMutex.RLock()
 	defer fake.authenticateMutex.RUnlock()
+	fake.authenticateClientCredentialsMutex.RLock()
+	defer fake.authenticateClientCredentialsMutex.RUnlock()
+	fake.authenticateWithBrowserMutex.RLock()
+	defer fake.authenticateWithBrowserMutex.RUnlock()
 	fake.authorizeMutex.RLock()
 	defer fake.authorizeMutex.RUnlock()
 	fake.getLoginPromptsAndSaveUAAServerURLMutex.RLock()
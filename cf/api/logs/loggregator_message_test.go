@@ -43,4 +43,23 @@ var _ = Describe("loggregatorMessage", func() {
 			Expect(terminal.Decolorize(msg.ToLog(time.FixedZone("the-zone", 3*60*60)))).To(Equal("2014-04-04T14:39:20.00+0300 [DEA/4]      ERR Hello World!"))
 		})
 	})
+
+	Describe("GetSourceInstance", func() {
+		It("returns the instance index", func() {
+			msg := testlogs.NewLogMessage("Hello World!", "", "DEA", "4", logmessage.LogMessage_OUT, time.Now())
+			Expect(msg.GetSourceInstance()).To(Equal("4"))
+		})
+	})
+
+	Describe("GetMessageType", func() {
+		It("returns OUT for messages written to stdout", func() {
+			msg := testlogs.NewLogMessage("Hello World!", "", "DEA", "4", logmessage.LogMessage_OUT, time.Now())
+			Expect(msg.GetMessageType()).To(Equal("OUT"))
+		})
+
+		It("returns ERR for messages written to stderr", func() {
+			msg := testlogs.NewLogMessage("Hello World!", "", "DEA", "4", logmessage.LogMessage_ERR, time.Now())
+			Expect(msg.GetMessageType()).To(Equal("ERR"))
+		})
+	})
 })
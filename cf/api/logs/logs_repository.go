@@ -6,6 +6,9 @@ type Loggable interface {
 	ToLog(loc *time.Location) string
 	ToSimpleLog() string
 	GetSourceName() string
+	GetSourceInstance() string
+	GetMessageType() string
+	GetTimestamp() time.Time
 }
 
 //go:generate counterfeiter . Repository
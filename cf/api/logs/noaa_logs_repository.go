@@ -3,6 +3,7 @@ package logs
 import (
 	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	. "code.cloudfoundry.org/cli/cf/i18n"
@@ -19,20 +20,23 @@ type NoaaLogsRepository struct {
 	config         coreconfig.Reader
 	consumer       NoaaConsumer
 	tokenRefresher authentication.TokenRefresher
-	messageQueue   *NoaaMessageQueue
 	BufferTime     time.Duration
 	retryTimeout   time.Duration
+
+	onConnectLock      sync.Mutex
+	onConnectCallbacks map[int]func()
+	nextTailID         int
 }
 
 func NewNoaaLogsRepository(config coreconfig.Reader, consumer NoaaConsumer, tr authentication.TokenRefresher, retryTimeout time.Duration) *NoaaLogsRepository {
 	consumer.RefreshTokenFrom(tr)
 	return &NoaaLogsRepository{
-		config:         config,
-		consumer:       consumer,
-		tokenRefresher: tr,
-		messageQueue:   NewNoaaMessageQueue(),
-		BufferTime:     defaultBufferTime,
-		retryTimeout:   retryTimeout,
+		config:             config,
+		consumer:           consumer,
+		tokenRefresher:     tr,
+		BufferTime:         defaultBufferTime,
+		retryTimeout:       retryTimeout,
+		onConnectCallbacks: map[int]func(){},
 	}
 }
 
@@ -59,9 +63,14 @@ func (repo *NoaaLogsRepository) RecentLogsFor(appGUID string) ([]Loggable, error
 	return loggableMessagesFromNoaaMessages(noaa.SortRecent(logs)), err
 }
 
+// TailLogsFor streams the logs of an app to logChan until the stream ends or
+// fails. It can be called for several apps at once; as the consumer does not
+// say which stream connected, onConnect is called whenever any of them does,
+// and a stream that fails to connect again after that times out on its own.
 func (repo *NoaaLogsRepository) TailLogsFor(appGUID string, onConnect func(), logChan chan<- Loggable, errChan chan<- error) {
 	ticker := time.NewTicker(repo.BufferTime)
 	retryTimer := newUnstartedTimer()
+	messageQueue := NewNoaaMessageQueue()
 
 	endpoint := repo.config.DopplerEndpoint()
 	if endpoint == "" {
@@ -69,15 +78,21 @@ func (repo *NoaaLogsRepository) TailLogsFor(appGUID string, onConnect func(), lo
 		return
 	}
 
-	repo.consumer.SetOnConnectCallback(func() {
-		retryTimer.Stop()
+	connected := make(chan struct{}, 1)
+	removeOnConnect := repo.addOnConnectCallback(func() {
+		select {
+		case connected <- struct{}{}:
+		default:
+		}
 		onConnect()
 	})
+	repo.consumer.SetOnConnectCallback(repo.onConnect)
 	c, e := repo.consumer.TailingLogs(appGUID, repo.config.AccessToken())
 
 	go func() {
 		defer close(logChan)
 		defer close(errChan)
+		defer removeOnConnect()
 
 		timerRunning := false
 		for {
@@ -85,11 +100,17 @@ func (repo *NoaaLogsRepository) TailLogsFor(appGUID string, onConnect func(), lo
 			case msg, ok := <-c:
 				if !ok {
 					ticker.Stop()
-					repo.flushMessages(logChan)
+					repo.flushMessages(messageQueue, logChan)
 					return
 				}
+				stopTimer(retryTimer)
+				timerRunning = false
+				messageQueue.PushMessage(msg)
+			case <-connected:
+				// It may have been another stream that connected, in which
+				// case the next RetryError of this one starts the timer again.
+				stopTimer(retryTimer)
 				timerRunning = false
-				repo.messageQueue.PushMessage(msg)
 			case err := <-e:
 				if err != nil {
 					if _, ok := err.(noaaerrors.RetryError); ok {
@@ -115,17 +136,56 @@ func (repo *NoaaLogsRepository) TailLogsFor(appGUID string, onConnect func(), lo
 
 	go func() {
 		for range ticker.C {
-			repo.flushMessages(logChan)
+			repo.flushMessages(messageQueue, logChan)
 		}
 	}()
 }
 
-func (repo *NoaaLogsRepository) flushMessages(c chan<- Loggable) {
-	repo.messageQueue.EnumerateAndClear(func(m *events.LogMessage) {
+func (repo *NoaaLogsRepository) flushMessages(messageQueue *NoaaMessageQueue, c chan<- Loggable) {
+	messageQueue.EnumerateAndClear(func(m *events.LogMessage) {
 		c <- NewNoaaLogMessage(m)
 	})
 }
 
+func (repo *NoaaLogsRepository) addOnConnectCallback(callback func()) func() {
+	repo.onConnectLock.Lock()
+	defer repo.onConnectLock.Unlock()
+
+	id := repo.nextTailID
+	repo.nextTailID++
+	repo.onConnectCallbacks[id] = callback
+
+	return func() {
+		repo.onConnectLock.Lock()
+		defer repo.onConnectLock.Unlock()
+		delete(repo.onConnectCallbacks, id)
+	}
+}
+
+func (repo *NoaaLogsRepository) onConnect() {
+	repo.onConnectLock.Lock()
+	callbacks := make([]func(), 0, len(repo.onConnectCallbacks))
+	for _, callback := range repo.onConnectCallbacks {
+		callbacks = append(callbacks, callback)
+	}
+	repo.onConnectLock.Unlock()
+
+	for _, callback := range callbacks {
+		callback()
+	}
+}
+
+// stopTimer stops timer and drains its channel, so that it does not fire
+// after being reset.
+func stopTimer(timer *time.Timer) {
+	if !timer.Stop() {
+		select {
+		case <-timer.C:
+		default:
+		}
+	}
+}
+
 // newUnstartedTimer returns a *time.Timer that is in an unstarted
 // state.
 func newUnstartedTimer() *time.Timer {
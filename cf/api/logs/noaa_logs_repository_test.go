@@ -214,6 +214,65 @@ var _ = Describe("logs with noaa repository", func() {
 			})
 		})
 
+		Context("when tailing the logs of two apps and only one connects", func() {
+			var (
+				otherErrChan chan error
+				otherLogChan chan logs.Loggable
+				e            map[string]chan error
+				c            map[string]chan *events.LogMessage
+			)
+
+			BeforeEach(func() {
+				errChan = make(chan error)
+				logChan = make(chan logs.Loggable)
+				otherErrChan = make(chan error, 1)
+				otherLogChan = make(chan logs.Loggable)
+
+				e = map[string]chan error{
+					"connecting-app-guid": make(chan error, 1),
+					"failing-app-guid":    make(chan error, 1),
+				}
+				c = map[string]chan *events.LogMessage{
+					"connecting-app-guid": make(chan *events.LogMessage),
+					"failing-app-guid":    make(chan *events.LogMessage),
+				}
+
+				fakeNoaaConsumer.TailingLogsStub = func(appGuid string, authToken string) (<-chan *events.LogMessage, <-chan error) {
+					e[appGuid] <- noaaerrors.NewRetryError(errors.New("oops"))
+					return c[appGuid], e[appGuid]
+				}
+				fakeNoaaConsumer.CloseStub = func() error {
+					for appGUID := range e {
+						close(e[appGUID])
+						close(c[appGUID])
+					}
+					return nil
+				}
+			})
+
+			AfterEach(func() {
+				Eventually(otherLogChan).Should(BeClosed())
+			})
+
+			It("times out the stream that does not connect", func() {
+				defer repo.Close()
+
+				repo.TailLogsFor("connecting-app-guid", func() {}, logChan, errChan)
+				repo.TailLogsFor("failing-app-guid", func() {}, otherLogChan, otherErrChan)
+
+				Eventually(func() int { return len(e["connecting-app-guid"]) }).Should(BeZero())
+				fakeNoaaConsumer.SetOnConnectCallbackArgsForCall(0)()
+				c["connecting-app-guid"] <- makeNoaaLogMessage("foo", 100)
+				Eventually(logChan).Should(Receive())
+
+				e["failing-app-guid"] <- noaaerrors.NewRetryError(errors.New("oops"))
+
+				expectedErr := errors.New("Timed out waiting for connection to Loggregator (doppler.test.com).")
+				Eventually(otherErrChan, 2*retryTimeout).Should(Receive(Equal(expectedErr)))
+				Consistently(errChan).ShouldNot(Receive())
+			})
+		})
+
 		Context("when no error occurs", func() {
 			var e chan error
 			var c chan *events.LogMessage
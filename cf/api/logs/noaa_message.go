@@ -30,6 +30,23 @@ func (m *noaaLogMessage) GetSourceName() string {
 	return m.msg.GetSourceType()
 }
 
+func (m *noaaLogMessage) GetSourceInstance() string {
+	return m.msg.GetSourceInstance()
+}
+
+// GetMessageType returns "ERR" for messages written to stderr and "OUT" for
+// everything else.
+func (m *noaaLogMessage) GetMessageType() string {
+	if m.msg.GetMessageType() == events.LogMessage_ERR {
+		return "ERR"
+	}
+	return "OUT"
+}
+
+func (m *noaaLogMessage) GetTimestamp() time.Time {
+	return time.Unix(0, m.msg.GetTimestamp())
+}
+
 func (m *noaaLogMessage) ToLog(loc *time.Location) string {
 	logMsg := m.msg
 
@@ -0,0 +1,92 @@
+package logs
+
+import (
+	"errors"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RouterLogSourceName is the source name of the access log lines that the
+// gorouter writes for each request to an app.
+const RouterLogSourceName = "RTR"
+
+// RouterLog is a request to an app as described by a gorouter access log line.
+type RouterLog struct {
+	Host          string
+	Method        string
+	Path          string
+	StatusCode    int
+	BytesReceived int64
+	BytesSent     int64
+	Referer       string
+	UserAgent     string
+	RemoteAddr    string
+	ForwardedFor  string
+	ResponseTime  time.Duration
+
+	// HasResponseTime is false when the line did not give a response time,
+	// in which case ResponseTime is zero.
+	HasResponseTime bool
+}
+
+var (
+	routerLogRegexp = regexp.MustCompile(
+		`^(\S+) - \[[^\]]*\] "(\S+) (\S+)[^"]*" (\d{3}) (\d+) (\d+) "([^"]*)" "([^"]*)" "?([^"\s]*)"?`)
+	routerLogResponseTimeRegexp = regexp.MustCompile(`\bresponse_time:([0-9.]+)`)
+	routerLogForwardedForRegexp = regexp.MustCompile(`\bx_forwarded_for:"([^"]*)"`)
+)
+
+// ParseRouterLog parses the message of an RTR log line, such as
+//
+//	example.com - [2017-03-04T10:00:00.123+0000] "GET /path HTTP/1.1" 200 0 512 "-" "curl/7.43.0" "10.0.0.1:54321" "10.0.0.2:61000" x_forwarded_for:"203.0.113.1" response_time:0.012345 ...
+func ParseRouterLog(message string) (RouterLog, error) {
+	matches := routerLogRegexp.FindStringSubmatch(message)
+	if matches == nil {
+		return RouterLog{}, errors.New("not a router access log line")
+	}
+
+	statusCode, _ := strconv.Atoi(matches[4])
+	bytesReceived, _ := strconv.ParseInt(matches[5], 10, 64)
+	bytesSent, _ := strconv.ParseInt(matches[6], 10, 64)
+
+	routerLog := RouterLog{
+		Host:          matches[1],
+		Method:        matches[2],
+		Path:          matches[3],
+		StatusCode:    statusCode,
+		BytesReceived: bytesReceived,
+		BytesSent:     bytesSent,
+		Referer:       matches[7],
+		UserAgent:     matches[8],
+		RemoteAddr:    matches[9],
+	}
+
+	if responseTime := routerLogResponseTimeRegexp.FindStringSubmatch(message); responseTime != nil {
+		seconds, err := strconv.ParseFloat(responseTime[1], 64)
+		if err == nil {
+			routerLog.ResponseTime = time.Duration(seconds * float64(time.Second))
+			routerLog.HasResponseTime = true
+		}
+	}
+	if forwardedFor := routerLogForwardedForRegexp.FindStringSubmatch(message); forwardedFor != nil {
+		routerLog.ForwardedFor = forwardedFor[1]
+	}
+
+	return routerLog, nil
+}
+
+// Client returns the address of the client that made the request: the first
+// address in X-Forwarded-For, or else the address the request came from.
+func (l RouterLog) Client() string {
+	if l.ForwardedFor != "" && l.ForwardedFor != "-" {
+		return strings.TrimSpace(strings.Split(l.ForwardedFor, ",")[0])
+	}
+
+	if host, _, err := net.SplitHostPort(l.RemoteAddr); err == nil {
+		return host
+	}
+	return l.RemoteAddr
+}
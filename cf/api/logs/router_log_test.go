@@ -0,0 +1,69 @@
+package logs_test
+
+import (
+	"time"
+
+	. "code.cloudfoundry.org/cli/cf/api/logs"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RouterLog", func() {
+	Describe("ParseRouterLog", func() {
+		It("parses a router access log line", func() {
+			routerLog, err := ParseRouterLog(`example.com - [2017-03-04T10:00:00.123+0000] "GET /path?q=1 HTTP/1.1" 404 12 512 "http://referer.example.com" "curl/7.43.0" "10.0.0.1:54321" "10.0.0.2:61000" x_forwarded_for:"203.0.113.1, 10.0.0.1" x_forwarded_proto:"https" vcap_request_id:"some-id" response_time:0.012345 app_id:"some-app-guid" app_index:"0"`)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(routerLog).To(Equal(RouterLog{
+				Host:          "example.com",
+				Method:        "GET",
+				Path:          "/path?q=1",
+				StatusCode:    404,
+				BytesReceived: 12,
+				BytesSent:     512,
+				Referer:       "http://referer.example.com",
+				UserAgent:     "curl/7.43.0",
+				RemoteAddr:    "10.0.0.1:54321",
+				ForwardedFor:  "203.0.113.1, 10.0.0.1",
+				ResponseTime:  12345 * time.Microsecond,
+
+				HasResponseTime: true,
+			}))
+		})
+
+		It("parses the older format with an unquoted remote address", func() {
+			routerLog, err := ParseRouterLog(`example.com - [04/03/2017:10:00:00.123 +0000] "POST / HTTP/1.1" 201 3 4 "-" "Go-http-client/1.1" 10.0.0.1:54321 x_forwarded_for:"-" vcap_request_id:some-id response_time:1.5 app_id:some-app-guid`)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(routerLog.Method).To(Equal("POST"))
+			Expect(routerLog.StatusCode).To(Equal(201))
+			Expect(routerLog.RemoteAddr).To(Equal("10.0.0.1:54321"))
+			Expect(routerLog.ResponseTime).To(Equal(1500 * time.Millisecond))
+		})
+
+		It("does not report a response time for a line without one", func() {
+			routerLog, err := ParseRouterLog(`example.com - [2017-03-04T10:00:00.123+0000] "GET / HTTP/1.1" 200 0 512 "-" "curl/7.43.0" "10.0.0.1:54321" "10.0.0.2:61000" x_forwarded_for:"-"`)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(routerLog.ResponseTime).To(BeZero())
+			Expect(routerLog.HasResponseTime).To(BeFalse())
+		})
+
+		It("returns an error for a line that is not an access log line", func() {
+			_, err := ParseRouterLog("Updated app with guid some-app-guid")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("Client", func() {
+		It("returns the first address in X-Forwarded-For", func() {
+			routerLog := RouterLog{RemoteAddr: "10.0.0.1:54321", ForwardedFor: "203.0.113.1, 10.0.0.1"}
+			Expect(routerLog.Client()).To(Equal("203.0.113.1"))
+		})
+
+		It("returns the remote address when there is no X-Forwarded-For", func() {
+			routerLog := RouterLog{RemoteAddr: "10.0.0.1:54321", ForwardedFor: "-"}
+			Expect(routerLog.Client()).To(Equal("10.0.0.1"))
+		})
+	})
+})
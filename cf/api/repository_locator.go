@@ -82,6 +82,9 @@ type RepositoryLocator struct {
 	copyAppSourceRepo               copyapplicationsource.Repository
 
 	v3Repository repository.Repository
+
+	newAppBitsRepo func(ui terminal.UI) applicationbits.CloudControllerApplicationBitsRepository
+	newLogsRepo    func() logs.Repository
 }
 
 const noaaRetryDefaultTimeout = 5 * time.Second
@@ -99,6 +102,11 @@ func NewRepositoryLocator(config coreconfig.ReadWriter, gatewaysByName map[strin
 	uaaGateway.SetTokenRefresher(loc.authRepo)
 
 	loc.appBitsRepo = applicationbits.NewCloudControllerApplicationBitsRepository(config, cloudControllerGateway)
+	loc.newAppBitsRepo = func(ui terminal.UI) applicationbits.CloudControllerApplicationBitsRepository {
+		gateway := cloudControllerGateway
+		gateway.SetUI(ui)
+		return applicationbits.NewCloudControllerApplicationBitsRepository(config, gateway)
+	}
 	loc.appEventsRepo = appevents.NewCloudControllerAppEventsRepository(config, cloudControllerGateway, strategy)
 	loc.appFilesRepo = api_appfiles.NewCloudControllerAppFilesRepository(config, cloudControllerGateway)
 	loc.appRepo = applications.NewCloudControllerRepository(config, cloudControllerGateway)
@@ -121,15 +129,19 @@ func NewRepositoryLocator(config coreconfig.ReadWriter, gatewaysByName map[strin
 		noaaRetryTimeout = time.Duration(convertedTime) * 3 * time.Second
 	}
 
-	if apiVersion.GTE(cf.NoaaMinimumAPIVersion) {
-		consumer := consumer.New(config.DopplerEndpoint(), tlsConfig, http.ProxyFromEnvironment)
-		consumer.SetDebugPrinter(terminal.DebugPrinter{Logger: logger})
-		loc.logsRepo = logs.NewNoaaLogsRepository(config, consumer, loc.authRepo, noaaRetryTimeout)
-	} else {
+	authRepo := loc.authRepo
+	loc.newLogsRepo = func() logs.Repository {
+		if apiVersion.GTE(cf.NoaaMinimumAPIVersion) {
+			consumer := consumer.New(config.DopplerEndpoint(), tlsConfig, http.ProxyFromEnvironment)
+			consumer.SetDebugPrinter(terminal.DebugPrinter{Logger: logger})
+			return logs.NewNoaaLogsRepository(config, consumer, authRepo, noaaRetryTimeout)
+		}
+
 		consumer := loggregator_consumer.New(config.LoggregatorEndpoint(), tlsConfig, http.ProxyFromEnvironment)
 		consumer.SetDebugPrinter(terminal.DebugPrinter{Logger: logger})
-		loc.logsRepo = logs.NewLoggregatorLogsRepository(config, consumer, loc.authRepo)
+		return logs.NewLoggregatorLogsRepository(config, consumer, authRepo)
 	}
+	loc.logsRepo = loc.newLogsRepo()
 
 	loc.organizationRepo = organizations.NewCloudControllerOrganizationRepository(config, cloudControllerGateway)
 	loc.passwordRepo = password.NewCloudControllerRepository(config, uaaGateway)
@@ -165,6 +177,19 @@ func NewRepositoryLocator(config coreconfig.ReadWriter, gatewaysByName map[strin
 	return
 }
 
+// ForUI returns a copy of the locator for a command that runs alongside
+// others: its application bits repository reports upload progress to ui, and
+// its logs repository streams logs over a connection of its own.
+func (locator RepositoryLocator) ForUI(ui terminal.UI) RepositoryLocator {
+	if locator.newAppBitsRepo != nil {
+		locator.appBitsRepo = locator.newAppBitsRepo(ui)
+	}
+	if locator.newLogsRepo != nil {
+		locator.logsRepo = locator.newLogsRepo()
+	}
+	return locator
+}
+
 func (locator RepositoryLocator) SetAuthenticationRepository(repo authentication.Repository) RepositoryLocator {
 	locator.authRepo = repo
 	return locator
@@ -8,6 +8,8 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"strings"
+	"sync"
 
 	"code.cloudfoundry.org/cli/cf/models"
 	"code.cloudfoundry.org/gofileutils/fileutils"
@@ -22,18 +24,42 @@ type AppFiles interface {
 	CopyFiles(appFiles []models.AppFileFields, fromDir, toDir string) (err error)
 	CountFiles(directory string) int64
 	WalkAppFiles(dir string, onEachFile func(string, string) error) (err error)
+	IgnoreDecisions(dir string) ([]IgnoreDecision, error)
 }
 
-type ApplicationFiles struct{}
+// ExtractedAppDirPrefix is the prefix of the temporary directories that
+// archives are extracted to for a push.
+const ExtractedAppDirPrefix = "unzipped-app"
+
+type ApplicationFiles struct {
+	// FingerprintCache, when set, is used to avoid hashing files that have not
+	// changed since they were last hashed. It is not used for extracted
+	// archives, whose files are new on every push.
+	FingerprintCache *FingerprintCache
+}
+
+// hashWorkers is the number of files that AppFilesInDir hashes at once.
+var hashWorkers = runtime.NumCPU()
+
+type fileToHash struct {
+	index    int
+	fullPath string
+	fileInfo os.FileInfo
+}
 
 func (appfiles ApplicationFiles) AppFilesInDir(dir string) ([]models.AppFileFields, error) {
 	appFiles := []models.AppFileFields{}
+	filesToHash := []fileToHash{}
 
 	fullDirPath, toplevelErr := filepath.Abs(dir)
 	if toplevelErr != nil {
 		return appFiles, toplevelErr
 	}
 
+	if isExtractedAppDir(fullDirPath) {
+		appfiles.FingerprintCache = nil
+	}
+
 	toplevelErr = appfiles.WalkAppFiles(fullDirPath, func(fileName string, fullPath string) error {
 		fileInfo, err := os.Lstat(fullPath)
 		if err != nil {
@@ -48,20 +74,82 @@ func (appfiles ApplicationFiles) AppFilesInDir(dir string) ([]models.AppFileFiel
 		if fileInfo.IsDir() {
 			appFile.Sha1 = "0"
 			appFile.Size = 0
-		} else {
-			sha, err := appfiles.shaFile(fullPath)
-			if err != nil {
-				return err
-			}
+		} else if sha, found := appfiles.FingerprintCache.Lookup(fullPath, fileInfo); found {
 			appFile.Sha1 = sha
+		} else {
+			filesToHash = append(filesToHash, fileToHash{
+				index:    len(appFiles),
+				fullPath: fullPath,
+				fileInfo: fileInfo,
+			})
 		}
 
 		appFiles = append(appFiles, appFile)
 
 		return nil
 	})
+	if toplevelErr != nil {
+		return appFiles, toplevelErr
+	}
 
-	return appFiles, toplevelErr
+	toplevelErr = appfiles.hashFiles(appFiles, filesToHash)
+	if toplevelErr != nil {
+		return appFiles, toplevelErr
+	}
+
+	appfiles.FingerprintCache.Prune(fullDirPath)
+	// The cache only saves time on the next push, so failing to write it is
+	// not a reason to fail this one.
+	_ = appfiles.FingerprintCache.Save()
+
+	return appFiles, nil
+}
+
+// isExtractedAppDir returns whether dir is a temporary directory that an
+// archive was extracted to.
+func isExtractedAppDir(dir string) bool {
+	return filepath.Dir(dir) == filepath.Clean(os.TempDir()) &&
+		strings.HasPrefix(filepath.Base(dir), ExtractedAppDirPrefix)
+}
+
+// hashFiles sets the SHA1 of each of filesToHash in appFiles, hashing up to
+// hashWorkers files at a time.
+func (appfiles ApplicationFiles) hashFiles(appFiles []models.AppFileFields, filesToHash []fileToHash) error {
+	var (
+		wg       sync.WaitGroup
+		errMutex sync.Mutex
+		firstErr error
+	)
+
+	files := make(chan fileToHash)
+	for i := 0; i < hashWorkers && i < len(filesToHash); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for file := range files {
+				sha, err := appfiles.shaFile(file.fullPath)
+				if err != nil {
+					errMutex.Lock()
+					if firstErr == nil {
+						firstErr = err
+					}
+					errMutex.Unlock()
+					continue
+				}
+
+				appFiles[file.index].Sha1 = sha
+				appfiles.FingerprintCache.Store(file.fullPath, file.fileInfo, sha)
+			}
+		}()
+	}
+
+	for _, file := range filesToHash {
+		files <- file
+	}
+	close(files)
+	wg.Wait()
+
+	return firstErr
 }
 
 func (appfiles ApplicationFiles) shaFile(fullPath string) (string, error) {
@@ -163,6 +251,44 @@ func (appfiles ApplicationFiles) CountFiles(directory string) int64 {
 }
 
 func (appfiles ApplicationFiles) WalkAppFiles(dir string, onEachFile func(string, string) error) error {
+	return walkAppFiles(dir, func(fileName string, fullPath string, _ os.FileInfo, ignored bool, _ *IgnoreRule) error {
+		if ignored {
+			return nil
+		}
+		return onEachFile(fileName, fullPath)
+	})
+}
+
+// IgnoreDecision says whether a file in an app directory is pushed, and which
+// rule decided it. Rule is nil when no rule matched the file.
+type IgnoreDecision struct {
+	Path    string
+	IsDir   bool
+	Ignored bool
+	Rule    *IgnoreRule
+}
+
+// IgnoreDecisions returns a decision for each file in dir that a push would
+// look at. An ignored directory is listed, but not the files in it.
+func (appfiles ApplicationFiles) IgnoreDecisions(dir string) ([]IgnoreDecision, error) {
+	decisions := []IgnoreDecision{}
+	err := walkAppFiles(dir, func(fileName string, _ string, fileInfo os.FileInfo, ignored bool, rule *IgnoreRule) error {
+		decisions = append(decisions, IgnoreDecision{
+			Path:    filepath.ToSlash(fileName),
+			IsDir:   fileInfo != nil && fileInfo.IsDir(),
+			Ignored: ignored,
+			Rule:    rule,
+		})
+		return nil
+	})
+	return decisions, err
+}
+
+// walkAppFiles calls onEachFile for each file and directory in dir, whether
+// or not it is ignored. The directories that are ignored are not walked, and
+// the .cfignore file of each directory that is walked applies to the files in
+// it.
+func walkAppFiles(dir string, onEachFile func(fileName string, fullPath string, fileInfo os.FileInfo, ignored bool, rule *IgnoreRule) error) error {
 	cfIgnore := loadIgnoreFile(dir)
 	walkFunc := func(fullPath string, f os.FileInfo, err error) error {
 		fileRelativePath, _ := filepath.Rel(dir, fullPath)
@@ -180,8 +306,16 @@ func (appfiles ApplicationFiles) WalkAppFiles(dir string, onEachFile func(string
 			return nil
 		}
 
-		if cfIgnore.FileShouldBeIgnored(fileRelativeUnixPath) {
-			if err == nil && f.IsDir() {
+		// The directories that fullPath is in have already been walked, so
+		// only the rules that match it directly need to be checked.
+		isDir := err == nil && f.IsDir()
+		ignored, rule := cfIgnore.matchPath(fileRelativeUnixPath, isDir)
+		if ignored {
+			callbackErr := onEachFile(fileRelativePath, fullPath, f, true, rule)
+			if callbackErr != nil {
+				return callbackErr
+			}
+			if isDir {
 				return filepath.SkipDir
 			}
 			return nil
@@ -195,17 +329,37 @@ func (appfiles ApplicationFiles) WalkAppFiles(dir string, onEachFile func(string
 			return nil
 		}
 
-		return onEachFile(fileRelativePath, fullPath)
+		err = onEachFile(fileRelativePath, fullPath, f, false, rule)
+		if err != nil {
+			return err
+		}
+
+		if isDir {
+			cfIgnore.loadNestedIgnoreFile(fullPath, fileRelativeUnixPath)
+		}
+
+		return nil
 	}
 
 	return filepath.Walk(dir, walkFunc)
 }
 
-func loadIgnoreFile(dir string) CfIgnore {
+func loadIgnoreFile(dir string) *cfIgnore {
 	fileContents, err := ioutil.ReadFile(filepath.Join(dir, ".cfignore"))
 	if err != nil {
-		return NewCfIgnore("")
+		return NewCfIgnore("").(*cfIgnore)
+	}
+
+	return NewCfIgnore(string(fileContents)).(*cfIgnore)
+}
+
+// loadNestedIgnoreFile adds the rules of the .cfignore file in the directory
+// at fullPath, if it has one.
+func (ignore *cfIgnore) loadNestedIgnoreFile(fullPath string, dir string) {
+	fileContents, err := ioutil.ReadFile(filepath.Join(fullPath, ".cfignore"))
+	if err != nil {
+		return
 	}
 
-	return NewCfIgnore(string(fileContents))
+	ignore.addRules(dir, dir+"/.cfignore", string(fileContents))
 }
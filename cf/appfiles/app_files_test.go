@@ -1,11 +1,14 @@
 package appfiles_test
 
 import (
+	"crypto/sha1"
+	"fmt"
 	"io/ioutil"
 	"os"
 	"path/filepath"
 	"runtime"
 	"strings"
+	"time"
 
 	"code.cloudfoundry.org/cli/cf/appfiles"
 	"github.com/nu7hatch/gouuid"
@@ -69,16 +72,72 @@ var _ = Describe("AppFiles", func() {
 			})
 
 			It("excludes ignored files", func() {
+				// dir1/child-dir/file3.txt is not included again, as the
+				// directory it is in is excluded.
 				Expect(paths).To(Equal([]string{
 					"dir1",
-					"dir1/child-dir",
-					"dir1/child-dir/file3.txt",
 					"dir1/file1.txt",
 					"dir2",
+				}))
+			})
+		})
+
+		Context("when a directory has its own .cfignore", func() {
+			var tmpDir string
+
+			BeforeEach(func() {
+				var err error
+				tmpDir, err = ioutil.TempDir("", "nested-cfignore")
+				Expect(err).NotTo(HaveOccurred())
 
-					// TODO: this should be excluded.
-					// .cfignore doesn't handle ** patterns right now
-					"dir2/child-dir2",
+				Expect(os.MkdirAll(filepath.Join(tmpDir, "sub", "deep"), 0700)).To(Succeed())
+				for _, file := range []string{"top.log", "app.rb", "sub/keep.log", "sub/deep/file.txt", "other.log"} {
+					Expect(ioutil.WriteFile(filepath.Join(tmpDir, file), []byte(file), 0600)).To(Succeed())
+				}
+				Expect(ioutil.WriteFile(filepath.Join(tmpDir, ".cfignore"), []byte("*.log\n"), 0600)).To(Succeed())
+				Expect(ioutil.WriteFile(filepath.Join(tmpDir, "sub", ".cfignore"), []byte("!keep.log\ndeep/\n"), 0600)).To(Succeed())
+			})
+
+			AfterEach(func() {
+				os.RemoveAll(tmpDir)
+			})
+
+			It("applies its rules to the files in that directory, after the ones above it", func() {
+				files, err := appFiles.AppFilesInDir(tmpDir)
+				Expect(err).NotTo(HaveOccurred())
+
+				paths := []string{}
+				for _, file := range files {
+					paths = append(paths, file.Path)
+				}
+				Expect(paths).To(Equal([]string{
+					"app.rb",
+					"sub",
+					"sub/keep.log",
+				}))
+			})
+
+			It("explains which rule decided each file", func() {
+				decisions, err := appFiles.IgnoreDecisions(tmpDir)
+				Expect(err).NotTo(HaveOccurred())
+
+				explained := []string{}
+				for _, decision := range decisions {
+					rule := ""
+					if decision.Rule != nil {
+						rule = decision.Rule.String()
+					}
+					explained = append(explained, fmt.Sprintf("%s %t %s", decision.Path, decision.Ignored, rule))
+				}
+				Expect(explained).To(Equal([]string{
+					".cfignore true (default):.cfignore",
+					"app.rb false ",
+					"other.log true .cfignore:1:*.log",
+					"sub false ",
+					"sub/.cfignore true (default):.cfignore",
+					"sub/deep true sub/.cfignore:2:deep/",
+					"sub/keep.log false sub/.cfignore:1:!keep.log",
+					"top.log true .cfignore:1:*.log",
 				}))
 			})
 		})
@@ -104,6 +163,105 @@ var _ = Describe("AppFiles", func() {
 				Expect(sizes).To(Equal([]int64{0}))
 			})
 		})
+
+		Context("when a fingerprint cache is given", func() {
+			var (
+				tempDir   string
+				appDir    string
+				cachePath string
+				appPath   string
+			)
+
+			BeforeEach(func() {
+				var err error
+				tempDir, err = ioutil.TempDir("", "app-files-fingerprints")
+				Expect(err).NotTo(HaveOccurred())
+
+				appDir = filepath.Join(tempDir, "app")
+				Expect(os.Mkdir(appDir, 0700)).To(Succeed())
+				for i := 0; i < 20; i++ {
+					path := filepath.Join(appDir, fmt.Sprintf("file-%d.txt", i))
+					Expect(ioutil.WriteFile(path, []byte(fmt.Sprintf("contents %d", i)), 0600)).To(Succeed())
+					Expect(os.Chtimes(path, time.Now(), time.Now().Add(-time.Hour))).To(Succeed())
+				}
+				appPath = filepath.Join(appDir, "file-0.txt")
+
+				cachePath = filepath.Join(tempDir, "fingerprints.json")
+				appFiles = appfiles.ApplicationFiles{FingerprintCache: appfiles.NewFingerprintCache(cachePath)}
+			})
+
+			AfterEach(func() {
+				os.RemoveAll(tempDir)
+			})
+
+			shaOf := func(files []models.AppFileFields, path string) string {
+				for _, file := range files {
+					if file.Path == path {
+						return file.Sha1
+					}
+				}
+				return ""
+			}
+
+			It("hashes every file and saves the fingerprints", func() {
+				files, err := appFiles.AppFilesInDir(appDir)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(files).To(HaveLen(20))
+				for i := 0; i < 20; i++ {
+					expectedSha := fmt.Sprintf("%x", sha1.Sum([]byte(fmt.Sprintf("contents %d", i))))
+					Expect(shaOf(files, fmt.Sprintf("file-%d.txt", i))).To(Equal(expectedSha))
+				}
+
+				Expect(cachePath).To(BeARegularFile())
+			})
+
+			It("uses the saved fingerprint of a file that has not changed", func() {
+				_, err := appFiles.AppFilesInDir(appDir)
+				Expect(err).NotTo(HaveOccurred())
+
+				// Replace the file's contents without changing its size or
+				// modification time, which only the cache would not notice.
+				info, err := os.Stat(appPath)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(ioutil.WriteFile(appPath, []byte("contents X"), 0600)).To(Succeed())
+				Expect(os.Chtimes(appPath, time.Now(), info.ModTime())).To(Succeed())
+
+				cachedFiles, err := appfiles.ApplicationFiles{FingerprintCache: appfiles.NewFingerprintCache(cachePath)}.AppFilesInDir(appDir)
+				Expect(err).NotTo(HaveOccurred())
+				uncachedFiles, err := appfiles.ApplicationFiles{}.AppFilesInDir(appDir)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(shaOf(cachedFiles, "file-0.txt")).NotTo(Equal(shaOf(uncachedFiles, "file-0.txt")))
+				Expect(shaOf(cachedFiles, "file-1.txt")).To(Equal(shaOf(uncachedFiles, "file-1.txt")))
+			})
+
+			It("hashes a file again when it has been modified", func() {
+				_, err := appFiles.AppFilesInDir(appDir)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(ioutil.WriteFile(appPath, []byte("new contents"), 0600)).To(Succeed())
+
+				cachedFiles, err := appfiles.ApplicationFiles{FingerprintCache: appfiles.NewFingerprintCache(cachePath)}.AppFilesInDir(appDir)
+				Expect(err).NotTo(HaveOccurred())
+				uncachedFiles, err := appfiles.ApplicationFiles{}.AppFilesInDir(appDir)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(cachedFiles).To(Equal(uncachedFiles))
+			})
+
+			It("does not cache the files of an extracted archive", func() {
+				extractedDir, err := ioutil.TempDir("", appfiles.ExtractedAppDirPrefix)
+				Expect(err).NotTo(HaveOccurred())
+				defer os.RemoveAll(extractedDir)
+				path := filepath.Join(extractedDir, "file.txt")
+				Expect(ioutil.WriteFile(path, []byte("contents"), 0600)).To(Succeed())
+				Expect(os.Chtimes(path, time.Now(), time.Now().Add(-time.Hour))).To(Succeed())
+
+				_, err = appFiles.AppFilesInDir(extractedDir)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cachePath).NotTo(BeAnExistingFile())
+			})
+		})
 	})
 
 	Describe("CopyFiles", func() {
@@ -45,11 +45,20 @@ type FakeAppFiles struct {
 	walkAppFilesReturns struct {
 		result1 error
 	}
+	IgnoreDecisionsStub        func(dir string) ([]appfiles.IgnoreDecision, error)
+	ignoreDecisionsMutex       sync.RWMutex
+	ignoreDecisionsArgsForCall []struct {
+		dir string
+	}
+	ignoreDecisionsReturns struct {
+		result1 []appfiles.IgnoreDecision
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeAppFiles) AppFilesInDir(dir string) (appFiles []models.AppFileFields, err error) {
+func (fake *FakeAppFiles) AppFilesInDir(dir string) ([]models.AppFileFields, error) {
 	fake.appFilesInDirMutex.Lock()
 	fake.appFilesInDirArgsForCall = append(fake.appFilesInDirArgsForCall, struct {
 		dir string
@@ -83,7 +92,7 @@ func (fake *FakeAppFiles) AppFilesInDirReturns(result1 []models.AppFileFields, r
 	}{result1, result2}
 }
 
-func (fake *FakeAppFiles) CopyFiles(appFiles []models.AppFileFields, fromDir string, toDir string) (err error) {
+func (fake *FakeAppFiles) CopyFiles(appFiles []models.AppFileFields, fromDir string, toDir string) error {
 	var appFilesCopy []models.AppFileFields
 	if appFiles != nil {
 		appFilesCopy = make([]models.AppFileFields, len(appFiles))
@@ -156,7 +165,7 @@ func (fake *FakeAppFiles) CountFilesReturns(result1 int64) {
 	}{result1}
 }
 
-func (fake *FakeAppFiles) WalkAppFiles(dir string, onEachFile func(string, string) error) (err error) {
+func (fake *FakeAppFiles) WalkAppFiles(dir string, onEachFile func(string, string) error) error {
 	fake.walkAppFilesMutex.Lock()
 	fake.walkAppFilesArgsForCall = append(fake.walkAppFilesArgsForCall, struct {
 		dir        string
@@ -190,6 +199,40 @@ func (fake *FakeAppFiles) WalkAppFilesReturns(result1 error) {
 	}{result1}
 }
 
+func (fake *FakeAppFiles) IgnoreDecisions(dir string) ([]appfiles.IgnoreDecision, error) {
+	fake.ignoreDecisionsMutex.Lock()
+	fake.ignoreDecisionsArgsForCall = append(fake.ignoreDecisionsArgsForCall, struct {
+		dir string
+	}{dir})
+	fake.recordInvocation("IgnoreDecisions", []interface{}{dir})
+	fake.ignoreDecisionsMutex.Unlock()
+	if fake.IgnoreDecisionsStub != nil {
+		return fake.IgnoreDecisionsStub(dir)
+	} else {
+		return fake.ignoreDecisionsReturns.result1, fake.ignoreDecisionsReturns.result2
+	}
+}
+
+func (fake *FakeAppFiles) IgnoreDecisionsCallCount() int {
+	fake.ignoreDecisionsMutex.RLock()
+	defer fake.ignoreDecisionsMutex.RUnlock()
+	return len(fake.ignoreDecisionsArgsForCall)
+}
+
+func (fake *FakeAppFiles) IgnoreDecisionsArgsForCall(i int) string {
+	fake.ignoreDecisionsMutex.RLock()
+	defer fake.ignoreDecisionsMutex.RUnlock()
+	return fake.ignoreDecisionsArgsForCall[i].dir
+}
+
+func (fake *FakeAppFiles) IgnoreDecisionsReturns(result1 []appfiles.IgnoreDecision, result2 error) {
+	fake.IgnoreDecisionsStub = nil
+	fake.ignoreDecisionsReturns = struct {
+		result1 []appfiles.IgnoreDecision
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeAppFiles) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -201,6 +244,8 @@ func (fake *FakeAppFiles) Invocations() map[string][][]interface{} {
 	defer fake.countFilesMutex.RUnlock()
 	fake.walkAppFilesMutex.RLock()
 	defer fake.walkAppFilesMutex.RUnlock()
+	fake.ignoreDecisionsMutex.RLock()
+	defer fake.ignoreDecisionsMutex.RUnlock()
 	return fake.invocations
 }
 
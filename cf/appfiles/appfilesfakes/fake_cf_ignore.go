@@ -16,6 +16,16 @@ type FakeCfIgnore struct {
 	fileShouldBeIgnoredReturns struct {
 		result1 bool
 	}
+	MatchStub        func(path string, isDir bool) (ignored bool, rule *appfiles.IgnoreRule)
+	matchMutex       sync.RWMutex
+	matchArgsForCall []struct {
+		path  string
+		isDir bool
+	}
+	matchReturns struct {
+		result1 bool
+		result2 *appfiles.IgnoreRule
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -53,11 +63,48 @@ func (fake *FakeCfIgnore) FileShouldBeIgnoredReturns(result1 bool) {
 	}{result1}
 }
 
+func (fake *FakeCfIgnore) Match(path string, isDir bool) (bool, *appfiles.IgnoreRule) {
+	fake.matchMutex.Lock()
+	fake.matchArgsForCall = append(fake.matchArgsForCall, struct {
+		path  string
+		isDir bool
+	}{path, isDir})
+	fake.recordInvocation("Match", []interface{}{path, isDir})
+	fake.matchMutex.Unlock()
+	if fake.MatchStub != nil {
+		return fake.MatchStub(path, isDir)
+	} else {
+		return fake.matchReturns.result1, fake.matchReturns.result2
+	}
+}
+
+func (fake *FakeCfIgnore) MatchCallCount() int {
+	fake.matchMutex.RLock()
+	defer fake.matchMutex.RUnlock()
+	return len(fake.matchArgsForCall)
+}
+
+func (fake *FakeCfIgnore) MatchArgsForCall(i int) (string, bool) {
+	fake.matchMutex.RLock()
+	defer fake.matchMutex.RUnlock()
+	return fake.matchArgsForCall[i].path, fake.matchArgsForCall[i].isDir
+}
+
+func (fake *FakeCfIgnore) MatchReturns(result1 bool, result2 *appfiles.IgnoreRule) {
+	fake.MatchStub = nil
+	fake.matchReturns = struct {
+		result1 bool
+		result2 *appfiles.IgnoreRule
+	}{result1, result2}
+}
+
 func (fake *FakeCfIgnore) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
 	fake.fileShouldBeIgnoredMutex.RLock()
 	defer fake.fileShouldBeIgnoredMutex.RUnlock()
+	fake.matchMutex.RLock()
+	defer fake.matchMutex.RUnlock()
 	return fake.invocations
 }
 
@@ -2,10 +2,12 @@
 package appfilesfakes
 
 import (
+	"io"
 	"os"
 	"sync"
 
 	"code.cloudfoundry.org/cli/cf/appfiles"
+	"code.cloudfoundry.org/cli/cf/models"
 )
 
 type FakeZipper struct {
@@ -18,6 +20,25 @@ type FakeZipper struct {
 	zipReturns struct {
 		result1 error
 	}
+	ZipToStub        func(dirOrZipFilePath string, writer io.Writer) (err error)
+	zipToMutex       sync.RWMutex
+	zipToArgsForCall []struct {
+		dirOrZipFilePath string
+		writer           io.Writer
+	}
+	zipToReturns struct {
+		result1 error
+	}
+	ZipFilesToStub        func(dir string, files []models.AppFileFields, writer io.Writer) (err error)
+	zipFilesToMutex       sync.RWMutex
+	zipFilesToArgsForCall []struct {
+		dir    string
+		files  []models.AppFileFields
+		writer io.Writer
+	}
+	zipFilesToReturns struct {
+		result1 error
+	}
 	IsZipFileStub        func(path string) bool
 	isZipFileMutex       sync.RWMutex
 	isZipFileArgsForCall []struct {
@@ -35,6 +56,23 @@ type FakeZipper struct {
 	unzipReturns struct {
 		result1 error
 	}
+	IsTarFileStub        func(path string) bool
+	isTarFileMutex       sync.RWMutex
+	isTarFileArgsForCall []struct {
+		path string
+	}
+	isTarFileReturns struct {
+		result1 bool
+	}
+	UntarStub        func(path string, destDir string) (err error)
+	untarMutex       sync.RWMutex
+	untarArgsForCall []struct {
+		path    string
+		destDir string
+	}
+	untarReturns struct {
+		result1 error
+	}
 	GetZipSizeStub        func(zipFile *os.File) (int64, error)
 	getZipSizeMutex       sync.RWMutex
 	getZipSizeArgsForCall []struct {
@@ -48,7 +86,7 @@ type FakeZipper struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeZipper) Zip(dirToZip string, targetFile *os.File) (err error) {
+func (fake *FakeZipper) Zip(dirToZip string, targetFile *os.File) error {
 	fake.zipMutex.Lock()
 	fake.zipArgsForCall = append(fake.zipArgsForCall, struct {
 		dirToZip   string
@@ -82,6 +120,80 @@ func (fake *FakeZipper) ZipReturns(result1 error) {
 	}{result1}
 }
 
+func (fake *FakeZipper) ZipTo(dirOrZipFilePath string, writer io.Writer) error {
+	fake.zipToMutex.Lock()
+	fake.zipToArgsForCall = append(fake.zipToArgsForCall, struct {
+		dirOrZipFilePath string
+		writer           io.Writer
+	}{dirOrZipFilePath, writer})
+	fake.recordInvocation("ZipTo", []interface{}{dirOrZipFilePath, writer})
+	fake.zipToMutex.Unlock()
+	if fake.ZipToStub != nil {
+		return fake.ZipToStub(dirOrZipFilePath, writer)
+	} else {
+		return fake.zipToReturns.result1
+	}
+}
+
+func (fake *FakeZipper) ZipToCallCount() int {
+	fake.zipToMutex.RLock()
+	defer fake.zipToMutex.RUnlock()
+	return len(fake.zipToArgsForCall)
+}
+
+func (fake *FakeZipper) ZipToArgsForCall(i int) (string, io.Writer) {
+	fake.zipToMutex.RLock()
+	defer fake.zipToMutex.RUnlock()
+	return fake.zipToArgsForCall[i].dirOrZipFilePath, fake.zipToArgsForCall[i].writer
+}
+
+func (fake *FakeZipper) ZipToReturns(result1 error) {
+	fake.ZipToStub = nil
+	fake.zipToReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeZipper) ZipFilesTo(dir string, files []models.AppFileFields, writer io.Writer) error {
+	var filesCopy []models.AppFileFields
+	if files != nil {
+		filesCopy = make([]models.AppFileFields, len(files))
+		copy(filesCopy, files)
+	}
+	fake.zipFilesToMutex.Lock()
+	fake.zipFilesToArgsForCall = append(fake.zipFilesToArgsForCall, struct {
+		dir    string
+		files  []models.AppFileFields
+		writer io.Writer
+	}{dir, filesCopy, writer})
+	fake.recordInvocation("ZipFilesTo", []interface{}{dir, filesCopy, writer})
+	fake.zipFilesToMutex.Unlock()
+	if fake.ZipFilesToStub != nil {
+		return fake.ZipFilesToStub(dir, files, writer)
+	} else {
+		return fake.zipFilesToReturns.result1
+	}
+}
+
+func (fake *FakeZipper) ZipFilesToCallCount() int {
+	fake.zipFilesToMutex.RLock()
+	defer fake.zipFilesToMutex.RUnlock()
+	return len(fake.zipFilesToArgsForCall)
+}
+
+func (fake *FakeZipper) ZipFilesToArgsForCall(i int) (string, []models.AppFileFields, io.Writer) {
+	fake.zipFilesToMutex.RLock()
+	defer fake.zipFilesToMutex.RUnlock()
+	return fake.zipFilesToArgsForCall[i].dir, fake.zipFilesToArgsForCall[i].files, fake.zipFilesToArgsForCall[i].writer
+}
+
+func (fake *FakeZipper) ZipFilesToReturns(result1 error) {
+	fake.ZipFilesToStub = nil
+	fake.zipFilesToReturns = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeZipper) IsZipFile(path string) bool {
 	fake.isZipFileMutex.Lock()
 	fake.isZipFileArgsForCall = append(fake.isZipFileArgsForCall, struct {
@@ -115,7 +227,7 @@ func (fake *FakeZipper) IsZipFileReturns(result1 bool) {
 	}{result1}
 }
 
-func (fake *FakeZipper) Unzip(appDir string, destDir string) (err error) {
+func (fake *FakeZipper) Unzip(appDir string, destDir string) error {
 	fake.unzipMutex.Lock()
 	fake.unzipArgsForCall = append(fake.unzipArgsForCall, struct {
 		appDir  string
@@ -149,6 +261,73 @@ func (fake *FakeZipper) UnzipReturns(result1 error) {
 	}{result1}
 }
 
+func (fake *FakeZipper) IsTarFile(path string) bool {
+	fake.isTarFileMutex.Lock()
+	fake.isTarFileArgsForCall = append(fake.isTarFileArgsForCall, struct {
+		path string
+	}{path})
+	fake.recordInvocation("IsTarFile", []interface{}{path})
+	fake.isTarFileMutex.Unlock()
+	if fake.IsTarFileStub != nil {
+		return fake.IsTarFileStub(path)
+	} else {
+		return fake.isTarFileReturns.result1
+	}
+}
+
+func (fake *FakeZipper) IsTarFileCallCount() int {
+	fake.isTarFileMutex.RLock()
+	defer fake.isTarFileMutex.RUnlock()
+	return len(fake.isTarFileArgsForCall)
+}
+
+func (fake *FakeZipper) IsTarFileArgsForCall(i int) string {
+	fake.isTarFileMutex.RLock()
+	defer fake.isTarFileMutex.RUnlock()
+	return fake.isTarFileArgsForCall[i].path
+}
+
+func (fake *FakeZipper) IsTarFileReturns(result1 bool) {
+	fake.IsTarFileStub = nil
+	fake.isTarFileReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeZipper) Untar(path string, destDir string) error {
+	fake.untarMutex.Lock()
+	fake.untarArgsForCall = append(fake.untarArgsForCall, struct {
+		path    string
+		destDir string
+	}{path, destDir})
+	fake.recordInvocation("Untar", []interface{}{path, destDir})
+	fake.untarMutex.Unlock()
+	if fake.UntarStub != nil {
+		return fake.UntarStub(path, destDir)
+	} else {
+		return fake.untarReturns.result1
+	}
+}
+
+func (fake *FakeZipper) UntarCallCount() int {
+	fake.untarMutex.RLock()
+	defer fake.untarMutex.RUnlock()
+	return len(fake.untarArgsForCall)
+}
+
+func (fake *FakeZipper) UntarArgsForCall(i int) (string, string) {
+	fake.untarMutex.RLock()
+	defer fake.untarMutex.RUnlock()
+	return fake.untarArgsForCall[i].path, fake.untarArgsForCall[i].destDir
+}
+
+func (fake *FakeZipper) UntarReturns(result1 error) {
+	fake.UntarStub = nil
+	fake.untarReturns = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeZipper) GetZipSize(zipFile *os.File) (int64, error) {
 	fake.getZipSizeMutex.Lock()
 	fake.getZipSizeArgsForCall = append(fake.getZipSizeArgsForCall, struct {
@@ -188,10 +367,18 @@ func (fake *FakeZipper) Invocations() map[string][][]interface{} {
 	defer fake.invocationsMutex.RUnlock()
 	fake.zipMutex.RLock()
 	defer fake.zipMutex.RUnlock()
+	fake.zipToMutex.RLock()
+	defer fake.zipToMutex.RUnlock()
+	fake.zipFilesToMutex.RLock()
+	defer fake.zipFilesToMutex.RUnlock()
 	fake.isZipFileMutex.RLock()
 	defer fake.isZipFileMutex.RUnlock()
 	fake.unzipMutex.RLock()
 	defer fake.unzipMutex.RUnlock()
+	fake.isTarFileMutex.RLock()
+	defer fake.isTarFileMutex.RUnlock()
+	fake.untarMutex.RLock()
+	defer fake.untarMutex.RUnlock()
 	fake.getZipSizeMutex.RLock()
 	defer fake.getZipSizeMutex.RUnlock()
 	return fake.invocations
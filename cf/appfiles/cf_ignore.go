@@ -1,79 +1,287 @@
 package appfiles
 
 import (
+	"bytes"
+	"fmt"
 	"path"
+	"regexp"
 	"strings"
-
-	"code.cloudfoundry.org/cli/util/glob"
 )
 
 //go:generate counterfeiter . CfIgnore
 
+// CfIgnore decides which app files are left out of a push, following the same
+// rules as .gitignore files.
 type CfIgnore interface {
 	FileShouldBeIgnored(path string) bool
+	Match(path string, isDir bool) (ignored bool, rule *IgnoreRule)
+}
+
+// IgnoreRule is a single pattern from a .cfignore file, or one of the
+// patterns that are ignored by default.
+type IgnoreRule struct {
+	// Source is the path of the .cfignore file relative to the app directory,
+	// or empty for the default patterns.
+	Source  string
+	Line    int
+	Pattern string
+
+	base     string
+	negate   bool
+	dirOnly  bool
+	anchored bool
+	regexp   *regexp.Regexp
 }
 
+func (rule IgnoreRule) String() string {
+	if rule.Source == "" {
+		return fmt.Sprintf("(default):%s", rule.Pattern)
+	}
+	return fmt.Sprintf("%s:%d:%s", rule.Source, rule.Line, rule.Pattern)
+}
+
+type cfIgnore struct {
+	rules []*IgnoreRule
+}
+
+// NewCfIgnore returns the rules of a .cfignore file at the top of the app
+// directory, after the default rules.
 func NewCfIgnore(text string) CfIgnore {
-	patterns := []ignorePattern{}
-	lines := strings.Split(text, "\n")
-	lines = append(defaultIgnoreLines, lines...)
+	ignore := &cfIgnore{}
+	ignore.addRules("", "", strings.Join(defaultIgnoreLines, "\n"))
+	ignore.addRules("", ".cfignore", text)
+	return ignore
+}
 
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
-		if line == "" {
+// addRules adds the patterns of a .cfignore file in the directory dir, which
+// is relative to the app directory. They take precedence over the rules that
+// were added before them.
+func (ignore *cfIgnore) addRules(dir string, source string, text string) {
+	for i, line := range strings.Split(text, "\n") {
+		rule := parseIgnoreLine(line)
+		if rule == nil {
 			continue
 		}
 
-		ignore := true
-		if strings.HasPrefix(line, "!") {
-			line = line[1:]
-			ignore = false
+		rule.Source = source
+		rule.Line = i + 1
+		rule.base = dir
+		ignore.rules = append(ignore.rules, rule)
+	}
+}
+
+// FileShouldBeIgnored reports whether the file at path is ignored. A path
+// that ends with a slash is treated as a directory.
+func (ignore *cfIgnore) FileShouldBeIgnored(path string) bool {
+	ignored, _ := ignore.Match(strings.TrimSuffix(path, "/"), strings.HasSuffix(path, "/"))
+	return ignored
+}
+
+// Match reports whether the file at path, which is relative to the app
+// directory and uses forward slashes, is ignored, and returns the rule that
+// decided it. As with .gitignore files, a file cannot be included again once
+// one of the directories it is in has been ignored.
+func (ignore *cfIgnore) Match(path string, isDir bool) (bool, *IgnoreRule) {
+	path = strings.TrimPrefix(path, "/")
+
+	parts := strings.Split(path, "/")
+	for i := 1; i < len(parts); i++ {
+		if ignored, rule := ignore.matchPath(strings.Join(parts[:i], "/"), true); ignored {
+			return true, rule
 		}
+	}
+
+	return ignore.matchPath(path, isDir)
+}
+
+// matchPath returns the decision of the last rule that matches path, without
+// looking at the directories that path is in.
+func (ignore *cfIgnore) matchPath(path string, isDir bool) (bool, *IgnoreRule) {
+	for i := len(ignore.rules) - 1; i >= 0; i-- {
+		rule := ignore.rules[i]
+		if rule.matches(path, isDir) {
+			return !rule.negate, rule
+		}
+	}
+	return false, nil
+}
+
+func (rule *IgnoreRule) matches(filePath string, isDir bool) bool {
+	if rule.dirOnly && !isDir {
+		return false
+	}
 
-		for _, p := range globsForPattern(path.Clean(line)) {
-			patterns = append(patterns, ignorePattern{ignore, p})
+	if rule.base != "" {
+		if !strings.HasPrefix(filePath, rule.base+"/") {
+			return false
 		}
+		filePath = strings.TrimPrefix(filePath, rule.base+"/")
 	}
 
-	return cfIgnore(patterns)
+	if !rule.anchored {
+		filePath = path.Base(filePath)
+	}
+
+	return rule.regexp.MatchString(filePath)
 }
 
-func (ignore cfIgnore) FileShouldBeIgnored(path string) bool {
-	result := false
+// parseIgnoreLine returns the rule for a line of a .cfignore file, or nil if
+// the line is blank, a comment, or a pattern that cannot match anything, such
+// as one with a reversed character range.
+func parseIgnoreLine(line string) *IgnoreRule {
+	// Unlike .gitignore files, leading whitespace is not part of the pattern,
+	// as .cfignore files have always been read that way.
+	line = strings.TrimLeft(strings.TrimSuffix(line, "\r"), " \t")
+	if strings.HasPrefix(line, "#") {
+		return nil
+	}
 
-	for _, pattern := range ignore {
-		if strings.HasPrefix(pattern.glob.String(), "/") && !strings.HasPrefix(path, "/") {
-			path = "/" + path
+	line = trimUnescapedTrailingSpaces(line)
+	if line == "" {
+		return nil
+	}
+
+	rule := &IgnoreRule{Pattern: line}
+
+	if strings.HasPrefix(line, "!") {
+		rule.negate = true
+		line = line[1:]
+	} else if strings.HasPrefix(line, `\!`) || strings.HasPrefix(line, `\#`) {
+		line = line[1:]
+	}
+
+	if strings.HasSuffix(line, "/") {
+		rule.dirOnly = true
+		line = strings.TrimRight(line, "/")
+	}
+
+	if line == "" {
+		return nil
+	}
+
+	// A pattern with a slash at the start or in the middle is relative to the
+	// directory of the .cfignore file; otherwise it matches a file name at
+	// any depth.
+	if strings.Contains(line, "/") {
+		rule.anchored = true
+		line = strings.TrimPrefix(line, "/")
+	}
+
+	expr, err := regexp.Compile("^" + globToRegexp(line) + "$")
+	if err != nil {
+		return nil
+	}
+
+	rule.regexp = expr
+	return rule
+}
+
+func trimUnescapedTrailingSpaces(line string) string {
+	for strings.HasSuffix(line, " ") && !strings.HasSuffix(line, `\ `) {
+		line = line[:len(line)-1]
+	}
+	return line
+}
+
+// globToRegexp converts a .gitignore style pattern to a regular expression.
+// "*" and "?" do not match slashes, while "**" matches any number of
+// directories when it is a whole path segment.
+func globToRegexp(pattern string) string {
+	var expr bytes.Buffer
+	segments := strings.Split(pattern, "/")
+
+	for i, segment := range segments {
+		last := i == len(segments)-1
+
+		if segment == "**" {
+			switch {
+			case len(segments) == 1:
+				expr.WriteString(".*")
+			case i == 0:
+				expr.WriteString("(?:.*/)?")
+			case last:
+				expr.WriteString(".*")
+			default:
+				expr.WriteString("(?:.*/)?")
+			}
+			continue
 		}
 
-		if pattern.glob.Match(path) {
-			result = pattern.exclude
+		expr.WriteString(segmentToRegexp(segment))
+		if !last {
+			expr.WriteString("/")
 		}
 	}
 
-	return result
+	return expr.String()
 }
 
-func globsForPattern(pattern string) (globs []glob.Glob) {
-	globs = append(globs, glob.MustCompileGlob(pattern))
-	globs = append(globs, glob.MustCompileGlob(path.Join(pattern, "*")))
-	globs = append(globs, glob.MustCompileGlob(path.Join(pattern, "**", "*")))
+func segmentToRegexp(segment string) string {
+	var expr bytes.Buffer
+	runes := []rune(segment)
 
-	if !strings.HasPrefix(pattern, "/") {
-		globs = append(globs, glob.MustCompileGlob(path.Join("**", pattern)))
-		globs = append(globs, glob.MustCompileGlob(path.Join("**", pattern, "*")))
-		globs = append(globs, glob.MustCompileGlob(path.Join("**", pattern, "**", "*")))
+	for i := 0; i < len(runes); i++ {
+		switch r := runes[i]; r {
+		case '*':
+			for i+1 < len(runes) && runes[i+1] == '*' {
+				i++
+			}
+			expr.WriteString("[^/]*")
+		case '?':
+			expr.WriteString("[^/]")
+		case '\\':
+			if i+1 < len(runes) {
+				i++
+				expr.WriteString(regexp.QuoteMeta(string(runes[i])))
+			} else {
+				expr.WriteString(regexp.QuoteMeta(`\`))
+			}
+		case '[':
+			class, length := bracketToRegexp(runes[i:])
+			if length == 0 {
+				expr.WriteString(regexp.QuoteMeta("["))
+				continue
+			}
+			expr.WriteString(class)
+			i += length - 1
+		default:
+			expr.WriteString(regexp.QuoteMeta(string(r)))
+		}
 	}
 
-	return
+	return expr.String()
 }
 
-type ignorePattern struct {
-	exclude bool
-	glob    glob.Glob
-}
+// bracketToRegexp converts the character class at the start of runes, and
+// returns it with the number of runes it took up. It returns a length of 0
+// when the class is not closed.
+func bracketToRegexp(runes []rune) (string, int) {
+	var class bytes.Buffer
+	class.WriteString("[")
+
+	i := 1
+	if i < len(runes) && (runes[i] == '!' || runes[i] == '^') {
+		class.WriteString("^/")
+		i++
+	}
 
-type cfIgnore []ignorePattern
+	for start := i; i < len(runes); i++ {
+		switch r := runes[i]; {
+		case r == ']' && i > start:
+			class.WriteString("]")
+			return class.String(), i + 1
+		case r == '\\' && i+1 < len(runes):
+			i++
+			class.WriteString(regexp.QuoteMeta(string(runes[i])))
+		case r == '[' || r == ']' || r == '^' || r == '\\':
+			class.WriteString(`\` + string(r))
+		default:
+			class.WriteRune(r)
+		}
+	}
+
+	return "", 0
+}
 
 var defaultIgnoreLines = []string{
 	".cfignore",
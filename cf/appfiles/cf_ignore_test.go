@@ -67,6 +67,108 @@ stuff/exclude.c`)
 		Expect(ignore.FileShouldBeIgnored(".git/objects")).To(BeFalse())
 	})
 
+	It("does not include files again once their directory is excluded", func() {
+		ignore := NewCfIgnore(`
+build/
+!build/keep.txt
+`)
+
+		Expect(ignore.FileShouldBeIgnored("build/")).To(BeTrue())
+		Expect(ignore.FileShouldBeIgnored("build/keep.txt")).To(BeTrue())
+	})
+
+	It("only excludes directories with patterns that end with a slash", func() {
+		ignore := NewCfIgnore(`logs/`)
+		Expect(ignore.FileShouldBeIgnored("logs/")).To(BeTrue())
+		Expect(ignore.FileShouldBeIgnored("app/logs/")).To(BeTrue())
+		Expect(ignore.FileShouldBeIgnored("logs")).To(BeFalse())
+	})
+
+	It("anchors patterns that contain a slash to the top of the app", func() {
+		ignore := NewCfIgnore(`
+/root.txt
+docs/*.txt
+`)
+
+		Expect(ignore.FileShouldBeIgnored("root.txt")).To(BeTrue())
+		Expect(ignore.FileShouldBeIgnored("dir/root.txt")).To(BeFalse())
+		Expect(ignore.FileShouldBeIgnored("docs/a.txt")).To(BeTrue())
+		Expect(ignore.FileShouldBeIgnored("docs/more/a.txt")).To(BeFalse())
+		Expect(ignore.FileShouldBeIgnored("other/docs/a.txt")).To(BeFalse())
+	})
+
+	It("supports double-star patterns at the start, middle and end", func() {
+		ignore := NewCfIgnore(`
+**/cache
+a/**/b
+vendor/**
+`)
+
+		Expect(ignore.FileShouldBeIgnored("cache")).To(BeTrue())
+		Expect(ignore.FileShouldBeIgnored("x/y/cache")).To(BeTrue())
+		Expect(ignore.FileShouldBeIgnored("a/b")).To(BeTrue())
+		Expect(ignore.FileShouldBeIgnored("a/x/y/b")).To(BeTrue())
+		Expect(ignore.FileShouldBeIgnored("vendor/lib/file.go")).To(BeTrue())
+		Expect(ignore.FileShouldBeIgnored("vendor")).To(BeFalse())
+	})
+
+	It("supports character classes and single character wildcards", func() {
+		ignore := NewCfIgnore(`
+file[0-9].txt
+[!a]*.md
+?.tmp
+`)
+
+		Expect(ignore.FileShouldBeIgnored("file1.txt")).To(BeTrue())
+		Expect(ignore.FileShouldBeIgnored("fileA.txt")).To(BeFalse())
+		Expect(ignore.FileShouldBeIgnored("bcd.md")).To(BeTrue())
+		Expect(ignore.FileShouldBeIgnored("abc.md")).To(BeFalse())
+		Expect(ignore.FileShouldBeIgnored("x.tmp")).To(BeTrue())
+		Expect(ignore.FileShouldBeIgnored("xy.tmp")).To(BeFalse())
+	})
+
+	It("skips patterns with a reversed character range", func() {
+		ignore := NewCfIgnore("file[z-a].txt\n*.log\n")
+
+		Expect(ignore.FileShouldBeIgnored("filez.txt")).To(BeFalse())
+		Expect(ignore.FileShouldBeIgnored("file[z-a].txt")).To(BeFalse())
+		Expect(ignore.FileShouldBeIgnored("debug.log")).To(BeTrue())
+	})
+
+	It("skips comments and supports escaped characters", func() {
+		ignore := NewCfIgnore("# a comment\n\\#hash\n\\!bang\ntrailing\\ \n")
+
+		Expect(ignore.FileShouldBeIgnored("# a comment")).To(BeFalse())
+		Expect(ignore.FileShouldBeIgnored("#hash")).To(BeTrue())
+		Expect(ignore.FileShouldBeIgnored("!bang")).To(BeTrue())
+		Expect(ignore.FileShouldBeIgnored("trailing ")).To(BeTrue())
+	})
+
+	Describe("Match", func() {
+		It("returns the rule that decided whether a file is ignored", func() {
+			ignore := NewCfIgnore(`
+*.log
+!important.log
+`)
+
+			ignored, rule := ignore.Match("debug.log", false)
+			Expect(ignored).To(BeTrue())
+			Expect(rule.String()).To(Equal(".cfignore:2:*.log"))
+
+			ignored, rule = ignore.Match("important.log", false)
+			Expect(ignored).To(BeFalse())
+			Expect(rule.String()).To(Equal(".cfignore:3:!important.log"))
+
+			ignored, rule = ignore.Match(".git", true)
+			Expect(ignored).To(BeTrue())
+			Expect(rule.String()).To(Equal("(default):.git"))
+
+			ignored, rule = ignore.Match("app.rb", false)
+			Expect(ignored).To(BeFalse())
+			Expect(rule).To(BeNil())
+		})
+	})
+
 	Describe("files named manifest.yml", func() {
 		var (
 			ignore CfIgnore
@@ -0,0 +1,176 @@
+package appfiles
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/cli/util/configfile"
+)
+
+// racyInterval is how recently a file can have been modified for its
+// fingerprint to be left out of the cache. A file that is changed again within
+// the resolution of its modification time would otherwise keep a stale
+// fingerprint.
+const racyInterval = 2 * time.Second
+
+// fingerprintMaxAge is how long a fingerprint is kept without being used, so
+// that the cache does not keep growing with apps that are no longer pushed.
+const fingerprintMaxAge = 30 * 24 * time.Hour
+
+// FingerprintCache remembers the SHA1 of the files that have been hashed, so
+// that files that have not changed since the last push are not read again. A
+// file is considered unchanged while its size, modification time and inode
+// stay the same. The cache is stored as a JSON file, usually in the CF home
+// directory. Fingerprints that have not been used for a while are dropped when
+// the cache is saved. A nil *FingerprintCache caches nothing.
+type FingerprintCache struct {
+	path string
+
+	mutex   sync.Mutex
+	loaded  bool
+	entries map[string]fingerprint
+	used    map[string]bool
+}
+
+type fingerprint struct {
+	Size    int64  `json:"size"`
+	ModTime int64  `json:"mtime"`
+	Inode   uint64 `json:"inode"`
+	Sha1    string `json:"sha1"`
+
+	// LastUsed is when the fingerprint was last stored or looked up, in
+	// seconds since the epoch.
+	LastUsed int64 `json:"used"`
+}
+
+// NewFingerprintCache returns a cache stored in the file at path. The file is
+// not read until the cache is first used.
+func NewFingerprintCache(path string) *FingerprintCache {
+	return &FingerprintCache{path: path}
+}
+
+func newFingerprint(fileInfo os.FileInfo, sha string) fingerprint {
+	return fingerprint{
+		Size:     fileInfo.Size(),
+		ModTime:  fileInfo.ModTime().UnixNano(),
+		Inode:    fileInode(fileInfo),
+		Sha1:     sha,
+		LastUsed: time.Now().Unix(),
+	}
+}
+
+// matches returns whether the file is unchanged since the fingerprint was
+// taken.
+func (entry fingerprint) matches(fileInfo os.FileInfo) bool {
+	return entry.Size == fileInfo.Size() &&
+		entry.ModTime == fileInfo.ModTime().UnixNano() &&
+		entry.Inode == fileInode(fileInfo)
+}
+
+// Lookup returns the SHA1 of the file at fullPath if it has not changed since
+// it was stored.
+func (cache *FingerprintCache) Lookup(fullPath string, fileInfo os.FileInfo) (string, bool) {
+	if cache == nil {
+		return "", false
+	}
+
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	cache.load()
+
+	entry, found := cache.entries[fullPath]
+	if !found || !entry.matches(fileInfo) {
+		return "", false
+	}
+
+	entry.LastUsed = time.Now().Unix()
+	cache.entries[fullPath] = entry
+	cache.used[fullPath] = true
+	return entry.Sha1, true
+}
+
+// Store remembers the SHA1 of the file at fullPath. Files modified in the last
+// couple of seconds are not stored.
+func (cache *FingerprintCache) Store(fullPath string, fileInfo os.FileInfo, sha string) {
+	if cache == nil || time.Since(fileInfo.ModTime()) < racyInterval {
+		return
+	}
+
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	cache.load()
+
+	cache.entries[fullPath] = newFingerprint(fileInfo, sha)
+	cache.used[fullPath] = true
+}
+
+// Prune forgets the files in dir that have not been looked up or stored since
+// the cache was loaded, such as files that have been deleted.
+func (cache *FingerprintCache) Prune(dir string) {
+	if cache == nil {
+		return
+	}
+
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	cache.load()
+
+	prefix := strings.TrimSuffix(dir, string(filepath.Separator)) + string(filepath.Separator)
+	for fullPath := range cache.entries {
+		if strings.HasPrefix(fullPath, prefix) && !cache.used[fullPath] {
+			delete(cache.entries, fullPath)
+		}
+	}
+}
+
+// Save writes the cache to its file. Fingerprints that have not been used for
+// fingerprintMaxAge are left out, whichever directory they are in.
+func (cache *FingerprintCache) Save() error {
+	if cache == nil {
+		return nil
+	}
+
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	cache.load()
+
+	oldest := time.Now().Add(-fingerprintMaxAge).Unix()
+	for fullPath, entry := range cache.entries {
+		if entry.LastUsed < oldest {
+			delete(cache.entries, fullPath)
+		}
+	}
+
+	data, err := json.Marshal(cache.entries)
+	if err != nil {
+		return err
+	}
+
+	return configfile.WriteAtomically(cache.path, data, 0600)
+}
+
+// load reads the cache file the first time it is called. A missing or
+// unreadable file leaves the cache empty.
+func (cache *FingerprintCache) load() {
+	if cache.loaded {
+		return
+	}
+	cache.loaded = true
+	cache.entries = map[string]fingerprint{}
+	cache.used = map[string]bool{}
+
+	data, err := ioutil.ReadFile(cache.path)
+	if err != nil {
+		return
+	}
+
+	entries := map[string]fingerprint{}
+	if json.Unmarshal(data, &entries) == nil {
+		cache.entries = entries
+	}
+}
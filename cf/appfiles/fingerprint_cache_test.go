@@ -0,0 +1,143 @@
+package appfiles_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"code.cloudfoundry.org/cli/cf/appfiles"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("FingerprintCache", func() {
+	var (
+		tempDir   string
+		cachePath string
+		filePath  string
+		cache     *appfiles.FingerprintCache
+	)
+
+	writeFile := func(path string, contents string, modTime time.Time) os.FileInfo {
+		Expect(ioutil.WriteFile(path, []byte(contents), 0600)).To(Succeed())
+		Expect(os.Chtimes(path, modTime, modTime)).To(Succeed())
+
+		info, err := os.Stat(path)
+		Expect(err).NotTo(HaveOccurred())
+		return info
+	}
+
+	BeforeEach(func() {
+		var err error
+		tempDir, err = ioutil.TempDir("", "fingerprint-cache")
+		Expect(err).NotTo(HaveOccurred())
+
+		cachePath = filepath.Join(tempDir, "fingerprints.json")
+		filePath = filepath.Join(tempDir, "app", "file.txt")
+		Expect(os.Mkdir(filepath.Join(tempDir, "app"), 0700)).To(Succeed())
+
+		cache = appfiles.NewFingerprintCache(cachePath)
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(tempDir)
+	})
+
+	It("returns the stored SHA1 while the file is unchanged", func() {
+		info := writeFile(filePath, "contents", time.Now().Add(-time.Hour))
+		cache.Store(filePath, info, "some-sha")
+
+		sha, found := cache.Lookup(filePath, info)
+		Expect(found).To(BeTrue())
+		Expect(sha).To(Equal("some-sha"))
+	})
+
+	It("does not return the SHA1 once the file's size or modification time has changed", func() {
+		info := writeFile(filePath, "contents", time.Now().Add(-time.Hour))
+		cache.Store(filePath, info, "some-sha")
+
+		info = writeFile(filePath, "more contents", time.Now().Add(-time.Hour))
+		_, found := cache.Lookup(filePath, info)
+		Expect(found).To(BeFalse())
+
+		info = writeFile(filePath, "contents", time.Now().Add(-time.Minute))
+		_, found = cache.Lookup(filePath, info)
+		Expect(found).To(BeFalse())
+	})
+
+	It("does not store files that were modified in the last few seconds", func() {
+		info := writeFile(filePath, "contents", time.Now())
+		cache.Store(filePath, info, "some-sha")
+
+		_, found := cache.Lookup(filePath, info)
+		Expect(found).To(BeFalse())
+	})
+
+	It("saves the fingerprints so that another cache can read them", func() {
+		info := writeFile(filePath, "contents", time.Now().Add(-time.Hour))
+		cache.Store(filePath, info, "some-sha")
+		Expect(cache.Save()).To(Succeed())
+
+		sha, found := appfiles.NewFingerprintCache(cachePath).Lookup(filePath, info)
+		Expect(found).To(BeTrue())
+		Expect(sha).To(Equal("some-sha"))
+	})
+
+	It("ignores a cache file that cannot be read", func() {
+		Expect(ioutil.WriteFile(cachePath, []byte("not json"), 0600)).To(Succeed())
+		info := writeFile(filePath, "contents", time.Now().Add(-time.Hour))
+
+		_, found := cache.Lookup(filePath, info)
+		Expect(found).To(BeFalse())
+	})
+
+	It("prunes the files in a directory that were not used since the cache was read", func() {
+		otherPath := filepath.Join(tempDir, "app", "deleted.txt")
+		info := writeFile(filePath, "contents", time.Now().Add(-time.Hour))
+		otherInfo := writeFile(otherPath, "deleted", time.Now().Add(-time.Hour))
+		cache.Store(filePath, info, "some-sha")
+		cache.Store(otherPath, otherInfo, "other-sha")
+		Expect(cache.Save()).To(Succeed())
+
+		cache = appfiles.NewFingerprintCache(cachePath)
+		_, found := cache.Lookup(filePath, info)
+		Expect(found).To(BeTrue())
+		cache.Prune(filepath.Join(tempDir, "app"))
+		Expect(cache.Save()).To(Succeed())
+
+		cache = appfiles.NewFingerprintCache(cachePath)
+		_, found = cache.Lookup(filePath, info)
+		Expect(found).To(BeTrue())
+		_, found = cache.Lookup(otherPath, otherInfo)
+		Expect(found).To(BeFalse())
+	})
+
+	It("drops fingerprints in any directory that have not been used for a month", func() {
+		info := writeFile(filePath, "contents", time.Now().Add(-time.Hour))
+		stale := time.Now().Add(-31 * 24 * time.Hour).Unix()
+		Expect(ioutil.WriteFile(cachePath, []byte(fmt.Sprintf(`{
+			"/some/other/app/old.txt": {"size": 3, "mtime": 1, "inode": 1, "sha1": "old-sha", "used": %d}
+		}`, stale)), 0600)).To(Succeed())
+
+		cache.Store(filePath, info, "some-sha")
+		Expect(cache.Save()).To(Succeed())
+
+		data, err := ioutil.ReadFile(cachePath)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).NotTo(ContainSubstring("old-sha"))
+		Expect(string(data)).To(ContainSubstring("some-sha"))
+	})
+
+	It("caches nothing when it is nil", func() {
+		var nilCache *appfiles.FingerprintCache
+		info := writeFile(filePath, "contents", time.Now().Add(-time.Hour))
+		nilCache.Store(filePath, info, "some-sha")
+
+		_, found := nilCache.Lookup(filePath, info)
+		Expect(found).To(BeFalse())
+		Expect(nilCache.Save()).To(Succeed())
+	})
+})
@@ -0,0 +1,15 @@
+// +build !windows
+
+package appfiles
+
+import (
+	"os"
+	"syscall"
+)
+
+func fileInode(fileInfo os.FileInfo) uint64 {
+	if stat, ok := fileInfo.Sys().(*syscall.Stat_t); ok {
+		return uint64(stat.Ino)
+	}
+	return 0
+}
@@ -0,0 +1,11 @@
+// +build windows
+
+package appfiles
+
+import "os"
+
+// fileInode returns 0 on Windows, where os.FileInfo does not carry the file
+// index, so only the size and modification time identify a file there.
+func fileInode(fileInfo os.FileInfo) uint64 {
+	return 0
+}
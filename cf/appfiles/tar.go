@@ -0,0 +1,197 @@
+package appfiles
+
+import (
+	"archive/tar"
+	"bufio"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// IsTarFile reports whether the file at name is a tar archive, which may be
+// compressed with gzip.
+func (zipper ApplicationZipper) IsTarFile(name string) bool {
+	f, err := os.Open(name)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+
+	fi, err := f.Stat()
+	if err != nil || fi.IsDir() {
+		return false
+	}
+
+	reader, err := newTarReader(f)
+	if err != nil {
+		return false
+	}
+
+	_, err = reader.Next()
+	return err == nil
+}
+
+type tarLink struct {
+	name   string
+	target string
+}
+
+// Untar extracts the tar archive at name, which may be compressed with gzip,
+// to destDir. Files are made readable by everyone, and executable by everyone
+// if they were executable by anyone. Links to files in the archive are
+// replaced with copies of those files; links to directories, or to anything
+// outside of the archive, are left out, as they are when pushing a directory.
+func (zipper ApplicationZipper) Untar(name string, destDir string) error {
+	f, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	reader, err := newTarReader(f)
+	if err != nil {
+		return err
+	}
+
+	links := []tarLink{}
+	for {
+		header, err := reader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		entryName, err := tarEntryName(header.Name)
+		if err != nil {
+			return err
+		}
+		if entryName == "." {
+			continue
+		}
+		destPath := filepath.Join(destDir, filepath.FromSlash(entryName))
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			err = os.MkdirAll(destPath, 0755)
+		case tar.TypeReg, tar.TypeRegA:
+			err = extractTarFile(reader, destPath, header.FileInfo().Mode())
+		case tar.TypeSymlink:
+			if !path.IsAbs(header.Linkname) {
+				links = append(links, tarLink{
+					name:   entryName,
+					target: path.Join(path.Dir(entryName), header.Linkname),
+				})
+			}
+		case tar.TypeLink:
+			links = append(links, tarLink{name: entryName, target: path.Clean(header.Linkname)})
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return copyTarLinks(links, destDir)
+}
+
+func newTarReader(f io.Reader) (*tar.Reader, error) {
+	buffered := bufio.NewReader(f)
+
+	magic, err := buffered.Peek(2)
+	if err == nil && magic[0] == 0x1f && magic[1] == 0x8b {
+		gzipReader, err := gzip.NewReader(buffered)
+		if err != nil {
+			return nil, err
+		}
+		return tar.NewReader(gzipReader), nil
+	}
+
+	return tar.NewReader(buffered), nil
+}
+
+// tarEntryName returns the name of an entry relative to the top of the
+// archive, or an error if it is outside of the archive.
+func tarEntryName(name string) (string, error) {
+	cleaned := path.Clean(strings.TrimLeft(name, "/"))
+	if cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+		return "", fmt.Errorf("archive entry %s is outside of the archive", name)
+	}
+	return cleaned, nil
+}
+
+func extractTarFile(reader io.Reader, destPath string, mode os.FileMode) error {
+	err := os.MkdirAll(filepath.Dir(destPath), 0755)
+	if err != nil {
+		return err
+	}
+
+	destFile, err := os.OpenFile(destPath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, normalizedFileMode(mode))
+	if err != nil {
+		return err
+	}
+	defer destFile.Close()
+
+	_, err = io.Copy(destFile, reader)
+	return err
+}
+
+func normalizedFileMode(mode os.FileMode) os.FileMode {
+	if mode&0111 != 0 {
+		return 0755
+	}
+	return 0644
+}
+
+// copyTarLinks replaces each link with a copy of the file it links to. As a
+// link can point at another link, the links are copied until no more of them
+// can be.
+func copyTarLinks(links []tarLink, destDir string) error {
+	for len(links) > 0 {
+		remaining := []tarLink{}
+		for _, link := range links {
+			if _, err := tarEntryName(link.target); err != nil {
+				continue
+			}
+
+			targetPath := filepath.Join(destDir, filepath.FromSlash(link.target))
+			info, err := os.Lstat(targetPath)
+			if os.IsNotExist(err) {
+				remaining = append(remaining, link)
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			if !info.Mode().IsRegular() {
+				continue
+			}
+
+			err = copyTarLink(targetPath, filepath.Join(destDir, filepath.FromSlash(link.name)), info.Mode())
+			if err != nil {
+				return err
+			}
+		}
+
+		if len(remaining) == len(links) {
+			break
+		}
+		links = remaining
+	}
+
+	return nil
+}
+
+func copyTarLink(targetPath string, destPath string, mode os.FileMode) error {
+	target, err := os.Open(targetPath)
+	if err != nil {
+		return err
+	}
+	defer target.Close()
+
+	return extractTarFile(target, destPath, mode)
+}
@@ -0,0 +1,204 @@
+package appfiles_test
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	. "code.cloudfoundry.org/cli/cf/appfiles"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type tarEntry struct {
+	header   tar.Header
+	contents string
+}
+
+func writeTar(target string, compress bool, entries []tarEntry) {
+	file, err := os.Create(target)
+	Expect(err).NotTo(HaveOccurred())
+	defer file.Close()
+
+	var writer io.Writer = file
+	if compress {
+		gzipWriter := gzip.NewWriter(file)
+		defer gzipWriter.Close()
+		writer = gzipWriter
+	}
+
+	tarWriter := tar.NewWriter(writer)
+	defer tarWriter.Close()
+
+	for _, entry := range entries {
+		header := entry.header
+		header.Size = int64(len(entry.contents))
+		Expect(tarWriter.WriteHeader(&header)).To(Succeed())
+		_, err = io.WriteString(tarWriter, entry.contents)
+		Expect(err).NotTo(HaveOccurred())
+	}
+}
+
+func tarFile(name string, mode int64, contents string) tarEntry {
+	return tarEntry{
+		header:   tar.Header{Name: name, Mode: mode, Typeflag: tar.TypeReg},
+		contents: contents,
+	}
+}
+
+var _ = Describe("Tar", func() {
+	var (
+		tempDir string
+		zipper  ApplicationZipper
+	)
+
+	BeforeEach(func() {
+		var err error
+		tempDir, err = ioutil.TempDir("", "zipper-tar")
+		Expect(err).NotTo(HaveOccurred())
+		zipper = ApplicationZipper{}
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(tempDir)
+	})
+
+	Describe("IsTarFile", func() {
+		It("returns true for a tar file", func() {
+			archive := filepath.Join(tempDir, "app.tar")
+			writeTar(archive, false, []tarEntry{tarFile("file1", 0644, "contents")})
+
+			Expect(zipper.IsTarFile(archive)).To(BeTrue())
+		})
+
+		It("returns true for a gzipped tar file", func() {
+			archive := filepath.Join(tempDir, "app.tgz")
+			writeTar(archive, true, []tarEntry{tarFile("file1", 0644, "contents")})
+
+			Expect(zipper.IsTarFile(archive)).To(BeTrue())
+		})
+
+		It("returns false for a file that is not a tar", func() {
+			file := filepath.Join(tempDir, "file.txt")
+			err := ioutil.WriteFile(file, []byte("not a tar"), 0644)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(zipper.IsTarFile(file)).To(BeFalse())
+		})
+
+		It("returns false for a directory", func() {
+			Expect(zipper.IsTarFile(tempDir)).To(BeFalse())
+		})
+	})
+
+	Describe("Untar", func() {
+		var (
+			archive string
+			destDir string
+		)
+
+		BeforeEach(func() {
+			archive = filepath.Join(tempDir, "app.tar.gz")
+			destDir = filepath.Join(tempDir, "dest")
+			Expect(os.Mkdir(destDir, 0755)).To(Succeed())
+		})
+
+		It("extracts the files and directories in the archive", func() {
+			writeTar(archive, true, []tarEntry{
+				{header: tar.Header{Name: "./", Mode: 0755, Typeflag: tar.TypeDir}},
+				{header: tar.Header{Name: "./dir1/", Mode: 0755, Typeflag: tar.TypeDir}},
+				tarFile("./dir1/file1", 0644, "file-1-contents"),
+				tarFile("/dir2/file2", 0644, "file-2-contents"),
+				{header: tar.Header{Name: "empty-dir/", Mode: 0755, Typeflag: tar.TypeDir}},
+			})
+
+			Expect(zipper.Untar(archive, destDir)).To(Succeed())
+
+			contents, err := ioutil.ReadFile(filepath.Join(destDir, "dir1", "file1"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(contents)).To(Equal("file-1-contents"))
+
+			contents, err = ioutil.ReadFile(filepath.Join(destDir, "dir2", "file2"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(contents)).To(Equal("file-2-contents"))
+
+			info, err := os.Stat(filepath.Join(destDir, "empty-dir"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(info.IsDir()).To(BeTrue())
+		})
+
+		It("normalizes the file permissions", func() {
+			if runtime.GOOS == "windows" {
+				Skip("This should not run on Windows")
+			}
+
+			writeTar(archive, true, []tarEntry{
+				tarFile("private", 0600, "private"),
+				tarFile("script", 0700, "#!/bin/sh"),
+			})
+
+			Expect(zipper.Untar(archive, destDir)).To(Succeed())
+
+			info, err := os.Stat(filepath.Join(destDir, "private"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(info.Mode().Perm()).To(Equal(os.FileMode(0644)))
+
+			info, err = os.Stat(filepath.Join(destDir, "script"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(info.Mode().Perm()).To(Equal(os.FileMode(0755)))
+		})
+
+		It("replaces links to files in the archive with copies of the files", func() {
+			writeTar(archive, false, []tarEntry{
+				tarFile("dir1/file1", 0644, "file-1-contents"),
+				{header: tar.Header{Name: "dir2/symlink", Typeflag: tar.TypeSymlink, Linkname: "../link-to-link"}},
+				{header: tar.Header{Name: "link-to-link", Typeflag: tar.TypeSymlink, Linkname: "hardlink"}},
+				{header: tar.Header{Name: "hardlink", Typeflag: tar.TypeLink, Linkname: "dir1/file1"}},
+			})
+
+			Expect(zipper.Untar(archive, destDir)).To(Succeed())
+
+			for _, name := range []string{"hardlink", "link-to-link", "dir2/symlink"} {
+				info, err := os.Lstat(filepath.Join(destDir, name))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(info.Mode().IsRegular()).To(BeTrue())
+
+				contents, err := ioutil.ReadFile(filepath.Join(destDir, name))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(contents)).To(Equal("file-1-contents"))
+			}
+		})
+
+		It("leaves out links to directories, missing files and files outside of the archive", func() {
+			writeTar(archive, false, []tarEntry{
+				{header: tar.Header{Name: "dir1/", Mode: 0755, Typeflag: tar.TypeDir}},
+				{header: tar.Header{Name: "dir-link", Typeflag: tar.TypeSymlink, Linkname: "dir1"}},
+				{header: tar.Header{Name: "dangling", Typeflag: tar.TypeSymlink, Linkname: "missing"}},
+				{header: tar.Header{Name: "outside", Typeflag: tar.TypeSymlink, Linkname: "../../etc/passwd"}},
+				{header: tar.Header{Name: "absolute", Typeflag: tar.TypeSymlink, Linkname: "/etc/passwd"}},
+			})
+
+			Expect(zipper.Untar(archive, destDir)).To(Succeed())
+
+			for _, name := range []string{"dir-link", "dangling", "outside", "absolute"} {
+				_, err := os.Lstat(filepath.Join(destDir, name))
+				Expect(os.IsNotExist(err)).To(BeTrue())
+			}
+		})
+
+		It("returns an error when an entry is outside of the archive", func() {
+			writeTar(archive, true, []tarEntry{tarFile("../escaped", 0644, "contents")})
+
+			err := zipper.Untar(archive, destDir)
+			Expect(err).To(MatchError(ContainSubstring("outside of the archive")))
+
+			_, err = os.Stat(filepath.Join(tempDir, "escaped"))
+			Expect(os.IsNotExist(err)).To(BeTrue())
+		})
+	})
+})
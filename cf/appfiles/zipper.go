@@ -10,6 +10,7 @@ import (
 	"runtime"
 
 	"code.cloudfoundry.org/cli/cf/errors"
+	"code.cloudfoundry.org/cli/cf/models"
 	"code.cloudfoundry.org/gofileutils/fileutils"
 )
 
@@ -17,14 +18,34 @@ import (
 
 type Zipper interface {
 	Zip(dirToZip string, targetFile *os.File) (err error)
+	ZipTo(dirOrZipFilePath string, writer io.Writer) (err error)
+	ZipFilesTo(dir string, files []models.AppFileFields, writer io.Writer) (err error)
 	IsZipFile(path string) bool
 	Unzip(appDir string, destDir string) (err error)
+	IsTarFile(path string) bool
+	Untar(path string, destDir string) (err error)
 	GetZipSize(zipFile *os.File) (int64, error)
 }
 
 type ApplicationZipper struct{}
 
 func (zipper ApplicationZipper) Zip(dirOrZipFilePath string, targetFile *os.File) error {
+	err := zipper.ZipTo(dirOrZipFilePath, targetFile)
+	if err != nil {
+		return err
+	}
+
+	_, err = targetFile.Seek(0, os.SEEK_SET)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ZipTo writes a zip of the directory, or the zip file itself, to writer as
+// it is produced, so that it can be streamed without being stored first.
+func (zipper ApplicationZipper) ZipTo(dirOrZipFilePath string, writer io.Writer) error {
 	if zipper.IsZipFile(dirOrZipFilePath) {
 		zipFile, err := os.Open(dirOrZipFilePath)
 		if err != nil {
@@ -32,23 +53,27 @@ func (zipper ApplicationZipper) Zip(dirOrZipFilePath string, targetFile *os.File
 		}
 		defer zipFile.Close()
 
-		_, err = io.Copy(targetFile, zipFile)
-		if err != nil {
-			return err
-		}
-	} else {
-		err := writeZipFile(dirOrZipFilePath, targetFile)
+		_, err = io.Copy(writer, zipFile)
+		return err
+	}
+
+	return writeZipFile(dirOrZipFilePath, writer)
+}
+
+// ZipFilesTo writes a zip of the given files of dir to writer as it is
+// produced. The files are named by their paths relative to dir.
+func (zipper ApplicationZipper) ZipFilesTo(dir string, files []models.AppFileFields, writer io.Writer) error {
+	zipWriter := zip.NewWriter(writer)
+
+	for _, file := range files {
+		err := writeZipEntry(zipWriter, file.Path, filepath.Join(dir, filepath.FromSlash(file.Path)))
 		if err != nil {
+			_ = zipWriter.Close()
 			return err
 		}
 	}
 
-	_, err := targetFile.Seek(0, os.SEEK_SET)
-	if err != nil {
-		return err
-	}
-
-	return nil
+	return zipWriter.Close()
 }
 
 func (zipper ApplicationZipper) IsZipFile(name string) bool {
@@ -136,7 +161,7 @@ func (zipper ApplicationZipper) GetZipSize(zipFile *os.File) (int64, error) {
 	return zipFileSize, nil
 }
 
-func writeZipFile(dir string, targetFile *os.File) error {
+func writeZipFile(dir string, targetFile io.Writer) error {
 	isEmpty, err := fileutils.IsDirEmpty(dir)
 	if err != nil {
 		return err
@@ -147,53 +172,60 @@ func writeZipFile(dir string, targetFile *os.File) error {
 	}
 
 	writer := zip.NewWriter(targetFile)
-	defer writer.Close()
 
 	appfiles := ApplicationFiles{}
-	return appfiles.WalkAppFiles(dir, func(fileName string, fullPath string) error {
-		fileInfo, err := os.Stat(fullPath)
-		if err != nil {
-			return err
-		}
-
-		header, err := zip.FileInfoHeader(fileInfo)
-		if err != nil {
-			return err
-		}
+	err = appfiles.WalkAppFiles(dir, func(fileName string, fullPath string) error {
+		return writeZipEntry(writer, fileName, fullPath)
+	})
+	if err != nil {
+		_ = writer.Close()
+		return err
+	}
 
-		if runtime.GOOS == "windows" {
-			header.SetMode(header.Mode() | 0700)
-		}
+	return writer.Close()
+}
 
-		header.Name = filepath.ToSlash(fileName)
-		header.Method = zip.Deflate
+// writeZipEntry adds the file or directory at fullPath to the zip as
+// fileName.
+func writeZipEntry(writer *zip.Writer, fileName string, fullPath string) error {
+	fileInfo, err := os.Stat(fullPath)
+	if err != nil {
+		return err
+	}
 
-		if fileInfo.IsDir() {
-			header.Name += "/"
-		}
+	header, err := zip.FileInfoHeader(fileInfo)
+	if err != nil {
+		return err
+	}
 
-		zipFilePart, err := writer.CreateHeader(header)
-		if err != nil {
-			return err
-		}
+	if runtime.GOOS == "windows" {
+		header.SetMode(header.Mode() | 0700)
+	}
 
-		if fileInfo.IsDir() {
-			return nil
-		}
+	header.Name = filepath.ToSlash(fileName)
+	header.Method = zip.Deflate
 
-		file, err := os.Open(fullPath)
-		if err != nil {
-			return err
-		}
-		defer file.Close()
+	if fileInfo.IsDir() {
+		header.Name += "/"
+	}
 
-		_, err = io.Copy(zipFilePart, file)
-		if err != nil {
-			return err
-		}
+	zipFilePart, err := writer.CreateHeader(header)
+	if err != nil {
+		return err
+	}
 
+	if fileInfo.IsDir() {
 		return nil
-	})
+	}
+
+	file, err := os.Open(fullPath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = io.Copy(zipFilePart, file)
+	return err
 }
 
 func (zipper ApplicationZipper) zipFileHeaderLocation(name string) (int64, error) {
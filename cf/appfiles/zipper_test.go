@@ -13,6 +13,7 @@ import (
 	"strings"
 
 	. "code.cloudfoundry.org/cli/cf/appfiles"
+	"code.cloudfoundry.org/cli/cf/models"
 	"code.cloudfoundry.org/gofileutils/fileutils"
 
 	. "github.com/onsi/ginkgo"
@@ -250,6 +251,86 @@ var _ = Describe("Zipper", func() {
 		})
 	})
 
+	Describe("ZipTo", func() {
+		var zipper ApplicationZipper
+
+		It("writes a zip of the source directory to the writer", func() {
+			workingDir, err := os.Getwd()
+			Expect(err).NotTo(HaveOccurred())
+
+			var buffer bytes.Buffer
+			err = zipper.ZipTo(filepath.Join(workingDir, "../../fixtures/zip/"), &buffer)
+			Expect(err).NotTo(HaveOccurred())
+
+			reader, err := zip.NewReader(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+			Expect(err).NotTo(HaveOccurred())
+
+			name, contents := readFileInZip(0, reader)
+			Expect(name).To(Equal("foo.txt"))
+			Expect(contents).To(Equal("This is a simple text file."))
+		})
+
+		It("writes the same zip each time it is called", func() {
+			workingDir, err := os.Getwd()
+			Expect(err).NotTo(HaveOccurred())
+			dir := filepath.Join(workingDir, "../../fixtures/zip/")
+
+			var first, second bytes.Buffer
+			Expect(zipper.ZipTo(dir, &first)).To(Succeed())
+			Expect(zipper.ZipTo(dir, &second)).To(Succeed())
+			Expect(first.Bytes()).To(Equal(second.Bytes()))
+		})
+
+		It("copies a zipfile to the writer", func() {
+			zipPath := filepath.Join("..", "..", "fixtures", "applications", "example-app.zip")
+			expected, err := ioutil.ReadFile(zipPath)
+			Expect(err).NotTo(HaveOccurred())
+
+			var buffer bytes.Buffer
+			Expect(zipper.ZipTo(zipPath, &buffer)).To(Succeed())
+			Expect(buffer.Bytes()).To(Equal(expected))
+		})
+	})
+
+	Describe("ZipFilesTo", func() {
+		var zipper ApplicationZipper
+
+		It("writes a zip of only the given files to the writer", func() {
+			dir := filepath.Join("..", "..", "fixtures", "applications", "app-copy-test")
+			files := []models.AppFileFields{
+				{Path: "dir1"},
+				{Path: "dir1/file1.txt"},
+				{Path: "dir2/child-dir2/grandchild-dir2/file4.txt"},
+			}
+
+			var buffer bytes.Buffer
+			Expect(zipper.ZipFilesTo(dir, files, &buffer)).To(Succeed())
+
+			reader, err := zip.NewReader(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+			Expect(err).NotTo(HaveOccurred())
+
+			names := []string{}
+			for _, f := range reader.File {
+				names = append(names, f.Name)
+			}
+			Expect(names).To(Equal([]string{
+				"dir1/",
+				"dir1/file1.txt",
+				"dir2/child-dir2/grandchild-dir2/file4.txt",
+			}))
+
+			name, contents := readFileInZip(1, reader)
+			Expect(name).To(Equal("dir1/file1.txt"))
+			Expect(contents).To(Equal("file1-content\n"))
+		})
+
+		It("returns an error when a file is missing", func() {
+			var buffer bytes.Buffer
+			err := zipper.ZipFilesTo("/a/bogus/directory", []models.AppFileFields{{Path: "foo"}}, &buffer)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
 	Describe("IsZipFile", func() {
 		var (
 			inDir, outDir string
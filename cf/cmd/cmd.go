@@ -46,7 +46,8 @@ func Main(traceEnv string, args []string) {
 	args = append([]string{args[0]}, handleHelp(args[1:])...)
 
 	newArgs, isVerbose := handleVerbose(args)
-	args = newArgs
+	args, coreconfig.Profile = handleProfile(newArgs)
+	args, commandregistry.NoResponseCache = handleNoCache(args)
 
 	errFunc := func(err error) {
 		if err != nil {
@@ -95,6 +96,16 @@ func Main(traceEnv string, args []string) {
 		flagContext.SkipFlagParsing(meta.SkipFlagParsing)
 
 		cmdArgs := args[2:]
+		if _, ok := meta.Flags["output"]; !ok {
+			var outputFormat string
+			cmdArgs, outputFormat = handleOutput(cmdArgs)
+			if outputFormat != "" && !strings.EqualFold(outputFormat, "text") {
+				deps.UI.Failed(T("Incorrect Usage. The '{{.CommandName}}' command does not support the '--output' option.",
+					map[string]interface{}{"CommandName": meta.Name}))
+				os.Exit(1)
+			}
+		}
+
 		err = flagContext.Parse(cmdArgs...)
 		if err != nil {
 			usage := cmdRegistry.CommandUsage(cmdName)
@@ -236,3 +247,46 @@ func handleVerbose(args []string) ([]string, bool) {
 
 	return args, verbose
 }
+
+// handleNoCache removes the '--no-cache' global flag from the args and returns
+// whether it was given.
+func handleNoCache(args []string) ([]string, bool) {
+	for i, arg := range args {
+		if arg == "--no-cache" {
+			return append(args[:i], args[i+1:]...), true
+		}
+	}
+
+	return args, false
+}
+
+// handleOutput removes the '--output' global flag from the args and returns
+// the format given with it. It is only used for commands that do not have
+// their own '--output' flag.
+func handleOutput(args []string) ([]string, string) {
+	for i, arg := range args {
+		if arg == "--output" && i+1 < len(args) {
+			return append(args[:i], args[i+2:]...), args[i+1]
+		}
+		if strings.HasPrefix(arg, "--output=") {
+			return append(args[:i], args[i+1:]...), strings.TrimPrefix(arg, "--output=")
+		}
+	}
+
+	return args, ""
+}
+
+// handleProfile removes the '--profile' global flag from the args and returns
+// the profile name given with it.
+func handleProfile(args []string) ([]string, string) {
+	for i, arg := range args {
+		if arg == "--profile" && i+1 < len(args) {
+			return append(args[:i], args[i+2:]...), args[i+1]
+		}
+		if strings.HasPrefix(arg, "--profile=") {
+			return append(args[:i], args[i+1:]...), strings.TrimPrefix(arg, "--profile=")
+		}
+	}
+
+	return args, ""
+}
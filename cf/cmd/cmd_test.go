@@ -134,6 +134,21 @@ var _ = Describe("main", func() {
 			Eventually(output.Out, 3*time.Second).Should(Say("Did you mean?"))
 		})
 	})
+
+	Describe("the --output flag", func() {
+		It("fails without running a command that does not support it", func() {
+			session := Cf("plugins", "--output", "json")
+			Eventually(session).Should(Exit(1))
+			Expect(session.Out).To(Say("Incorrect Usage. The 'plugins' command does not support the '--output' option."))
+			Expect(session.Out).NotTo(Say("Listing Installed Plugins"))
+		})
+
+		It("runs a command that does not support it when the format is text", func() {
+			session := Cf("plugins", "--output=text")
+			Eventually(session).Should(Exit(0))
+			Expect(session.Out).To(Say("Listing Installed Plugins"))
+		})
+	})
 })
 
 func Cf(args ...string) *Session {
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"strconv"
 	"time"
 
 	"path/filepath"
@@ -19,6 +20,8 @@ import (
 	"code.cloudfoundry.org/cli/cf/configuration/confighelpers"
 	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
 	"code.cloudfoundry.org/cli/cf/configuration/pluginconfig"
+	"code.cloudfoundry.org/cli/cf/formatters"
+	. "code.cloudfoundry.org/cli/cf/i18n"
 	"code.cloudfoundry.org/cli/cf/manifest"
 	"code.cloudfoundry.org/cli/cf/net"
 	"code.cloudfoundry.org/cli/cf/terminal"
@@ -54,6 +57,10 @@ type Dependency struct {
 	Logger             trace.Printer
 }
 
+// NoResponseCache turns off the Cloud Controller response cache for the
+// command being run, as the --no-cache global flag does.
+var NoResponseCache bool
+
 type PluginModels struct {
 	Application   *plugin_models.GetAppModel
 	AppsSummary   *[]plugin_models.GetAppsModel
@@ -98,8 +105,22 @@ func NewDependency(writer io.Writer, logger trace.Printer, envDialTimeout string
 	terminal.UserAskedForColors = deps.Config.ColorEnabled()
 	terminal.InitColorSupport()
 
+	ccGateway := net.NewCloudControllerGateway(deps.Config, time.Now, deps.UI, logger, envDialTimeout)
+	if envUploadRateLimit := os.Getenv("CF_UPLOAD_RATE_LIMIT"); envUploadRateLimit != "" {
+		uploadRateLimit, err := formatters.ToBytes(envUploadRateLimit)
+		if err == nil && uploadRateLimit > 0 {
+			ccGateway.UploadRateLimit = uploadRateLimit
+		} else {
+			deps.UI.Warn(T("Ignoring invalid CF_UPLOAD_RATE_LIMIT '{{.Value}}': it must be a positive size with a unit, e.g. 512K or 2M",
+				map[string]interface{}{"Value": envUploadRateLimit}))
+		}
+	}
+	if responseCacheEnabled() && configPath != "" {
+		ccGateway.ResponseCacheDir = filepath.Join(filepath.Dir(configPath), "cache")
+	}
+
 	deps.Gateways = map[string]net.Gateway{
-		"cloud-controller": net.NewCloudControllerGateway(deps.Config, time.Now, deps.UI, logger, envDialTimeout),
+		"cloud-controller": ccGateway,
 		"uaa":              net.NewUAAGateway(deps.Config, deps.UI, logger, envDialTimeout),
 		"routing-api":      net.NewRoutingAPIGateway(deps.Config, time.Now, deps.UI, logger, envDialTimeout),
 	}
@@ -142,7 +163,9 @@ func NewDependency(writer io.Writer, logger trace.Printer, envDialTimeout string
 	deps.WordGenerator = generator.NewWordGenerator()
 
 	deps.AppZipper = appfiles.ApplicationZipper{}
-	deps.AppFiles = appfiles.ApplicationFiles{}
+	deps.AppFiles = appfiles.ApplicationFiles{
+		FingerprintCache: appfiles.NewFingerprintCache(filepath.Join(filepath.Dir(configPath), "fingerprints.json")),
+	}
 
 	deps.RouteActor = actors.NewRouteActor(deps.UI, deps.RepoLocator.GetRouteRepository(), deps.RepoLocator.GetDomainRepository())
 	deps.PushActor = actors.NewPushActor(deps.RepoLocator.GetApplicationBitsRepository(), deps.AppZipper, deps.AppFiles, deps.RouteActor)
@@ -153,3 +176,14 @@ func NewDependency(writer io.Writer, logger trace.Printer, envDialTimeout string
 
 	return deps
 }
+
+// responseCacheEnabled returns whether Cloud Controller responses are cached
+// on disk, which is turned on with $CF_CACHE.
+func responseCacheEnabled() bool {
+	if NoResponseCache {
+		return false
+	}
+
+	enabled, err := strconv.ParseBool(os.Getenv("CF_CACHE"))
+	return err == nil && enabled
+}
@@ -0,0 +1,261 @@
+package application
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/cli/cf/api/logs"
+	"code.cloudfoundry.org/cli/cf/commandregistry"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/errors"
+	"code.cloudfoundry.org/cli/cf/flags"
+	. "code.cloudfoundry.org/cli/cf/i18n"
+	"code.cloudfoundry.org/cli/cf/models"
+	"code.cloudfoundry.org/cli/cf/requirements"
+	"code.cloudfoundry.org/cli/cf/terminal"
+)
+
+// trafficRefreshInterval is how often app-traffic shows a new summary while
+// watching live traffic.
+const trafficRefreshInterval = 5 * time.Second
+
+const (
+	trafficOutputText = "text"
+	trafficOutputJSON = "json"
+)
+
+type AppTraffic struct {
+	ui       terminal.UI
+	config   coreconfig.Reader
+	logsRepo logs.Repository
+	appReq   requirements.ApplicationRequirement
+}
+
+func init() {
+	commandregistry.Register(&AppTraffic{})
+}
+
+func (cmd *AppTraffic) MetaData() commandregistry.CommandMetadata {
+	fs := make(map[string]flags.FlagSet)
+	fs["recent"] = &flags.BoolFlag{Name: "recent", Usage: T("Summarize recent requests instead of watching live traffic")}
+	fs["window"] = &flags.StringFlag{Name: "window", Value: "1m", Usage: T("How far back the summary looks, such as 30s or 5m (Default: 1m)")}
+	fs["output"] = &flags.StringFlag{Name: "output", Usage: T("Format of the summary: text or json"), Hidden: true}
+
+	return commandregistry.CommandMetadata{
+		Name:        "app-traffic",
+		Description: T("Summarize the HTTP requests to an app from its router logs"),
+		Usage: []string{
+			T("CF_NAME app-traffic APP_NAME [--recent] [--window DURATION] [--output json]"),
+		},
+		Flags: fs,
+	}
+}
+
+func (cmd *AppTraffic) Requirements(requirementsFactory requirements.Factory, fc flags.FlagContext) ([]requirements.Requirement, error) {
+	if len(fc.Args()) != 1 {
+		cmd.ui.Failed(T("Incorrect Usage. Requires an argument\n\n") + commandregistry.Commands.CommandUsage("app-traffic"))
+		return nil, fmt.Errorf("Incorrect usage: %d arguments of %d required", len(fc.Args()), 1)
+	}
+
+	cmd.appReq = requirementsFactory.NewApplicationRequirement(fc.Args()[0])
+
+	reqs := []requirements.Requirement{
+		requirementsFactory.NewLoginRequirement(),
+		requirementsFactory.NewTargetedSpaceRequirement(),
+		cmd.appReq,
+	}
+
+	return reqs, nil
+}
+
+func (cmd *AppTraffic) SetDependency(deps commandregistry.Dependency, pluginCall bool) commandregistry.Command {
+	cmd.ui = deps.UI
+	cmd.config = deps.Config
+	cmd.logsRepo = deps.RepoLocator.GetLogsRepository()
+	return cmd
+}
+
+func (cmd *AppTraffic) Execute(c flags.FlagContext) error {
+	window, err := trafficWindow(c)
+	if err != nil {
+		return err
+	}
+
+	output, err := trafficOutput(c)
+	if err != nil {
+		return err
+	}
+
+	app := cmd.appReq.GetApplication()
+	if c.Bool("recent") {
+		return cmd.summarizeRecentTraffic(app, window, output)
+	}
+	return cmd.watchTraffic(app, window, output)
+}
+
+func trafficWindow(c flags.FlagContext) (time.Duration, error) {
+	value := c.String("window")
+	if value == "" {
+		return time.Minute, nil
+	}
+
+	window, err := time.ParseDuration(value)
+	if err != nil || window <= 0 {
+		return 0, errors.New(T("Incorrect Usage. The '--window' option must be a duration such as 30s or 5m."))
+	}
+	return window, nil
+}
+
+func trafficOutput(c flags.FlagContext) (string, error) {
+	output := strings.ToLower(c.String("output"))
+	switch output {
+	case "", trafficOutputText:
+		return trafficOutputText, nil
+	case trafficOutputJSON:
+		return output, nil
+	}
+	return "", errors.New(T("Incorrect Usage. The '--output' option must be one of text or json."))
+}
+
+func (cmd *AppTraffic) summarizeRecentTraffic(app models.Application, window time.Duration, output string) error {
+	if output == trafficOutputText {
+		cmd.ui.Say(T("Summarizing recent traffic to app {{.AppName}} in org {{.OrgName}} / space {{.SpaceName}} as {{.Username}}...\n",
+			map[string]interface{}{
+				"AppName":   terminal.EntityNameColor(app.Name),
+				"OrgName":   terminal.EntityNameColor(cmd.config.OrganizationFields().Name),
+				"SpaceName": terminal.EntityNameColor(cmd.config.SpaceFields().Name),
+				"Username":  terminal.EntityNameColor(cmd.config.Username())}))
+	}
+
+	messages, err := cmd.logsRepo.RecentLogsFor(app.GUID)
+	if err != nil {
+		return handleLogsError(err)
+	}
+
+	requests := []trafficRequest{}
+	for _, msg := range messages {
+		if request, ok := routerLogRequest(msg); ok {
+			requests = append(requests, request)
+		}
+	}
+
+	return cmd.showTrafficSummary(summarizeTraffic(app.Name, requests, window, time.Time{}, time.Now()), output)
+}
+
+// watchTraffic shows a summary of the requests in the last window every
+// trafficRefreshInterval, until the app's log stream ends.
+func (cmd *AppTraffic) watchTraffic(app models.Application, window time.Duration, output string) error {
+	onConnect := func() {
+		if output == trafficOutputText {
+			cmd.ui.Say(T("Connected, watching traffic to app {{.AppName}} in org {{.OrgName}} / space {{.SpaceName}} as {{.Username}}...\n",
+				map[string]interface{}{
+					"AppName":   terminal.EntityNameColor(app.Name),
+					"OrgName":   terminal.EntityNameColor(cmd.config.OrganizationFields().Name),
+					"SpaceName": terminal.EntityNameColor(cmd.config.SpaceFields().Name),
+					"Username":  terminal.EntityNameColor(cmd.config.Username())}))
+		}
+	}
+
+	c := make(chan logs.Loggable)
+	e := make(chan error)
+
+	started := time.Now()
+	go cmd.logsRepo.TailLogsFor(app.GUID, onConnect, c, e)
+
+	ticker := time.NewTicker(trafficRefreshInterval)
+	defer ticker.Stop()
+
+	requests := []trafficRequest{}
+	for {
+		select {
+		case msg, ok := <-c:
+			if !ok {
+				return cmd.showTrafficSummary(summarizeTraffic(app.Name, requests, window, started, time.Now()), output)
+			}
+			if request, ok := routerLogRequest(msg); ok {
+				requests = append(requests, request)
+			}
+		case <-ticker.C:
+			now := time.Now()
+			requests = requestsInWindow(requests, window, now)
+			err := cmd.showTrafficSummary(summarizeTraffic(app.Name, requests, window, started, now), output)
+			if err != nil {
+				return err
+			}
+		case err := <-e:
+			if err != nil {
+				return handleLogsError(err)
+			}
+			return cmd.showTrafficSummary(summarizeTraffic(app.Name, requests, window, started, time.Now()), output)
+		}
+	}
+}
+
+func (cmd *AppTraffic) showTrafficSummary(summary trafficSummary, output string) error {
+	if output == trafficOutputJSON {
+		document, err := json.Marshal(summary)
+		if err != nil {
+			return err
+		}
+		cmd.ui.Say("%s", string(document))
+		return nil
+	}
+
+	cmd.ui.Say(T("Traffic to app {{.AppName}} in the last {{.Window}} as of {{.Time}}:",
+		map[string]interface{}{
+			"AppName": terminal.EntityNameColor(summary.App),
+			"Window":  summary.Window,
+			"Time":    summary.At.Format("15:04:05")}))
+
+	cmd.ui.Say("%s %d (%s/s)", terminal.HeaderColor(T("requests:")), summary.Requests, strconv.FormatFloat(summary.RequestsPerSecond, 'f', 2, 64))
+	if summary.Requests == 0 {
+		cmd.ui.Say("")
+		return nil
+	}
+
+	codes := make([]string, 0, len(summary.StatusCodes))
+	for code := range summary.StatusCodes {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	statusCodes := make([]string, 0, len(codes))
+	for _, code := range codes {
+		statusCodes = append(statusCodes, fmt.Sprintf("%s: %d", code, summary.StatusCodes[code]))
+	}
+	cmd.ui.Say("%s %s", terminal.HeaderColor(T("status codes:")), strings.Join(statusCodes, ", "))
+
+	if summary.ResponseTimeMS.Samples == 0 {
+		cmd.ui.Say("%s %s", terminal.HeaderColor(T("response time:")), T("not reported"))
+	} else {
+		cmd.ui.Say("%s p50 %sms, p95 %sms, p99 %sms (%s)", terminal.HeaderColor(T("response time:")),
+			strconv.FormatFloat(summary.ResponseTimeMS.P50, 'f', 1, 64),
+			strconv.FormatFloat(summary.ResponseTimeMS.P95, 'f', 1, 64),
+			strconv.FormatFloat(summary.ResponseTimeMS.P99, 'f', 1, 64),
+			T("samples: {{.Count}}", map[string]interface{}{"Count": summary.ResponseTimeMS.Samples}))
+	}
+	cmd.ui.Say("")
+
+	for _, top := range []struct {
+		header string
+		counts []trafficCount
+	}{
+		{header: T("path"), counts: summary.TopPaths},
+		{header: T("client"), counts: summary.TopClients},
+	} {
+		table := cmd.ui.Table([]string{top.header, T("requests")})
+		for _, count := range top.counts {
+			table.Add(count.Name, strconv.Itoa(count.Requests))
+		}
+		err := table.Print()
+		if err != nil {
+			return err
+		}
+		cmd.ui.Say("")
+	}
+
+	return nil
+}
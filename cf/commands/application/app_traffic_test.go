@@ -0,0 +1,222 @@
+package application_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"code.cloudfoundry.org/cli/cf/api/logs"
+	"code.cloudfoundry.org/cli/cf/api/logs/logsfakes"
+	"code.cloudfoundry.org/cli/cf/commandregistry"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/errors"
+	"code.cloudfoundry.org/cli/cf/models"
+	"code.cloudfoundry.org/cli/cf/requirements"
+	"code.cloudfoundry.org/cli/cf/requirements/requirementsfakes"
+	testcmd "code.cloudfoundry.org/cli/util/testhelpers/commands"
+	testconfig "code.cloudfoundry.org/cli/util/testhelpers/configuration"
+	testlogs "code.cloudfoundry.org/cli/util/testhelpers/logs"
+	testterm "code.cloudfoundry.org/cli/util/testhelpers/terminal"
+	"github.com/cloudfoundry/loggregatorlib/logmessage"
+
+	. "code.cloudfoundry.org/cli/util/testhelpers/matchers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func routerLogMessage(path string, statusCode int, responseTime string, client string, timestamp time.Time) logs.Loggable {
+	message := fmt.Sprintf(`my-app.example.com - [2017-03-04T10:00:00.123+0000] "GET %s HTTP/1.1" %d 0 512 "-" "curl/7.43.0" "10.0.0.1:54321" "10.0.0.2:61000" x_forwarded_for:"%s" response_time:%s app_id:"my-app-guid" app_index:"0"`,
+		path, statusCode, client, responseTime)
+	return testlogs.NewLogMessage(message, "my-app-guid", "RTR", "0", logmessage.LogMessage_OUT, timestamp)
+}
+
+var _ = Describe("app-traffic command", func() {
+	var (
+		ui                  *testterm.FakeUI
+		logsRepo            *logsfakes.FakeRepository
+		requirementsFactory *requirementsfakes.FakeFactory
+		configRepo          coreconfig.Repository
+		deps                commandregistry.Dependency
+	)
+
+	updateCommandDependency := func(pluginCall bool) {
+		deps.UI = ui
+		deps.RepoLocator = deps.RepoLocator.SetLogsRepository(logsRepo)
+		deps.Config = configRepo
+		commandregistry.Commands.SetCommand(commandregistry.Commands.FindCommand("app-traffic").SetDependency(deps, pluginCall))
+	}
+
+	BeforeEach(func() {
+		ui = &testterm.FakeUI{}
+		configRepo = testconfig.NewRepositoryWithDefaults()
+		logsRepo = new(logsfakes.FakeRepository)
+		requirementsFactory = new(requirementsfakes.FakeFactory)
+	})
+
+	runCommand := func(args ...string) bool {
+		return testcmd.RunCLICommand("app-traffic", args, requirementsFactory, updateCommandDependency, false, ui)
+	}
+
+	Describe("requirements", func() {
+		It("fails with usage when called without an app name", func() {
+			requirementsFactory.NewLoginRequirementReturns(requirements.Passing{})
+
+			runCommand()
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"Incorrect Usage", "Requires an argument"},
+			))
+		})
+
+		It("fails requirements when not logged in", func() {
+			requirementsFactory.NewLoginRequirementReturns(requirements.Failing{})
+
+			Expect(runCommand("my-app")).To(BeFalse())
+		})
+	})
+
+	Context("when logged in", func() {
+		var appLogs []logs.Loggable
+
+		BeforeEach(func() {
+			requirementsFactory.NewLoginRequirementReturns(requirements.Passing{})
+			requirementsFactory.NewTargetedSpaceRequirementReturns(requirements.Passing{})
+
+			app := models.Application{}
+			app.Name = "my-app"
+			app.GUID = "my-app-guid"
+			applicationReq := new(requirementsfakes.FakeApplicationRequirement)
+			applicationReq.GetApplicationReturns(app)
+			requirementsFactory.NewApplicationRequirementReturns(applicationReq)
+
+			now := time.Now()
+			appLogs = []logs.Loggable{
+				routerLogMessage("/", 200, "0.010", "203.0.113.1", now.Add(-50*time.Second)),
+				routerLogMessage("/?page=2", 200, "0.020", "203.0.113.1", now.Add(-40*time.Second)),
+				routerLogMessage("/api", 500, "0.300", "203.0.113.2", now.Add(-30*time.Second)),
+				routerLogMessage("/missing", 404, "0.005", "203.0.113.1", now.Add(-20*time.Second)),
+				routerLogMessage("/old", 200, "0.001", "203.0.113.3", now.Add(-2*time.Hour)),
+				testlogs.NewLogMessage("Hello from the app", "my-app-guid", "APP/PROC/WEB", "0", logmessage.LogMessage_OUT, now.Add(-10*time.Second)),
+			}
+			logsRepo.RecentLogsForReturns(appLogs, nil)
+			logsRepo.TailLogsForStub = func(appGUID string, onConnect func(), logChan chan<- logs.Loggable, errChan chan<- error) {
+				onConnect()
+				for _, log := range appLogs {
+					logChan <- log
+				}
+				close(logChan)
+				close(errChan)
+			}
+		})
+
+		It("summarizes the recent requests in the window", func() {
+			Expect(runCommand("--recent", "my-app")).To(BeTrue())
+
+			Expect(logsRepo.RecentLogsForArgsForCall(0)).To(Equal("my-app-guid"))
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"Summarizing recent traffic to app", "my-app", "my-org", "my-space", "my-user"},
+				[]string{"Traffic to app my-app in the last 1m0s"},
+				[]string{"requests:", "4 (0.07/s)"},
+				[]string{"status codes:", "200: 2, 404: 1, 500: 1"},
+				[]string{"response time:", "p50 10.0ms, p95 300.0ms, p99 300.0ms (samples: 4)"},
+				[]string{"path", "requests"},
+				[]string{"/", "2"},
+				[]string{"client", "requests"},
+				[]string{"203.0.113.1", "3"},
+				[]string{"203.0.113.2", "1"},
+			))
+			Expect(ui.Outputs()).NotTo(ContainSubstrings([]string{"/old"}))
+			Expect(ui.Outputs()).NotTo(ContainSubstrings([]string{"203.0.113.3"}))
+		})
+
+		It("only summarizes the requests in the given window", func() {
+			Expect(runCommand("--recent", "--window", "35s", "my-app")).To(BeTrue())
+
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"Traffic to app my-app in the last 35s"},
+				[]string{"requests:", "2"},
+				[]string{"status codes:", "404: 1, 500: 1"},
+			))
+		})
+
+		It("leaves requests without a response time out of the percentiles", func() {
+			now := time.Now()
+			logsRepo.RecentLogsForReturns([]logs.Loggable{
+				routerLogMessage("/", 200, "0.100", "203.0.113.1", now.Add(-30*time.Second)),
+				routerLogMessage("/", 200, "-", "203.0.113.1", now.Add(-20*time.Second)),
+				routerLogMessage("/", 200, "-", "203.0.113.1", now.Add(-10*time.Second)),
+			}, nil)
+
+			Expect(runCommand("--recent", "my-app")).To(BeTrue())
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"requests:", "3"},
+				[]string{"response time:", "p50 100.0ms, p95 100.0ms, p99 100.0ms (samples: 1)"},
+			))
+		})
+
+		It("says when no request has a response time", func() {
+			logsRepo.RecentLogsForReturns([]logs.Loggable{
+				routerLogMessage("/", 200, "-", "203.0.113.1", time.Now().Add(-10*time.Second)),
+			}, nil)
+
+			Expect(runCommand("--recent", "my-app")).To(BeTrue())
+			Expect(ui.Outputs()).To(ContainSubstrings([]string{"response time:", "not reported"}))
+		})
+
+		It("fails when the window is not a duration", func() {
+			Expect(runCommand("--recent", "--window", "forever", "my-app")).To(BeFalse())
+			Expect(ui.Outputs()).To(ContainSubstrings([]string{"The '--window' option must be a duration"}))
+		})
+
+		It("shows the summary as JSON with --output json", func() {
+			Expect(runCommand("--recent", "--output", "json", "my-app")).To(BeTrue())
+
+			Expect(ui.Outputs()).To(HaveLen(1))
+
+			var summary map[string]interface{}
+			Expect(json.Unmarshal([]byte(ui.Outputs()[0]), &summary)).To(Succeed())
+			Expect(summary["app"]).To(Equal("my-app"))
+			Expect(summary["window"]).To(Equal("1m0s"))
+			Expect(summary["requests"]).To(BeEquivalentTo(4))
+			Expect(summary["status_codes"]).To(Equal(map[string]interface{}{"200": 2.0, "404": 1.0, "500": 1.0}))
+			Expect(summary["response_time_ms"]).To(Equal(map[string]interface{}{"samples": 4.0, "p50": 10.0, "p95": 300.0, "p99": 300.0}))
+			Expect(summary["top_paths"]).To(ContainElement(map[string]interface{}{"name": "/", "requests": 2.0}))
+		})
+
+		It("fails when the output format is not supported", func() {
+			Expect(runCommand("--recent", "--output", "xml", "my-app")).To(BeFalse())
+			Expect(ui.Outputs()).To(ContainSubstrings([]string{"The '--output' option must be one of text or json"}))
+		})
+
+		It("summarizes live traffic until the log stream ends", func() {
+			Expect(runCommand("my-app")).To(BeTrue())
+
+			appGUID, _, _, _ := logsRepo.TailLogsForArgsForCall(0)
+			Expect(appGUID).To(Equal("my-app-guid"))
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"Connected, watching traffic to app", "my-app"},
+				[]string{"requests:", "4"},
+			))
+		})
+
+		It("divides live traffic by the time observed so far rather than the whole window", func() {
+			Expect(runCommand("--output", "json", "my-app")).To(BeTrue())
+
+			var summary map[string]interface{}
+			Expect(json.Unmarshal([]byte(ui.Outputs()[0]), &summary)).To(Succeed())
+			Expect(summary["requests"]).To(BeEquivalentTo(4))
+			Expect(summary["requests_per_second"]).To(BeNumerically("~", 4.0/50, 0.001))
+		})
+
+		It("reports the error when the log stream fails", func() {
+			logsRepo.TailLogsForStub = func(appGUID string, onConnect func(), logChan chan<- logs.Loggable, errChan chan<- error) {
+				errChan <- errors.NewInvalidSSLCert("https://example.com", "it don't work good")
+			}
+
+			Expect(runCommand("my-app")).To(BeFalse())
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"Received invalid SSL certificate", "https://example.com"},
+				[]string{"TIP"},
+			))
+		})
+	})
+})
@@ -2,8 +2,11 @@ package application
 
 import (
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 
+	"code.cloudfoundry.org/cli/cf/api"
 	"code.cloudfoundry.org/cli/cf/api/logs"
 	"code.cloudfoundry.org/cli/cf/commandregistry"
 	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
@@ -15,11 +18,19 @@ import (
 	"code.cloudfoundry.org/cli/cf/terminal"
 )
 
+const (
+	logOutputText = "text"
+	logOutputJSON = "json"
+)
+
 type Logs struct {
-	ui       terminal.UI
-	logsRepo logs.Repository
-	config   coreconfig.Reader
-	appReq   requirements.ApplicationRequirement
+	ui             terminal.UI
+	logsRepo       logs.Repository
+	appSummaryRepo api.AppSummaryRepository
+	config         coreconfig.Reader
+	appReqs        []requirements.ApplicationRequirement
+	filter         logFilter
+	printer        logPrinter
 }
 
 func init() {
@@ -29,29 +40,54 @@ func init() {
 func (cmd *Logs) MetaData() commandregistry.CommandMetadata {
 	fs := make(map[string]flags.FlagSet)
 	fs["recent"] = &flags.BoolFlag{Name: "recent", Usage: T("Dump recent logs instead of tailing")}
+	fs["space"] = &flags.BoolFlag{Name: "space", Usage: T("Show the logs of all apps in the targeted space")}
+	fs["source"] = &flags.StringSliceFlag{Name: "source", Usage: T("Only show logs from this source type, such as APP, RTR, STG, CELL or API (can be given more than once)")}
+	fs["instance"] = &flags.StringFlag{Name: "instance", Usage: T("Only show logs from the app instance with this index")}
+	fs["stdout"] = &flags.BoolFlag{Name: "stdout", Usage: T("Only show logs written to stdout")}
+	fs["stderr"] = &flags.BoolFlag{Name: "stderr", Usage: T("Only show logs written to stderr")}
+	fs["match"] = &flags.StringFlag{Name: "match", Usage: T("Only show logs whose message matches this regular expression")}
+	fs["since"] = &flags.StringFlag{Name: "since", Usage: T("Only show recent logs written after this time, given as RFC 3339 or as a duration ago such as 30m")}
+	fs["until"] = &flags.StringFlag{Name: "until", Usage: T("Only show recent logs written before this time, given as RFC 3339 or as a duration ago such as 30m")}
+	fs["output"] = &flags.StringFlag{Name: "output", Usage: T("Format of the logs: text, or json to show each log as a line of JSON with timestamp, app, source, instance, type and message fields")}
 
 	return commandregistry.CommandMetadata{
 		Name:        "logs",
 		Description: T("Tail or show recent logs for an app"),
 		Usage: []string{
-			T("CF_NAME logs APP_NAME"),
+			T("CF_NAME logs APP_NAME [APP_NAME...] [--recent] [--since TIME] [--until TIME] [--source SOURCE] [--instance INDEX] [--stdout | --stderr] [--match REGEX] [--output FORMAT]"),
+			"\n   ",
+			T("CF_NAME logs --space [--recent] ..."),
 		},
 		Flags: fs,
 	}
 }
 
 func (cmd *Logs) Requirements(requirementsFactory requirements.Factory, fc flags.FlagContext) ([]requirements.Requirement, error) {
-	if len(fc.Args()) != 1 {
+	if fc.Bool("space") {
+		if len(fc.Args()) != 0 {
+			cmd.ui.Failed(T("Incorrect Usage. APP_NAME cannot be given with --space\n\n") + commandregistry.Commands.CommandUsage("logs"))
+			return nil, fmt.Errorf("Incorrect usage: %d arguments of %d required", len(fc.Args()), 0)
+		}
+	} else if len(fc.Args()) == 0 {
 		cmd.ui.Failed(T("Incorrect Usage. Requires an argument\n\n") + commandregistry.Commands.CommandUsage("logs"))
 		return nil, fmt.Errorf("Incorrect usage: %d arguments of %d required", len(fc.Args()), 1)
 	}
 
-	cmd.appReq = requirementsFactory.NewApplicationRequirement(fc.Args()[0])
+	if (fc.IsSet("since") || fc.IsSet("until")) && !fc.Bool("recent") {
+		cmd.ui.Failed(T("Incorrect Usage. --since and --until can only be used with --recent\n\n") + commandregistry.Commands.CommandUsage("logs"))
+		return nil, fmt.Errorf("Incorrect usage: --since and --until require --recent")
+	}
 
 	reqs := []requirements.Requirement{
 		requirementsFactory.NewLoginRequirement(),
 		requirementsFactory.NewTargetedSpaceRequirement(),
-		cmd.appReq,
+	}
+
+	cmd.appReqs = nil
+	for _, appName := range fc.Args() {
+		appReq := requirementsFactory.NewApplicationRequirement(appName)
+		cmd.appReqs = append(cmd.appReqs, appReq)
+		reqs = append(reqs, appReq)
 	}
 
 	return reqs, nil
@@ -61,26 +97,87 @@ func (cmd *Logs) SetDependency(deps commandregistry.Dependency, pluginCall bool)
 	cmd.ui = deps.UI
 	cmd.config = deps.Config
 	cmd.logsRepo = deps.RepoLocator.GetLogsRepository()
+	cmd.appSummaryRepo = deps.RepoLocator.GetAppSummaryRepository()
 	return cmd
 }
 
 func (cmd *Logs) Execute(c flags.FlagContext) error {
-	app := cmd.appReq.GetApplication()
+	filter, err := newLogFilter(c, time.Now())
+	if err != nil {
+		return err
+	}
+	cmd.filter = filter
 
-	var err error
-	if c.Bool("recent") {
-		err = cmd.recentLogsFor(app)
-	} else {
-		err = cmd.tailLogsFor(app)
+	output, err := logOutput(c)
+	if err != nil {
+		return err
 	}
+
+	if !c.Bool("space") && len(cmd.appReqs) == 1 {
+		app := cmd.appReqs[0].GetApplication()
+		cmd.printer = newLogPrinter(cmd.ui, nil, output == logOutputJSON)
+
+		var err error
+		if c.Bool("recent") {
+			err = cmd.recentLogsFor(app)
+		} else {
+			err = cmd.tailLogsFor(app)
+		}
+		if err != nil {
+			return err
+		}
+		return nil
+	}
+
+	apps, err := cmd.appsToShow(c)
 	if err != nil {
 		return err
 	}
-	return nil
+	cmd.printer = newLogPrinter(cmd.ui, apps, output == logOutputJSON)
+
+	if c.Bool("recent") {
+		return cmd.recentLogsForApps(apps)
+	}
+	return cmd.tailLogsForApps(apps)
+}
+
+// appsToShow returns the apps named on the command line, or every app in the
+// targeted space when --space is given.
+func logOutput(c flags.FlagContext) (string, error) {
+	output := strings.ToLower(c.String("output"))
+	switch output {
+	case "", logOutputText:
+		return logOutputText, nil
+	case logOutputJSON:
+		return output, nil
+	}
+	return "", errors.New(T("Incorrect Usage. The '--output' option must be one of text or json."))
+}
+
+func (cmd *Logs) appsToShow(c flags.FlagContext) ([]models.Application, error) {
+	if !c.Bool("space") {
+		apps := make([]models.Application, 0, len(cmd.appReqs))
+		for _, appReq := range cmd.appReqs {
+			apps = append(apps, appReq.GetApplication())
+		}
+		return apps, nil
+	}
+
+	apps, err := cmd.appSummaryRepo.GetSummariesInCurrentSpace()
+	if err != nil {
+		return nil, err
+	}
+	if len(apps) == 0 {
+		return nil, errors.New(T("No apps found in space {{.SpaceName}}",
+			map[string]interface{}{"SpaceName": cmd.config.SpaceFields().Name}))
+	}
+
+	sort.Sort(appsByName(apps))
+	return apps, nil
 }
 
 func (cmd *Logs) recentLogsFor(app models.Application) error {
-	cmd.ui.Say(T("Connected, dumping recent logs for app {{.AppName}} in org {{.OrgName}} / space {{.SpaceName}} as {{.Username}}...\n",
+	cmd.printer.sayStatus(T("Connected, dumping recent logs for app {{.AppName}} in org {{.OrgName}} / space {{.SpaceName}} as {{.Username}}...\n",
 		map[string]interface{}{
 			"AppName":   terminal.EntityNameColor(app.Name),
 			"OrgName":   terminal.EntityNameColor(cmd.config.OrganizationFields().Name),
@@ -89,18 +186,20 @@ func (cmd *Logs) recentLogsFor(app models.Application) error {
 
 	messages, err := cmd.logsRepo.RecentLogsFor(app.GUID)
 	if err != nil {
-		return cmd.handleError(err)
+		return handleLogsError(err)
 	}
 
 	for _, msg := range messages {
-		cmd.ui.Say("%s", msg.ToLog(time.Local))
+		if cmd.filter.matches(msg) {
+			cmd.printer.print(app, msg)
+		}
 	}
 	return nil
 }
 
 func (cmd *Logs) tailLogsFor(app models.Application) error {
 	onConnect := func() {
-		cmd.ui.Say(T("Connected, tailing logs for app {{.AppName}} in org {{.OrgName}} / space {{.SpaceName}} as {{.Username}}...\n",
+		cmd.printer.sayStatus(T("Connected, tailing logs for app {{.AppName}} in org {{.OrgName}} / space {{.SpaceName}} as {{.Username}}...\n",
 			map[string]interface{}{
 				"AppName":   terminal.EntityNameColor(app.Name),
 				"OrgName":   terminal.EntityNameColor(cmd.config.OrganizationFields().Name),
@@ -119,14 +218,18 @@ func (cmd *Logs) tailLogsFor(app models.Application) error {
 			if !ok {
 				return nil
 			}
-			cmd.ui.Say("%s", msg.ToLog(time.Local))
+			if cmd.filter.matches(msg) {
+				cmd.printer.print(app, msg)
+			}
 		case err := <-e:
-			return cmd.handleError(err)
+			return handleLogsError(err)
 		}
 	}
 }
 
-func (cmd *Logs) handleError(err error) error {
+// handleLogsError adds a tip about --skip-ssl-validation to certificate errors
+// from the logging endpoint.
+func handleLogsError(err error) error {
 	switch err.(type) {
 	case nil:
 	case *errors.InvalidSSLCert:
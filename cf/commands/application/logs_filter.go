@@ -0,0 +1,117 @@
+package application
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/cli/cf/api/logs"
+	"code.cloudfoundry.org/cli/cf/errors"
+	"code.cloudfoundry.org/cli/cf/flags"
+	. "code.cloudfoundry.org/cli/cf/i18n"
+)
+
+// logFilter selects the log messages that cf logs shows. Its zero value
+// selects every message.
+type logFilter struct {
+	sources     []string
+	instance    string
+	messageType string
+	pattern     *regexp.Regexp
+	since       time.Time
+	until       time.Time
+}
+
+func newLogFilter(c flags.FlagContext, now time.Time) (logFilter, error) {
+	filter := logFilter{instance: c.String("instance")}
+
+	for _, value := range c.StringSlice("source") {
+		for _, source := range strings.Split(value, ",") {
+			source = strings.ToUpper(strings.TrimSpace(source))
+			if source != "" {
+				filter.sources = append(filter.sources, source)
+			}
+		}
+	}
+
+	if c.Bool("stdout") != c.Bool("stderr") {
+		if c.Bool("stderr") {
+			filter.messageType = "ERR"
+		} else {
+			filter.messageType = "OUT"
+		}
+	}
+
+	if c.IsSet("match") {
+		pattern, err := regexp.Compile(c.String("match"))
+		if err != nil {
+			return logFilter{}, errors.New(T("Invalid --match pattern {{.Pattern}}: {{.Error}}",
+				map[string]interface{}{"Pattern": c.String("match"), "Error": err.Error()}))
+		}
+		filter.pattern = pattern
+	}
+
+	var err error
+	if c.IsSet("since") {
+		filter.since, err = parseLogTime("since", c.String("since"), now)
+		if err != nil {
+			return logFilter{}, err
+		}
+	}
+	if c.IsSet("until") {
+		filter.until, err = parseLogTime("until", c.String("until"), now)
+		if err != nil {
+			return logFilter{}, err
+		}
+	}
+
+	return filter, nil
+}
+
+// parseLogTime accepts either a time in RFC 3339 format or a duration, which
+// is taken to mean that long before now.
+func parseLogTime(flagName string, value string, now time.Time) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	if d, err := time.ParseDuration(value); err == nil && d >= 0 {
+		return now.Add(-d), nil
+	}
+
+	return time.Time{}, errors.New(T("Invalid --{{.FlagName}} value {{.Value}}: use a time such as 2017-01-02T15:04:05Z or a duration such as 1h30m",
+		map[string]interface{}{"FlagName": flagName, "Value": value}))
+}
+
+func (f logFilter) matches(log logs.Loggable) bool {
+	if len(f.sources) > 0 && !f.matchesSource(log.GetSourceName()) {
+		return false
+	}
+	if f.instance != "" && log.GetSourceInstance() != f.instance {
+		return false
+	}
+	if f.messageType != "" && log.GetMessageType() != f.messageType {
+		return false
+	}
+	if f.pattern != nil && !f.pattern.MatchString(log.ToSimpleLog()) {
+		return false
+	}
+	if !f.since.IsZero() && log.GetTimestamp().Before(f.since) {
+		return false
+	}
+	if !f.until.IsZero() && log.GetTimestamp().After(f.until) {
+		return false
+	}
+	return true
+}
+
+// matchesSource reports whether the source is one of the filter's sources,
+// or is below one of them, as APP/PROC/WEB is below APP.
+func (f logFilter) matchesSource(source string) bool {
+	source = strings.ToUpper(source)
+	for _, s := range f.sources {
+		if source == s || strings.HasPrefix(source, s+"/") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,195 @@
+package application
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/cli/cf/api/logs"
+	. "code.cloudfoundry.org/cli/cf/i18n"
+	"code.cloudfoundry.org/cli/cf/models"
+	"code.cloudfoundry.org/cli/cf/terminal"
+)
+
+// multiAppLogFlushInterval is how long logs from several apps are held before
+// being shown, so that they can be shown in the order they were written
+// rather than the order their streams delivered them in.
+const multiAppLogFlushInterval = 250 * time.Millisecond
+
+type appLog struct {
+	app models.Application
+	log logs.Loggable
+}
+
+type appLogsByTime []appLog
+
+func (l appLogsByTime) Len() int      { return len(l) }
+func (l appLogsByTime) Swap(i, j int) { l[i], l[j] = l[j], l[i] }
+func (l appLogsByTime) Less(i, j int) bool {
+	return l[i].log.GetTimestamp().Before(l[j].log.GetTimestamp())
+}
+
+type appsByName []models.Application
+
+func (a appsByName) Len() int           { return len(a) }
+func (a appsByName) Swap(i, j int)      { a[i], a[j] = a[j], a[i] }
+func (a appsByName) Less(i, j int) bool { return a[i].Name < a[j].Name }
+
+type appLogStreamResult struct {
+	appName string
+	err     error
+}
+
+func (cmd *Logs) appNames(apps []models.Application) string {
+	names := make([]string, 0, len(apps))
+	for _, app := range apps {
+		names = append(names, terminal.EntityNameColor(app.Name))
+	}
+	return strings.Join(names, ", ")
+}
+
+func (cmd *Logs) recentLogsForApps(apps []models.Application) error {
+	cmd.printer.sayStatus(T("Connected, dumping recent logs for apps {{.AppNames}} in org {{.OrgName}} / space {{.SpaceName}} as {{.Username}}...\n",
+		map[string]interface{}{
+			"AppNames":  cmd.appNames(apps),
+			"OrgName":   terminal.EntityNameColor(cmd.config.OrganizationFields().Name),
+			"SpaceName": terminal.EntityNameColor(cmd.config.SpaceFields().Name),
+			"Username":  terminal.EntityNameColor(cmd.config.Username())}))
+
+	var (
+		appLogs  []appLog
+		firstErr error
+		failures int
+	)
+	for _, app := range apps {
+		messages, err := cmd.logsRepo.RecentLogsFor(app.GUID)
+		if err != nil {
+			err = handleLogsError(err)
+			cmd.ui.Warn(T("Failed to get logs for app {{.AppName}}: {{.Error}}",
+				map[string]interface{}{"AppName": app.Name, "Error": err.Error()}))
+			if firstErr == nil {
+				firstErr = err
+			}
+			failures++
+			continue
+		}
+
+		for _, msg := range messages {
+			if cmd.filter.matches(msg) {
+				appLogs = append(appLogs, appLog{app: app, log: msg})
+			}
+		}
+	}
+
+	sort.Stable(appLogsByTime(appLogs))
+	for _, l := range appLogs {
+		cmd.printer.print(l.app, l.log)
+	}
+
+	if failures == len(apps) {
+		return firstErr
+	}
+	return nil
+}
+
+// tailLogsForApps tails the logs of every app at once, showing them as a
+// single stream. An error in one app's stream is shown as a warning and ends
+// only that stream; an error is returned only if every stream failed.
+func (cmd *Logs) tailLogsForApps(apps []models.Application) error {
+	var connected sync.Once
+	onConnect := func() {
+		connected.Do(func() {
+			cmd.printer.sayStatus(T("Connected, tailing logs for apps {{.AppNames}} in org {{.OrgName}} / space {{.SpaceName}} as {{.Username}}...\n",
+				map[string]interface{}{
+					"AppNames":  cmd.appNames(apps),
+					"OrgName":   terminal.EntityNameColor(cmd.config.OrganizationFields().Name),
+					"SpaceName": terminal.EntityNameColor(cmd.config.SpaceFields().Name),
+					"Username":  terminal.EntityNameColor(cmd.config.Username())}))
+		})
+	}
+
+	var (
+		queueLock sync.Mutex
+		queue     []appLog
+	)
+	results := make(chan appLogStreamResult, len(apps))
+
+	for _, app := range apps {
+		c := make(chan logs.Loggable)
+		e := make(chan error)
+
+		go cmd.logsRepo.TailLogsFor(app.GUID, onConnect, c, e)
+
+		go func(app models.Application) {
+			for {
+				select {
+				case msg, ok := <-c:
+					if !ok {
+						results <- appLogStreamResult{appName: app.Name}
+						return
+					}
+					if !cmd.filter.matches(msg) {
+						continue
+					}
+					queueLock.Lock()
+					queue = append(queue, appLog{app: app, log: msg})
+					queueLock.Unlock()
+				case err := <-e:
+					if err != nil {
+						// keep draining the stream so that it can shut down
+						go func() {
+							for range c {
+							}
+						}()
+					}
+					results <- appLogStreamResult{appName: app.Name, err: err}
+					return
+				}
+			}
+		}(app)
+	}
+
+	flush := func() {
+		queueLock.Lock()
+		appLogs := queue
+		queue = nil
+		queueLock.Unlock()
+
+		sort.Stable(appLogsByTime(appLogs))
+		for _, l := range appLogs {
+			cmd.printer.print(l.app, l.log)
+		}
+	}
+
+	ticker := time.NewTicker(multiAppLogFlushInterval)
+	defer ticker.Stop()
+
+	var (
+		firstErr error
+		failures int
+	)
+	for remaining := len(apps); remaining > 0; {
+		select {
+		case <-ticker.C:
+			flush()
+		case result := <-results:
+			remaining--
+			if err := handleLogsError(result.err); err != nil {
+				flush()
+				cmd.ui.Warn(T("Stopped tailing logs for app {{.AppName}}: {{.Error}}",
+					map[string]interface{}{"AppName": result.appName, "Error": err.Error()}))
+				if firstErr == nil {
+					firstErr = err
+				}
+				failures++
+			}
+		}
+	}
+	flush()
+
+	if failures == len(apps) {
+		return firstErr
+	}
+	return nil
+}
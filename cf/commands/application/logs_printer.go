@@ -0,0 +1,92 @@
+package application
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/cli/cf/api/logs"
+	"code.cloudfoundry.org/cli/cf/models"
+	"code.cloudfoundry.org/cli/cf/terminal"
+)
+
+// logPrinter shows log messages either as decorated text or, for piping into
+// other tools, as one JSON object per line. When showing the logs of several
+// apps as text, each line is prefixed with the name of its app, padded so
+// that the logs of all apps line up.
+type logPrinter struct {
+	ui       terminal.UI
+	json     bool
+	prefixes map[string]string
+	indent   string
+}
+
+type jsonLog struct {
+	Timestamp string `json:"timestamp"`
+	App       string `json:"app"`
+	Source    string `json:"source"`
+	Instance  string `json:"instance"`
+	Type      string `json:"type"`
+	Message   string `json:"message"`
+}
+
+func newLogPrinter(ui terminal.UI, apps []models.Application, asJSON bool) logPrinter {
+	printer := logPrinter{ui: ui, json: asJSON}
+	if len(apps) == 0 {
+		return printer
+	}
+
+	width := 0
+	for _, app := range apps {
+		if len(app.Name) > width {
+			width = len(app.Name)
+		}
+	}
+
+	printer.prefixes = map[string]string{}
+	printer.indent = strings.Repeat(" ", width+3)
+	for i, app := range apps {
+		label := "[" + app.Name + "]"
+		printer.prefixes[app.GUID] = terminal.LogAppNameColor(label, i) + strings.Repeat(" ", width+3-len(label))
+	}
+	return printer
+}
+
+// sayStatus shows a message about the logs, which is left out of JSON output
+// so that every line of it can be parsed.
+func (p logPrinter) sayStatus(message string) {
+	if !p.json {
+		p.ui.Say(message)
+	}
+}
+
+func (p logPrinter) print(app models.Application, log logs.Loggable) {
+	if p.json {
+		p.ui.Say("%s", jsonLogLine(app, log))
+		return
+	}
+
+	text := log.ToLog(time.Local)
+	if prefix, ok := p.prefixes[app.GUID]; ok {
+		text = prefix + strings.Replace(text, "\n", "\n"+p.indent, -1)
+	}
+	p.ui.Say("%s", text)
+}
+
+func jsonLogLine(app models.Application, log logs.Loggable) string {
+	buffer := &bytes.Buffer{}
+	encoder := json.NewEncoder(buffer)
+	encoder.SetEscapeHTML(false)
+
+	_ = encoder.Encode(jsonLog{
+		Timestamp: log.GetTimestamp().UTC().Format(time.RFC3339Nano),
+		App:       app.Name,
+		Source:    log.GetSourceName(),
+		Instance:  log.GetSourceInstance(),
+		Type:      log.GetMessageType(),
+		Message:   log.ToSimpleLog(),
+	})
+
+	return strings.TrimSuffix(buffer.String(), "\n")
+}
@@ -1,8 +1,10 @@
 package application_test
 
 import (
+	"strings"
 	"time"
 
+	"code.cloudfoundry.org/cli/cf/api/apifakes"
 	"code.cloudfoundry.org/cli/cf/api/logs"
 	"code.cloudfoundry.org/cli/cf/api/logs/logsfakes"
 	"code.cloudfoundry.org/cli/cf/commandregistry"
@@ -26,6 +28,7 @@ var _ = Describe("logs command", func() {
 	var (
 		ui                  *testterm.FakeUI
 		logsRepo            *logsfakes.FakeRepository
+		appSummaryRepo      *apifakes.FakeAppSummaryRepository
 		requirementsFactory *requirementsfakes.FakeFactory
 		configRepo          coreconfig.Repository
 		deps                commandregistry.Dependency
@@ -34,6 +37,7 @@ var _ = Describe("logs command", func() {
 	updateCommandDependency := func(pluginCall bool) {
 		deps.UI = ui
 		deps.RepoLocator = deps.RepoLocator.SetLogsRepository(logsRepo)
+		deps.RepoLocator = deps.RepoLocator.SetAppSummaryRepository(appSummaryRepo)
 		deps.Config = configRepo
 		commandregistry.Commands.SetCommand(commandregistry.Commands.FindCommand("logs").SetDependency(deps, pluginCall))
 	}
@@ -42,6 +46,7 @@ var _ = Describe("logs command", func() {
 		ui = &testterm.FakeUI{}
 		configRepo = testconfig.NewRepositoryWithDefaults()
 		logsRepo = new(logsfakes.FakeRepository)
+		appSummaryRepo = new(apifakes.FakeAppSummaryRepository)
 		requirementsFactory = new(requirementsfakes.FakeFactory)
 	})
 
@@ -71,6 +76,36 @@ var _ = Describe("logs command", func() {
 			Expect(runCommand("--recent", "my-app")).To(BeFalse())
 		})
 
+		It("fails with usage when app names are given with --space", func() {
+			requirementsFactory.NewLoginRequirementReturns(requirements.Passing{})
+
+			Expect(runCommand("--space", "my-app")).To(BeFalse())
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"Incorrect Usage", "APP_NAME cannot be given with --space"},
+			))
+		})
+
+		It("requires an app requirement for each app name", func() {
+			requirementsFactory.NewLoginRequirementReturns(requirements.Passing{})
+			requirementsFactory.NewTargetedSpaceRequirementReturns(requirements.Passing{})
+			applicationReq := new(requirementsfakes.FakeApplicationRequirement)
+			applicationReq.ExecuteReturns(errors.New("app not found"))
+			requirementsFactory.NewApplicationRequirementReturns(applicationReq)
+
+			Expect(runCommand("app-1", "app-2")).To(BeFalse())
+			Expect(requirementsFactory.NewApplicationRequirementCallCount()).To(Equal(2))
+			Expect(requirementsFactory.NewApplicationRequirementArgsForCall(0)).To(Equal("app-1"))
+			Expect(requirementsFactory.NewApplicationRequirementArgsForCall(1)).To(Equal("app-2"))
+		})
+
+		It("does not require an app with --space", func() {
+			requirementsFactory.NewLoginRequirementReturns(requirements.Passing{})
+			requirementsFactory.NewTargetedSpaceRequirementReturns(requirements.Passing{})
+			appSummaryRepo.GetSummariesInCurrentSpaceReturns(nil, nil)
+
+			runCommand("--space")
+			Expect(requirementsFactory.NewApplicationRequirementCallCount()).To(Equal(0))
+		})
 	})
 
 	Context("when logged in", func() {
@@ -183,4 +218,290 @@ var _ = Describe("logs command", func() {
 			})
 		})
 	})
+
+	Context("when filtering logs", func() {
+		var baseTime time.Time
+
+		BeforeEach(func() {
+			requirementsFactory.NewLoginRequirementReturns(requirements.Passing{})
+			requirementsFactory.NewTargetedSpaceRequirementReturns(requirements.Passing{})
+
+			app := models.Application{}
+			app.Name = "my-app"
+			app.GUID = "my-app-guid"
+			applicationReq := new(requirementsfakes.FakeApplicationRequirement)
+			applicationReq.GetApplicationReturns(app)
+			requirementsFactory.NewApplicationRequirementReturns(applicationReq)
+
+			baseTime = time.Date(2017, 3, 4, 10, 0, 0, 0, time.UTC)
+			appLogs := []logs.Loggable{
+				testlogs.NewLogMessage("app out 0", app.GUID, "APP/PROC/WEB", "0", logmessage.LogMessage_OUT, baseTime),
+				testlogs.NewLogMessage("app err 1", app.GUID, "APP/PROC/WEB", "1", logmessage.LogMessage_ERR, baseTime.Add(time.Minute)),
+				testlogs.NewLogMessage("GET /path 200", app.GUID, "RTR", "0", logmessage.LogMessage_OUT, baseTime.Add(2*time.Minute)),
+				testlogs.NewLogMessage("Staging complete", app.GUID, "STG", "0", logmessage.LogMessage_OUT, baseTime.Add(3*time.Minute)),
+			}
+			logsRepo.RecentLogsForReturns(appLogs, nil)
+			logsRepo.TailLogsForStub = func(appGUID string, onConnect func(), logChan chan<- logs.Loggable, errChan chan<- error) {
+				onConnect()
+				for _, log := range appLogs {
+					logChan <- log
+				}
+				close(logChan)
+				close(errChan)
+			}
+		})
+
+		It("only shows logs from the given sources", func() {
+			Expect(runCommand("--source", "app", "--source", "STG", "my-app")).To(BeTrue())
+
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"app out 0"},
+				[]string{"app err 1"},
+				[]string{"Staging complete"},
+			))
+			Expect(ui.Outputs()).NotTo(ContainSubstrings([]string{"GET /path"}))
+		})
+
+		It("only shows logs from the given instance", func() {
+			Expect(runCommand("--recent", "--instance", "1", "my-app")).To(BeTrue())
+
+			Expect(ui.Outputs()).To(ContainSubstrings([]string{"app err 1"}))
+			Expect(ui.Outputs()).NotTo(ContainSubstrings([]string{"app out 0"}))
+			Expect(ui.Outputs()).NotTo(ContainSubstrings([]string{"GET /path"}))
+		})
+
+		It("only shows logs written to stderr with --stderr", func() {
+			Expect(runCommand("--recent", "--stderr", "my-app")).To(BeTrue())
+
+			Expect(ui.Outputs()).To(ContainSubstrings([]string{"app err 1"}))
+			Expect(ui.Outputs()).NotTo(ContainSubstrings([]string{"app out 0"}))
+		})
+
+		It("only shows logs written to stdout with --stdout", func() {
+			Expect(runCommand("--recent", "--stdout", "my-app")).To(BeTrue())
+
+			Expect(ui.Outputs()).To(ContainSubstrings([]string{"app out 0"}, []string{"GET /path"}))
+			Expect(ui.Outputs()).NotTo(ContainSubstrings([]string{"app err 1"}))
+		})
+
+		It("only shows logs whose message matches --match", func() {
+			Expect(runCommand("--match", "^app .* [01]$", "my-app")).To(BeTrue())
+
+			Expect(ui.Outputs()).To(ContainSubstrings([]string{"app out 0"}, []string{"app err 1"}))
+			Expect(ui.Outputs()).NotTo(ContainSubstrings([]string{"GET /path"}))
+			Expect(ui.Outputs()).NotTo(ContainSubstrings([]string{"Staging complete"}))
+		})
+
+		It("fails when --match is not a valid regular expression", func() {
+			Expect(runCommand("--match", "(", "my-app")).To(BeFalse())
+			Expect(ui.Outputs()).To(ContainSubstrings([]string{"Invalid --match pattern"}))
+		})
+
+		It("only shows recent logs between --since and --until", func() {
+			Expect(runCommand("--recent", "--since", "2017-03-04T10:01:00Z", "--until", "2017-03-04T10:02:30Z", "my-app")).To(BeTrue())
+
+			Expect(ui.Outputs()).To(ContainSubstrings([]string{"app err 1"}, []string{"GET /path"}))
+			Expect(ui.Outputs()).NotTo(ContainSubstrings([]string{"app out 0"}))
+			Expect(ui.Outputs()).NotTo(ContainSubstrings([]string{"Staging complete"}))
+		})
+
+		It("takes a duration given to --since as that long ago", func() {
+			recentLog := testlogs.NewLogMessage("recent", "my-app-guid", "APP", "0", logmessage.LogMessage_OUT, time.Now().Add(-time.Minute))
+			oldLog := testlogs.NewLogMessage("old", "my-app-guid", "APP", "0", logmessage.LogMessage_OUT, time.Now().Add(-time.Hour))
+			logsRepo.RecentLogsForReturns([]logs.Loggable{oldLog, recentLog}, nil)
+
+			Expect(runCommand("--recent", "--since", "10m", "my-app")).To(BeTrue())
+
+			Expect(ui.Outputs()).To(ContainSubstrings([]string{"recent"}))
+			Expect(ui.Outputs()).NotTo(ContainSubstrings([]string{"old"}))
+		})
+
+		It("fails when --since is neither a time nor a duration", func() {
+			Expect(runCommand("--recent", "--since", "yesterday", "my-app")).To(BeFalse())
+			Expect(ui.Outputs()).To(ContainSubstrings([]string{"Invalid --since value yesterday"}))
+		})
+
+		It("fails with usage when --since or --until is given without --recent", func() {
+			Expect(runCommand("--since", "10m", "my-app")).To(BeFalse())
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"Incorrect Usage", "--since and --until can only be used with --recent"},
+			))
+		})
+
+		It("shows each log as a line of JSON with --output json", func() {
+			Expect(runCommand("--recent", "--output", "json", "--source", "RTR", "my-app")).To(BeTrue())
+
+			Expect(ui.Outputs()).To(HaveLen(1))
+			Expect(ui.Outputs()[0]).To(MatchJSON(`{
+				"timestamp": "2017-03-04T10:02:00Z",
+				"app": "my-app",
+				"source": "RTR",
+				"instance": "0",
+				"type": "OUT",
+				"message": "GET /path 200"
+			}`))
+		})
+
+		It("fails when --output is not text or json", func() {
+			Expect(runCommand("--recent", "--output", "yaml", "my-app")).To(BeFalse())
+			Expect(ui.Outputs()).To(ContainSubstrings([]string{"Incorrect Usage", "'--output' option must be one of text or json"}))
+		})
+	})
+
+	Context("when showing the logs of several apps", func() {
+		var (
+			apps     []models.Application
+			baseTime time.Time
+		)
+
+		BeforeEach(func() {
+			requirementsFactory.NewLoginRequirementReturns(requirements.Passing{})
+			requirementsFactory.NewTargetedSpaceRequirementReturns(requirements.Passing{})
+
+			apps = []models.Application{
+				{ApplicationFields: models.ApplicationFields{Name: "app-1", GUID: "app-1-guid"}},
+				{ApplicationFields: models.ApplicationFields{Name: "long-app-2", GUID: "app-2-guid"}},
+			}
+			requirementsFactory.NewApplicationRequirementStub = func(name string) requirements.ApplicationRequirement {
+				applicationReq := new(requirementsfakes.FakeApplicationRequirement)
+				for _, app := range apps {
+					if app.Name == name {
+						applicationReq.GetApplicationReturns(app)
+					}
+				}
+				return applicationReq
+			}
+
+			baseTime = time.Now()
+			logsFor := map[string][]logs.Loggable{
+				"app-1-guid": {
+					testlogs.NewLogMessage("first", "app-1-guid", "DEA", "1", logmessage.LogMessage_OUT, baseTime),
+					testlogs.NewLogMessage("third", "app-1-guid", "DEA", "1", logmessage.LogMessage_OUT, baseTime.Add(2*time.Second)),
+				},
+				"app-2-guid": {
+					testlogs.NewLogMessage("second", "app-2-guid", "DEA", "1", logmessage.LogMessage_OUT, baseTime.Add(time.Second)),
+				},
+			}
+
+			logsRepo.RecentLogsForStub = func(appGUID string) ([]logs.Loggable, error) {
+				return logsFor[appGUID], nil
+			}
+			logsRepo.TailLogsForStub = func(appGUID string, onConnect func(), logChan chan<- logs.Loggable, errChan chan<- error) {
+				onConnect()
+				for _, log := range logsFor[appGUID] {
+					logChan <- log
+				}
+				close(logChan)
+				close(errChan)
+			}
+		})
+
+		It("tails the logs of every app in the order they were written", func() {
+			Expect(runCommand("app-1", "long-app-2")).To(BeTrue())
+
+			Expect(logsRepo.TailLogsForCallCount()).To(Equal(2))
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"Connected, tailing logs for apps", "app-1", "long-app-2", "my-org", "my-space", "my-user"},
+				[]string{"[app-1]", "first"},
+				[]string{"[long-app-2]", "second"},
+				[]string{"[app-1]", "third"},
+			))
+
+			connectedCount := 0
+			for _, line := range ui.Outputs() {
+				if strings.Contains(line, "Connected, tailing logs") {
+					connectedCount++
+				}
+			}
+			Expect(connectedCount).To(Equal(1))
+		})
+
+		It("shows the recent logs of every app in the order they were written", func() {
+			Expect(runCommand("--recent", "app-1", "long-app-2")).To(BeTrue())
+
+			Expect(logsRepo.RecentLogsForCallCount()).To(Equal(2))
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"Connected, dumping recent logs for apps", "app-1", "long-app-2"},
+				[]string{"[app-1]", "first"},
+				[]string{"[long-app-2]", "second"},
+				[]string{"[app-1]", "third"},
+			))
+		})
+
+		It("shows the logs of every app in the space with --space", func() {
+			appSummaryRepo.GetSummariesInCurrentSpaceReturns([]models.Application{apps[1], apps[0]}, nil)
+
+			Expect(runCommand("--space", "--recent")).To(BeTrue())
+
+			Expect(appSummaryRepo.GetSummariesInCurrentSpaceCallCount()).To(Equal(1))
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"Connected, dumping recent logs for apps", "app-1, ", "long-app-2"},
+				[]string{"[app-1]", "first"},
+				[]string{"[long-app-2]", "second"},
+			))
+		})
+
+		It("names the app of each log in JSON output", func() {
+			Expect(runCommand("--recent", "--output", "json", "app-1", "long-app-2")).To(BeTrue())
+
+			Expect(ui.Outputs()).To(HaveLen(3))
+			Expect(ui.Outputs()[0]).To(ContainSubstring(`"app":"app-1"`))
+			Expect(ui.Outputs()[1]).To(ContainSubstring(`"app":"long-app-2"`))
+			Expect(ui.Outputs()[1]).To(ContainSubstring(`"message":"second"`))
+		})
+
+		It("fails when there are no apps in the space", func() {
+			appSummaryRepo.GetSummariesInCurrentSpaceReturns([]models.Application{}, nil)
+
+			Expect(runCommand("--space")).To(BeFalse())
+			Expect(ui.Outputs()).To(ContainSubstrings([]string{"No apps found in space", "my-space"}))
+		})
+
+		Context("when the logs of one app fail", func() {
+			BeforeEach(func() {
+				tailLogs := logsRepo.TailLogsForStub
+				logsRepo.TailLogsForStub = func(appGUID string, onConnect func(), logChan chan<- logs.Loggable, errChan chan<- error) {
+					if appGUID == "app-2-guid" {
+						errChan <- errors.New("stream failed")
+						return
+					}
+					tailLogs(appGUID, onConnect, logChan, errChan)
+				}
+				logsRepo.RecentLogsForStub = func(appGUID string) ([]logs.Loggable, error) {
+					if appGUID == "app-2-guid" {
+						return nil, errors.New("recent failed")
+					}
+					return []logs.Loggable{
+						testlogs.NewLogMessage("first", "app-1-guid", "DEA", "1", logmessage.LogMessage_OUT, baseTime),
+					}, nil
+				}
+			})
+
+			It("warns about that app and keeps tailing the others", func() {
+				Expect(runCommand("app-1", "long-app-2")).To(BeTrue())
+
+				Expect(ui.WarnOutputs).To(ContainSubstrings([]string{"Stopped tailing logs for app long-app-2", "stream failed"}))
+				Expect(ui.Outputs()).To(ContainSubstrings(
+					[]string{"[app-1]", "first"},
+					[]string{"[app-1]", "third"},
+				))
+			})
+
+			It("warns about that app and shows the recent logs of the others", func() {
+				Expect(runCommand("--recent", "app-1", "long-app-2")).To(BeTrue())
+
+				Expect(ui.WarnOutputs).To(ContainSubstrings([]string{"Failed to get logs for app long-app-2", "recent failed"}))
+				Expect(ui.Outputs()).To(ContainSubstrings([]string{"[app-1]", "first"}))
+			})
+
+			It("fails when the logs of every app fail", func() {
+				logsRepo.TailLogsForStub = func(appGUID string, onConnect func(), logChan chan<- logs.Loggable, errChan chan<- error) {
+					errChan <- errors.New("stream failed")
+				}
+
+				Expect(runCommand("app-1", "long-app-2")).To(BeFalse())
+				Expect(ui.Outputs()).To(ContainSubstrings([]string{"FAILED"}, []string{"stream failed"}))
+			})
+		})
+	})
 })
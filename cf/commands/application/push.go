@@ -2,11 +2,12 @@ package application
 
 import (
 	"fmt"
-	"io/ioutil"
+	"io"
 	"os"
 	"regexp"
 	"strconv"
 	"strings"
+	"sync"
 
 	"code.cloudfoundry.org/cli/cf/flags"
 	. "code.cloudfoundry.org/cli/cf/i18n"
@@ -14,6 +15,7 @@ import (
 	"code.cloudfoundry.org/cli/cf"
 	"code.cloudfoundry.org/cli/cf/actors"
 	"code.cloudfoundry.org/cli/cf/api"
+	"code.cloudfoundry.org/cli/cf/api/applicationbits"
 	"code.cloudfoundry.org/cli/cf/api/applications"
 	"code.cloudfoundry.org/cli/cf/api/authentication"
 	"code.cloudfoundry.org/cli/cf/api/stacks"
@@ -30,24 +32,34 @@ import (
 	"code.cloudfoundry.org/cli/util/words/generator"
 )
 
+// BlueGreenStrategy is the push strategy that replaces an existing app with a
+// new copy of it, moving the routes over only once the copy is healthy.
+const BlueGreenStrategy = "blue-green"
+
+// blueGreenAppSuffix is appended to the app name to name the new copy of an
+// app pushed with the blue-green strategy.
+const blueGreenAppSuffix = "-new"
+
 type Push struct {
-	ui            terminal.UI
-	config        coreconfig.Reader
-	manifestRepo  manifest.Repository
-	appStarter    Starter
-	appStopper    Stopper
-	serviceBinder service.Binder
-	appRepo       applications.Repository
-	domainRepo    api.DomainRepository
-	routeRepo     api.RouteRepository
-	serviceRepo   api.ServiceRepository
-	stackRepo     stacks.StackRepository
-	authRepo      authentication.Repository
-	wordGenerator generator.WordGenerator
-	actor         actors.PushActor
-	routeActor    actors.RouteActor
-	zipper        appfiles.Zipper
-	appfiles      appfiles.AppFiles
+	ui             terminal.UI
+	config         coreconfig.Reader
+	manifestRepo   manifest.Repository
+	appStarter     Starter
+	appStopper     Stopper
+	serviceBinder  service.Binder
+	appRepo        applications.Repository
+	appSummaryRepo api.AppSummaryRepository
+	domainRepo     api.DomainRepository
+	routeRepo      api.RouteRepository
+	serviceRepo    api.ServiceRepository
+	stackRepo      stacks.StackRepository
+	authRepo       authentication.Repository
+	wordGenerator  generator.WordGenerator
+	actor          actors.PushActor
+	routeActor     actors.RouteActor
+	zipper         appfiles.Zipper
+	appfiles       appfiles.AppFiles
+	repoLocator    api.RepositoryLocator
 }
 
 func init() {
@@ -64,7 +76,7 @@ func (cmd *Push) MetaData() commandregistry.CommandMetadata {
 	fs["k"] = &flags.StringFlag{ShortName: "k", Usage: T("Disk limit (e.g. 256M, 1024M, 1G)")}
 	fs["m"] = &flags.StringFlag{ShortName: "m", Usage: T("Memory limit (e.g. 256M, 1024M, 1G)")}
 	fs["hostname"] = &flags.StringFlag{Name: "hostname", ShortName: "n", Usage: T("Hostname (e.g. my-subdomain)")}
-	fs["p"] = &flags.StringFlag{ShortName: "p", Usage: T("Path to app directory or to a zip file of the contents of the app directory")}
+	fs["p"] = &flags.StringFlag{ShortName: "p", Usage: T("Path to app directory, to a zip, jar, war, tar, tar.gz or tgz file of the contents of the app directory, or to an http(s) URL of such a file (add #sha1=CHECKSUM to verify it)")}
 	fs["s"] = &flags.StringFlag{ShortName: "s", Usage: T("Stack to use (a stack is a pre-built file system, including an operating system, that can run apps)")}
 	fs["t"] = &flags.StringFlag{ShortName: "t", Usage: T("Maximum time (in seconds) for CLI to wait for application start, other server side timeouts may apply")}
 	fs["docker-image"] = &flags.StringFlag{Name: "docker-image", ShortName: "o", Usage: T("Docker-image to be used (e.g. user/docker-image-name)")}
@@ -75,6 +87,13 @@ func (cmd *Push) MetaData() commandregistry.CommandMetadata {
 	fs["no-start"] = &flags.BoolFlag{Name: "no-start", Usage: T("Do not start an app after pushing")}
 	fs["random-route"] = &flags.BoolFlag{Name: "random-route", Usage: T("Create a random route for this app")}
 	fs["route-path"] = &flags.StringFlag{Name: "route-path", Usage: T("Path for the route")}
+	fs["var"] = &flags.StringSliceFlag{Name: "var", Usage: T("Variable key value pair for variable substitution in the manifest (e.g. name=app1), can be specified multiple times")}
+	fs["vars-file"] = &flags.StringSliceFlag{Name: "vars-file", Usage: T("Path to a YAML file of variables for variable substitution in the manifest, can be specified multiple times")}
+	fs["parallel"] = &flags.IntFlag{Name: "parallel", Usage: T("Number of apps from the manifest to push at the same time. Apps listed in an app's 'depends-on' are pushed before it")}
+	fs["dry-run"] = &flags.BoolFlag{Name: "dry-run", Usage: T("Show the changes the push would make to each app without making them. Use with '--output json' or '--output yaml' for structured output")}
+	fs["show-ignored"] = &flags.BoolFlag{Name: "show-ignored", Usage: T("List the app files that would be uploaded or ignored, and the .cfignore rule that decided each, without pushing")}
+	fs["output"] = &flags.StringFlag{Name: "output", Usage: T("Format of the dry run: text, json or yaml"), Hidden: true}
+	fs["strategy"] = &flags.StringFlag{Name: "strategy", Usage: T("Deployment strategy, either 'default' or 'blue-green'. With 'blue-green' an existing app is replaced by a new copy, which receives its routes once it has started")}
 	// Hidden:true to hide app-ports for release #117189491
 	fs["app-ports"] = &flags.StringFlag{Name: "app-ports", Usage: T("Comma delimited list of ports the application may listen on"), Hidden: true}
 
@@ -102,6 +121,11 @@ func (cmd *Push) MetaData() commandregistry.CommandMetadata {
 			fmt.Sprintf("[-u %s] ", T("HEALTH_CHECK_TYPE")),
 			fmt.Sprintf("[--route-path %s] ", T("ROUTE_PATH")),
 			"\n   ",
+			fmt.Sprintf("[--strategy %s] ", T("STRATEGY")),
+			fmt.Sprintf("[--var %s] ", T("KEY=VALUE")),
+			fmt.Sprintf("[--vars-file %s]", T("VARS_FILE_PATH")),
+			"\n   ",
+			"[--dry-run] [--show-ignored] ",
 			// Commented to hide app-ports for release #117189491
 			// fmt.Sprintf("[--app-ports %s] ", T("APP_PORTS")),
 			"[--no-hostname] [--no-manifest] [--no-route] [--no-start] [--random-route]\n",
@@ -110,6 +134,10 @@ func (cmd *Push) MetaData() commandregistry.CommandMetadata {
 			":\n   ",
 			"CF_NAME push ",
 			fmt.Sprintf("[-f %s] ", T("MANIFEST_PATH")),
+			fmt.Sprintf("[--parallel %s] ", T("NUM_APPS")),
+			"[--dry-run] [--show-ignored] ",
+			fmt.Sprintf("[--var %s] ", T("KEY=VALUE")),
+			fmt.Sprintf("[--vars-file %s]", T("VARS_FILE_PATH")),
 		},
 		Flags: fs,
 	}
@@ -163,6 +191,7 @@ func (cmd *Push) SetDependency(deps commandregistry.Dependency, pluginCall bool)
 	cmd.serviceBinder = appCommand.(service.Binder)
 
 	cmd.appRepo = deps.RepoLocator.GetApplicationRepository()
+	cmd.appSummaryRepo = deps.RepoLocator.GetAppSummaryRepository()
 	cmd.domainRepo = deps.RepoLocator.GetDomainRepository()
 	cmd.routeRepo = deps.RepoLocator.GetRouteRepository()
 	cmd.serviceRepo = deps.RepoLocator.GetServiceRepository()
@@ -173,11 +202,31 @@ func (cmd *Push) SetDependency(deps commandregistry.Dependency, pluginCall bool)
 	cmd.routeActor = deps.RouteActor
 	cmd.zipper = deps.AppZipper
 	cmd.appfiles = deps.AppFiles
+	cmd.repoLocator = deps.RepoLocator
 
 	return cmd
 }
 
 func (cmd *Push) Execute(c flags.FlagContext) error {
+	blueGreen, err := cmd.isBlueGreen(c)
+	if err != nil {
+		return err
+	}
+
+	parallel := 1
+	if c.IsSet("parallel") {
+		parallel = c.Int("parallel")
+		if parallel < 1 {
+			return errors.New(T("Incorrect Usage. The '--parallel' option must be a positive number."))
+		}
+	}
+
+	dryRun := c.Bool("dry-run")
+	output, err := dryRunOutput(c)
+	if dryRun && err != nil {
+		return err
+	}
+
 	appsFromManifest, err := cmd.getAppParamsFromManifest(c)
 	if err != nil {
 		return err
@@ -209,108 +258,543 @@ func (cmd *Push) Execute(c flags.FlagContext) error {
 		return err
 	}
 
+	if c.Bool("show-ignored") {
+		return cmd.showIgnored(appSet)
+	}
+
 	_, err = cmd.authRepo.RefreshAuthToken()
 	if err != nil {
 		return err
 	}
 
+	appSet, err = orderByDependencies(appSet)
+	if err != nil {
+		return err
+	}
+
+	if dryRun {
+		return cmd.dryRun(appSet, output)
+	}
+
+	if parallel > 1 && len(appSet) > 1 {
+		return cmd.pushInParallel(appSet, appFromContext, blueGreen, parallel, c)
+	}
+
 	for _, appParams := range appSet {
-		if appParams.Name == nil {
-			return errors.New(T("Error: No name found for app"))
+		err = cmd.pushApp(appParams, appFromContext, blueGreen, c)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// pushApp creates or updates the app and deploys it.
+func (cmd *Push) pushApp(appParams models.AppParams, appFromContext models.AppParams, blueGreen bool, c flags.FlagContext) error {
+	if appParams.Name == nil {
+		return errors.New(T("Error: No name found for app"))
+	}
+
+	err := cmd.fetchStackGUID(&appParams)
+	if err != nil {
+		return err
+	}
+
+	if isDockerApp(appParams) {
+		diego := true
+		appParams.Diego = &diego
+	}
+
+	var app, existingApp models.Application
+	existingApp, err = cmd.appRepo.Read(*appParams.Name)
+	switch err.(type) {
+	case nil:
+		if blueGreen {
+			return cmd.pushBlueGreen(existingApp, appParams, appFromContext, c)
 		}
 
-		err = cmd.fetchStackGUID(&appParams)
+		cmd.ui.Say(T("Updating app {{.AppName}} in org {{.OrgName}} / space {{.SpaceName}} as {{.Username}}...",
+			map[string]interface{}{
+				"AppName":   terminal.EntityNameColor(existingApp.Name),
+				"OrgName":   terminal.EntityNameColor(cmd.config.OrganizationFields().Name),
+				"SpaceName": terminal.EntityNameColor(cmd.config.SpaceFields().Name),
+				"Username":  terminal.EntityNameColor(cmd.config.Username())}))
+
+		if appParams.EnvironmentVars != nil {
+			for key, val := range existingApp.EnvironmentVars {
+				if _, ok := (*appParams.EnvironmentVars)[key]; !ok {
+					(*appParams.EnvironmentVars)[key] = val
+				}
+			}
+		}
+
+		app, err = cmd.appRepo.Update(existingApp.GUID, appParams)
 		if err != nil {
 			return err
 		}
+	case *errors.ModelNotFoundError:
+		spaceGUID := cmd.config.SpaceFields().GUID
+		appParams.SpaceGUID = &spaceGUID
+
+		cmd.ui.Say(T("Creating app {{.AppName}} in org {{.OrgName}} / space {{.SpaceName}} as {{.Username}}...",
+			map[string]interface{}{
+				"AppName":   terminal.EntityNameColor(*appParams.Name),
+				"OrgName":   terminal.EntityNameColor(cmd.config.OrganizationFields().Name),
+				"SpaceName": terminal.EntityNameColor(cmd.config.SpaceFields().Name),
+				"Username":  terminal.EntityNameColor(cmd.config.Username())}))
 
-		if c.IsSet("docker-image") {
-			diego := true
-			appParams.Diego = &diego
+		app, err = cmd.appRepo.Create(appParams)
+		if err != nil {
+			return err
 		}
+	default:
+		return err
+	}
 
-		var app, existingApp models.Application
-		existingApp, err = cmd.appRepo.Read(*appParams.Name)
-		switch err.(type) {
-		case nil:
-			cmd.ui.Say(T("Updating app {{.AppName}} in org {{.OrgName}} / space {{.SpaceName}} as {{.Username}}...",
-				map[string]interface{}{
-					"AppName":   terminal.EntityNameColor(existingApp.Name),
-					"OrgName":   terminal.EntityNameColor(cmd.config.OrganizationFields().Name),
-					"SpaceName": terminal.EntityNameColor(cmd.config.SpaceFields().Name),
-					"Username":  terminal.EntityNameColor(cmd.config.Username())}))
-
-			if appParams.EnvironmentVars != nil {
-				for key, val := range existingApp.EnvironmentVars {
-					if _, ok := (*appParams.EnvironmentVars)[key]; !ok {
-						(*appParams.EnvironmentVars)[key] = val
-					}
+	cmd.ui.Ok()
+	cmd.ui.Say("")
+
+	err = cmd.updateRoutes(app, appParams, appFromContext)
+	if err != nil {
+		return err
+	}
+
+	return cmd.deployApp(app, appParams, c)
+}
+
+// pushOutcome is what happened to an app pushed by pushInParallel.
+type pushOutcome struct {
+	appName string
+	status  string
+	err     error
+}
+
+const (
+	pushOutcomePushed  = "pushed"
+	pushOutcomeFailed  = "failed"
+	pushOutcomeSkipped = "skipped"
+)
+
+// pushInParallel pushes up to parallel apps at a time, each with its own
+// prefixed output. An app is only started once every app it depends on has
+// been pushed. When an app fails, the apps that depend on it, directly or
+// through other apps, are skipped while the rest carry on being pushed. A
+// summary of what happened to each app is printed at the end.
+func (cmd *Push) pushInParallel(appSet []models.AppParams, appFromContext models.AppParams, blueGreen bool, parallel int, c flags.FlagContext) error {
+	cmd.ui.Say(T("Pushing {{.AppCount}} apps, {{.Parallel}} at a time...",
+		map[string]interface{}{
+			"AppCount": len(appSet),
+			"Parallel": parallel,
+		}))
+	cmd.ui.Say("")
+
+	pushing := map[string]bool{}
+	for _, appParams := range appSet {
+		pushing[appName(appParams)] = true
+	}
+
+	outputMutex := new(sync.Mutex)
+	done := make(chan pushOutcome)
+	outcomes := map[string]pushOutcome{}
+	pending := appSet
+	running := 0
+
+	for len(pending) > 0 || running > 0 {
+		var waiting []models.AppParams
+		for _, appParams := range pending {
+			name := appName(appParams)
+
+			if dependency, ok := failedDependency(appParams, outcomes); ok {
+				outcomes[name] = pushOutcome{
+					appName: name,
+					status:  pushOutcomeSkipped,
+					err: errors.New(T("{{.AppName}} was not pushed",
+						map[string]interface{}{"AppName": dependency})),
 				}
+				continue
 			}
 
-			app, err = cmd.appRepo.Update(existingApp.GUID, appParams)
-			if err != nil {
-				return err
+			if running < parallel && dependenciesPushed(appParams, pushing, outcomes) {
+				running++
+				go func(appParams models.AppParams) {
+					ui := terminal.NewPrefixedUI(cmd.ui, fmt.Sprintf("[%s] ", appName(appParams)), outputMutex)
+					err := cmd.appCopy(ui).pushApp(appParams, appFromContext, blueGreen, c)
+					done <- pushOutcome{appName: appName(appParams), err: err}
+				}(appParams)
+				continue
 			}
-		case *errors.ModelNotFoundError:
-			spaceGUID := cmd.config.SpaceFields().GUID
-			appParams.SpaceGUID = &spaceGUID
 
-			cmd.ui.Say(T("Creating app {{.AppName}} in org {{.OrgName}} / space {{.SpaceName}} as {{.Username}}...",
-				map[string]interface{}{
-					"AppName":   terminal.EntityNameColor(*appParams.Name),
-					"OrgName":   terminal.EntityNameColor(cmd.config.OrganizationFields().Name),
-					"SpaceName": terminal.EntityNameColor(cmd.config.SpaceFields().Name),
-					"Username":  terminal.EntityNameColor(cmd.config.Username())}))
+			waiting = append(waiting, appParams)
+		}
+		pending = waiting
 
-			app, err = cmd.appRepo.Create(appParams)
-			if err != nil {
-				return err
+		if running > 0 {
+			outcome := <-done
+			running--
+
+			outcome.status = pushOutcomePushed
+			if outcome.err != nil {
+				outcome.status = pushOutcomeFailed
 			}
-		default:
-			return err
+			outcomes[outcome.appName] = outcome
 		}
+	}
 
-		cmd.ui.Ok()
-		cmd.ui.Say("")
+	return cmd.showPushSummary(appSet, outcomes)
+}
 
-		err = cmd.updateRoutes(app, appParams, appFromContext)
-		if err != nil {
-			return err
+func (cmd *Push) showPushSummary(appSet []models.AppParams, outcomes map[string]pushOutcome) error {
+	cmd.ui.Say("")
+	table := cmd.ui.Table([]string{T("app"), T("status"), T("details")})
+
+	notPushed := 0
+	for _, appParams := range appSet {
+		outcome := outcomes[appName(appParams)]
+
+		var details string
+		if outcome.err != nil {
+			notPushed++
+			details = strings.Replace(strings.TrimSpace(outcome.err.Error()), "\n", " ", -1)
 		}
+		table.Add(outcome.appName, T(outcome.status), details)
+	}
 
-		if c.String("docker-image") == "" {
-			err = cmd.actor.ProcessPath(*appParams.Path, cmd.processPathCallback(*appParams.Path, app))
-			if err != nil {
-				return errors.New(
-					T("Error processing app files: {{.Error}}",
-						map[string]interface{}{
-							"Error": err.Error(),
-						}),
-				)
+	err := table.Print()
+	if err != nil {
+		return err
+	}
+
+	if notPushed > 0 {
+		return errors.New(T("{{.NotPushed}} of {{.AppCount}} apps were not pushed",
+			map[string]interface{}{
+				"NotPushed": notPushed,
+				"AppCount":  len(appSet),
+			}))
+	}
+	return nil
+}
+
+// appCopy returns a copy of cmd that writes its output, and that of the
+// commands it runs, to ui, so that several apps can be pushed at once. Each
+// copy uploads with its own progress output and streams logs over its own
+// connection.
+func (cmd *Push) appCopy(ui terminal.UI) *Push {
+	repoLocator := cmd.repoLocator.ForUI(ui)
+
+	appCmd := *cmd
+	appCmd.ui = ui
+	appCmd.routeActor = actors.NewRouteActor(ui, cmd.routeRepo, cmd.domainRepo)
+
+	if _, ok := cmd.actor.(actors.PushActorImpl); ok {
+		appCmd.actor = actors.NewPushActor(repoLocator.GetApplicationBitsRepository(), cmd.zipper, cmd.appfiles, appCmd.routeActor)
+	}
+
+	if starter, ok := cmd.appStarter.(*Start); ok {
+		appStarter := *starter
+		appStarter.ui = ui
+		appStarter.logRepo = repoLocator.GetLogsRepository()
+		if displayer, ok := starter.appDisplayer.(*ShowApp); ok {
+			appDisplayer := *displayer
+			appDisplayer.ui = ui
+			appStarter.appDisplayer = &appDisplayer
+		}
+		appCmd.appStarter = &appStarter
+	}
+
+	if stopper, ok := cmd.appStopper.(*Stop); ok {
+		appStopper := *stopper
+		appStopper.ui = ui
+		appCmd.appStopper = &appStopper
+	}
+
+	return &appCmd
+}
+
+func appName(appParams models.AppParams) string {
+	if appParams.Name == nil {
+		return ""
+	}
+	return *appParams.Name
+}
+
+func dependenciesPushed(appParams models.AppParams, pushing map[string]bool, outcomes map[string]pushOutcome) bool {
+	for _, dependency := range appParams.DependsOn {
+		if pushing[dependency] && outcomes[dependency].status != pushOutcomePushed {
+			return false
+		}
+	}
+	return true
+}
+
+func failedDependency(appParams models.AppParams, outcomes map[string]pushOutcome) (string, bool) {
+	for _, dependency := range appParams.DependsOn {
+		if outcome, ok := outcomes[dependency]; ok && outcome.status != pushOutcomePushed {
+			return dependency, true
+		}
+	}
+	return "", false
+}
+
+// orderByDependencies orders apps so that every app comes after the apps it
+// depends on, otherwise keeping the order they were given in. Dependencies on
+// apps that are not being pushed are ignored.
+func orderByDependencies(apps []models.AppParams) ([]models.AppParams, error) {
+	pushing := map[string]bool{}
+	for _, app := range apps {
+		pushing[appName(app)] = true
+	}
+
+	ordered := make([]models.AppParams, 0, len(apps))
+	placed := map[string]bool{}
+	remaining := apps
+	for len(remaining) > 0 {
+		next := -1
+		for i, app := range remaining {
+			if dependenciesPlaced(app, pushing, placed) {
+				next = i
+				break
 			}
 		}
 
-		if appParams.ServicesToBind != nil {
-			err = cmd.bindAppToServices(appParams.ServicesToBind, app)
-			if err != nil {
-				return err
+		if next < 0 {
+			names := make([]string, 0, len(remaining))
+			for _, app := range remaining {
+				names = append(names, appName(app))
 			}
+			return nil, errors.New(T("Apps {{.AppNames}} depend on each other and cannot be ordered",
+				map[string]interface{}{"AppNames": strings.Join(names, ", ")}))
+		}
+
+		ordered = append(ordered, remaining[next])
+		placed[appName(remaining[next])] = true
+		remaining = append(remaining[:next:next], remaining[next+1:]...)
+	}
+
+	return ordered, nil
+}
+
+func dependenciesPlaced(app models.AppParams, pushing map[string]bool, placed map[string]bool) bool {
+	for _, dependency := range app.DependsOn {
+		if pushing[dependency] && !placed[dependency] {
+			return false
+		}
+	}
+	return true
+}
+
+func (cmd *Push) isBlueGreen(c flags.FlagContext) (bool, error) {
+	switch c.String("strategy") {
+	case "", "default":
+		return false, nil
+	case BlueGreenStrategy:
+		if c.Bool("no-start") {
+			return false, errors.New(T("Incorrect Usage. The '--strategy blue-green' option cannot be used with '--no-start'."))
 		}
+		return true, nil
+	default:
+		return false, errors.New(T("Invalid strategy: {{.Strategy}}\nStrategy must be 'default' or 'blue-green'",
+			map[string]interface{}{"Strategy": c.String("strategy")}))
+	}
+}
 
-		err = cmd.restart(app, appParams, c)
+// isDockerApp reports whether the app is pushed from a docker image, given
+// with --docker-image or in the manifest, rather than from app files.
+func isDockerApp(appParams models.AppParams) bool {
+	return appParams.DockerImage != nil && *appParams.DockerImage != ""
+}
+
+// deployApp uploads the app's files, binds its services and restarts it.
+func (cmd *Push) deployApp(app models.Application, appParams models.AppParams, c flags.FlagContext) error {
+	if !isDockerApp(appParams) {
+		err := cmd.actor.ProcessPath(*appParams.Path, cmd.processPathCallback(*appParams.Path, app))
 		if err != nil {
 			return errors.New(
-				T("Error restarting application: {{.Error}}",
+				T("Error processing app files: {{.Error}}",
 					map[string]interface{}{
 						"Error": err.Error(),
 					}),
 			)
 		}
 	}
+
+	if appParams.ServicesToBind != nil {
+		err := cmd.bindAppToServices(appParams.ServicesToBind, app)
+		if err != nil {
+			return err
+		}
+	}
+
+	err := cmd.restart(app, appParams, c)
+	if err != nil {
+		return errors.New(
+			T("Error restarting application: {{.Error}}",
+				map[string]interface{}{
+					"Error": err.Error(),
+				}),
+		)
+	}
+	return nil
+}
+
+// pushBlueGreen pushes a new copy of an existing app without any routes and
+// waits for it to start. Only then are the routes mapped to the copy and
+// unmapped from the existing app, which is deleted so that the copy can take
+// its name. If the copy fails to start, the existing app is left untouched.
+func (cmd *Push) pushBlueGreen(existingApp models.Application, appParams models.AppParams, appParamsFromContext models.AppParams, c flags.FlagContext) error {
+	appName := existingApp.Name
+
+	summary, err := cmd.appSummaryRepo.GetSummary(existingApp.GUID)
+	if err != nil {
+		return err
+	}
+	newAppParams := blueGreenAppParams(existingApp, summary.Services, appParams)
+	newAppName := *newAppParams.Name
+
+	cmd.ui.Say(T("Creating app {{.NewAppName}} to replace {{.AppName}} in org {{.OrgName}} / space {{.SpaceName}} as {{.Username}}...",
+		map[string]interface{}{
+			"NewAppName": terminal.EntityNameColor(newAppName),
+			"AppName":    terminal.EntityNameColor(appName),
+			"OrgName":    terminal.EntityNameColor(cmd.config.OrganizationFields().Name),
+			"SpaceName":  terminal.EntityNameColor(cmd.config.SpaceFields().Name),
+			"Username":   terminal.EntityNameColor(cmd.config.Username())}))
+
+	newApp, err := cmd.appRepo.Read(newAppName)
+	switch err.(type) {
+	case nil:
+		newApp, err = cmd.appRepo.Update(newApp.GUID, newAppParams)
+	case *errors.ModelNotFoundError:
+		newApp, err = cmd.appRepo.Create(newAppParams)
+	}
+	if err != nil {
+		return err
+	}
+
+	cmd.ui.Ok()
+	cmd.ui.Say("")
+
+	err = cmd.deployApp(newApp, newAppParams, c)
+	if err != nil {
+		cmd.ui.Warn(T("App {{.AppName}} was not changed and is still mapped to its routes. Fix {{.NewAppName}} and push again, or delete it.",
+			map[string]interface{}{
+				"AppName":    appName,
+				"NewAppName": newAppName,
+			}))
+		return err
+	}
+
+	if !newAppParams.NoRoute {
+		err = cmd.mapBlueGreenRoutes(existingApp, newApp, newAppParams, appParamsFromContext)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = cmd.routeActor.UnbindAll(existingApp)
+	if err != nil {
+		return err
+	}
+
+	cmd.ui.Say(T("Deleting app {{.AppName}}...",
+		map[string]interface{}{"AppName": terminal.EntityNameColor(appName)}))
+	err = cmd.appRepo.Delete(existingApp.GUID)
+	if err != nil {
+		return err
+	}
+	cmd.ui.Ok()
+
+	cmd.ui.Say(T("Renaming app {{.NewAppName}} to {{.AppName}}...",
+		map[string]interface{}{
+			"NewAppName": terminal.EntityNameColor(newAppName),
+			"AppName":    terminal.EntityNameColor(appName),
+		}))
+	_, err = cmd.appRepo.Update(newApp.GUID, models.AppParams{Name: &appName})
+	if err != nil {
+		return err
+	}
+	cmd.ui.Ok()
+	cmd.ui.Say("")
 	return nil
 }
 
+// mapBlueGreenRoutes maps the existing app's routes to the new app, followed
+// by the routes the existing app would have been given by a regular push.
+func (cmd *Push) mapBlueGreenRoutes(existingApp models.Application, newApp models.Application, newAppParams models.AppParams, appParamsFromContext models.AppParams) error {
+	// Routes are generated from the name of the existing app, since the new
+	// app takes over that name.
+	routedApp := newApp
+	routedApp.Name = existingApp.Name
+	routedApp.Routes = nil
+
+	for _, route := range existingApp.Routes {
+		err := cmd.routeActor.BindRoute(routedApp, models.Route{
+			GUID:   route.GUID,
+			Host:   route.Host,
+			Domain: route.Domain,
+			Path:   route.Path,
+			Port:   route.Port,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	routedApp.Routes = existingApp.Routes
+	return cmd.updateRoutes(routedApp, newAppParams, appParamsFromContext)
+}
+
+// blueGreenAppParams returns the parameters for the new copy of an existing
+// app. Settings that are not pushed are copied from the existing app, as a
+// regular push would leave them unchanged, and the services bound to it are
+// bound to the copy.
+func blueGreenAppParams(existingApp models.Application, boundServices []models.ServicePlanSummary, appParams models.AppParams) models.AppParams {
+	if appParams.EnvironmentVars != nil {
+		envVars := map[string]interface{}{}
+		for key, val := range existingApp.EnvironmentVars {
+			envVars[key] = val
+		}
+		for key, val := range *appParams.EnvironmentVars {
+			envVars[key] = val
+		}
+		appParams.EnvironmentVars = &envVars
+	}
+
+	newAppParams := existingApp.ToParams()
+	newAppParams.GUID = nil
+	newAppParams.State = nil
+	newAppParams.Merge(&appParams)
+	newAppParams.Routes = appParams.Routes
+	newAppParams.UseRandomPort = appParams.UseRandomPort
+
+	if appParams.Diego != nil {
+		newAppParams.Diego = appParams.Diego
+	} else {
+		newAppParams.Diego = &existingApp.Diego
+	}
+	if appParams.EnableSSH == nil {
+		newAppParams.EnableSSH = &existingApp.EnableSSH
+	}
+	if appParams.HealthCheckTimeout == nil && existingApp.HealthCheckTimeout != 0 {
+		newAppParams.HealthCheckTimeout = &existingApp.HealthCheckTimeout
+	}
+	if appParams.HealthCheckHTTPEndpoint == nil && existingApp.HealthCheckHTTPEndpoint != "" {
+		newAppParams.HealthCheckHTTPEndpoint = &existingApp.HealthCheckHTTPEndpoint
+	}
+
+	servicesToBind := append([]string{}, appParams.ServicesToBind...)
+	for _, service := range boundServices {
+		if !containsString(servicesToBind, service.Name) {
+			servicesToBind = append(servicesToBind, service.Name)
+		}
+	}
+	if len(servicesToBind) > 0 {
+		newAppParams.ServicesToBind = servicesToBind
+	}
+
+	newAppName := existingApp.Name + blueGreenAppSuffix
+	newAppParams.Name = &newAppName
+	return newAppParams
+}
+
 func (cmd *Push) processPathCallback(path string, app models.Application) func(string) error {
 	return func(appDir string) error {
 		localFiles, err := cmd.appfiles.AppFilesInDir(appDir)
@@ -594,13 +1078,20 @@ func (cmd *Push) getAppParamsFromManifest(c flags.FlagContext) ([]models.AppPara
 		return nil, errors.New(T("Error reading manifest file:\n{{.Err}}", map[string]interface{}{"Err": err.Error()}))
 	}
 
+	m.Variables, err = manifest.LoadVariables(c.StringSlice("vars-file"), c.StringSlice("var"))
+	if err != nil {
+		return nil, err
+	}
+
 	apps, err := m.Applications()
 	if err != nil {
 		return nil, errors.New(T("Error reading manifest file:\n{{.Err}}", map[string]interface{}{"Err": err.Error()}))
 	}
 
-	cmd.ui.Say(T("Using manifest file {{.Path}}\n",
-		map[string]interface{}{"Path": terminal.EntityNameColor(m.Path)}))
+	if !isStructuredDryRun(c) {
+		cmd.ui.Say(T("Using manifest file {{.Path}}\n",
+			map[string]interface{}{"Path": terminal.EntityNameColor(m.Path)}))
+	}
 	return apps, nil
 }
 
@@ -808,54 +1299,21 @@ func (cmd Push) ValidateContextAndAppParams(appsFromManifest []models.AppParams,
 }
 
 func (cmd *Push) uploadApp(appGUID, appDir, appDirOrZipFile string, localFiles []models.AppFileFields) error {
-	uploadDir, err := ioutil.TempDir("", "apps")
+	remoteFiles, filesToUpload, err := cmd.actor.GatherFiles(localFiles, appDir)
 	if err != nil {
 		return err
 	}
 
-	remoteFiles, hasFileToUpload, err := cmd.actor.GatherFiles(localFiles, appDir, uploadDir)
-	if err != nil {
-		return err
-	}
+	var writeZip applicationbits.ZipWriter
+	if len(filesToUpload) > 0 {
+		cmd.ui.Say(T("Uploading app files from: {{.Path}}", map[string]interface{}{"Path": appDir}))
+		cmd.ui.Say(T("Uploading {{.FileCount}} files",
+			map[string]interface{}{"FileCount": len(filesToUpload)}))
 
-	zipFile, err := ioutil.TempFile("", "uploads")
-	if err != nil {
-		return err
-	}
-	defer func() {
-		zipFile.Close()
-		os.Remove(zipFile.Name())
-	}()
-
-	if hasFileToUpload {
-		err = cmd.zipper.Zip(uploadDir, zipFile)
-		if err != nil {
-			if emptyDirErr, ok := err.(*errors.EmptyDirError); ok {
-				return emptyDirErr
-			}
-			return fmt.Errorf("%s: %s", T("Error zipping application"), err.Error())
-		}
-
-		var zipFileSize int64
-		zipFileSize, err = cmd.zipper.GetZipSize(zipFile)
-		if err != nil {
-			return err
+		writeZip = func(writer io.Writer) error {
+			return cmd.zipper.ZipFilesTo(appDir, filesToUpload, writer)
 		}
-
-		zipFileCount := cmd.appfiles.CountFiles(uploadDir)
-		if zipFileCount > 0 {
-			cmd.ui.Say(T("Uploading app files from: {{.Path}}", map[string]interface{}{"Path": appDir}))
-			cmd.ui.Say(T("Uploading {{.ZipFileBytes}}, {{.FileCount}} files",
-				map[string]interface{}{
-					"ZipFileBytes": formatters.ByteSize(zipFileSize),
-					"FileCount":    zipFileCount}))
-		}
-	}
-
-	err = os.RemoveAll(uploadDir)
-	if err != nil {
-		return err
 	}
 
-	return cmd.actor.UploadApp(appGUID, zipFile, remoteFiles)
+	return cmd.actor.UploadApp(appGUID, writeZip, remoteFiles)
 }
@@ -0,0 +1,429 @@
+package application
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"code.cloudfoundry.org/cli/cf/errors"
+	"code.cloudfoundry.org/cli/cf/flags"
+	"code.cloudfoundry.org/cli/cf/formatters"
+	. "code.cloudfoundry.org/cli/cf/i18n"
+	"code.cloudfoundry.org/cli/cf/models"
+	"code.cloudfoundry.org/cli/cf/terminal"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	dryRunOutputText = "text"
+	dryRunOutputJSON = "json"
+	dryRunOutputYAML = "yaml"
+
+	maskedValue = "***"
+)
+
+// pushPlan describes the changes that a push would make to each app.
+type pushPlan struct {
+	Apps []appPlan `json:"apps" yaml:"apps"`
+}
+
+// appPlan describes the changes that a push would make to a single app. The
+// action is either "create" or "update".
+type appPlan struct {
+	Name    string       `json:"name" yaml:"name"`
+	Action  string       `json:"action" yaml:"action"`
+	Changes []planChange `json:"changes" yaml:"changes"`
+}
+
+// planChange is a single property of an app that a push would add, remove or
+// update.
+type planChange struct {
+	Change   string `json:"change" yaml:"change"`
+	Property string `json:"property" yaml:"property"`
+	Current  string `json:"current,omitempty" yaml:"current,omitempty"`
+	New      string `json:"new,omitempty" yaml:"new,omitempty"`
+}
+
+const (
+	planActionCreate = "create"
+	planActionUpdate = "update"
+
+	planChangeAdd    = "add"
+	planChangeRemove = "remove"
+	planChangeUpdate = "update"
+)
+
+func (plan *appPlan) set(property string, current string, new string) {
+	switch {
+	case current == new:
+		return
+	case current == "":
+		plan.Changes = append(plan.Changes, planChange{Change: planChangeAdd, Property: property, New: new})
+	case new == "":
+		plan.Changes = append(plan.Changes, planChange{Change: planChangeRemove, Property: property, Current: current})
+	default:
+		plan.Changes = append(plan.Changes, planChange{Change: planChangeUpdate, Property: property, Current: current, New: new})
+	}
+}
+
+func dryRunOutput(c flags.FlagContext) (string, error) {
+	output := strings.ToLower(c.String("output"))
+	switch output {
+	case "", dryRunOutputText:
+		return dryRunOutputText, nil
+	case dryRunOutputJSON, dryRunOutputYAML:
+		return output, nil
+	}
+	return "", errors.New(T("Incorrect Usage. The '--output' option must be one of text, json or yaml."))
+}
+
+func isStructuredDryRun(c flags.FlagContext) bool {
+	output, err := dryRunOutput(c)
+	return c.Bool("dry-run") && err == nil && output != dryRunOutputText
+}
+
+// dryRun shows what pushing appSet would change without changing anything.
+// Only the existing apps, their bound services and the org's domains are read.
+func (cmd *Push) dryRun(appSet []models.AppParams, output string) error {
+	plan := pushPlan{Apps: []appPlan{}}
+	for _, appParams := range appSet {
+		if appParams.Name == nil {
+			return errors.New(T("Error: No name found for app"))
+		}
+
+		appPlan, err := cmd.planApp(appParams)
+		if err != nil {
+			return err
+		}
+		plan.Apps = append(plan.Apps, appPlan)
+	}
+
+	switch output {
+	case dryRunOutputJSON:
+		document, err := json.MarshalIndent(plan, "", "  ")
+		if err != nil {
+			return err
+		}
+		cmd.ui.Say("%s", string(document))
+	case dryRunOutputYAML:
+		document, err := yaml.Marshal(plan)
+		if err != nil {
+			return err
+		}
+		cmd.ui.Say("%s", strings.TrimSuffix(string(document), "\n"))
+	default:
+		return cmd.showPushPlan(plan)
+	}
+	return nil
+}
+
+func (cmd *Push) showPushPlan(plan pushPlan) error {
+	cmd.ui.Say(T("Showing the changes push would make in org {{.OrgName}} / space {{.SpaceName}} as {{.Username}}...",
+		map[string]interface{}{
+			"OrgName":   terminal.EntityNameColor(cmd.config.OrganizationFields().Name),
+			"SpaceName": terminal.EntityNameColor(cmd.config.SpaceFields().Name),
+			"Username":  terminal.EntityNameColor(cmd.config.Username())}))
+
+	symbols := map[string]string{
+		planChangeAdd:    "+",
+		planChangeRemove: "-",
+		planChangeUpdate: "~",
+	}
+
+	for _, appPlan := range plan.Apps {
+		cmd.ui.Say("")
+		if appPlan.Action == planActionCreate {
+			cmd.ui.Say(T("App {{.AppName}} would be created",
+				map[string]interface{}{"AppName": terminal.EntityNameColor(appPlan.Name)}))
+		} else {
+			cmd.ui.Say(T("App {{.AppName}} would be updated",
+				map[string]interface{}{"AppName": terminal.EntityNameColor(appPlan.Name)}))
+		}
+
+		if len(appPlan.Changes) == 0 {
+			cmd.ui.Say(T("No changes to the app's configuration"))
+			continue
+		}
+
+		table := cmd.ui.Table([]string{"", T("property"), T("current"), T("new")})
+		for _, change := range appPlan.Changes {
+			table.Add(symbols[change.Change], change.Property, change.Current, change.New)
+		}
+		err := table.Print()
+		if err != nil {
+			return err
+		}
+	}
+
+	cmd.ui.Say("")
+	cmd.ui.Say(T("This was a dry run, nothing was changed."))
+	return nil
+}
+
+func (cmd *Push) planApp(appParams models.AppParams) (appPlan, error) {
+	plan := appPlan{Name: *appParams.Name, Changes: []planChange{}}
+
+	var boundServices []string
+	var boundRoutes []models.RouteSummary
+	existingApp, err := cmd.appRepo.Read(*appParams.Name)
+	switch err.(type) {
+	case nil:
+		plan.Action = planActionUpdate
+
+		summary, err := cmd.appSummaryRepo.GetSummary(existingApp.GUID)
+		if err != nil {
+			return appPlan{}, err
+		}
+		for _, service := range summary.Services {
+			boundServices = append(boundServices, service.Name)
+		}
+		boundRoutes = summary.Routes
+	case *errors.ModelNotFoundError:
+		plan.Action = planActionCreate
+		existingApp = models.Application{}
+		existingApp.Name = *appParams.Name
+	default:
+		return appPlan{}, err
+	}
+
+	exists := plan.Action == planActionUpdate
+	current := func(value string) string {
+		if !exists {
+			return ""
+		}
+		return value
+	}
+
+	if appParams.Memory != nil {
+		plan.set("memory", current(formatters.ByteSize(existingApp.Memory*formatters.MEGABYTE)), formatters.ByteSize(*appParams.Memory*formatters.MEGABYTE))
+	}
+	if appParams.DiskQuota != nil {
+		plan.set("disk_quota", current(formatters.ByteSize(existingApp.DiskQuota*formatters.MEGABYTE)), formatters.ByteSize(*appParams.DiskQuota*formatters.MEGABYTE))
+	}
+	if appParams.InstanceCount != nil {
+		plan.set("instances", current(strconv.Itoa(existingApp.InstanceCount)), strconv.Itoa(*appParams.InstanceCount))
+	}
+	if appParams.BuildpackURL != nil {
+		plan.set("buildpack", existingApp.BuildpackURL, *appParams.BuildpackURL)
+	}
+	if appParams.StackName != nil {
+		var stackName string
+		if existingApp.Stack != nil {
+			stackName = existingApp.Stack.Name
+		}
+		plan.set("stack", stackName, *appParams.StackName)
+	}
+	if appParams.Command != nil {
+		plan.set("command", existingApp.Command, *appParams.Command)
+	}
+	if appParams.HealthCheckType != nil {
+		plan.set("health-check-type", existingApp.HealthCheckType, *appParams.HealthCheckType)
+	}
+
+	if appParams.EnvironmentVars != nil {
+		for _, key := range sortedEnvKeys(*appParams.EnvironmentVars) {
+			currentValue, found := existingApp.EnvironmentVars[key]
+			switch {
+			case !found:
+				plan.set("env: "+key, "", maskedValue)
+			case fmt.Sprint(currentValue) != fmt.Sprint((*appParams.EnvironmentVars)[key]):
+				plan.Changes = append(plan.Changes, planChange{Change: planChangeUpdate, Property: "env: " + key, Current: maskedValue, New: maskedValue})
+			}
+		}
+	}
+
+	routesToAdd, routesToRemove, err := cmd.planRoutes(existingApp.Name, boundRoutes, appParams)
+	if err != nil {
+		return appPlan{}, err
+	}
+	for _, route := range routesToAdd {
+		plan.set("route", "", route)
+	}
+	for _, route := range routesToRemove {
+		plan.set("route", route, "")
+	}
+
+	for _, serviceName := range appParams.ServicesToBind {
+		if !containsString(boundServices, serviceName) {
+			plan.set("service", "", serviceName)
+		}
+	}
+
+	return plan, nil
+}
+
+// planRoutes works out the routes that updateRoutes would bind to and unbind
+// from an app with boundRoutes, without creating or binding any of them.
+func (cmd *Push) planRoutes(appName string, boundRoutes []models.RouteSummary, appParams models.AppParams) ([]string, []string, error) {
+	if appParams.NoRoute {
+		routesToRemove := []string{}
+		for _, route := range boundRoutes {
+			routesToRemove = append(routesToRemove, route.URL())
+		}
+		return nil, routesToRemove, nil
+	}
+
+	defaultRouteAcceptable := len(boundRoutes) == 0
+	routeDefined := appParams.Domains != nil || !appParams.IsHostEmpty() || appParams.IsNoHostnameTrue()
+
+	var routes []plannedRoute
+	switch {
+	case len(appParams.Routes) > 0:
+		for _, manifestRoute := range appParams.Routes {
+			route, err := cmd.planManifestRoute(manifestRoute.Route)
+			if err != nil {
+				return nil, nil, err
+			}
+			routes = append(routes, route)
+		}
+	case routeDefined || defaultRouteAcceptable:
+		var domains []models.DomainFields
+		if appParams.Domains == nil {
+			domain, err := cmd.findDomain(nil)
+			if err != nil {
+				return nil, nil, err
+			}
+			domains = append(domains, domain)
+		} else {
+			for _, d := range appParams.Domains {
+				domain, err := cmd.findDomain(&d)
+				if err != nil {
+					return nil, nil, err
+				}
+				domains = append(domains, domain)
+			}
+		}
+
+		hosts := appParams.Hosts
+		if appParams.IsHostEmpty() {
+			hosts = []string{""}
+		}
+
+		for _, domain := range domains {
+			for _, host := range hosts {
+				routes = append(routes, planRoute(appName, host, domain, appParams))
+			}
+		}
+	}
+
+	routesToAdd := []string{}
+	for _, route := range routes {
+		if !route.boundTo(boundRoutes) && !containsString(routesToAdd, route.URL()) {
+			routesToAdd = append(routesToAdd, route.URL())
+		}
+	}
+	return routesToAdd, nil, nil
+}
+
+// plannedRoute is a route that a push would bind. Random hostnames and ports
+// are only known once the route is created.
+type plannedRoute struct {
+	models.RoutePresenter
+	randomHost bool
+	randomPort bool
+}
+
+func (route plannedRoute) URL() string {
+	url := route.RoutePresenter.URL()
+	if route.randomPort {
+		url = fmt.Sprintf("%s:%s", url, T("RANDOM_PORT"))
+	}
+	return url
+}
+
+// boundTo returns whether one of boundRoutes has the route's host, domain,
+// path and port.
+func (route plannedRoute) boundTo(boundRoutes []models.RouteSummary) bool {
+	if route.randomHost || route.randomPort {
+		return false
+	}
+
+	for _, boundRoute := range boundRoutes {
+		if strings.EqualFold(boundRoute.Host, route.Host) &&
+			strings.EqualFold(boundRoute.Domain.Name, route.Domain) &&
+			routePath(boundRoute.Path) == routePath(route.Path) &&
+			boundRoute.Port == route.Port {
+			return true
+		}
+	}
+	return false
+}
+
+// planManifestRoute splits a route from the manifest into its host, domain,
+// path and port the way the route actor does when binding it.
+func (cmd *Push) planManifestRoute(routeName string) (plannedRoute, error) {
+	routeWithoutPath, path := cmd.routeActor.FindPath(routeName)
+
+	routeWithoutPathAndPort, port, err := cmd.routeActor.FindPort(routeWithoutPath)
+	if err != nil {
+		return plannedRoute{}, err
+	}
+
+	host, domain, err := cmd.routeActor.FindDomain(routeWithoutPathAndPort)
+	if err != nil {
+		return plannedRoute{}, err
+	}
+
+	return plannedRoute{
+		RoutePresenter: models.RoutePresenter{
+			Host:   host,
+			Domain: domain.Name,
+			Path:   routePath(path),
+			Port:   port,
+		},
+	}, nil
+}
+
+// planRoute returns the route that createAndBindRoute would bind. Random
+// hostnames and ports are shown as placeholders.
+func planRoute(appName string, host string, domain models.DomainFields, appParams models.AppParams) plannedRoute {
+	route := plannedRoute{randomPort: isTCP(domain)}
+
+	if !appParams.IsNoHostnameTrue() {
+		switch {
+		case host != "":
+			route.Host = host
+		case route.randomPort:
+		case appParams.UseRandomRoute:
+			route.Host = hostNameForString(appName) + "-" + T("RANDOM_WORD")
+			route.randomHost = true
+		default:
+			route.Host = hostNameForString(appName)
+		}
+	}
+
+	route.Domain = domain.Name
+	if appParams.RoutePath != nil {
+		route.Path = routePath(*appParams.RoutePath)
+	}
+	return route
+}
+
+// routePath returns path with the leading slash that Cloud Controller
+// stores route paths with.
+func routePath(path string) string {
+	if path == "" || strings.HasPrefix(path, "/") {
+		return path
+	}
+	return "/" + path
+}
+
+func sortedEnvKeys(env map[string]interface{}) []string {
+	keys := make([]string, 0, len(env))
+	for key := range env {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func containsString(list []string, item string) bool {
+	for _, s := range list {
+		if s == item {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,93 @@
+package application
+
+import (
+	"code.cloudfoundry.org/cli/cf/appfiles"
+	"code.cloudfoundry.org/cli/cf/errors"
+	. "code.cloudfoundry.org/cli/cf/i18n"
+	"code.cloudfoundry.org/cli/cf/models"
+	"code.cloudfoundry.org/cli/cf/terminal"
+)
+
+// showIgnored lists the files of each app in appSet that a push would upload
+// or leave out, with the .cfignore rule that decided each one. Nothing is
+// pushed.
+func (cmd *Push) showIgnored(appSet []models.AppParams) error {
+	for i, appParams := range appSet {
+		if appParams.Name == nil {
+			return errors.New(T("Error: No name found for app"))
+		}
+
+		if i > 0 {
+			cmd.ui.Say("")
+		}
+
+		if isDockerApp(appParams) {
+			cmd.ui.Say(T("App {{.AppName}} is pushed from a docker image and has no files to upload",
+				map[string]interface{}{"AppName": terminal.EntityNameColor(*appParams.Name)}))
+			continue
+		}
+
+		err := cmd.actor.ProcessPath(*appParams.Path, func(appDir string) error {
+			decisions, err := cmd.appfiles.IgnoreDecisions(appDir)
+			if err != nil {
+				return err
+			}
+			return cmd.showIgnoreDecisions(*appParams.Name, *appParams.Path, decisions)
+		})
+		if err != nil {
+			return errors.New(
+				T("Error processing app files: {{.Error}}",
+					map[string]interface{}{
+						"Error": err.Error(),
+					}),
+			)
+		}
+	}
+
+	return nil
+}
+
+func (cmd *Push) showIgnoreDecisions(appName string, path string, decisions []appfiles.IgnoreDecision) error {
+	cmd.ui.Say(T("Showing the files push would upload for app {{.AppName}} from {{.Path}}...",
+		map[string]interface{}{
+			"AppName": terminal.EntityNameColor(appName),
+			"Path":    terminal.EntityNameColor(path),
+		}))
+	cmd.ui.Say("")
+
+	var included, ignored int
+	table := cmd.ui.Table([]string{T("status"), T("file"), T("rule")})
+	for _, decision := range decisions {
+		status := T("included")
+		if decision.Ignored {
+			status = T("ignored")
+			ignored++
+		} else if !decision.IsDir {
+			included++
+		}
+
+		name := decision.Path
+		if decision.IsDir {
+			name += "/"
+		}
+
+		var rule string
+		if decision.Rule != nil {
+			rule = decision.Rule.String()
+		}
+
+		table.Add(status, name, rule)
+	}
+	err := table.Print()
+	if err != nil {
+		return err
+	}
+
+	cmd.ui.Say("")
+	cmd.ui.Say(T("{{.Included}} files included, {{.Ignored}} files or directories ignored",
+		map[string]interface{}{
+			"Included": included,
+			"Ignored":  ignored,
+		}))
+	return nil
+}
@@ -1,8 +1,12 @@
 package application_test
 
 import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strings"
 	"syscall"
 
 	"code.cloudfoundry.org/cli/cf"
@@ -12,6 +16,7 @@ import (
 	"code.cloudfoundry.org/cli/cf/api/authentication/authenticationfakes"
 	"code.cloudfoundry.org/cli/cf/api/resources"
 	"code.cloudfoundry.org/cli/cf/api/stacks/stacksfakes"
+	cfappfiles "code.cloudfoundry.org/cli/cf/appfiles"
 	"code.cloudfoundry.org/cli/cf/appfiles/appfilesfakes"
 	"code.cloudfoundry.org/cli/cf/commandregistry"
 	"code.cloudfoundry.org/cli/cf/commands/application"
@@ -395,7 +400,7 @@ var _ = Describe("Push Command", func() {
 
 				Context("when pushing the app", func() {
 					BeforeEach(func() {
-						actor.GatherFilesReturns([]resources.AppFileResource{}, false, errors.New("failed to get file mode"))
+						actor.GatherFilesReturns([]resources.AppFileResource{}, nil, errors.New("failed to get file mode"))
 					})
 
 					It("notifies users about the error actor.GatherFiles() returns", func() {
@@ -818,6 +823,34 @@ var _ = Describe("Push Command", func() {
 					})
 				})
 
+				Context("when the manifest gives a docker image", func() {
+					BeforeEach(func() {
+						m := &manifest.Manifest{
+							Path: "manifest.yml",
+							Data: generic.NewMap(map[interface{}]interface{}{
+								"applications": []interface{}{
+									generic.NewMap(map[interface{}]interface{}{
+										"name":   "testApp",
+										"docker": generic.NewMap(map[interface{}]interface{}{"image": "sample/dockerImage"}),
+									}),
+								},
+							}),
+						}
+						manifestRepo.ReadManifestReturns(m, nil)
+						args = []string{}
+					})
+
+					It("pushes the docker image without uploading appbits", func() {
+						Expect(executeErr).NotTo(HaveOccurred())
+
+						params := appRepo.CreateArgsForCall(0)
+						Expect(*params.DockerImage).To(Equal("sample/dockerImage"))
+						Expect(*params.Diego).To(BeTrue())
+						Expect(actor.ProcessPathCallCount()).To(Equal(0))
+						Expect(actor.UploadAppCallCount()).To(Equal(0))
+					})
+				})
+
 				Context("when health-check-type '-u' or '--health-check-type' is set", func() {
 					Context("when the value is not 'port' or 'none'", func() {
 						BeforeEach(func() {
@@ -918,8 +951,8 @@ var _ = Describe("Push Command", func() {
 							deps.UI = uiWithContents
 
 							expectedDomain = models.DomainFields{
-								GUID: "some-guid",
-								Name: "some-name",
+								GUID:                   "some-guid",
+								Name:                   "some-name",
 								OwningOrganizationGUID: "some-organization-guid",
 								RouterGroupGUID:        "some-router-group-guid",
 								RouterGroupType:        "tcp",
@@ -988,7 +1021,7 @@ var _ = Describe("Push Command", func() {
 					It("includes the app files in dir", func() {
 						Expect(executeErr).NotTo(HaveOccurred())
 
-						actualLocalFiles, _, _ := actor.GatherFilesArgsForCall(0)
+						actualLocalFiles, _ := actor.GatherFilesArgsForCall(0)
 						Expect(actualLocalFiles).To(Equal(expectedLocalFiles))
 					})
 				})
@@ -1027,7 +1060,7 @@ var _ = Describe("Push Command", func() {
 					It("pushes the contents of the app directory or zip file specified", func() {
 						Expect(executeErr).NotTo(HaveOccurred())
 
-						_, appDir, _ := actor.GatherFilesArgsForCall(0)
+						_, appDir := actor.GatherFilesArgsForCall(0)
 						Expect(appDir).To(Equal("../some/path-to/an-app/file.zip"))
 					})
 				})
@@ -1063,7 +1096,7 @@ var _ = Describe("Push Command", func() {
 						Expect(executeErr).NotTo(HaveOccurred())
 
 						dir, _ := os.Getwd()
-						_, appDir, _ := actor.GatherFilesArgsForCall(0)
+						_, appDir := actor.GatherFilesArgsForCall(0)
 						Expect(appDir).To(Equal(dir))
 					})
 				})
@@ -1135,6 +1168,57 @@ var _ = Describe("Push Command", func() {
 					})
 				})
 
+				Context("when the manifest contains variables", func() {
+					var varsFile string
+
+					BeforeEach(func() {
+						m := &manifest.Manifest{
+							Path: "manifest.yml",
+							Data: generic.NewMap(map[interface{}]interface{}{
+								"applications": []interface{}{
+									generic.NewMap(map[interface{}]interface{}{
+										"name":      "((name))",
+										"instances": "((instances))",
+									}),
+								},
+							}),
+						}
+						manifestRepo.ReadManifestReturns(m, nil)
+
+						file, err := ioutil.TempFile("", "vars-file")
+						Expect(err).NotTo(HaveOccurred())
+						_, err = file.WriteString("name: vars-file-app\ninstances: 4\n")
+						Expect(err).NotTo(HaveOccurred())
+						Expect(file.Close()).To(Succeed())
+						varsFile = file.Name()
+
+						args = []string{"--vars-file", varsFile, "--var", "name=var-app"}
+					})
+
+					AfterEach(func() {
+						os.Remove(varsFile)
+					})
+
+					It("replaces them with the values of --var and --vars-file", func() {
+						Expect(executeErr).NotTo(HaveOccurred())
+
+						params := appRepo.CreateArgsForCall(0)
+						Expect(*params.Name).To(Equal("var-app"))
+						Expect(*params.InstanceCount).To(Equal(4))
+					})
+
+					Context("when a variable has no value", func() {
+						BeforeEach(func() {
+							args = []string{"--var", "name=var-app"}
+						})
+
+						It("returns an error listing it", func() {
+							Expect(executeErr).To(HaveOccurred())
+							Expect(executeErr.Error()).To(ContainSubstring("Expected to find variables: instances"))
+						})
+					})
+				})
+
 				Context("when the 'no-manifest'flag is passed", func() {
 					BeforeEach(func() {
 						args = []string{"--no-route", "--no-manifest", "app-name"}
@@ -1364,6 +1448,426 @@ var _ = Describe("Push Command", func() {
 						})
 					})
 				})
+
+				Context("when apps in the manifest depend on each other", func() {
+					var apps []interface{}
+
+					BeforeEach(func() {
+						deps.UI = uiWithContents
+						apps = []interface{}{
+							generic.NewMap(map[interface{}]interface{}{
+								"name":       "web",
+								"no-route":   true,
+								"depends-on": []interface{}{"api"},
+							}),
+							generic.NewMap(map[interface{}]interface{}{
+								"name":       "api",
+								"no-route":   true,
+								"depends-on": []interface{}{"db-migrate"},
+							}),
+							generic.NewMap(map[interface{}]interface{}{
+								"name":     "db-migrate",
+								"no-route": true,
+							}),
+							generic.NewMap(map[interface{}]interface{}{
+								"name":     "worker",
+								"no-route": true,
+							}),
+						}
+						manifestRepo.ReadManifestStub = func(string) (*manifest.Manifest, error) {
+							return &manifest.Manifest{
+								Data: generic.NewMap(map[interface{}]interface{}{"applications": apps}),
+							}, nil
+						}
+						args = []string{}
+					})
+
+					createdApps := func() []string {
+						var names []string
+						for i := 0; i < appRepo.CreateCallCount(); i++ {
+							names = append(names, *appRepo.CreateArgsForCall(i).Name)
+						}
+						return names
+					}
+
+					It("pushes every app after the apps it depends on", func() {
+						Expect(executeErr).NotTo(HaveOccurred())
+						Expect(createdApps()).To(Equal([]string{"db-migrate", "api", "web", "worker"}))
+					})
+
+					Context("when the apps depend on each other in a cycle", func() {
+						BeforeEach(func() {
+							apps[2].(generic.Map).Set("depends-on", []interface{}{"web"})
+						})
+
+						It("returns an error without pushing anything", func() {
+							Expect(executeErr).To(MatchError("Apps web, api, db-migrate depend on each other and cannot be ordered"))
+							Expect(appRepo.CreateCallCount()).To(BeZero())
+						})
+					})
+
+					Context("when a single app is given as an arg", func() {
+						BeforeEach(func() {
+							args = []string{"web"}
+						})
+
+						It("pushes only that app", func() {
+							Expect(executeErr).NotTo(HaveOccurred())
+							Expect(createdApps()).To(Equal([]string{"web"}))
+						})
+					})
+
+					Context("when --parallel is given", func() {
+						BeforeEach(func() {
+							args = []string{"--parallel", "2"}
+						})
+
+						It("pushes every app, with each line of output prefixed by the app name", func() {
+							Expect(executeErr).NotTo(HaveOccurred())
+							Expect(createdApps()).To(ConsistOf("db-migrate", "api", "web", "worker"))
+
+							totalOutput := terminal.Decolorize(string(output.Contents()))
+							Expect(totalOutput).To(ContainSubstring("Pushing 4 apps, 2 at a time..."))
+							Expect(totalOutput).To(ContainSubstring("[web] Creating app web"))
+							Expect(totalOutput).To(ContainSubstring("[worker] Creating app worker"))
+							Expect(totalOutput).To(MatchRegexp(`web\s+pushed`))
+							Expect(totalOutput).To(MatchRegexp(`worker\s+pushed`))
+						})
+
+						It("pushes every app after the apps it depends on", func() {
+							Expect(executeErr).NotTo(HaveOccurred())
+
+							names := createdApps()
+							Expect(indexOf(names, "db-migrate")).To(BeNumerically("<", indexOf(names, "api")))
+							Expect(indexOf(names, "api")).To(BeNumerically("<", indexOf(names, "web")))
+						})
+
+						It("starts every app", func() {
+							Expect(starter.ApplicationStartCallCount()).To(Equal(4))
+						})
+
+						Context("when an app fails to push", func() {
+							BeforeEach(func() {
+								appRepo.CreateStub = func(params models.AppParams) (models.Application, error) {
+									if *params.Name == "api" {
+										return models.Application{}, errors.New("api could not be created")
+									}
+									return models.Application{GUID: *params.Name + "-guid", Name: *params.Name, State: "stopped"}, nil
+								}
+							})
+
+							It("does not push the apps that depend on it and lists what happened to each app", func() {
+								Expect(executeErr).To(MatchError("2 of 4 apps were not pushed"))
+								Expect(createdApps()).NotTo(ContainElement("web"))
+
+								totalOutput := terminal.Decolorize(string(output.Contents()))
+								Expect(totalOutput).To(MatchRegexp(`db-migrate\s+pushed`))
+								Expect(totalOutput).To(MatchRegexp(`api\s+failed\s+api could not be created`))
+								Expect(totalOutput).To(MatchRegexp(`web\s+skipped\s+api was not pushed`))
+							})
+
+							Context("when an app that does not depend on it is still waiting to be pushed", func() {
+								BeforeEach(func() {
+									apps = append(apps, generic.NewMap(map[interface{}]interface{}{
+										"name":       "reports",
+										"no-route":   true,
+										"depends-on": []interface{}{"worker"},
+									}))
+
+									apiCreated := make(chan struct{})
+									appRepo.CreateStub = func(params models.AppParams) (models.Application, error) {
+										switch *params.Name {
+										case "api":
+											close(apiCreated)
+											return models.Application{}, errors.New("api could not be created")
+										case "worker":
+											<-apiCreated
+										}
+										return models.Application{GUID: *params.Name + "-guid", Name: *params.Name, State: "stopped"}, nil
+									}
+								})
+
+								It("keeps pushing it", func() {
+									Expect(executeErr).To(MatchError("2 of 5 apps were not pushed"))
+									Expect(createdApps()).To(ContainElement("reports"))
+
+									totalOutput := terminal.Decolorize(string(output.Contents()))
+									Expect(totalOutput).To(MatchRegexp(`worker\s+pushed`))
+									Expect(totalOutput).To(MatchRegexp(`reports\s+pushed`))
+								})
+							})
+						})
+
+						Context("when an app that others depend on through another app fails to push", func() {
+							BeforeEach(func() {
+								appRepo.CreateStub = func(params models.AppParams) (models.Application, error) {
+									if *params.Name == "db-migrate" {
+										return models.Application{}, errors.New("db-migrate could not be created")
+									}
+									return models.Application{GUID: *params.Name + "-guid", Name: *params.Name, State: "stopped"}, nil
+								}
+							})
+
+							It("skips every app that depends on it and pushes the others", func() {
+								Expect(executeErr).To(MatchError("3 of 4 apps were not pushed"))
+								Expect(createdApps()).To(ConsistOf("db-migrate", "worker"))
+
+								totalOutput := terminal.Decolorize(string(output.Contents()))
+								Expect(totalOutput).To(MatchRegexp(`db-migrate\s+failed\s+db-migrate could not be created`))
+								Expect(totalOutput).To(MatchRegexp(`api\s+skipped\s+db-migrate was not pushed`))
+								Expect(totalOutput).To(MatchRegexp(`web\s+skipped\s+api was not pushed`))
+								Expect(totalOutput).To(MatchRegexp(`worker\s+pushed`))
+							})
+						})
+
+						Context("when --parallel is not a positive number", func() {
+							BeforeEach(func() {
+								args = []string{"--parallel", "0"}
+							})
+
+							It("returns an error", func() {
+								Expect(executeErr).To(MatchError("Incorrect Usage. The '--parallel' option must be a positive number."))
+								Expect(appRepo.CreateCallCount()).To(BeZero())
+							})
+						})
+					})
+				})
+			})
+		})
+
+		Context("when --show-ignored is given", func() {
+			BeforeEach(func() {
+				deps.UI = uiWithContents
+
+				ignore := cfappfiles.NewCfIgnore("*.log\n")
+				_, logRule := ignore.Match("debug.log", false)
+				_, gitRule := ignore.Match(".git", true)
+				appfiles.IgnoreDecisionsReturns([]cfappfiles.IgnoreDecision{
+					{Path: ".git", IsDir: true, Ignored: true, Rule: gitRule},
+					{Path: "app.rb"},
+					{Path: "debug.log", Ignored: true, Rule: logRule},
+					{Path: "lib", IsDir: true},
+					{Path: "lib/helper.rb"},
+				}, nil)
+
+				args = []string{"app-name", "-p", "/some/app", "--no-manifest", "--show-ignored"}
+			})
+
+			It("lists the files that would be uploaded or ignored without pushing", func() {
+				Expect(executeErr).NotTo(HaveOccurred())
+
+				Expect(actor.ProcessPathCallCount()).To(Equal(1))
+				path, _ := actor.ProcessPathArgsForCall(0)
+				Expect(path).To(Equal("/some/app"))
+				Expect(appfiles.IgnoreDecisionsArgsForCall(0)).To(Equal("/some/app"))
+
+				Expect(appRepo.CreateCallCount()).To(BeZero())
+				Expect(appRepo.UpdateCallCount()).To(BeZero())
+				Expect(actor.UploadAppCallCount()).To(BeZero())
+				Expect(authRepo.RefreshAuthTokenCallCount()).To(BeZero())
+
+				totalOutput := terminal.Decolorize(string(output.Contents()))
+				Expect(totalOutput).To(ContainSubstring("Showing the files push would upload for app app-name from /some/app..."))
+				Expect(totalOutput).To(MatchRegexp(`ignored\s+\.git/\s+\(default\):\.git`))
+				Expect(totalOutput).To(MatchRegexp(`included\s+app\.rb`))
+				Expect(totalOutput).To(MatchRegexp(`ignored\s+debug\.log\s+\.cfignore:1:\*\.log`))
+				Expect(totalOutput).To(MatchRegexp(`included\s+lib/`))
+				Expect(totalOutput).To(ContainSubstring("2 files included, 2 files or directories ignored"))
+			})
+		})
+
+		Context("when --dry-run is given", func() {
+			var appSummaryRepo *apifakes.FakeAppSummaryRepository
+
+			BeforeEach(func() {
+				deps.UI = uiWithContents
+				appSummaryRepo = new(apifakes.FakeAppSummaryRepository)
+				appSummaryRepo.GetSummaryReturns(models.Application{
+					Services: []models.ServicePlanSummary{{Name: "db"}},
+					Routes: []models.RouteSummary{
+						{Host: "existing-app", Domain: models.DomainFields{Name: "foo.cf-app.com"}},
+						{Host: "existing-app", Domain: models.DomainFields{Name: "foo.cf-app.com"}, Path: "/api"},
+					},
+				}, nil)
+				deps.RepoLocator = deps.RepoLocator.SetAppSummaryRepository(appSummaryRepo)
+
+				manifestRepo.ReadManifestReturns(&manifest.Manifest{
+					Path: "manifest.yml",
+					Data: generic.NewMap(map[interface{}]interface{}{
+						"applications": []interface{}{
+							generic.NewMap(map[interface{}]interface{}{
+								"name":      "existing-app",
+								"memory":    "512M",
+								"instances": 3,
+								"host":      "new-host",
+								"services":  []interface{}{"db", "cache"},
+								"env": generic.NewMap(map[interface{}]interface{}{
+									"CHANGED": "secret-new",
+									"ADDED":   "secret-added",
+									"SAME":    "unchanged",
+								}),
+							}),
+							generic.NewMap(map[interface{}]interface{}{
+								"name":     "new-app",
+								"memory":   "256M",
+								"no-route": true,
+							}),
+						},
+					}),
+				}, nil)
+
+				appRepo.ReadStub = func(name string) (models.Application, error) {
+					if name != "existing-app" {
+						return models.Application{}, errors.NewModelNotFoundError("App", name)
+					}
+
+					app := models.Application{}
+					app.Name = "existing-app"
+					app.GUID = "existing-app-guid"
+					app.Memory = 256
+					app.InstanceCount = 3
+					app.EnvironmentVars = map[string]interface{}{
+						"CHANGED": "secret-old",
+						"SAME":    "unchanged",
+					}
+					return app, nil
+				}
+
+				args = []string{"--dry-run"}
+			})
+
+			It("shows the changes to each app without making them", func() {
+				Expect(executeErr).NotTo(HaveOccurred())
+
+				Expect(appRepo.CreateCallCount()).To(BeZero())
+				Expect(appRepo.UpdateCallCount()).To(BeZero())
+				Expect(routeActor.FindOrCreateRouteCallCount()).To(BeZero())
+				Expect(routeActor.BindRouteCallCount()).To(BeZero())
+				Expect(actor.UploadAppCallCount()).To(BeZero())
+				Expect(starter.ApplicationStartCallCount()).To(BeZero())
+				Expect(appSummaryRepo.GetSummaryArgsForCall(0)).To(Equal("existing-app-guid"))
+
+				totalOutput := terminal.Decolorize(string(output.Contents()))
+				Expect(totalOutput).To(ContainSubstring("App existing-app would be updated"))
+				Expect(totalOutput).To(MatchRegexp(`~\s+memory\s+256M\s+512M`))
+				Expect(totalOutput).To(MatchRegexp(`\+\s+env: ADDED\s+\*\*\*`))
+				Expect(totalOutput).To(MatchRegexp(`~\s+env: CHANGED\s+\*\*\*\s+\*\*\*`))
+				Expect(totalOutput).To(MatchRegexp(`\+\s+route\s+new-host.foo.cf-app.com`))
+				Expect(totalOutput).To(MatchRegexp(`\+\s+service\s+cache`))
+				Expect(totalOutput).NotTo(MatchRegexp(`service\s+db`))
+				Expect(totalOutput).NotTo(ContainSubstring("instances"))
+				Expect(totalOutput).NotTo(ContainSubstring("SAME"))
+				Expect(totalOutput).NotTo(ContainSubstring("secret"))
+
+				Expect(totalOutput).To(ContainSubstring("App new-app would be created"))
+				Expect(totalOutput).To(MatchRegexp(`\+\s+memory\s+256M`))
+				Expect(totalOutput).To(ContainSubstring("This was a dry run, nothing was changed."))
+			})
+
+			Context("when the manifest lists routes", func() {
+				BeforeEach(func() {
+					manifestRepo.ReadManifestReturns(&manifest.Manifest{
+						Path: "manifest.yml",
+						Data: generic.NewMap(map[interface{}]interface{}{
+							"applications": []interface{}{
+								generic.NewMap(map[interface{}]interface{}{
+									"name": "existing-app",
+									"routes": []interface{}{
+										map[interface{}]interface{}{"route": "existing-app.foo.cf-app.com/api"},
+										map[interface{}]interface{}{"route": "existing-app.foo.cf-app.com/admin"},
+									},
+								}),
+							},
+						}),
+					}, nil)
+
+					routeActor.FindPathStub = func(routeName string) (string, string) {
+						parts := strings.SplitN(routeName, "/", 2)
+						return parts[0], parts[1]
+					}
+					routeActor.FindPortStub = func(routeName string) (string, int, error) {
+						return routeName, 0, nil
+					}
+					routeActor.FindDomainReturns("existing-app", models.DomainFields{Name: "foo.cf-app.com"}, nil)
+				})
+
+				It("only shows the routes that differ from the bound ones in host, domain, path or port", func() {
+					Expect(executeErr).NotTo(HaveOccurred())
+
+					totalOutput := terminal.Decolorize(string(output.Contents()))
+					Expect(totalOutput).To(MatchRegexp(`\+\s+route\s+existing-app.foo.cf-app.com/admin`))
+					Expect(totalOutput).NotTo(ContainSubstring("existing-app.foo.cf-app.com/api"))
+				})
+			})
+
+			Context("when the app has routes and --no-route is given", func() {
+				BeforeEach(func() {
+					args = []string{"existing-app", "--dry-run", "--no-route", "--no-manifest"}
+				})
+
+				It("shows the routes that would be unbound", func() {
+					Expect(executeErr).NotTo(HaveOccurred())
+					Expect(routeActor.UnbindAllCallCount()).To(BeZero())
+
+					totalOutput := terminal.Decolorize(string(output.Contents()))
+					Expect(totalOutput).To(MatchRegexp(`-\s+route\s+existing-app.foo.cf-app.com`))
+				})
+			})
+
+			Context("when --output json is given", func() {
+				BeforeEach(func() {
+					args = []string{"--dry-run", "--output", "json"}
+				})
+
+				It("shows the changes as a single JSON document", func() {
+					Expect(executeErr).NotTo(HaveOccurred())
+
+					var plan struct {
+						Apps []struct {
+							Name    string              `json:"name"`
+							Action  string              `json:"action"`
+							Changes []map[string]string `json:"changes"`
+						} `json:"apps"`
+					}
+					Expect(json.Unmarshal(output.Contents(), &plan)).To(Succeed())
+
+					Expect(plan.Apps).To(HaveLen(2))
+					Expect(plan.Apps[0].Name).To(Equal("existing-app"))
+					Expect(plan.Apps[0].Action).To(Equal("update"))
+					Expect(plan.Apps[0].Changes).To(ContainElement(map[string]string{
+						"change":   "update",
+						"property": "memory",
+						"current":  "256M",
+						"new":      "512M",
+					}))
+					Expect(plan.Apps[1].Name).To(Equal("new-app"))
+					Expect(plan.Apps[1].Action).To(Equal("create"))
+				})
+			})
+
+			Context("when --output yaml is given", func() {
+				BeforeEach(func() {
+					args = []string{"--dry-run", "--output", "yaml"}
+				})
+
+				It("shows the changes as a single YAML document", func() {
+					Expect(executeErr).NotTo(HaveOccurred())
+
+					totalOutput := string(output.Contents())
+					Expect(totalOutput).To(HavePrefix("apps:\n- name: existing-app\n  action: update\n"))
+					Expect(totalOutput).To(ContainSubstring("- name: new-app\n  action: create\n"))
+				})
+			})
+
+			Context("when --output is not a known format", func() {
+				BeforeEach(func() {
+					args = []string{"--dry-run", "--output", "xml"}
+				})
+
+				It("returns an error", func() {
+					Expect(executeErr).To(MatchError("Incorrect Usage. The '--output' option must be one of text, json or yaml."))
+					Expect(appRepo.ReadCallCount()).To(BeZero())
+				})
 			})
 		})
 
@@ -1621,6 +2125,204 @@ var _ = Describe("Push Command", func() {
 				})
 			})
 
+			Context("when --strategy blue-green is given", func() {
+				var (
+					existingRoute models.RouteSummary
+					events        []string
+				)
+
+				BeforeEach(func() {
+					events = nil
+					existingRoute = models.RouteSummary{
+						GUID:   "existing-route-guid",
+						Host:   "existing-app",
+						Domain: models.DomainFields{Name: "example.com", GUID: "domain-guid"},
+					}
+					existingApp.Routes = []models.RouteSummary{existingRoute}
+					existingApp.HealthCheckHTTPEndpoint = "/health"
+
+					appSummaryRepo := new(apifakes.FakeAppSummaryRepository)
+					appSummaryRepo.GetSummaryReturns(models.Application{
+						Services: []models.ServicePlanSummary{{GUID: "existing-service-guid", Name: "existing-service"}},
+					}, nil)
+					deps.RepoLocator = deps.RepoLocator.SetAppSummaryRepository(appSummaryRepo)
+
+					serviceRepo.FindInstanceByNameStub = func(name string) (models.ServiceInstance, error) {
+						return models.ServiceInstance{
+							ServiceInstanceFields: models.ServiceInstanceFields{Name: name},
+						}, nil
+					}
+
+					appRepo.ReadStub = func(name string) (models.Application, error) {
+						if name == "existing-app" {
+							return existingApp, nil
+						}
+						return models.Application{}, errors.NewModelNotFoundError("App", name)
+					}
+					appRepo.CreateStub = func(params models.AppParams) (models.Application, error) {
+						a := models.Application{}
+						a.GUID = *params.Name + "-guid"
+						a.Name = *params.Name
+						a.State = "stopped"
+						return a, nil
+					}
+					starter.ApplicationStartStub = func(app models.Application, _ string, _ string) (models.Application, error) {
+						events = append(events, "start "+app.GUID)
+						return app, nil
+					}
+					routeActor.BindRouteStub = func(app models.Application, route models.Route) error {
+						events = append(events, "bind "+route.GUID+" "+app.GUID)
+						return nil
+					}
+					routeActor.UnbindAllStub = func(app models.Application) error {
+						events = append(events, "unbind "+app.GUID)
+						return nil
+					}
+					appRepo.DeleteStub = func(appGUID string) error {
+						events = append(events, "delete "+appGUID)
+						return nil
+					}
+					appRepo.UpdateStub = func(appGUID string, params models.AppParams) (models.Application, error) {
+						events = append(events, "rename "+appGUID+" "+*params.Name)
+						return models.Application{}, nil
+					}
+
+					args = []string{"--strategy", "blue-green", "existing-app"}
+				})
+
+				It("pushes a new app with the existing app's settings", func() {
+					Expect(executeErr).NotTo(HaveOccurred())
+
+					Expect(appRepo.CreateCallCount()).To(Equal(1))
+					params := appRepo.CreateArgsForCall(0)
+					Expect(*params.Name).To(Equal("existing-app-new"))
+					Expect(*params.Command).To(Equal("unicorn -c config/unicorn.rb -D"))
+					Expect(*params.EnvironmentVars).To(HaveKeyWithValue("crazy", "pants"))
+					Expect(params.GUID).To(BeNil())
+					Expect(params.State).To(BeNil())
+					Expect(*params.HealthCheckHTTPEndpoint).To(Equal("/health"))
+
+					appGUID, _, _ := actor.UploadAppArgsForCall(0)
+					Expect(appGUID).To(Equal("existing-app-new-guid"))
+					Expect(stopper.ApplicationStopCallCount()).To(Equal(0))
+				})
+
+				It("moves the routes once the new app has started and replaces the existing app", func() {
+					Expect(executeErr).NotTo(HaveOccurred())
+
+					Expect(events).To(Equal([]string{
+						"start existing-app-new-guid",
+						"bind existing-route-guid existing-app-new-guid",
+						"unbind existing-app-guid",
+						"delete existing-app-guid",
+						"rename existing-app-new-guid existing-app",
+					}))
+
+					Expect(terminal.Decolorize(string(output.Contents()))).To(ContainSubstring("Creating app existing-app-new to replace existing-app"))
+				})
+
+				It("binds the existing app's services to the new app", func() {
+					Expect(executeErr).NotTo(HaveOccurred())
+
+					Expect(serviceBinder.AppsToBind).To(HaveLen(1))
+					Expect(serviceBinder.AppsToBind[0].Name).To(Equal("existing-app-new"))
+					Expect(serviceBinder.InstancesToBindTo[0].Name).To(Equal("existing-service"))
+				})
+
+				Context("when a route is given", func() {
+					BeforeEach(func() {
+						args = []string{"--strategy", "blue-green", "-n", "new-host", "existing-app"}
+					})
+
+					It("maps it to the new app as well", func() {
+						Expect(executeErr).NotTo(HaveOccurred())
+
+						Expect(routeActor.FindOrCreateRouteCallCount()).To(Equal(1))
+						hostname, _, _, _, _ := routeActor.FindOrCreateRouteArgsForCall(0)
+						Expect(hostname).To(Equal("new-host"))
+						Expect(routeActor.BindRouteCallCount()).To(Equal(2))
+					})
+				})
+
+				Context("when the new app fails to start", func() {
+					BeforeEach(func() {
+						starter.ApplicationStartStub = nil
+						starter.ApplicationStartReturns(models.Application{}, errors.New("app crashed"))
+					})
+
+					It("leaves the existing app and its routes alone", func() {
+						Expect(executeErr).To(MatchError(ContainSubstring("app crashed")))
+
+						Expect(routeActor.BindRouteCallCount()).To(Equal(0))
+						Expect(routeActor.UnbindAllCallCount()).To(Equal(0))
+						Expect(appRepo.DeleteCallCount()).To(Equal(0))
+						Expect(appRepo.UpdateCallCount()).To(Equal(0))
+						Expect(terminal.Decolorize(string(output.Contents()))).To(ContainSubstring("App existing-app was not changed and is still mapped to its routes."))
+					})
+				})
+
+				Context("when the new app already exists from an earlier push", func() {
+					BeforeEach(func() {
+						appRepo.ReadStub = func(name string) (models.Application, error) {
+							app := existingApp
+							if name == "existing-app-new" {
+								app = models.Application{}
+								app.Name = name
+								app.GUID = "leftover-guid"
+								app.State = "stopped"
+							}
+							return app, nil
+						}
+						updateStub := appRepo.UpdateStub
+						appRepo.UpdateStub = func(appGUID string, params models.AppParams) (models.Application, error) {
+							if appGUID == "leftover-guid" && *params.Name == "existing-app-new" {
+								return models.Application{ApplicationFields: models.ApplicationFields{GUID: appGUID, Name: *params.Name, State: "stopped"}}, nil
+							}
+							return updateStub(appGUID, params)
+						}
+					})
+
+					It("updates it instead of creating another app", func() {
+						Expect(executeErr).NotTo(HaveOccurred())
+						Expect(appRepo.CreateCallCount()).To(Equal(0))
+						Expect(events).To(ContainElement("rename leftover-guid existing-app"))
+					})
+				})
+
+				Context("when the app does not exist yet", func() {
+					BeforeEach(func() {
+						args = []string{"--strategy", "blue-green", "brand-new-app"}
+					})
+
+					It("pushes it as usual", func() {
+						Expect(executeErr).NotTo(HaveOccurred())
+						Expect(*appRepo.CreateArgsForCall(0).Name).To(Equal("brand-new-app"))
+						Expect(appRepo.DeleteCallCount()).To(Equal(0))
+					})
+				})
+
+				Context("when --no-start is given", func() {
+					BeforeEach(func() {
+						args = []string{"--strategy", "blue-green", "--no-start", "existing-app"}
+					})
+
+					It("returns an error", func() {
+						Expect(executeErr).To(MatchError("Incorrect Usage. The '--strategy blue-green' option cannot be used with '--no-start'."))
+						Expect(appRepo.CreateCallCount()).To(Equal(0))
+					})
+				})
+
+				Context("when the strategy is unknown", func() {
+					BeforeEach(func() {
+						args = []string{"--strategy", "rolling", "existing-app"}
+					})
+
+					It("returns an error", func() {
+						Expect(executeErr).To(MatchError("Invalid strategy: rolling\nStrategy must be 'default' or 'blue-green'"))
+					})
+				})
+			})
+
 			Context("service instances", func() {
 				BeforeEach(func() {
 					appRepo.CreateStub = func(params models.AppParams) (models.Application, error) {
@@ -1731,10 +2433,11 @@ var _ = Describe("Push Command", func() {
 
 			Context("displaying information about files being uploaded", func() {
 				BeforeEach(func() {
-					appfiles.CountFilesReturns(11)
-					zipper.ZipReturns(nil)
-					zipper.GetZipSizeReturns(6100000, nil)
-					actor.GatherFilesReturns([]resources.AppFileResource{{Path: "path/to/app"}, {Path: "bar"}}, true, nil)
+					actor.GatherFilesReturns(
+						[]resources.AppFileResource{{Path: "path/to/app"}, {Path: "bar"}},
+						[]models.AppFileFields{{Path: "foo"}, {Path: "baz"}},
+						nil,
+					)
 					args = []string{"appName"}
 				})
 
@@ -1746,7 +2449,25 @@ var _ = Describe("Push Command", func() {
 
 					totalOutputs := terminal.Decolorize(string(output.Contents()))
 					Expect(totalOutputs).To(ContainSubstring("Uploading app files from: " + curDir))
-					Expect(totalOutputs).To(ContainSubstring("Uploading 5.8M, 11 files\nOK"))
+					Expect(totalOutputs).To(ContainSubstring("Uploading 2 files\nOK"))
+				})
+
+				It("streams a zip of the files that were gathered", func() {
+					Expect(executeErr).NotTo(HaveOccurred())
+
+					Expect(actor.UploadAppCallCount()).To(Equal(1))
+					_, writeZip, _ := actor.UploadAppArgsForCall(0)
+					Expect(writeZip).NotTo(BeNil())
+
+					var zip bytes.Buffer
+					Expect(writeZip(&zip)).To(Succeed())
+					Expect(zipper.ZipFilesToCallCount()).To(Equal(1))
+
+					_, appDir := actor.GatherFilesArgsForCall(0)
+					zipDir, files, writer := zipper.ZipFilesToArgsForCall(0)
+					Expect(zipDir).To(Equal(appDir))
+					Expect(files).To(Equal([]models.AppFileFields{{Path: "foo"}, {Path: "baz"}}))
+					Expect(writer).To(Equal(&zip))
 				})
 			})
 
@@ -1923,3 +2644,12 @@ var _ = Describe("Push Command", func() {
 		})
 	})
 })
+
+func indexOf(list []string, item string) int {
+	for i, element := range list {
+		if element == item {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,245 @@
+package application
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/cli/cf/commandregistry"
+	"code.cloudfoundry.org/cli/cf/commands"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/flags"
+	"code.cloudfoundry.org/cli/cf/formatters"
+	. "code.cloudfoundry.org/cli/cf/i18n"
+	"code.cloudfoundry.org/cli/cf/net"
+	"code.cloudfoundry.org/cli/cf/requirements"
+	sshCmd "code.cloudfoundry.org/cli/cf/ssh"
+	"code.cloudfoundry.org/cli/cf/ssh/options"
+	sshTerminal "code.cloudfoundry.org/cli/cf/ssh/terminal"
+	"code.cloudfoundry.org/cli/cf/terminal"
+)
+
+// scpProgressInterval is how often the progress of a file being copied is
+// updated.
+const scpProgressInterval = 500 * time.Millisecond
+
+type SCP struct {
+	ui            terminal.UI
+	config        coreconfig.Reader
+	gateway       net.Gateway
+	appReq        requirements.ApplicationRequirement
+	sshCodeGetter commands.SSHCodeGetter
+	opts          *options.SSHOptions
+	secureShell   sshCmd.SecureShell
+
+	source scpPath
+	target scpPath
+}
+
+// scpPath is a SOURCE or TARGET argument: either a local path or, when
+// written as APP_NAME:PATH, a path on an instance of the app.
+type scpPath struct {
+	appName string
+	path    string
+	remote  bool
+}
+
+func init() {
+	commandregistry.Register(&SCP{})
+}
+
+func (cmd *SCP) MetaData() commandregistry.CommandMetadata {
+	fs := make(map[string]flags.FlagSet)
+	fs["r"] = &flags.BoolFlag{ShortName: "r", Usage: T("Recursively copy directories")}
+	fs["p"] = &flags.BoolFlag{ShortName: "p", Usage: T("Preserve the permissions and modification times of copied files")}
+	fs["q"] = &flags.BoolFlag{ShortName: "q", Usage: T("Do not show the progress of copied files")}
+	fs["app-instance-index"] = &flags.IntFlag{Name: "app-instance-index", ShortName: "i", Usage: T("Application instance index")}
+	fs["skip-host-validation"] = &flags.BoolFlag{Name: "skip-host-validation", ShortName: "k", Usage: T("Skip host key validation")}
+
+	return commandregistry.CommandMetadata{
+		Name:        "scp",
+		Description: T("Copy files to or from an application container instance"),
+		Usage: []string{
+			T(`CF_NAME scp [-r] [-p] [-q] [-i app-instance-index] [--skip-host-validation] SOURCE TARGET
+
+   Exactly one of SOURCE and TARGET is a path on the app instance, written
+   as APP_NAME:PATH. A relative PATH is relative to the home directory of
+   the app's user.`),
+		},
+		Examples: []string{
+			"CF_NAME scp my-app:app/logs/app.log .",
+			"CF_NAME scp -r ./config my-app:app/",
+			"CF_NAME scp -i 2 -p my-app:/tmp/heap.hprof ./heap.hprof",
+		},
+		Flags: fs,
+	}
+}
+
+func (cmd *SCP) Requirements(requirementsFactory requirements.Factory, fc flags.FlagContext) ([]requirements.Requirement, error) {
+	if len(fc.Args()) != 2 {
+		cmd.ui.Failed(T("Incorrect Usage. Requires SOURCE and TARGET as arguments") + "\n\n" + commandregistry.Commands.CommandUsage("scp"))
+		return nil, fmt.Errorf("Incorrect usage: %d arguments of %d required", len(fc.Args()), 2)
+	}
+
+	cmd.source = parseSCPPath(fc.Args()[0])
+	cmd.target = parseSCPPath(fc.Args()[1])
+	if cmd.source.remote == cmd.target.remote {
+		cmd.ui.Failed(fmt.Sprintf(T("Incorrect Usage:")+" %s\n\n%s", T("Exactly one of SOURCE and TARGET must be written as APP_NAME:PATH"), commandregistry.Commands.CommandUsage("scp")))
+		return nil, fmt.Errorf("Incorrect usage: exactly one of SOURCE and TARGET must be remote")
+	}
+
+	if fc.IsSet("i") && fc.Int("i") < 0 {
+		cmd.ui.Failed(fmt.Sprintf(T("Incorrect Usage:")+" %s\n\n%s", T("Value for flag 'app-instance-index' cannot be negative"), commandregistry.Commands.CommandUsage("scp")))
+		return nil, fmt.Errorf("Incorrect usage: app-instance-index cannot be negative")
+	}
+
+	remote := cmd.source
+	if cmd.target.remote {
+		remote = cmd.target
+	}
+
+	cmd.opts = &options.SSHOptions{
+		AppName:            remote.appName,
+		Index:              uint(fc.Int("i")),
+		SkipHostValidation: fc.Bool("k"),
+	}
+
+	cmd.appReq = requirementsFactory.NewApplicationRequirement(cmd.opts.AppName)
+
+	reqs := []requirements.Requirement{
+		requirementsFactory.NewLoginRequirement(),
+		requirementsFactory.NewTargetedSpaceRequirement(),
+		cmd.appReq,
+	}
+
+	return reqs, nil
+}
+
+func (cmd *SCP) SetDependency(deps commandregistry.Dependency, pluginCall bool) commandregistry.Command {
+	cmd.ui = deps.UI
+	cmd.config = deps.Config
+	cmd.gateway = deps.Gateways["cloud-controller"]
+
+	if deps.WildcardDependency != nil {
+		cmd.secureShell = deps.WildcardDependency.(sshCmd.SecureShell)
+	}
+
+	//get ssh-code for dependency
+	sshCodeGetter := commandregistry.Commands.FindCommand("ssh-code")
+	sshCodeGetter = sshCodeGetter.SetDependency(deps, false)
+	cmd.sshCodeGetter = sshCodeGetter.(commands.SSHCodeGetter)
+
+	return cmd
+}
+
+func (cmd *SCP) Execute(fc flags.FlagContext) error {
+	app := cmd.appReq.GetApplication()
+	info, err := getSSHEndpointInfo(cmd.gateway, cmd.config)
+	if err != nil {
+		return errors.New(T("Error getting SSH info:") + err.Error())
+	}
+
+	sshAuthCode, err := cmd.sshCodeGetter.Get()
+	if err != nil {
+		return errors.New(T("Error getting one time auth code: ") + err.Error())
+	}
+
+	//init secureShell if it is not already set by SetDependency() with fakes
+	if cmd.secureShell == nil {
+		cmd.secureShell = sshCmd.NewSecureShell(
+			sshCmd.DefaultSecureDialer(),
+			sshTerminal.DefaultHelper(),
+			sshCmd.DefaultListenerFactory(),
+			30*time.Second,
+			app,
+			info.SSHEndpointFingerprint,
+			info.SSHEndpoint,
+			sshAuthCode,
+		)
+	}
+
+	err = cmd.secureShell.Connect(cmd.opts)
+	if err != nil {
+		return errors.New(T("Error opening SSH connection: ") + err.Error())
+	}
+	defer cmd.secureShell.Close()
+
+	client, err := cmd.secureShell.SFTPClient()
+	if err != nil {
+		return errors.New(T("Error opening SFTP session: ") + err.Error())
+	}
+	defer client.Close()
+
+	copyOpts := sshCmd.CopyOptions{
+		Recursive: fc.Bool("r"),
+		Preserve:  fc.Bool("p"),
+	}
+	if !fc.Bool("q") {
+		copyOpts.Progress = newSCPProgress(cmd.ui, time.Now).report
+	}
+
+	if cmd.source.remote {
+		err = sshCmd.CopyFromRemote(client, cmd.source.path, cmd.target.path, copyOpts)
+	} else {
+		err = sshCmd.CopyToRemote(client, cmd.source.path, cmd.target.path, copyOpts)
+	}
+	if err != nil {
+		return errors.New(T("Error copying files: ") + err.Error())
+	}
+
+	return nil
+}
+
+// parseSCPPath parses a SOURCE or TARGET argument. As with scp, it is a path
+// on the app when there is a colon before any slash, so local paths with a
+// colon in them can be given as ./PATH. A Windows path such as C:\dir is
+// always local.
+func parseSCPPath(arg string) scpPath {
+	colon := strings.Index(arg, ":")
+	if colon <= 0 || filepath.VolumeName(arg) != "" || strings.ContainsAny(arg[:colon], `/\`) {
+		return scpPath{path: arg}
+	}
+
+	path := arg[colon+1:]
+	if path == "" {
+		path = "."
+	}
+	return scpPath{appName: arg[:colon], path: path, remote: true}
+}
+
+// scpProgress shows the progress of the file being copied on a single line,
+// which is replaced with the name and size of the file once it is copied.
+type scpProgress struct {
+	ui        terminal.UI
+	now       func() time.Time
+	lastShown time.Time
+	width     int
+}
+
+func newSCPProgress(ui terminal.UI, now func() time.Time) *scpProgress {
+	return &scpProgress{ui: ui, now: now}
+}
+
+func (p *scpProgress) report(name string, copied int64, size int64) {
+	if copied >= size {
+		//The spaces are there to ensure we overwrite the entire line
+		p.ui.PrintCapturingNoOutput("\r%s\r", strings.Repeat(" ", p.width))
+		p.ui.Say("%s %s", name, formatters.ByteSize(size))
+		p.width = 0
+		return
+	}
+
+	now := p.now()
+	if copied > 0 && now.Sub(p.lastShown) < scpProgressInterval {
+		return
+	}
+	p.lastShown = now
+
+	line := fmt.Sprintf("%s %s/%s (%d%%)", name, formatters.ByteSize(copied), formatters.ByteSize(size), copied*100/size)
+	p.ui.PrintCapturingNoOutput("\r%s", line)
+	if len(line) > p.width {
+		p.width = len(line)
+	}
+}
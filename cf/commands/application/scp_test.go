@@ -0,0 +1,277 @@
+package application_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"time"
+
+	"code.cloudfoundry.org/cli/cf/api/apifakes"
+	"code.cloudfoundry.org/cli/cf/commandregistry"
+	"code.cloudfoundry.org/cli/cf/commands/commandsfakes"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/models"
+	"code.cloudfoundry.org/cli/cf/net"
+	"code.cloudfoundry.org/cli/cf/requirements"
+	"code.cloudfoundry.org/cli/cf/requirements/requirementsfakes"
+	"code.cloudfoundry.org/cli/cf/ssh/options"
+	"code.cloudfoundry.org/cli/cf/ssh/sshfakes"
+	"code.cloudfoundry.org/cli/cf/trace/tracefakes"
+	testcmd "code.cloudfoundry.org/cli/util/testhelpers/commands"
+	testconfig "code.cloudfoundry.org/cli/util/testhelpers/configuration"
+	testnet "code.cloudfoundry.org/cli/util/testhelpers/net"
+	testsftp "code.cloudfoundry.org/cli/util/testhelpers/sftp"
+	testterm "code.cloudfoundry.org/cli/util/testhelpers/terminal"
+
+	. "code.cloudfoundry.org/cli/util/testhelpers/matchers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("scp command", func() {
+	var (
+		ui *testterm.FakeUI
+
+		sshCodeGetter         *commandsfakes.FakeSSHCodeGetter
+		originalSSHCodeGetter commandregistry.Command
+
+		requirementsFactory *requirementsfakes.FakeFactory
+		configRepo          coreconfig.Repository
+		deps                commandregistry.Dependency
+	)
+
+	BeforeEach(func() {
+		ui = &testterm.FakeUI{}
+		configRepo = testconfig.NewRepositoryWithDefaults()
+		requirementsFactory = new(requirementsfakes.FakeFactory)
+		deps.Gateways = make(map[string]net.Gateway)
+		deps.WildcardDependency = nil
+
+		//save original command and restore later
+		originalSSHCodeGetter = commandregistry.Commands.FindCommand("ssh-code")
+
+		sshCodeGetter = new(commandsfakes.FakeSSHCodeGetter)
+
+		//setup fakes to correctly interact with commandregistry
+		sshCodeGetter.SetDependencyStub = func(_ commandregistry.Dependency, _ bool) commandregistry.Command {
+			return sshCodeGetter
+		}
+		sshCodeGetter.MetaDataReturns(commandregistry.CommandMetadata{Name: "ssh-code"})
+	})
+
+	AfterEach(func() {
+		//restore original command
+		commandregistry.Register(originalSSHCodeGetter)
+	})
+
+	updateCommandDependency := func(pluginCall bool) {
+		deps.UI = ui
+		deps.Config = configRepo
+
+		//inject fake 'sshCodeGetter' into registry
+		commandregistry.Register(sshCodeGetter)
+
+		commandregistry.Commands.SetCommand(commandregistry.Commands.FindCommand("scp").SetDependency(deps, pluginCall))
+	}
+
+	runCommand := func(args ...string) bool {
+		return testcmd.RunCLICommand("scp", args, requirementsFactory, updateCommandDependency, false, ui)
+	}
+
+	Describe("Requirements", func() {
+		BeforeEach(func() {
+			requirementsFactory.NewLoginRequirementReturns(requirements.Passing{})
+			requirementsFactory.NewTargetedSpaceRequirementReturns(requirements.Passing{})
+			requirementsFactory.NewApplicationRequirementReturns(new(requirementsfakes.FakeApplicationRequirement))
+		})
+
+		It("fails with usage when not provided exactly two args", func() {
+			Expect(runCommand("my-app:file")).To(BeFalse())
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"Incorrect Usage", "Requires SOURCE and TARGET as arguments"},
+			))
+		})
+
+		It("fails with usage when neither path is on the app", func() {
+			Expect(runCommand("./file", "/tmp/file")).To(BeFalse())
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"Incorrect Usage", "Exactly one of SOURCE and TARGET"},
+			))
+		})
+
+		It("fails with usage when both paths are on the app", func() {
+			Expect(runCommand("my-app:file", "other-app:file")).To(BeFalse())
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"Incorrect Usage", "Exactly one of SOURCE and TARGET"},
+			))
+		})
+
+		It("treats local paths with a colon after a slash as local", func() {
+			Expect(runCommand("./dir:with:colons", "/tmp/a:b")).To(BeFalse())
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"Incorrect Usage", "Exactly one of SOURCE and TARGET"},
+			))
+		})
+
+		It("fails with usage when the instance index is negative", func() {
+			Expect(runCommand("-i", "-3", "my-app:file", ".")).To(BeFalse())
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"Incorrect Usage", "cannot be negative"},
+			))
+		})
+
+		It("requires the app in the remote path", func() {
+			applicationReq := new(requirementsfakes.FakeApplicationRequirement)
+			applicationReq.ExecuteReturns(errors.New("no app"))
+			requirementsFactory.NewApplicationRequirementReturns(applicationReq)
+
+			runCommand("./file", "my-app:app/file")
+
+			Expect(requirementsFactory.NewApplicationRequirementCallCount()).To(Equal(1))
+			Expect(requirementsFactory.NewApplicationRequirementArgsForCall(0)).To(Equal("my-app"))
+		})
+
+		It("fails requirements when not logged in", func() {
+			requirementsFactory.NewLoginRequirementReturns(requirements.Failing{Message: "not logged in"})
+			Expect(runCommand("my-app:file", ".")).To(BeFalse())
+		})
+
+		It("fails if a space is not targeted", func() {
+			requirementsFactory.NewTargetedSpaceRequirementReturns(requirements.Failing{Message: "not targeting space"})
+			Expect(runCommand("my-app:file", ".")).To(BeFalse())
+		})
+
+		It("fails if the application is not found", func() {
+			applicationReq := new(requirementsfakes.FakeApplicationRequirement)
+			applicationReq.ExecuteReturns(errors.New("no app"))
+			requirementsFactory.NewApplicationRequirementReturns(applicationReq)
+
+			Expect(runCommand("my-app:file", ".")).To(BeFalse())
+		})
+	})
+
+	Describe("copying files", func() {
+		var (
+			testServer      *httptest.Server
+			fakeSecureShell *sshfakes.FakeSecureShell
+			closeClient     func()
+
+			localDir  string
+			remoteDir string
+		)
+
+		BeforeEach(func() {
+			requirementsFactory.NewLoginRequirementReturns(requirements.Passing{})
+			requirementsFactory.NewTargetedSpaceRequirementReturns(requirements.Passing{})
+
+			currentApp := models.Application{}
+			currentApp.Name = "my-app"
+			currentApp.State = "started"
+			currentApp.GUID = "my-app-guid"
+			currentApp.EnableSSH = true
+			currentApp.Diego = true
+
+			applicationReq := new(requirementsfakes.FakeApplicationRequirement)
+			applicationReq.GetApplicationReturns(currentApp)
+			requirementsFactory.NewApplicationRequirementReturns(applicationReq)
+
+			getRequest := apifakes.NewCloudControllerTestRequest(testnet.TestRequest{
+				Method: "GET",
+				Path:   "/v2/info",
+				Response: testnet.TestResponse{
+					Status: http.StatusOK,
+					Body:   getInfoResponseBody,
+				},
+			})
+
+			testServer, _ = testnet.NewServer([]testnet.TestRequest{getRequest})
+			configRepo.SetAPIEndpoint(testServer.URL)
+			deps.Gateways["cloud-controller"] = net.NewCloudControllerGateway(configRepo, time.Now, &testterm.FakeUI{}, new(tracefakes.FakePrinter), "")
+
+			client, closeSFTP := testsftp.NewClient()
+			closeClient = closeSFTP
+			fakeSecureShell = new(sshfakes.FakeSecureShell)
+			fakeSecureShell.SFTPClientReturns(client, nil)
+			deps.WildcardDependency = fakeSecureShell
+
+			var err error
+			localDir, err = ioutil.TempDir("", "scp-local")
+			Expect(err).NotTo(HaveOccurred())
+			remoteDir, err = ioutil.TempDir("", "scp-remote")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			closeClient()
+			testServer.Close()
+			os.RemoveAll(localDir)
+			os.RemoveAll(remoteDir)
+		})
+
+		It("connects to the given instance of the app", func() {
+			Expect(ioutil.WriteFile(filepath.Join(remoteDir, "file"), []byte("contents"), 0644)).To(Succeed())
+
+			Expect(runCommand("-i", "2", "-k", "my-app:"+filepath.Join(remoteDir, "file"), localDir)).To(BeTrue())
+
+			Expect(fakeSecureShell.ConnectCallCount()).To(Equal(1))
+			Expect(fakeSecureShell.ConnectArgsForCall(0)).To(Equal(&options.SSHOptions{
+				AppName:            "my-app",
+				Index:              2,
+				SkipHostValidation: true,
+			}))
+			Expect(fakeSecureShell.CloseCallCount()).To(Equal(1))
+		})
+
+		It("copies a file from the app and shows its progress", func() {
+			Expect(ioutil.WriteFile(filepath.Join(remoteDir, "file"), []byte("contents"), 0644)).To(Succeed())
+
+			Expect(runCommand("my-app:"+filepath.Join(remoteDir, "file"), localDir)).To(BeTrue())
+
+			contents, err := ioutil.ReadFile(filepath.Join(localDir, "file"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(contents)).To(Equal("contents"))
+			Expect(ui.Outputs()).To(ContainSubstrings([]string{"file", "8B"}))
+		})
+
+		It("copies a directory to the app when -r is given", func() {
+			Expect(os.MkdirAll(filepath.Join(localDir, "config", "nested"), 0755)).To(Succeed())
+			Expect(ioutil.WriteFile(filepath.Join(localDir, "config", "nested", "file"), []byte("contents"), 0644)).To(Succeed())
+
+			Expect(runCommand("-r", "-q", filepath.Join(localDir, "config"), "my-app:"+remoteDir)).To(BeTrue())
+
+			contents, err := ioutil.ReadFile(filepath.Join(remoteDir, "config", "nested", "file"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(contents)).To(Equal("contents"))
+			Expect(ui.Outputs()).To(BeEmpty())
+		})
+
+		It("does not copy a directory without -r", func() {
+			Expect(runCommand(localDir, "my-app:"+remoteDir)).To(BeFalse())
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"Error copying files", "is a directory"},
+			))
+		})
+
+		It("notifies users when connecting fails", func() {
+			fakeSecureShell.ConnectReturns(errors.New("dial error"))
+
+			Expect(runCommand("my-app:file", localDir)).To(BeFalse())
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"Error opening SSH connection", "dial error"},
+			))
+		})
+
+		It("notifies users when the SFTP session cannot be opened", func() {
+			fakeSecureShell.SFTPClientReturns(nil, errors.New("subsystem error"))
+
+			Expect(runCommand("my-app:file", localDir)).To(BeFalse())
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"Error opening SFTP session", "subsystem error"},
+			))
+			Expect(fakeSecureShell.CloseCallCount()).To(Equal(1))
+		})
+	})
+})
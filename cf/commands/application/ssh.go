@@ -43,6 +43,8 @@ func init() {
 func (cmd *SSH) MetaData() commandregistry.CommandMetadata {
 	fs := make(map[string]flags.FlagSet)
 	fs["L"] = &flags.StringSliceFlag{ShortName: "L", Usage: T("Local port forward specification. This flag can be defined more than once.")}
+	fs["R"] = &flags.StringSliceFlag{ShortName: "R", Usage: T("Remote port forward specification. This flag can be defined more than once.")}
+	fs["D"] = &flags.StringSliceFlag{ShortName: "D", Usage: T("Dynamic port forward specification, which starts a local SOCKS5 proxy. This flag can be defined more than once.")}
 	fs["command"] = &flags.StringSliceFlag{Name: "command", ShortName: "c", Usage: T("Command to run. This flag can be defined more than once.")}
 	fs["app-instance-index"] = &flags.IntFlag{Name: "app-instance-index", ShortName: "i", Usage: T("Application instance index")}
 	fs["skip-host-validation"] = &flags.BoolFlag{Name: "skip-host-validation", ShortName: "k", Usage: T("Skip host key validation")}
@@ -55,7 +57,7 @@ func (cmd *SSH) MetaData() commandregistry.CommandMetadata {
 		Name:        "ssh",
 		Description: T("SSH to an application container instance"),
 		Usage: []string{
-			T("CF_NAME ssh APP_NAME [-i app-instance-index] [-c command] [-L [bind_address:]port:host:hostport] [--skip-host-validation] [--skip-remote-execution] [--request-pseudo-tty] [--force-pseudo-tty] [--disable-pseudo-tty]"),
+			T("CF_NAME ssh APP_NAME [-i app-instance-index] [-c command] [-L [bind_address:]port:host:hostport] [-R [bind_address:]port:host:hostport] [-D [bind_address:]port] [--skip-host-validation] [--skip-remote-execution] [--request-pseudo-tty] [--force-pseudo-tty] [--disable-pseudo-tty]"),
 		},
 		Flags: fs,
 	}
@@ -110,7 +112,7 @@ func (cmd *SSH) SetDependency(deps commandregistry.Dependency, pluginCall bool)
 
 func (cmd *SSH) Execute(fc flags.FlagContext) error {
 	app := cmd.appReq.GetApplication()
-	info, err := cmd.getSSHEndpointInfo()
+	info, err := getSSHEndpointInfo(cmd.gateway, cmd.config)
 	if err != nil {
 		return errors.New(T("Error getting SSH info:") + err.Error())
 	}
@@ -145,6 +147,16 @@ func (cmd *SSH) Execute(fc flags.FlagContext) error {
 		return errors.New(T("Error forwarding port: ") + err.Error())
 	}
 
+	err = cmd.secureShell.RemotePortForward()
+	if err != nil {
+		return errors.New(T("Error forwarding remote port: ") + err.Error())
+	}
+
+	err = cmd.secureShell.DynamicPortForward()
+	if err != nil {
+		return errors.New(T("Error starting SOCKS proxy: ") + err.Error())
+	}
+
 	if cmd.opts.SkipRemoteExecution {
 		err = cmd.secureShell.Wait()
 	} else {
@@ -168,8 +180,8 @@ func (cmd *SSH) Execute(fc flags.FlagContext) error {
 	return nil
 }
 
-func (cmd *SSH) getSSHEndpointInfo() (sshInfo, error) {
+func getSSHEndpointInfo(gateway net.Gateway, config coreconfig.Reader) (sshInfo, error) {
 	info := sshInfo{}
-	err := cmd.gateway.GetResource(cmd.config.APIEndpoint()+"/v2/info", &info)
+	err := gateway.GetResource(config.APIEndpoint()+"/v2/info", &info)
 	return info, err
 }
@@ -296,6 +296,30 @@ var _ = Describe("SSH command", func() {
 				})
 			})
 
+			Context("Error forwarding a remote port when -R is provided", func() {
+				It("notifies users", func() {
+					fakeSecureShell.RemotePortForwardReturns(errors.New("tcpip-forward request denied by peer"))
+
+					runCommand("my-app", "-R", "5005:localhost:5005")
+
+					Expect(ui.Outputs()).To(ContainSubstrings(
+						[]string{"Error forwarding remote port", "tcpip-forward request denied by peer"},
+					))
+				})
+			})
+
+			Context("Error starting a SOCKS proxy when -D is provided", func() {
+				It("notifies users", func() {
+					fakeSecureShell.DynamicPortForwardReturns(errors.New("address in use"))
+
+					runCommand("my-app", "-D", "1080")
+
+					Expect(ui.Outputs()).To(ContainSubstrings(
+						[]string{"Error starting SOCKS proxy", "address in use"},
+					))
+				})
+			})
+
 			Context("when -N is provided", func() {
 				It("calls secureShell.Wait()", func() {
 					fakeSecureShell.ConnectReturns(nil)
@@ -0,0 +1,186 @@
+package application
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/cli/cf/api/logs"
+)
+
+const trafficTopCount = 5
+
+type trafficRequest struct {
+	time time.Time
+	log  logs.RouterLog
+}
+
+type trafficCount struct {
+	Name     string `json:"name"`
+	Requests int    `json:"requests"`
+}
+
+// trafficResponseTimes are the percentiles of the response times of the
+// requests whose router log gave one, which number Samples.
+type trafficResponseTimes struct {
+	Samples int     `json:"samples"`
+	P50     float64 `json:"p50"`
+	P95     float64 `json:"p95"`
+	P99     float64 `json:"p99"`
+}
+
+// trafficSummary describes the requests to an app in the window of time up
+// to At.
+type trafficSummary struct {
+	App               string               `json:"app"`
+	At                time.Time            `json:"at"`
+	Window            string               `json:"window"`
+	Requests          int                  `json:"requests"`
+	RequestsPerSecond float64              `json:"requests_per_second"`
+	StatusCodes       map[string]int       `json:"status_codes"`
+	ResponseTimeMS    trafficResponseTimes `json:"response_time_ms"`
+	TopPaths          []trafficCount       `json:"top_paths"`
+	TopClients        []trafficCount       `json:"top_clients"`
+}
+
+// routerLogRequest returns the request described by log if it is a router
+// access log line.
+func routerLogRequest(log logs.Loggable) (trafficRequest, bool) {
+	source := strings.ToUpper(log.GetSourceName())
+	if source != logs.RouterLogSourceName && !strings.HasPrefix(source, logs.RouterLogSourceName+"/") {
+		return trafficRequest{}, false
+	}
+
+	routerLog, err := logs.ParseRouterLog(log.ToSimpleLog())
+	if err != nil {
+		return trafficRequest{}, false
+	}
+	return trafficRequest{time: log.GetTimestamp(), log: routerLog}, true
+}
+
+// requestsInWindow returns the requests made in the window up to now.
+func requestsInWindow(requests []trafficRequest, window time.Duration, now time.Time) []trafficRequest {
+	start := now.Add(-window)
+
+	inWindow := []trafficRequest{}
+	for _, request := range requests {
+		if request.time.After(start) && !request.time.After(now) {
+			inWindow = append(inWindow, request)
+		}
+	}
+	return inWindow
+}
+
+// summarizeTraffic summarizes the requests in the window up to now. Requests
+// have been collected since the time given, which is zero when the whole
+// window was observed.
+func summarizeTraffic(appName string, requests []trafficRequest, window time.Duration, since time.Time, now time.Time) trafficSummary {
+	requests = requestsInWindow(requests, window, now)
+
+	summary := trafficSummary{
+		App:               appName,
+		At:                now,
+		Window:            window.String(),
+		Requests:          len(requests),
+		RequestsPerSecond: requestsPerSecond(requests, window, since, now),
+		StatusCodes:       map[string]int{},
+		TopPaths:          []trafficCount{},
+		TopClients:        []trafficCount{},
+	}
+	if len(requests) == 0 {
+		return summary
+	}
+
+	paths := map[string]int{}
+	clients := map[string]int{}
+	responseTimes := make([]time.Duration, 0, len(requests))
+	for _, request := range requests {
+		summary.StatusCodes[strconv.Itoa(request.log.StatusCode)]++
+		paths[strings.SplitN(request.log.Path, "?", 2)[0]]++
+		clients[request.log.Client()]++
+		if request.log.HasResponseTime {
+			responseTimes = append(responseTimes, request.log.ResponseTime)
+		}
+	}
+
+	summary.ResponseTimeMS.Samples = len(responseTimes)
+	if len(responseTimes) > 0 {
+		sort.Sort(durations(responseTimes))
+		summary.ResponseTimeMS.P50 = milliseconds(percentile(responseTimes, 50))
+		summary.ResponseTimeMS.P95 = milliseconds(percentile(responseTimes, 95))
+		summary.ResponseTimeMS.P99 = milliseconds(percentile(responseTimes, 99))
+	}
+	summary.TopPaths = topTrafficCounts(paths)
+	summary.TopClients = topTrafficCounts(clients)
+
+	return summary
+}
+
+// requestsPerSecond returns the rate of requests over the part of the window
+// that was observed. That part starts when collecting began, or at the first
+// request if the log stream delivered earlier ones, so it is shorter than the
+// window until traffic has been watched for that long.
+func requestsPerSecond(requests []trafficRequest, window time.Duration, since time.Time, now time.Time) float64 {
+	start := now.Add(-window)
+	if since.After(start) {
+		start = since
+	}
+	for _, request := range requests {
+		if request.time.Before(start) {
+			start = request.time
+		}
+	}
+
+	observed := now.Sub(start)
+	if observed <= 0 {
+		return 0
+	}
+	return float64(len(requests)) / observed.Seconds()
+}
+
+type durations []time.Duration
+
+func (d durations) Len() int           { return len(d) }
+func (d durations) Swap(i, j int)      { d[i], d[j] = d[j], d[i] }
+func (d durations) Less(i, j int) bool { return d[i] < d[j] }
+
+// percentile returns the nearest-rank percentile of sorted, which must not be
+// empty.
+func percentile(sorted []time.Duration, p int) time.Duration {
+	rank := int(math.Ceil(float64(p) / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+// milliseconds returns d in milliseconds, to the nearest microsecond.
+func milliseconds(d time.Duration) float64 {
+	return float64(d/time.Microsecond) / 1000
+}
+
+type trafficCountsByRequests []trafficCount
+
+func (c trafficCountsByRequests) Len() int      { return len(c) }
+func (c trafficCountsByRequests) Swap(i, j int) { c[i], c[j] = c[j], c[i] }
+func (c trafficCountsByRequests) Less(i, j int) bool {
+	if c[i].Requests != c[j].Requests {
+		return c[i].Requests > c[j].Requests
+	}
+	return c[i].Name < c[j].Name
+}
+
+func topTrafficCounts(counts map[string]int) []trafficCount {
+	top := make([]trafficCount, 0, len(counts))
+	for name, requests := range counts {
+		top = append(top, trafficCount{Name: name, Requests: requests})
+	}
+
+	sort.Sort(trafficCountsByRequests(top))
+	if len(top) > trafficTopCount {
+		top = top[:trafficTopCount]
+	}
+	return top
+}
@@ -0,0 +1,79 @@
+package application
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"code.cloudfoundry.org/cli/cf/commandregistry"
+	"code.cloudfoundry.org/cli/cf/flags"
+	. "code.cloudfoundry.org/cli/cf/i18n"
+	"code.cloudfoundry.org/cli/cf/manifest"
+	"code.cloudfoundry.org/cli/cf/requirements"
+	"code.cloudfoundry.org/cli/cf/terminal"
+)
+
+type ValidateManifest struct {
+	ui terminal.UI
+}
+
+func init() {
+	commandregistry.Register(&ValidateManifest{})
+}
+
+func (cmd *ValidateManifest) MetaData() commandregistry.CommandMetadata {
+	return commandregistry.CommandMetadata{
+		Name:        "validate-manifest",
+		Description: T("Check a manifest, and the manifests it inherits from, for problems"),
+		Usage: []string{
+			T("CF_NAME validate-manifest [PATH]"),
+			"\n\n   ",
+			T("PATH is a manifest file or a directory containing manifest.yml. The current directory is used if PATH is not given."),
+		},
+	}
+}
+
+func (cmd *ValidateManifest) Requirements(requirementsFactory requirements.Factory, fc flags.FlagContext) ([]requirements.Requirement, error) {
+	if len(fc.Args()) > 1 {
+		cmd.ui.Failed(T("Incorrect Usage. Too many arguments\n\n") + commandregistry.Commands.CommandUsage("validate-manifest"))
+		return nil, fmt.Errorf("Incorrect usage: %d arguments of at most %d allowed", len(fc.Args()), 1)
+	}
+
+	reqs := []requirements.Requirement{}
+	return reqs, nil
+}
+
+func (cmd *ValidateManifest) SetDependency(deps commandregistry.Dependency, pluginCall bool) commandregistry.Command {
+	cmd.ui = deps.UI
+	return cmd
+}
+
+func (cmd *ValidateManifest) Execute(c flags.FlagContext) error {
+	var path string
+	if len(c.Args()) == 1 {
+		path = c.Args()[0]
+	} else {
+		var err error
+		path, err = os.Getwd()
+		if err != nil {
+			return err
+		}
+	}
+
+	cmd.ui.Say(T("Validating manifest {{.Path}}...", map[string]interface{}{"Path": terminal.EntityNameColor(path)}))
+
+	diagnostics, err := manifest.Validate(path)
+	if err != nil {
+		return err
+	}
+
+	if len(diagnostics) > 0 {
+		for _, diagnostic := range diagnostics {
+			cmd.ui.Say(diagnostic.String())
+		}
+		return errors.New(T("Found {{.Count}} problem(s) in the manifest", map[string]interface{}{"Count": len(diagnostics)}))
+	}
+
+	cmd.ui.Ok()
+	return nil
+}
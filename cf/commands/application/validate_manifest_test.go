@@ -0,0 +1,90 @@
+package application_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"code.cloudfoundry.org/cli/cf/commandregistry"
+	"code.cloudfoundry.org/cli/cf/requirements/requirementsfakes"
+	testcmd "code.cloudfoundry.org/cli/util/testhelpers/commands"
+	testterm "code.cloudfoundry.org/cli/util/testhelpers/terminal"
+
+	. "code.cloudfoundry.org/cli/util/testhelpers/matchers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("validate-manifest command", func() {
+	var (
+		ui                  *testterm.FakeUI
+		requirementsFactory *requirementsfakes.FakeFactory
+		deps                commandregistry.Dependency
+		tmpDir              string
+	)
+
+	BeforeEach(func() {
+		ui = &testterm.FakeUI{}
+		requirementsFactory = new(requirementsfakes.FakeFactory)
+
+		var err error
+		tmpDir, err = ioutil.TempDir("", "validate-manifest-command")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(tmpDir)
+	})
+
+	updateCommandDependency := func(pluginCall bool) {
+		deps.UI = ui
+		commandregistry.Commands.SetCommand(commandregistry.Commands.FindCommand("validate-manifest").SetDependency(deps, pluginCall))
+	}
+
+	runCommand := func(args ...string) bool {
+		return testcmd.RunCLICommand("validate-manifest", args, requirementsFactory, updateCommandDependency, false, ui)
+	}
+
+	writeManifest := func(contents string) string {
+		path := filepath.Join(tmpDir, "manifest.yml")
+		Expect(ioutil.WriteFile(path, []byte(contents), 0600)).To(Succeed())
+		return path
+	}
+
+	It("fails with usage when given more than one path", func() {
+		Expect(runCommand("a", "b")).To(BeFalse())
+		Expect(ui.Outputs()).To(ContainSubstrings(
+			[]string{"Incorrect Usage", "Too many arguments"},
+		))
+	})
+
+	It("says OK when the manifest has no problems", func() {
+		writeManifest("applications:\n- name: my-app\n  memory: 256M\n")
+
+		Expect(runCommand(tmpDir)).To(BeTrue())
+		Expect(ui.Outputs()).To(ContainSubstrings(
+			[]string{"Validating manifest", tmpDir},
+			[]string{"OK"},
+		))
+	})
+
+	It("lists each problem with its file, line and column and fails", func() {
+		path := writeManifest("applications:\n- name: my-app\n  memory: lots\n  instanses: 2\n")
+
+		Expect(runCommand(path)).To(BeFalse())
+		Expect(ui.Outputs()).To(ContainSubstrings(
+			[]string{path + ":3:3: Invalid value for 'memory': lots."},
+			[]string{path + ":4:3: Unknown key 'instanses'. Did you mean 'instances'?"},
+			[]string{"FAILED"},
+			[]string{"Found 2 problem(s) in the manifest"},
+		))
+	})
+
+	It("fails when there is no manifest", func() {
+		Expect(runCommand(tmpDir)).To(BeFalse())
+		Expect(ui.Outputs()).To(ContainSubstrings(
+			[]string{"FAILED"},
+			[]string{"Error finding manifest"},
+		))
+	})
+})
@@ -101,6 +101,17 @@ var _ = Describe("auth command", func() {
 			Expect(authRepo.GetLoginPromptsAndSaveUAAServerURLCallCount()).To(Equal(1))
 		})
 
+		It("authenticates as a client when --client-credentials is passed", func() {
+			testcmd.RunCLICommand("auth", []string{"some-client", "some-secret", "--client-credentials"}, requirementsFactory, updateCommandDependency, false, ui)
+
+			Expect(ui.Outputs()).To(ContainSubstrings([]string{"OK"}))
+			Expect(authRepo.AuthenticateCallCount()).To(Equal(0))
+			Expect(authRepo.AuthenticateClientCredentialsCallCount()).To(Equal(1))
+			clientID, clientSecret := authRepo.AuthenticateClientCredentialsArgsForCall(0)
+			Expect(clientID).To(Equal("some-client"))
+			Expect(clientSecret).To(Equal("some-secret"))
+		})
+
 		Describe("when authentication fails", func() {
 			BeforeEach(func() {
 				authRepo.AuthenticateReturns(errors.New("Error authenticating."))
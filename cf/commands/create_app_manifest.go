@@ -4,7 +4,9 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"regexp"
 	"sort"
+	"strconv"
 
 	"code.cloudfoundry.org/cli/cf/flags"
 	. "code.cloudfoundry.org/cli/cf/i18n"
@@ -37,18 +39,35 @@ func init() {
 func (cmd *CreateAppManifest) MetaData() commandregistry.CommandMetadata {
 	fs := make(map[string]flags.FlagSet)
 	fs["p"] = &flags.StringFlag{ShortName: "p", Usage: T("Specify a path for file creation. If path not specified, manifest file is created in current working directory.")}
+	fs["all-apps"] = &flags.BoolFlag{Name: "all-apps", Usage: T("Create one manifest for all apps in the targeted space")}
+	fs["mask-secrets"] = &flags.BoolFlag{Name: "mask-secrets", Usage: T("Replace the values of env vars that look like secrets, such as passwords and tokens, with ((variables)) to give with --var or --vars-file when pushing")}
 
 	return commandregistry.CommandMetadata{
 		Name:        "create-app-manifest",
 		Description: T("Create an app manifest for an app that has been pushed successfully"),
 		Usage: []string{
-			T("CF_NAME create-app-manifest APP_NAME [-p /path/to/<app-name>-manifest.yml ]"),
+			T("CF_NAME create-app-manifest APP_NAME [-p /path/to/<app-name>-manifest.yml ] [--mask-secrets]"),
+			"\n   ",
+			T("CF_NAME create-app-manifest --all-apps [-p /path/to/<space-name>-manifest.yml ] [--mask-secrets]"),
 		},
 		Flags: fs,
 	}
 }
 
 func (cmd *CreateAppManifest) Requirements(requirementsFactory requirements.Factory, fc flags.FlagContext) ([]requirements.Requirement, error) {
+	if fc.Bool("all-apps") {
+		if len(fc.Args()) != 0 {
+			cmd.ui.Failed(T("Incorrect Usage. APP_NAME cannot be given with --all-apps\n\n") + commandregistry.Commands.CommandUsage("create-app-manifest"))
+			return nil, fmt.Errorf("Incorrect usage: %d arguments of %d required", len(fc.Args()), 0)
+		}
+
+		reqs := []requirements.Requirement{
+			requirementsFactory.NewLoginRequirement(),
+			requirementsFactory.NewTargetedSpaceRequirement(),
+		}
+		return reqs, nil
+	}
+
 	if len(fc.Args()) != 1 {
 		cmd.ui.Failed(T("Incorrect Usage. Requires APP_NAME as argument\n\n") + commandregistry.Commands.CommandUsage("create-app-manifest"))
 		return nil, fmt.Errorf("Incorrect usage: %d arguments of %d required", len(fc.Args()), 1)
@@ -75,6 +94,10 @@ func (cmd *CreateAppManifest) SetDependency(deps commandregistry.Dependency, plu
 }
 
 func (cmd *CreateAppManifest) Execute(c flags.FlagContext) error {
+	if c.Bool("all-apps") {
+		return cmd.createSpaceManifest(c)
+	}
+
 	application, apiErr := cmd.appSummaryRepo.GetSummary(cmd.appReq.GetApplication().GUID)
 	if apiErr != nil {
 		return errors.New(T("Error getting application summary: ") + apiErr.Error())
@@ -96,16 +119,78 @@ func (cmd *CreateAppManifest) Execute(c flags.FlagContext) error {
 		savePath = c.String("p")
 	}
 
+	err = cmd.createManifest(application, c.Bool("mask-secrets"))
+	if err != nil {
+		return err
+	}
+
+	return cmd.saveManifest(savePath, c.Bool("mask-secrets"))
+}
+
+// createSpaceManifest writes one manifest with the current settings of every
+// app in the targeted space.
+func (cmd *CreateAppManifest) createSpaceManifest(c flags.FlagContext) error {
+	spaceName := cmd.config.SpaceFields().Name
+
+	cmd.ui.Say(T("Creating an app manifest from current settings of all apps in space {{.SpaceName}} ...",
+		map[string]interface{}{"SpaceName": spaceName}))
+	cmd.ui.Say("")
+
+	summaries, err := cmd.appSummaryRepo.GetSummariesInCurrentSpace()
+	if err != nil {
+		return errors.New(T("Error getting application summaries: ") + err.Error())
+	}
+	if len(summaries) == 0 {
+		return errors.New(T("No apps found in space {{.SpaceName}}", map[string]interface{}{"SpaceName": spaceName}))
+	}
+
+	appGUIDs := make(map[string]string, len(summaries))
+	appNames := make([]string, 0, len(summaries))
+	for _, summary := range summaries {
+		appGUIDs[summary.Name] = summary.GUID
+		appNames = append(appNames, summary.Name)
+	}
+	sort.Strings(appNames)
+
+	stacks := map[string]models.Stack{}
+	for _, appName := range appNames {
+		application, err := cmd.appSummaryRepo.GetSummary(appGUIDs[appName])
+		if err != nil {
+			return errors.New(T("Error getting application summary: ") + err.Error())
+		}
+
+		stack, found := stacks[application.StackGUID]
+		if !found {
+			stack, err = cmd.stackRepo.FindByGUID(application.StackGUID)
+			if err != nil {
+				return errors.New(T("Error retrieving stack: ") + err.Error())
+			}
+			stacks[application.StackGUID] = stack
+		}
+		application.Stack = &stack
+
+		err = cmd.createManifest(application, c.Bool("mask-secrets"))
+		if err != nil {
+			return err
+		}
+	}
+
+	savePath := "./" + spaceName + "_manifest.yml"
+
+	if c.String("p") != "" {
+		savePath = c.String("p")
+	}
+
+	return cmd.saveManifest(savePath, c.Bool("mask-secrets"))
+}
+
+func (cmd *CreateAppManifest) saveManifest(savePath string, maskSecrets bool) error {
 	f, err := os.Create(savePath)
 	if err != nil {
 		return errors.New(T("Error creating manifest file: ") + err.Error())
 	}
 	defer f.Close()
 
-	err = cmd.createManifest(application)
-	if err != nil {
-		return err
-	}
 	err = cmd.manifest.Save(f)
 	if err != nil {
 		return errors.New(T("Error creating manifest file: ") + err.Error())
@@ -113,11 +198,14 @@ func (cmd *CreateAppManifest) Execute(c flags.FlagContext) error {
 
 	cmd.ui.Ok()
 	cmd.ui.Say(T("Manifest file created successfully at ") + savePath)
+	if maskSecrets {
+		cmd.ui.Say(T("Env vars that look like secrets were replaced with ((variables)). Give their values with --var or --vars-file when pushing."))
+	}
 	cmd.ui.Say("")
 	return nil
 }
 
-func (cmd *CreateAppManifest) createManifest(app models.Application) error {
+func (cmd *CreateAppManifest) createManifest(app models.Application, maskSecrets bool) error {
 	cmd.manifest.Memory(app.Name, app.Memory)
 	cmd.manifest.Instances(app.Name, app.InstanceCount)
 	cmd.manifest.Stack(app.Name, app.Stack.Name)
@@ -130,7 +218,9 @@ func (cmd *CreateAppManifest) createManifest(app models.Application) error {
 		cmd.manifest.StartCommand(app.Name, app.Command)
 	}
 
-	if app.BuildpackURL != "" {
+	if app.DockerImage != "" {
+		cmd.manifest.DockerImage(app.Name, app.DockerImage)
+	} else if app.BuildpackURL != "" {
 		cmd.manifest.BuildpackURL(app.Name, app.BuildpackURL)
 	}
 
@@ -140,6 +230,14 @@ func (cmd *CreateAppManifest) createManifest(app models.Application) error {
 		}
 	}
 
+	if app.HealthCheckType != "" {
+		cmd.manifest.HealthCheckType(app.Name, app.HealthCheckType)
+	}
+
+	if app.HealthCheckHTTPEndpoint != "" {
+		cmd.manifest.HealthCheckHTTPEndpoint(app.Name, app.HealthCheckHTTPEndpoint)
+	}
+
 	if app.HealthCheckTimeout > 0 {
 		cmd.manifest.HealthCheckTimeout(app.Name, app.HealthCheckTimeout)
 	}
@@ -147,18 +245,23 @@ func (cmd *CreateAppManifest) createManifest(app models.Application) error {
 	if len(app.EnvironmentVars) > 0 {
 		sorted := sortEnvVar(app.EnvironmentVars)
 		for _, envVarKey := range sorted {
-			switch app.EnvironmentVars[envVarKey].(type) {
+			var value string
+			switch envVarValue := app.EnvironmentVars[envVarKey].(type) {
 			default:
 				return errors.New(T("Failed to create manifest, unable to parse environment variable: ") + envVarKey)
 			case float64:
 				//json.Unmarshal turn all numbers to float64
-				value := int(app.EnvironmentVars[envVarKey].(float64))
-				cmd.manifest.EnvironmentVars(app.Name, envVarKey, fmt.Sprintf("%d", value))
+				value = strconv.FormatFloat(envVarValue, 'f', -1, 64)
 			case bool:
-				cmd.manifest.EnvironmentVars(app.Name, envVarKey, fmt.Sprintf("%t", app.EnvironmentVars[envVarKey].(bool)))
+				value = fmt.Sprintf("%t", envVarValue)
 			case string:
-				cmd.manifest.EnvironmentVars(app.Name, envVarKey, app.EnvironmentVars[envVarKey].(string))
+				value = envVarValue
 			}
+
+			if maskSecrets && secretEnvVarRegex.MatchString(envVarKey) {
+				value = secretVariable(app.Name, envVarKey)
+			}
+			cmd.manifest.EnvironmentVars(app.Name, envVarKey, value)
 		}
 	}
 
@@ -175,6 +278,18 @@ func (cmd *CreateAppManifest) createManifest(app models.Application) error {
 	return nil
 }
 
+// secretEnvVarRegex matches the names of env vars that are likely to hold
+// secrets.
+var secretEnvVarRegex = regexp.MustCompile(`(?i)passw(or)?d|secret|token|credential|private|api_?key|access_?key`)
+
+var variableNameUnsafeRegex = regexp.MustCompile(`[^\w-]`)
+
+// secretVariable returns the ((variable)) that replaces the value of the env
+// var key of the app appName.
+func secretVariable(appName string, key string) string {
+	return "((" + variableNameUnsafeRegex.ReplaceAllString(appName+"_"+key, "_") + "))"
+}
+
 func sortEnvVar(vars map[string]interface{}) []string {
 	var varsAry []string
 	for k := range vars {
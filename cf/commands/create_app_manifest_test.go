@@ -2,6 +2,7 @@ package commands_test
 
 import (
 	"errors"
+	"strings"
 
 	"code.cloudfoundry.org/cli/cf/commandregistry"
 	"code.cloudfoundry.org/cli/cf/commands"
@@ -120,6 +121,36 @@ var _ = Describe("CreateAppManifest", func() {
 		})
 	})
 
+	Describe("Requirements with --all-apps", func() {
+		Context("when no args are provided", func() {
+			BeforeEach(func() {
+				flagContext.Parse("--all-apps")
+			})
+
+			It("returns a LoginRequirement and a TargetedSpaceRequirement", func() {
+				actualRequirements, err := cmd.Requirements(factory, flagContext)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(actualRequirements).To(ConsistOf(loginRequirement, targetedSpaceRequirement))
+				Expect(factory.NewApplicationRequirementCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when an app name is provided", func() {
+			BeforeEach(func() {
+				flagContext.Parse("app-name", "--all-apps")
+			})
+
+			It("fails with usage", func() {
+				_, err := cmd.Requirements(factory, flagContext)
+				Expect(err).To(HaveOccurred())
+				Expect(ui.Outputs()).To(ContainSubstrings(
+					[]string{"FAILED"},
+					[]string{"Incorrect Usage. APP_NAME cannot be given with --all-apps"},
+				))
+			})
+		})
+	})
+
 	Describe("Execute", func() {
 		var (
 			application models.Application
@@ -374,6 +405,54 @@ var _ = Describe("CreateAppManifest", func() {
 				})
 			})
 
+			Context("when the app has an environment var with a fractional number", func() {
+				BeforeEach(func() {
+					application.EnvironmentVars = map[string]interface{}{
+						"float64-key": float64(1.5),
+					}
+					appSummaryRepo.GetSummaryReturns(application, nil)
+				})
+
+				It("keeps the fraction", func() {
+					Expect(runCLIErr).NotTo(HaveOccurred())
+					_, _, value := fakeManifest.EnvironmentVarsArgsForCall(0)
+					Expect(value).To(Equal("1.5"))
+				})
+			})
+
+			Context("when --mask-secrets is given", func() {
+				BeforeEach(func() {
+					flagContext = flags.NewFlagContext(cmd.MetaData().Flags)
+					err := flagContext.Parse("app-name", "--mask-secrets")
+					Expect(err).NotTo(HaveOccurred())
+
+					application.EnvironmentVars = map[string]interface{}{
+						"DB_PASSWORD": "hunter2",
+						"API_KEY":     "abc123",
+						"LOG_LEVEL":   "debug",
+					}
+					appSummaryRepo.GetSummaryReturns(application, nil)
+				})
+
+				It("replaces the values of secret env vars with variables", func() {
+					Expect(runCLIErr).NotTo(HaveOccurred())
+					actuals := map[string]string{}
+					for i := 0; i < fakeManifest.EnvironmentVarsCallCount(); i++ {
+						_, k, v := fakeManifest.EnvironmentVarsArgsForCall(i)
+						actuals[k] = v
+					}
+
+					Expect(actuals).To(Equal(map[string]string{
+						"DB_PASSWORD": "((app-name_DB_PASSWORD))",
+						"API_KEY":     "((app-name_API_KEY))",
+						"LOG_LEVEL":   "debug",
+					}))
+					Expect(ui.Outputs()).To(ContainSubstrings(
+						[]string{"Env vars that look like secrets were replaced with ((variables))"},
+					))
+				})
+			})
+
 			Context("when the app has an environment var of an unsupported type", func() {
 				BeforeEach(func() {
 					application.EnvironmentVars = map[string]interface{}{
@@ -431,6 +510,43 @@ var _ = Describe("CreateAppManifest", func() {
 				})
 			})
 
+			Context("when the app has a health check type and endpoint", func() {
+				BeforeEach(func() {
+					application.HealthCheckType = "http"
+					application.HealthCheckHTTPEndpoint = "/health"
+					appSummaryRepo.GetSummaryReturns(application, nil)
+				})
+
+				It("sets the health check type and endpoint", func() {
+					Expect(runCLIErr).NotTo(HaveOccurred())
+					Expect(fakeManifest.HealthCheckTypeCallCount()).To(Equal(1))
+					name, healthCheckType := fakeManifest.HealthCheckTypeArgsForCall(0)
+					Expect(name).To(Equal("app-name"))
+					Expect(healthCheckType).To(Equal("http"))
+
+					Expect(fakeManifest.HealthCheckHTTPEndpointCallCount()).To(Equal(1))
+					name, endpoint := fakeManifest.HealthCheckHTTPEndpointArgsForCall(0)
+					Expect(name).To(Equal("app-name"))
+					Expect(endpoint).To(Equal("/health"))
+				})
+			})
+
+			Context("when the app has a docker image", func() {
+				BeforeEach(func() {
+					application.DockerImage = "user/docker-image"
+					appSummaryRepo.GetSummaryReturns(application, nil)
+				})
+
+				It("sets the docker image", func() {
+					Expect(runCLIErr).NotTo(HaveOccurred())
+					Expect(fakeManifest.DockerImageCallCount()).To(Equal(1))
+					name, image := fakeManifest.DockerImageArgsForCall(0)
+					Expect(name).To(Equal("app-name"))
+					Expect(image).To(Equal("user/docker-image"))
+					Expect(fakeManifest.BuildpackURLCallCount()).To(Equal(0))
+				})
+			})
+
 			Context("when the app has a disk quota", func() {
 				BeforeEach(func() {
 					application.DiskQuota = 1024
@@ -447,4 +563,95 @@ var _ = Describe("CreateAppManifest", func() {
 			})
 		})
 	})
+
+	Describe("Execute with --all-apps", func() {
+		var runCLIErr error
+
+		BeforeEach(func() {
+			err := flagContext.Parse("--all-apps")
+			Expect(err).NotTo(HaveOccurred())
+			cmd.Requirements(factory, flagContext)
+		})
+
+		JustBeforeEach(func() {
+			runCLIErr = cmd.Execute(flagContext)
+		})
+
+		AfterEach(func() {
+			os.Remove("my-space_manifest.yml")
+		})
+
+		Context("when the space has apps", func() {
+			BeforeEach(func() {
+				app1 := models.Application{}
+				app1.Name = "app-b"
+				app1.GUID = "app-b-guid"
+				app2 := models.Application{}
+				app2.Name = "app-a"
+				app2.GUID = "app-a-guid"
+				appSummaryRepo.GetSummariesInCurrentSpaceReturns([]models.Application{app1, app2}, nil)
+
+				appSummaryRepo.GetSummaryStub = func(guid string) (models.Application, error) {
+					app := models.Application{}
+					app.GUID = guid
+					app.Name = strings.TrimSuffix(guid, "-guid")
+					app.StackGUID = "the-stack-guid"
+					app.Memory = 256
+					app.InstanceCount = 1
+					return app, nil
+				}
+				stackRepo.FindByGUIDReturns(models.Stack{GUID: "the-stack-guid", Name: "the-stack-name"}, nil)
+			})
+
+			It("adds every app to one manifest, in order of name", func() {
+				Expect(runCLIErr).NotTo(HaveOccurred())
+				Expect(appSummaryRepo.GetSummaryCallCount()).To(Equal(2))
+				Expect(appSummaryRepo.GetSummaryArgsForCall(0)).To(Equal("app-a-guid"))
+				Expect(appSummaryRepo.GetSummaryArgsForCall(1)).To(Equal("app-b-guid"))
+
+				Expect(fakeManifest.MemoryCallCount()).To(Equal(2))
+				name, _ := fakeManifest.MemoryArgsForCall(0)
+				Expect(name).To(Equal("app-a"))
+				name, _ = fakeManifest.MemoryArgsForCall(1)
+				Expect(name).To(Equal("app-b"))
+
+				Expect(fakeManifest.SaveCallCount()).To(Equal(1))
+			})
+
+			It("looks up each stack once", func() {
+				Expect(runCLIErr).NotTo(HaveOccurred())
+				Expect(stackRepo.FindByGUIDCallCount()).To(Equal(1))
+			})
+
+			It("saves the manifest under the name of the space", func() {
+				Expect(runCLIErr).NotTo(HaveOccurred())
+				Expect(ui.Outputs()).To(ContainSubstrings(
+					[]string{"Creating an app manifest from current settings of all apps in space my-space"},
+					[]string{"OK"},
+					[]string{"Manifest file created successfully at ./my-space_manifest.yml"},
+				))
+			})
+		})
+
+		Context("when the space has no apps", func() {
+			BeforeEach(func() {
+				appSummaryRepo.GetSummariesInCurrentSpaceReturns([]models.Application{}, nil)
+			})
+
+			It("fails with error", func() {
+				Expect(runCLIErr).To(MatchError("No apps found in space my-space"))
+				Expect(fakeManifest.SaveCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when getting the app summaries fails", func() {
+			BeforeEach(func() {
+				appSummaryRepo.GetSummariesInCurrentSpaceReturns(nil, errors.New("summaries-err"))
+			})
+
+			It("fails with error", func() {
+				Expect(runCLIErr).To(MatchError("Error getting application summaries: summaries-err"))
+			})
+		})
+	})
 })
@@ -2,7 +2,9 @@ package commands
 
 import (
 	"errors"
+	"fmt"
 	"strconv"
+	"time"
 
 	"code.cloudfoundry.org/cli/cf/commandregistry"
 	"code.cloudfoundry.org/cli/cf/flags"
@@ -20,6 +22,10 @@ import (
 const maxLoginTries = 3
 const maxChoices = 50
 
+// browserLoginTimeout is how long login waits for the user to authorize the
+// CLI in a browser.
+const browserLoginTimeout = 5 * time.Minute
+
 type Login struct {
 	ui            terminal.UI
 	config        coreconfig.ReadWriter
@@ -41,6 +47,8 @@ func (cmd *Login) MetaData() commandregistry.CommandMetadata {
 	fs["o"] = &flags.StringFlag{ShortName: "o", Usage: T("Org")}
 	fs["s"] = &flags.StringFlag{ShortName: "s", Usage: T("Space")}
 	fs["sso"] = &flags.BoolFlag{Name: "sso", Usage: T("Use a one-time password to login")}
+	fs["sso-passcode"] = &flags.StringFlag{Name: "sso-passcode", Usage: T("One-time password")}
+	fs["browser"] = &flags.BoolFlag{Name: "browser", Usage: T("Authorize the CLI in a browser")}
 	fs["skip-ssl-validation"] = &flags.BoolFlag{Name: "skip-ssl-validation", Usage: T("Skip verification of the API endpoint. Not recommended!")}
 
 	return commandregistry.CommandMetadata{
@@ -48,7 +56,7 @@ func (cmd *Login) MetaData() commandregistry.CommandMetadata {
 		ShortName:   "l",
 		Description: T("Log user in"),
 		Usage: []string{
-			T("CF_NAME login [-a API_URL] [-u USERNAME] [-p PASSWORD] [-o ORG] [-s SPACE]\n\n"),
+			T("CF_NAME login [-a API_URL] [-u USERNAME] [-p PASSWORD] [-o ORG] [-s SPACE] [--sso | --sso-passcode PASSCODE | --browser]\n\n"),
 			terminal.WarningColor(T("WARNING:\n   Providing your password as a command line option is highly discouraged\n   Your password may be visible to others and may be recorded in your shell history")),
 		},
 		Examples: []string{
@@ -57,12 +65,24 @@ func (cmd *Login) MetaData() commandregistry.CommandMetadata {
 			T("CF_NAME login -u name@example.com -p \"my password\" (use quotes for passwords with a space)"),
 			T("CF_NAME login -u name@example.com -p \"\\\"password\\\"\" (escape quotes if used in password)"),
 			T("CF_NAME login --sso (CF_NAME will provide a url to obtain a one-time password to login)"),
+			T("CF_NAME login --browser (CF_NAME will provide a url to authorize the CLI in a browser)"),
 		},
 		Flags: fs,
 	}
 }
 
 func (cmd *Login) Requirements(requirementsFactory requirements.Factory, fc flags.FlagContext) ([]requirements.Requirement, error) {
+	authFlags := 0
+	for _, set := range []bool{fc.Bool("sso"), fc.String("sso-passcode") != "", fc.Bool("browser")} {
+		if set {
+			authFlags++
+		}
+	}
+	if authFlags > 1 {
+		cmd.ui.Failed(T("Incorrect Usage. --sso, --sso-passcode and --browser cannot be used together\n\n") + commandregistry.Commands.CommandUsage("login"))
+		return nil, fmt.Errorf("Incorrect usage: --sso, --sso-passcode and --browser cannot be used together")
+	}
+
 	reqs := []requirements.Requirement{}
 	return reqs, nil
 }
@@ -107,16 +127,18 @@ func (cmd *Login) Execute(c flags.FlagContext) error {
 	//   EITHER   username and password
 	//   OR       a one-time passcode
 
-	if c.Bool("sso") {
+	switch {
+	case c.String("sso-passcode") != "":
+		err = cmd.authenticateSSOPasscode(c)
+	case c.Bool("sso"):
 		err = cmd.authenticateSSO(c)
-		if err != nil {
-			return err
-		}
-	} else {
+	case c.Bool("browser"):
+		err = cmd.authenticateWithBrowser()
+	default:
 		err = cmd.authenticate(c)
-		if err != nil {
-			return err
-		}
+	}
+	if err != nil {
+		return err
 	}
 
 	orgIsSet, err := cmd.setOrganization(c)
@@ -181,6 +203,47 @@ func (cmd Login) authenticateSSO(c flags.FlagContext) error {
 	return nil
 }
 
+// authenticateSSOPasscode logs in with the passcode given on the command
+// line. As the passcode can only be used once, it is not retried.
+func (cmd Login) authenticateSSOPasscode(c flags.FlagContext) error {
+	_, err := cmd.authenticator.GetLoginPromptsAndSaveUAAServerURL()
+	if err != nil {
+		return err
+	}
+
+	cmd.ui.Say(T("Authenticating..."))
+	err = cmd.authenticator.Authenticate(map[string]string{"passcode": c.String("sso-passcode")})
+	if err != nil {
+		return err
+	}
+
+	cmd.ui.Ok()
+	cmd.ui.Say("")
+	return nil
+}
+
+func (cmd Login) authenticateWithBrowser() error {
+	_, err := cmd.authenticator.GetLoginPromptsAndSaveUAAServerURL()
+	if err != nil {
+		return err
+	}
+
+	err = cmd.authenticator.AuthenticateWithBrowser(browserLoginTimeout, func(authorizationURL string) error {
+		cmd.ui.Say(T("Open the following URL in a browser to log in:"))
+		cmd.ui.Say(authorizationURL)
+		cmd.ui.Say("")
+		cmd.ui.Say(T("Waiting for authorization..."))
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	cmd.ui.Ok()
+	cmd.ui.Say("")
+	return nil
+}
+
 func (cmd Login) authenticate(c flags.FlagContext) error {
 	usernameFlagValue := c.String("u")
 	passwordFlagValue := c.String("p")
@@ -2,6 +2,7 @@ package commands_test
 
 import (
 	"strconv"
+	"time"
 
 	"code.cloudfoundry.org/cli/cf/api/authentication/authenticationfakes"
 	"code.cloudfoundry.org/cli/cf/api/organizations/organizationsfakes"
@@ -445,6 +446,55 @@ var _ = Describe("Login Command", func() {
 				})
 			})
 
+			Context("when the user provides the --sso-passcode flag", func() {
+				It("authenticates with the passcode without prompting", func() {
+					Flags = []string{"--sso-passcode", "the-one-time-code", "-a", "api.example.com"}
+
+					testcmd.RunCLICommand("login", Flags, nil, updateCommandDependency, false, ui)
+
+					Expect(ui.Prompts).To(BeEmpty())
+					Expect(ui.PasswordPrompts).To(BeEmpty())
+					Expect(authRepo.AuthenticateCallCount()).To(Equal(1))
+					Expect(authRepo.AuthenticateArgsForCall(0)).To(Equal(map[string]string{
+						"passcode": "the-one-time-code",
+					}))
+				})
+			})
+
+			Context("when the user provides the --browser flag", func() {
+				It("displays the URL to authorize the CLI at", func() {
+					Flags = []string{"--browser", "-a", "api.example.com"}
+					authRepo.AuthenticateWithBrowserStub = func(_ time.Duration, openURL func(string) error) error {
+						return openURL("https://login.example.com/oauth/authorize?client_id=cf")
+					}
+
+					testcmd.RunCLICommand("login", Flags, nil, updateCommandDependency, false, ui)
+
+					Expect(ui.Prompts).To(BeEmpty())
+					Expect(ui.PasswordPrompts).To(BeEmpty())
+					Expect(authRepo.AuthenticateCallCount()).To(Equal(0))
+					Expect(authRepo.AuthenticateWithBrowserCallCount()).To(Equal(1))
+					Expect(ui.Outputs()).To(ContainSubstrings(
+						[]string{"Open the following URL in a browser to log in:"},
+						[]string{"https://login.example.com/oauth/authorize?client_id=cf"},
+						[]string{"Waiting for authorization..."},
+						[]string{"OK"},
+					))
+				})
+			})
+
+			Context("when more than one of --sso, --sso-passcode and --browser is provided", func() {
+				It("fails with usage", func() {
+					Flags = []string{"--sso", "--browser", "-a", "api.example.com"}
+
+					Expect(testcmd.RunCLICommand("login", Flags, nil, updateCommandDependency, false, ui)).To(BeFalse())
+					Expect(ui.Outputs()).To(ContainSubstrings(
+						[]string{"Incorrect Usage", "--sso, --sso-passcode and --browser cannot be used together"},
+					))
+					Expect(authRepo.AuthenticateWithBrowserCallCount()).To(Equal(0))
+				})
+			})
+
 			It("takes the password from the -p flag", func() {
 				Flags = []string{"-p", "the-password"}
 				ui.Inputs = []string{"api.example.com", "the-username", "the-account-number", "the-pin"}
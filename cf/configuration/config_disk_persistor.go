@@ -1,8 +1,11 @@
 package configuration
 
 import (
+	"bytes"
 	"io/ioutil"
 	"os"
+
+	"code.cloudfoundry.org/cli/util/configfile"
 )
 
 const (
@@ -28,11 +31,16 @@ type DataInterface interface {
 
 type DiskPersistor struct {
 	filePath string
+
+	// lastContents is what was last read from or written to the file. It is
+	// shared by copies of the DiskPersistor.
+	lastContents *[]byte
 }
 
 func NewDiskPersistor(path string) DiskPersistor {
 	return DiskPersistor{
-		filePath: path,
+		filePath:     path,
+		lastContents: new([]byte),
 	}
 }
 
@@ -76,15 +84,56 @@ func (dp DiskPersistor) read(data DataInterface) error {
 	}
 
 	err = data.JSONUnmarshalV3(jsonBytes)
-	return err
+	if err != nil {
+		return err
+	}
+
+	*dp.lastContents = jsonBytes
+	return nil
 }
 
+// write saves the data while holding a lock on the file. If another process
+// changed the file since it was last read or written, only the values changed
+// in data are written over the other process's changes, and data is updated
+// with the merged contents.
 func (dp DiskPersistor) write(data DataInterface) error {
-	bytes, err := data.JSONMarshalV3()
+	jsonBytes, err := data.JSONMarshalV3()
 	if err != nil {
 		return err
 	}
 
-	err = ioutil.WriteFile(dp.filePath, bytes, filePermissions)
-	return err
+	lock, err := configfile.LockFile(dp.filePath)
+	if err != nil {
+		return err
+	}
+	defer lock.Unlock()
+
+	currentBytes, err := ioutil.ReadFile(dp.filePath)
+	if err == nil && len(currentBytes) > 0 && !bytes.Equal(currentBytes, *dp.lastContents) {
+		jsonBytes, err = dp.merge(data, jsonBytes, currentBytes)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = configfile.WriteAtomically(dp.filePath, jsonBytes, filePermissions)
+	if err != nil {
+		return err
+	}
+
+	*dp.lastContents = jsonBytes
+	return nil
+}
+
+func (dp DiskPersistor) merge(data DataInterface, jsonBytes []byte, currentBytes []byte) ([]byte, error) {
+	mergedBytes, err := configfile.MergeJSON(*dp.lastContents, jsonBytes, currentBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	err = data.JSONUnmarshalV3(mergedBytes)
+	if err != nil {
+		return nil, err
+	}
+	return data.JSONMarshalV3()
 }
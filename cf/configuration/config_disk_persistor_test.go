@@ -30,6 +30,7 @@ var _ = Describe("DiskPersistor", func() {
 
 	AfterEach(func() {
 		os.Remove(tmpFile.Name())
+		os.Remove(tmpFile.Name() + ".lock")
 	})
 
 	Describe(".Delete", func() {
@@ -54,6 +55,27 @@ var _ = Describe("DiskPersistor", func() {
 			Expect(err).ToNot(HaveOccurred())
 			Expect(string(dataBytes)).To(ContainSubstring(d.Info))
 		})
+
+		Context("when another process changed the file since it was loaded", func() {
+			It("keeps the other process's changes to values that were not changed", func() {
+				err := ioutil.WriteFile(tmpFile.Name(), []byte(`{"Info":"old info","Other":"old other"}`), 0600)
+				Expect(err).ToNot(HaveOccurred())
+
+				d := &data{}
+				Expect(diskPersistor.Load(d)).To(Succeed())
+
+				err = ioutil.WriteFile(tmpFile.Name(), []byte(`{"Info":"old info","Other":"their other"}`), 0600)
+				Expect(err).ToNot(HaveOccurred())
+
+				d.Info = "new info"
+				Expect(diskPersistor.Save(d)).To(Succeed())
+				Expect(d.Other).To(Equal("their other"))
+
+				saved := &data{}
+				Expect(NewDiskPersistor(tmpFile.Name()).Load(saved)).To(Succeed())
+				Expect(*saved).To(Equal(data{Info: "new info", Other: "their other"}))
+			})
+		})
 	})
 
 	Describe(".Load", func() {
@@ -79,7 +101,8 @@ var _ = Describe("DiskPersistor", func() {
 })
 
 type data struct {
-	Info string
+	Info  string
+	Other string
 }
 
 func (d *data) JSONMarshalV3() ([]byte, error) {
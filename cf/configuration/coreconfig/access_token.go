@@ -10,6 +10,8 @@ type TokenInfo struct {
 	Username string `json:"user_name"`
 	Email    string `json:"email"`
 	UserGUID string `json:"user_id"`
+	ClientID string `json:"client_id"`
+	Expiry   int64  `json:"exp"`
 }
 
 func NewTokenInfo(accessToken string) (info TokenInfo) {
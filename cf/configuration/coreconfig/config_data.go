@@ -2,8 +2,11 @@ package coreconfig
 
 import (
 	"encoding/json"
+	"os"
+	"strconv"
 
 	"code.cloudfoundry.org/cli/cf/models"
+	"code.cloudfoundry.org/cli/util/configv3"
 )
 
 type AuthPromptType string
@@ -30,6 +33,7 @@ type Data struct {
 	AccessToken              string
 	UAAOAuthClient           string
 	UAAOAuthClientSecret     string
+	UAAGrantType             string
 	SSHOAuthClient           string
 	RefreshToken             string
 	OrganizationFields       models.OrganizationFields
@@ -42,8 +46,20 @@ type Data struct {
 	PluginRepos              []models.PluginRepo
 	MinCLIVersion            string
 	MinRecommendedCLIVersion string
+	ActiveProfile            string                      `json:",omitempty"`
+	Profiles                 map[string]configv3.Profile `json:",omitempty"`
+
+	// profileName is the named profile whose settings are in use. It is
+	// empty for the default profile, whose settings are stored at the top
+	// level of the config file.
+	profileName     string
+	defaultSettings configv3.Profile
 }
 
+// Profile is the profile selected with the '--profile' global flag. It takes
+// precedence over $CF_PROFILE and the active profile.
+var Profile string
+
 func NewData() *Data {
 	data := new(Data)
 
@@ -55,7 +71,7 @@ func NewData() *Data {
 
 func (d *Data) JSONMarshalV3() ([]byte, error) {
 	d.ConfigVersion = 3
-	return json.MarshalIndent(d, "", "  ")
+	return json.MarshalIndent(d.fileContents(), "", "  ")
 }
 
 func (d *Data) JSONUnmarshalV3(input []byte) error {
@@ -69,5 +85,143 @@ func (d *Data) JSONUnmarshalV3(input []byte) error {
 		return nil
 	}
 
+	d.useProfile()
+	return nil
+}
+
+// selectedProfile returns the name of the profile selected with the
+// '--profile' flag, $CF_PROFILE or the active profile, in that order.
+func (d *Data) selectedProfile() string {
+	if Profile != "" {
+		return Profile
+	}
+	if os.Getenv("CF_PROFILE") != "" {
+		return os.Getenv("CF_PROFILE")
+	}
+	return d.ActiveProfile
+}
+
+// checkProfile returns a ProfileNotFoundError if the selected profile does
+// not exist.
+func (d *Data) checkProfile() error {
+	name := d.selectedProfile()
+	if name == "" || name == configv3.DefaultProfileName || name == d.profileName {
+		return nil
+	}
+	if _, ok := d.Profiles[name]; !ok {
+		return configv3.ProfileNotFoundError{Name: name}
+	}
 	return nil
 }
+
+// useProfile puts the settings of the selected profile in place of the
+// default profile's settings. Unknown profiles are left to checkProfile.
+func (d *Data) useProfile() {
+	name := d.selectedProfile()
+	profile, ok := d.Profiles[name]
+	if !ok {
+		return
+	}
+
+	d.defaultSettings = d.profile()
+	d.setProfile(profile)
+	d.profileName = name
+}
+
+// fileContents returns the data as it is stored on disk, with the settings
+// of the profile in use moved back into Profiles.
+func (d *Data) fileContents() *Data {
+	if d.profileName == "" {
+		return d
+	}
+
+	file := *d
+	file.Profiles = map[string]configv3.Profile{}
+	for name, profile := range d.Profiles {
+		file.Profiles[name] = profile
+	}
+	file.Profiles[d.profileName] = d.profile()
+	file.setProfile(d.defaultSettings)
+	return &file
+}
+
+func (d *Data) profile() configv3.Profile {
+	return configv3.Profile{
+		Target:                   d.Target,
+		APIVersion:               d.APIVersion,
+		AuthorizationEndpoint:    d.AuthorizationEndpoint,
+		LoggregatorEndpoint:      d.LoggregatorEndPoint,
+		DopplerEndpoint:          d.DopplerEndPoint,
+		UAAEndpoint:              d.UaaEndpoint,
+		RoutingEndpoint:          d.RoutingAPIEndpoint,
+		AccessToken:              d.AccessToken,
+		SSHOAuthClient:           d.SSHOAuthClient,
+		UAAOAuthClient:           d.UAAOAuthClient,
+		UAAOAuthClientSecret:     d.UAAOAuthClientSecret,
+		UAAGrantType:             d.UAAGrantType,
+		RefreshToken:             d.RefreshToken,
+		TargetedOrganization:     profileOrganization(d.OrganizationFields),
+		TargetedSpace:            configv3.Space{GUID: d.SpaceFields.GUID, Name: d.SpaceFields.Name, AllowSSH: d.SpaceFields.AllowSSH},
+		SkipSSLValidation:        d.SSLDisabled,
+		MinCLIVersion:            d.MinCLIVersion,
+		MinRecommendedCLIVersion: d.MinRecommendedCLIVersion,
+	}
+}
+
+func (d *Data) setProfile(profile configv3.Profile) {
+	d.Target = profile.Target
+	d.APIVersion = profile.APIVersion
+	d.AuthorizationEndpoint = profile.AuthorizationEndpoint
+	d.LoggregatorEndPoint = profile.LoggregatorEndpoint
+	d.DopplerEndPoint = profile.DopplerEndpoint
+	d.UaaEndpoint = profile.UAAEndpoint
+	d.RoutingAPIEndpoint = profile.RoutingEndpoint
+	d.AccessToken = profile.AccessToken
+	d.SSHOAuthClient = profile.SSHOAuthClient
+	d.UAAOAuthClient = profile.UAAOAuthClient
+	d.UAAOAuthClientSecret = profile.UAAOAuthClientSecret
+	d.UAAGrantType = profile.UAAGrantType
+	d.RefreshToken = profile.RefreshToken
+	d.OrganizationFields = organizationFields(profile.TargetedOrganization)
+	d.SpaceFields = models.SpaceFields{GUID: profile.TargetedSpace.GUID, Name: profile.TargetedSpace.Name, AllowSSH: profile.TargetedSpace.AllowSSH}
+	d.SSLDisabled = profile.SkipSSLValidation
+	d.MinCLIVersion = profile.MinCLIVersion
+	d.MinRecommendedCLIVersion = profile.MinRecommendedCLIVersion
+}
+
+func profileOrganization(org models.OrganizationFields) configv3.Organization {
+	reservedRoutePorts, _ := org.QuotaDefinition.ReservedRoutePorts.Int64()
+	return configv3.Organization{
+		GUID: org.GUID,
+		Name: org.Name,
+		QuotaDefinition: configv3.QuotaDefinition{
+			GUID:                    org.QuotaDefinition.GUID,
+			Name:                    org.QuotaDefinition.Name,
+			MemoryLimit:             int(org.QuotaDefinition.MemoryLimit),
+			InstanceMemoryLimit:     int(org.QuotaDefinition.InstanceMemoryLimit),
+			TotalRoutes:             org.QuotaDefinition.RoutesLimit,
+			TotalServices:           org.QuotaDefinition.ServicesLimit,
+			NonBasicServicesAllowed: org.QuotaDefinition.NonBasicServicesAllowed,
+			AppInstanceLimit:        org.QuotaDefinition.AppInstanceLimit,
+			TotalReservedRoutePorts: int(reservedRoutePorts),
+		},
+	}
+}
+
+func organizationFields(org configv3.Organization) models.OrganizationFields {
+	return models.OrganizationFields{
+		GUID: org.GUID,
+		Name: org.Name,
+		QuotaDefinition: models.QuotaFields{
+			GUID:                    org.QuotaDefinition.GUID,
+			Name:                    org.QuotaDefinition.Name,
+			MemoryLimit:             int64(org.QuotaDefinition.MemoryLimit),
+			InstanceMemoryLimit:     int64(org.QuotaDefinition.InstanceMemoryLimit),
+			RoutesLimit:             org.QuotaDefinition.TotalRoutes,
+			ServicesLimit:           org.QuotaDefinition.TotalServices,
+			NonBasicServicesAllowed: org.QuotaDefinition.NonBasicServicesAllowed,
+			AppInstanceLimit:        org.QuotaDefinition.AppInstanceLimit,
+			ReservedRoutePorts:      json.Number(strconv.Itoa(org.QuotaDefinition.TotalReservedRoutePorts)),
+		},
+	}
+}
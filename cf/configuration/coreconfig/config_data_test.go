@@ -1,6 +1,9 @@
 package coreconfig_test
 
 import (
+	"encoding/json"
+	"os"
+
 	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
 	"code.cloudfoundry.org/cli/cf/models"
 
@@ -22,6 +25,7 @@ var _ = Describe("V3 Config files", func() {
 		"AccessToken": "the-access-token",
 		"UAAOAuthClient": "cf-oauth-client-id",
 		"UAAOAuthClientSecret": "cf-oauth-client-secret",
+		"UAAGrantType": "",
 		"SSHOAuthClient": "ssh-oauth-client-id",
 		"RefreshToken": "the-refresh-token",
 		"OrganizationFields": {
@@ -71,6 +75,7 @@ var _ = Describe("V3 Config files", func() {
 		"AccessToken": "the-access-token",
 		"UAAOAuthClient": "cf-oauth-client-id",
 		"UAAOAuthClientSecret": "cf-oauth-client-secret",
+		"UAAGrantType": "",
 		"SSHOAuthClient": "ssh-oauth-client-id",
 		"RefreshToken": "the-refresh-token",
 		"OrganizationFields": {
@@ -218,4 +223,111 @@ var _ = Describe("V3 Config files", func() {
 			Expect(*actualData).To(Equal(coreconfig.Data{}))
 		})
 	})
+
+	Describe("profiles", func() {
+		var profilesJSON = `
+		{
+			"ConfigVersion": 3,
+			"Target": "api.default.com",
+			"AccessToken": "default-token",
+			"Locale": "fr_FR",
+			"ActiveProfile": "staging",
+			"Profiles": {
+				"staging": {
+					"Target": "api.staging.com",
+					"AccessToken": "staging-token",
+					"SSLDisabled": true
+				},
+				"production": {
+					"Target": "api.production.com",
+					"AccessToken": "production-token"
+				}
+			}
+		}`
+
+		var data *coreconfig.Data
+
+		BeforeEach(func() {
+			data = coreconfig.NewData()
+		})
+
+		It("uses the active profile", func() {
+			err := data.JSONUnmarshalV3([]byte(profilesJSON))
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(data.Target).To(Equal("api.staging.com"))
+			Expect(data.AccessToken).To(Equal("staging-token"))
+			Expect(data.SSLDisabled).To(BeTrue())
+			Expect(data.Locale).To(Equal("fr_FR"))
+		})
+
+		Context("when $CF_PROFILE is set", func() {
+			BeforeEach(func() {
+				os.Setenv("CF_PROFILE", "production")
+			})
+
+			AfterEach(func() {
+				os.Unsetenv("CF_PROFILE")
+			})
+
+			It("uses that profile", func() {
+				err := data.JSONUnmarshalV3([]byte(profilesJSON))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(data.Target).To(Equal("api.production.com"))
+			})
+		})
+
+		Context("when a profile is selected with the --profile flag", func() {
+			BeforeEach(func() {
+				os.Setenv("CF_PROFILE", "staging")
+				coreconfig.Profile = "production"
+			})
+
+			AfterEach(func() {
+				os.Unsetenv("CF_PROFILE")
+				coreconfig.Profile = ""
+			})
+
+			It("uses that profile over $CF_PROFILE", func() {
+				err := data.JSONUnmarshalV3([]byte(profilesJSON))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(data.Target).To(Equal("api.production.com"))
+			})
+		})
+
+		Context("when the default profile is selected", func() {
+			BeforeEach(func() {
+				coreconfig.Profile = "default"
+			})
+
+			AfterEach(func() {
+				coreconfig.Profile = ""
+			})
+
+			It("uses the top level settings", func() {
+				err := data.JSONUnmarshalV3([]byte(profilesJSON))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(data.Target).To(Equal("api.default.com"))
+			})
+		})
+
+		It("stores changes in the profile in use", func() {
+			err := data.JSONUnmarshalV3([]byte(profilesJSON))
+			Expect(err).NotTo(HaveOccurred())
+
+			data.AccessToken = "new-staging-token"
+			jsonData, err := data.JSONMarshalV3()
+			Expect(err).NotTo(HaveOccurred())
+
+			var written coreconfig.Data
+			Expect(json.Unmarshal(jsonData, &written)).To(Succeed())
+			Expect(written.Target).To(Equal("api.default.com"))
+			Expect(written.AccessToken).To(Equal("default-token"))
+			Expect(written.ActiveProfile).To(Equal("staging"))
+			Expect(written.Profiles["staging"].AccessToken).To(Equal("new-staging-token"))
+			Expect(written.Profiles["production"].AccessToken).To(Equal("production-token"))
+
+			Expect(data.AccessToken).To(Equal("new-staging-token"))
+		})
+	})
 })
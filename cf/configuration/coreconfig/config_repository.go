@@ -69,6 +69,7 @@ type Reader interface {
 	AccessToken() string
 	UAAOAuthClient() string
 	UAAOAuthClientSecret() string
+	UAAGrantType() string
 	SSHOAuthClient() string
 	RefreshToken() string
 
@@ -116,6 +117,7 @@ type ReadWriter interface {
 	SetAccessToken(string)
 	SetUAAOAuthClient(string)
 	SetUAAOAuthClientSecret(string)
+	SetUAAGrantType(string)
 	SetSSHOAuthClient(string)
 	SetRefreshToken(string)
 	SetOrganizationFields(models.OrganizationFields)
@@ -142,6 +144,9 @@ type Repository interface {
 func (c *ConfigRepository) init() {
 	c.initOnce.Do(func() {
 		err := c.persistor.Load(c.data)
+		if err == nil {
+			err = c.data.checkProfile()
+		}
 		if err != nil {
 			c.onError(err)
 		}
@@ -262,6 +267,13 @@ func (c *ConfigRepository) UAAOAuthClientSecret() (clientID string) {
 	return
 }
 
+func (c *ConfigRepository) UAAGrantType() (grantType string) {
+	c.read(func() {
+		grantType = c.data.UAAGrantType
+	})
+	return
+}
+
 func (c *ConfigRepository) SSHOAuthClient() (clientID string) {
 	c.read(func() {
 		clientID = c.data.SSHOAuthClient
@@ -443,6 +455,11 @@ func (c *ConfigRepository) ClearSession() {
 	c.write(func() {
 		c.data.AccessToken = ""
 		c.data.RefreshToken = ""
+		if c.data.UAAGrantType == "client_credentials" {
+			c.data.UAAOAuthClient = "cf"
+			c.data.UAAOAuthClientSecret = ""
+		}
+		c.data.UAAGrantType = ""
 		c.data.OrganizationFields = models.OrganizationFields{}
 		c.data.SpaceFields = models.SpaceFields{}
 	})
@@ -520,6 +537,12 @@ func (c *ConfigRepository) SetUAAOAuthClientSecret(clientID string) {
 	})
 }
 
+func (c *ConfigRepository) SetUAAGrantType(grantType string) {
+	c.write(func() {
+		c.data.UAAGrantType = grantType
+	})
+}
+
 func (c *ConfigRepository) SetSSHOAuthClient(clientID string) {
 	c.write(func() {
 		c.data.SSHOAuthClient = clientID
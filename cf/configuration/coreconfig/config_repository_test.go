@@ -9,6 +9,7 @@ import (
 	"code.cloudfoundry.org/cli/cf/configuration/configurationfakes"
 	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
 	"code.cloudfoundry.org/cli/cf/models"
+	"code.cloudfoundry.org/cli/util/configv3"
 	"github.com/blang/semver"
 
 	. "github.com/onsi/ginkgo"
@@ -100,6 +101,9 @@ var _ = Describe("Configuration Repository", func() {
 		config.SetUAAOAuthClientSecret("cf-oauth-client-secret")
 		Expect(config.UAAOAuthClientSecret()).To(Equal("cf-oauth-client-secret"))
 
+		config.SetUAAGrantType("client_credentials")
+		Expect(config.UAAGrantType()).To(Equal("client_credentials"))
+
 		config.SetSSHOAuthClient("oauth-client-id")
 		Expect(config.SSHOAuthClient()).To(Equal("oauth-client-id"))
 
@@ -134,6 +138,38 @@ var _ = Describe("Configuration Repository", func() {
 		Expect(config.MinRecommendedCLIVersion()).To(Equal("6.9.0"))
 	})
 
+	Describe("ClearSession", func() {
+		BeforeEach(func() {
+			config.SetAccessToken("the-token")
+			config.SetRefreshToken("the-refresh-token")
+			config.SetOrganizationFields(models.OrganizationFields{Name: "the-org"})
+			config.SetSpaceFields(models.SpaceFields{Name: "the-space"})
+		})
+
+		It("removes the tokens and the targeted org and space", func() {
+			config.ClearSession()
+			Expect(config.AccessToken()).To(BeEmpty())
+			Expect(config.RefreshToken()).To(BeEmpty())
+			Expect(config.OrganizationFields()).To(Equal(models.OrganizationFields{}))
+			Expect(config.SpaceFields()).To(Equal(models.SpaceFields{}))
+		})
+
+		Context("when authenticated with client credentials", func() {
+			BeforeEach(func() {
+				config.SetUAAGrantType("client_credentials")
+				config.SetUAAOAuthClient("some-client")
+				config.SetUAAOAuthClientSecret("some-secret")
+			})
+
+			It("restores the default UAA client", func() {
+				config.ClearSession()
+				Expect(config.UAAGrantType()).To(BeEmpty())
+				Expect(config.UAAOAuthClient()).To(Equal("cf"))
+				Expect(config.UAAOAuthClientSecret()).To(BeEmpty())
+			})
+		})
+	})
+
 	Describe("HasAPIEndpoint", func() {
 		Context("when both endpoint and version are set", func() {
 			BeforeEach(func() {
@@ -266,6 +302,61 @@ var _ = Describe("Configuration Repository", func() {
 		})
 	})
 
+	Describe("profiles", func() {
+		var (
+			configPath string
+			configErr  error
+		)
+
+		BeforeEach(func() {
+			tmpDir, err := ioutil.TempDir("", "test-config")
+			Expect(err).NotTo(HaveOccurred())
+			configPath = filepath.Join(tmpDir, ".cf", "config.json")
+			Expect(os.MkdirAll(filepath.Dir(configPath), 0700)).To(Succeed())
+			Expect(ioutil.WriteFile(configPath, []byte(`{
+				"ConfigVersion": 3,
+				"Target": "api.default.com",
+				"Profiles": {
+					"staging": {"Target": "api.staging.com"}
+				}
+			}`), 0600)).To(Succeed())
+			configErr = nil
+		})
+
+		AfterEach(func() {
+			coreconfig.Profile = ""
+			os.RemoveAll(filepath.Dir(filepath.Dir(configPath)))
+		})
+
+		loadConfig := func() coreconfig.Repository {
+			return coreconfig.NewRepositoryFromFilepath(configPath, func(err error) { configErr = err })
+		}
+
+		It("uses the selected profile", func() {
+			coreconfig.Profile = "staging"
+			Expect(loadConfig().APIEndpoint()).To(Equal("api.staging.com"))
+			Expect(configErr).NotTo(HaveOccurred())
+		})
+
+		Context("when the selected profile does not exist", func() {
+			BeforeEach(func() {
+				coreconfig.Profile = "missing"
+			})
+
+			It("reports a ProfileNotFoundError and leaves the file alone", func() {
+				before, err := ioutil.ReadFile(configPath)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(loadConfig().APIEndpoint()).To(Equal("api.default.com"))
+				Expect(configErr).To(MatchError(configv3.ProfileNotFoundError{Name: "missing"}))
+
+				after, err := ioutil.ReadFile(configPath)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(after).To(Equal(before))
+			})
+		})
+	})
+
 	Describe("IsMinCLIVersion", func() {
 		It("returns true when the actual version is BUILT_FROM_SOURCE", func() {
 			Expect(config.IsMinCLIVersion("BUILT_FROM_SOURCE")).To(BeTrue())
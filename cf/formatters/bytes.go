@@ -47,6 +47,16 @@ func ByteSize(bytes int64) string {
 }
 
 func ToMegabytes(s string) (int64, error) {
+	bytes, err := ToBytes(s)
+	if err != nil {
+		return 0, err
+	}
+
+	return bytes / MEGABYTE, nil
+}
+
+// ToBytes converts a quantity with a unit, such as 512K or 2G, to bytes.
+func ToBytes(s string) (int64, error) {
 	parts := bytesPattern.FindStringSubmatch(strings.TrimSpace(s))
 	if len(parts) < 3 {
 		return 0, invalidByteQuantityError()
@@ -70,7 +80,7 @@ func ToMegabytes(s string) (int64, error) {
 		bytes = value * KILOBYTE
 	}
 
-	return bytes / MEGABYTE, nil
+	return bytes, nil
 }
 
 var (
@@ -66,6 +66,23 @@ var _ = Describe("formatting bytes to / from strings", func() {
 		Expect(err).NotTo(HaveOccurred())
 	})
 
+	Describe("ToBytes()", func() {
+		It("parses byte amounts with units", func() {
+			bytes, err := ToBytes("512K")
+			Expect(bytes).To(Equal(int64(512 * KILOBYTE)))
+			Expect(err).NotTo(HaveOccurred())
+
+			bytes, err = ToBytes("2MB")
+			Expect(bytes).To(Equal(int64(2 * MEGABYTE)))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns an error when the unit is missing", func() {
+			_, err := ToBytes("512")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
 	It("returns an error when the unit is missing", func() {
 		_, err := ToMegabytes("5")
 		Expect(err).To(HaveOccurred())
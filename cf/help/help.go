@@ -142,6 +142,7 @@ func newAppPresenter() appPresenter {
 					presentCommand("events"),
 					presentCommand("files"),
 					presentCommand("logs"),
+					presentCommand("app-traffic"),
 				}, {
 					presentCommand("env"),
 					presentCommand("set-env"),
@@ -153,6 +154,7 @@ func newAppPresenter() appPresenter {
 					presentCommand("copy-source"),
 				}, {
 					presentCommand("create-app-manifest"),
+					presentCommand("validate-manifest"),
 				}, {
 					presentCommand("get-health-check"),
 					presentCommand("set-health-check"),
@@ -160,6 +162,7 @@ func newAppPresenter() appPresenter {
 					presentCommand("disable-ssh"),
 					presentCommand("ssh-enabled"),
 					presentCommand("ssh"),
+					presentCommand("scp"),
 				},
 			},
 		}, {
@@ -16,6 +16,7 @@ func GetHelpTemplate() string {
 {{range .}}   {{.Name}} {{.Description}}
 {{end}}{{end}}{{end}}
 {{.Title "` + T("ENVIRONMENT VARIABLES:") + `"}}
+   CF_CACHE=true                      ` + T("Cache Cloud Controller responses on disk and revalidate them") + `
    CF_COLOR=false                     ` + T("Do not colorize output") + `
    CF_HOME=path/to/dir/               ` + T("Override path to default config directory") + `
    CF_DIAL_TIMEOUT=5                  ` + T("Max wait time to establish a connection, including name resolution, in seconds") + `
@@ -29,5 +30,6 @@ func GetHelpTemplate() string {
 {{.Title "` + T("GLOBAL OPTIONS:") + `"}}
    --help, -h                         ` + T("Show help") + `
    -v                                 ` + T("Print API request diagnostics to stdout") + `
+   --no-cache                         ` + T("Do not read or write cached Cloud Controller responses") + `
 `
 }
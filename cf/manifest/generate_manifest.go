@@ -23,6 +23,9 @@ type App interface {
 	StartCommand(string, string)
 	EnvironmentVars(string, string, string)
 	HealthCheckTimeout(string, int)
+	HealthCheckType(string, string)
+	HealthCheckHTTPEndpoint(string, string)
+	DockerImage(string, string)
 	Instances(string, int)
 	Route(string, string, string, string, int)
 	GetContents() []models.Application
@@ -40,11 +43,19 @@ type Application struct {
 	Routes    []map[string]string    `yaml:"routes,omitempty"`
 	NoRoute   bool                   `yaml:"no-route,omitempty"`
 	Buildpack string                 `yaml:"buildpack,omitempty"`
+	Docker    *ApplicationDocker     `yaml:"docker,omitempty"`
 	Command   string                 `yaml:"command,omitempty"`
 	Env       map[string]interface{} `yaml:"env,omitempty"`
 	Services  []string               `yaml:"services,omitempty"`
 	Stack     string                 `yaml:"stack,omitempty"`
 	Timeout   int                    `yaml:"timeout,omitempty"`
+
+	HealthCheckType         string `yaml:"health-check-type,omitempty"`
+	HealthCheckHTTPEndpoint string `yaml:"health-check-http-endpoint,omitempty"`
+}
+
+type ApplicationDocker struct {
+	Image string `yaml:"image"`
 }
 
 type Applications struct {
@@ -91,6 +102,21 @@ func (m *appManifest) HealthCheckTimeout(appName string, timeout int) {
 	m.contents[i].HealthCheckTimeout = timeout
 }
 
+func (m *appManifest) HealthCheckType(appName string, healthCheckType string) {
+	i := m.findOrCreateApplication(appName)
+	m.contents[i].HealthCheckType = healthCheckType
+}
+
+func (m *appManifest) HealthCheckHTTPEndpoint(appName string, endpoint string) {
+	i := m.findOrCreateApplication(appName)
+	m.contents[i].HealthCheckHTTPEndpoint = endpoint
+}
+
+func (m *appManifest) DockerImage(appName string, image string) {
+	i := m.findOrCreateApplication(appName)
+	m.contents[i].DockerImage = image
+}
+
 func (m *appManifest) Instances(appName string, instances int) {
 	i := m.findOrCreateApplication(appName)
 	m.contents[i].InstanceCount = instances
@@ -170,6 +196,13 @@ func generateAppMap(app models.Application) (Application, error) {
 		Stack:     app.Stack.Name,
 		AppPorts:  app.AppPorts,
 		Routes:    routes,
+
+		HealthCheckType:         app.HealthCheckType,
+		HealthCheckHTTPEndpoint: app.HealthCheckHTTPEndpoint,
+	}
+
+	if app.DockerImage != "" {
+		m.Docker = &ApplicationDocker{Image: app.DockerImage}
 	}
 
 	if len(app.Routes) == 0 {
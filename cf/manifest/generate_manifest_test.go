@@ -137,6 +137,35 @@ var _ = Describe("generate_manifest", func() {
 				})
 			})
 
+			Context("when an application has a health check type and endpoint", func() {
+				BeforeEach(func() {
+					m.HealthCheckType("app1", "http")
+					m.HealthCheckHTTPEndpoint("app1", "/health")
+				})
+
+				It("includes the health check type and endpoint for that app", func() {
+					err := m.Save(f)
+					Expect(err).NotTo(HaveOccurred())
+					application := getYaml(f).Applications[0]
+					Expect(application.HealthCheckType).To(Equal("http"))
+					Expect(application.HealthCheckHTTPEndpoint).To(Equal("/health"))
+				})
+			})
+
+			Context("when an application has a docker image", func() {
+				BeforeEach(func() {
+					m.DockerImage("app1", "user/docker-image")
+				})
+
+				It("includes the docker image for that app", func() {
+					err := m.Save(f)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(f.String()).To(ContainSubstring("docker:\n    image: user/docker-image\n"))
+					application := getYaml(f).Applications[0]
+					Expect(application.Docker).To(Equal(&ApplicationDocker{Image: "user/docker-image"}))
+				})
+			})
+
 			It("includes no-route when the application has no routes", func() {
 				m.Save(f)
 				contents := getYaml(f)
@@ -324,6 +353,10 @@ type YApplication struct {
 	DiskQuota string                 `yaml:"disk_quota"`
 	Stack     string                 `yaml:"stack"`
 	AppPorts  []int                  `yaml:"app-ports"`
+
+	HealthCheckType         string             `yaml:"health-check-type"`
+	HealthCheckHTTPEndpoint string             `yaml:"health-check-http-endpoint"`
+	Docker                  *ApplicationDocker `yaml:"docker"`
 }
 
 func getYaml(f *bytes.Buffer) YManifest {
@@ -19,6 +19,9 @@ import (
 type Manifest struct {
 	Path string
 	Data generic.Map
+
+	// Variables are the values of the ((variables)) in Data.
+	Variables map[string]interface{}
 }
 
 func NewEmptyManifest() (m *Manifest) {
@@ -26,7 +29,12 @@ func NewEmptyManifest() (m *Manifest) {
 }
 
 func (m Manifest) Applications() ([]models.AppParams, error) {
-	rawData, err := expandProperties(m.Data, generator.NewWordGenerator())
+	interpolatedData, err := interpolateVariables(m.Data, m.Variables)
+	if err != nil {
+		return []models.AppParams{}, err
+	}
+
+	rawData, err := expandProperties(interpolatedData, generator.NewWordGenerator())
 	if err != nil {
 		return []models.AppParams{}, err
 	}
@@ -202,16 +210,22 @@ func mapToAppParams(basePath string, yamlMap generic.Map) (models.AppParams, err
 	appParams.NoHostname = boolOrNil(yamlMap, "no-hostname", &errs)
 	appParams.UseRandomRoute = boolVal(yamlMap, "random-route", &errs)
 	appParams.ServicesToBind = sliceOrNil(yamlMap, "services", &errs)
+	appParams.DependsOn = sliceOrNil(yamlMap, "depends-on", &errs)
 	appParams.EnvironmentVars = envVarOrEmptyMap(yamlMap, &errs)
 	appParams.HealthCheckType = stringVal(yamlMap, "health-check-type", &errs)
+	appParams.HealthCheckHTTPEndpoint = stringVal(yamlMap, "health-check-http-endpoint", &errs)
+	appParams.DockerImage = dockerImageVal(yamlMap, &errs)
 	appParams.AppPorts = intSliceVal(yamlMap, "app-ports", &errs)
 	appParams.Routes = parseRoutes(yamlMap, &errs)
 
 	if appParams.Path != nil {
 		path := *appParams.Path
-		if filepath.IsAbs(path) {
+		switch {
+		case strings.HasPrefix(path, "http://") || strings.HasPrefix(path, "https://"):
+			// a URL of an archive to download is not relative to the manifest
+		case filepath.IsAbs(path):
 			path = filepath.Clean(path)
-		} else {
+		default:
 			path = filepath.Join(basePath, path)
 		}
 		appParams.Path = &path
@@ -487,6 +501,29 @@ func validateEnvVars(input generic.Map) (errs []error) {
 	return
 }
 
+// dockerImageVal returns the image of the 'docker' key, which is a set of
+// key => value such as {image: user/docker-image-name}.
+func dockerImageVal(yamlMap generic.Map, errs *[]error) *string {
+	key := "docker"
+	if !yamlMap.Has(key) {
+		return nil
+	}
+
+	if !generic.IsMappable(yamlMap.Get(key)) {
+		*errs = append(*errs, fmt.Errorf(T("Expected {{.Name}} to be a set of key => value, but it was a {{.Type}}.",
+			map[string]interface{}{"Name": key, "Type": yamlMap.Get(key)})))
+		return nil
+	}
+
+	docker := generic.NewMap(yamlMap.Get(key))
+	if !docker.Has("image") {
+		*errs = append(*errs, fmt.Errorf(T("'docker' must have an 'image' property")))
+		return nil
+	}
+
+	return stringVal(docker, "image", errs)
+}
+
 func parseRoutes(input generic.Map, errs *[]error) []models.ManifestRoute {
 	if !input.Has("routes") {
 		return nil
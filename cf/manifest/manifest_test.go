@@ -222,6 +222,53 @@ var _ = Describe("Manifests", func() {
 		}
 	})
 
+	It("leaves app paths that are http(s) URLs as they are", func() {
+		m := NewManifest("/some/path/manifest.yml", generic.NewMap(map[interface{}]interface{}{
+			"applications": []interface{}{
+				map[interface{}]interface{}{
+					"path": "https://example.com/app.tgz#sha1=abc123",
+				},
+			},
+		}))
+
+		apps, err := m.Applications()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(*apps[0].Path).To(Equal("https://example.com/app.tgz#sha1=abc123"))
+	})
+
+	It("parses the docker image and health check http endpoint", func() {
+		m := NewManifest("/some/path/manifest.yml", generic.NewMap(map[interface{}]interface{}{
+			"applications": []interface{}{
+				map[interface{}]interface{}{
+					"name":                       "app-name",
+					"docker":                     map[interface{}]interface{}{"image": "user/docker-image"},
+					"health-check-type":          "http",
+					"health-check-http-endpoint": "/health",
+				},
+			},
+		}))
+
+		apps, err := m.Applications()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(*apps[0].DockerImage).To(Equal("user/docker-image"))
+		Expect(*apps[0].HealthCheckType).To(Equal("http"))
+		Expect(*apps[0].HealthCheckHTTPEndpoint).To(Equal("/health"))
+	})
+
+	It("returns an error when docker has no image", func() {
+		m := NewManifest("/some/path/manifest.yml", generic.NewMap(map[interface{}]interface{}{
+			"applications": []interface{}{
+				map[interface{}]interface{}{
+					"name":   "app-name",
+					"docker": map[interface{}]interface{}{"username": "user"},
+				},
+			},
+		}))
+
+		_, err := m.Applications()
+		Expect(err).To(MatchError(ContainSubstring("'docker' must have an 'image' property")))
+	})
+
 	It("returns errors when there are null values", func() {
 		m := NewManifest("/some/path", generic.NewMap(map[interface{}]interface{}{
 			"applications": []interface{}{
@@ -544,6 +591,39 @@ var _ = Describe("Manifests", func() {
 		})
 	})
 
+	Context("parsing depends-on", func() {
+		It("can read a list of application names", func() {
+			m := NewManifest("/some/path/manifest.yml", generic.NewMap(map[interface{}]interface{}{
+				"applications": []interface{}{
+					map[interface{}]interface{}{
+						"name":       "web",
+						"depends-on": []interface{}{"api", "worker"},
+					},
+				},
+			}))
+
+			app, err := m.Applications()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(app[0].DependsOn).To(Equal([]string{"api", "worker"}))
+		})
+
+		It("returns an error when depends-on is not a list of strings", func() {
+			m := NewManifest("/some/path/manifest.yml", generic.NewMap(map[interface{}]interface{}{
+				"applications": []interface{}{
+					map[interface{}]interface{}{
+						"name":       "web",
+						"depends-on": "api",
+					},
+				},
+			}))
+
+			_, err := m.Applications()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Expected depends-on to be a list of strings."))
+		})
+	})
+
 	Context("when routes are provided", func() {
 		var manifest *manifest.Manifest
 
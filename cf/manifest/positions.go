@@ -0,0 +1,185 @@
+package manifest
+
+import (
+	"fmt"
+	"strings"
+)
+
+// position is a 1-based line and column in a manifest file.
+type position struct {
+	Line   int
+	Column int
+}
+
+// positions maps the paths of the keys and list items in a manifest, such as
+// "applications[0].memory", to where they appear in the file.
+type positions map[string]position
+
+// find returns the position of path, or of its closest ancestor that has one.
+func (p positions) find(path string) position {
+	for path != "" {
+		if pos, ok := p[path]; ok {
+			return pos
+		}
+		path = parentPath(path)
+	}
+	return position{Line: 1, Column: 1}
+}
+
+func childPath(parent string, key string) string {
+	if parent == "" {
+		return key
+	}
+	return parent + "." + key
+}
+
+func itemPath(parent string, index int) string {
+	return fmt.Sprintf("%s[%d]", parent, index)
+}
+
+func parentPath(path string) string {
+	i := strings.LastIndexAny(path, ".[")
+	if i < 0 {
+		return ""
+	}
+	return path[:i]
+}
+
+type positionFrame struct {
+	indent   int
+	path     string
+	sequence bool
+	index    int
+}
+
+// findPositions scans the block style mappings and sequences of a YAML
+// document for the positions of their keys and items. It does not look
+// inside flow style collections or multi-line scalars; values in those are
+// given the position of the key that holds them by positions.find.
+func findPositions(contents []byte) positions {
+	found := positions{}
+	stack := []positionFrame{{indent: 0}}
+
+	var (
+		pending       bool
+		pendingPath   string
+		pendingIndent int
+		scalarIndent  = -1
+	)
+
+	for lineIndex, line := range strings.Split(string(contents), "\n") {
+		lineNumber := lineIndex + 1
+		line = strings.TrimRight(line, " \t\r")
+		content := strings.TrimLeft(line, " ")
+		indent := len(line) - len(content)
+
+		if scalarIndent >= 0 {
+			if content == "" || indent > scalarIndent {
+				continue
+			}
+			scalarIndent = -1
+		}
+		if content == "" || strings.HasPrefix(content, "#") || content == "---" || content == "..." {
+			continue
+		}
+
+		if pending {
+			pending = false
+			if isSequenceItem(content) && indent >= pendingIndent {
+				stack = append(stack, positionFrame{indent: indent, path: pendingPath, sequence: true, index: -1})
+			} else if indent > pendingIndent {
+				stack = append(stack, positionFrame{indent: indent, path: pendingPath})
+			}
+		}
+
+		for len(stack) > 1 {
+			top := stack[len(stack)-1]
+			if indent < top.indent || indent == top.indent && top.sequence && !isSequenceItem(content) {
+				stack = stack[:len(stack)-1]
+				continue
+			}
+			break
+		}
+
+		column := indent
+		for {
+			top := &stack[len(stack)-1]
+			if top.sequence && isSequenceItem(content) {
+				top.index++
+				path := itemPath(top.path, top.index)
+				found[path] = position{Line: lineNumber, Column: column + 1}
+
+				rest := strings.TrimLeft(content[1:], " ")
+				if rest == "" || strings.HasPrefix(rest, "#") {
+					pending, pendingPath, pendingIndent = true, path, column
+					break
+				}
+
+				if isBlockScalar(rest) {
+					scalarIndent = column
+					break
+				}
+
+				column += len(content) - len(rest)
+				content = rest
+				if _, _, ok := splitKey(content); ok {
+					stack = append(stack, positionFrame{indent: column, path: path})
+					continue
+				}
+				break
+			}
+
+			key, value, ok := splitKey(content)
+			if !ok {
+				break
+			}
+
+			path := childPath(top.path, key)
+			found[path] = position{Line: lineNumber, Column: column + 1}
+			switch {
+			case value == "" || strings.HasPrefix(value, "#"):
+				pending, pendingPath, pendingIndent = true, path, column
+			case isBlockScalar(value):
+				scalarIndent = column
+			}
+			break
+		}
+	}
+
+	return found
+}
+
+func isSequenceItem(content string) bool {
+	return content == "-" || strings.HasPrefix(content, "- ")
+}
+
+func isBlockScalar(value string) bool {
+	return strings.HasPrefix(value, "|") || strings.HasPrefix(value, ">")
+}
+
+// splitKey splits a 'key: value' line into its key and value.
+func splitKey(content string) (string, string, bool) {
+	if strings.HasPrefix(content, "{") || strings.HasPrefix(content, "[") {
+		return "", "", false
+	}
+
+	if quote := content[0]; quote == '"' || quote == '\'' {
+		end := strings.IndexByte(content[1:], quote)
+		if end < 0 {
+			return "", "", false
+		}
+		rest := content[end+2:]
+		if rest != ":" && !strings.HasPrefix(rest, ": ") {
+			return "", "", false
+		}
+		return content[1 : end+1], strings.TrimSpace(rest[1:]), true
+	}
+
+	if i := strings.Index(content, ": "); i >= 0 {
+		return content[:i], strings.TrimSpace(content[i+2:]), true
+	}
+	if strings.HasSuffix(content, ":") {
+		return content[:len(content)-1], "", true
+	}
+	return "", "", false
+}
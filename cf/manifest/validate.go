@@ -0,0 +1,419 @@
+package manifest
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	. "code.cloudfoundry.org/cli/cf/i18n"
+
+	"code.cloudfoundry.org/cli/cf/formatters"
+	"code.cloudfoundry.org/cli/util/generic"
+	"code.cloudfoundry.org/cli/util/spellcheck"
+	"gopkg.in/yaml.v2"
+)
+
+// appKeys are the keys that an application in a manifest may have. They may
+// also be given at the top level of a manifest, where they apply to every
+// application.
+var appKeys = []string{
+	"app-ports",
+	"buildpack",
+	"command",
+	"depends-on",
+	"disk_quota",
+	"docker",
+	"domain",
+	"domains",
+	"env",
+	"health-check-http-endpoint",
+	"health-check-type",
+	"host",
+	"hosts",
+	"instances",
+	"memory",
+	"name",
+	"no-hostname",
+	"no-route",
+	"path",
+	"random-route",
+	"routes",
+	"services",
+	"stack",
+	"timeout",
+}
+
+// topLevelKeys are the keys that may be given at the top level of a manifest.
+var topLevelKeys = append([]string{"applications", "inherit"}, appKeys...)
+
+// keyConflicts maps keys to the keys that cannot be used together with them.
+var keyConflicts = []struct {
+	key       string
+	conflicts []string
+}{
+	{"routes", []string{"host", "hosts", "domain", "domains", "no-hostname"}},
+	{"host", []string{"hosts"}},
+	{"domain", []string{"domains"}},
+}
+
+var yamlErrorLineRegex = regexp.MustCompile(`^yaml: line (\d+): `)
+
+// Diagnostic is a problem found in a manifest, and where it was found.
+type Diagnostic struct {
+	File    string
+	Line    int
+	Column  int
+	Message string
+}
+
+func (d Diagnostic) String() string {
+	return fmt.Sprintf("%s:%d:%d: %s", d.File, d.Line, d.Column, d.Message)
+}
+
+type manifestFile struct {
+	path        string
+	data        generic.Map
+	positions   positions
+	diagnostics []Diagnostic
+}
+
+func (file *manifestFile) report(path string, message string) {
+	pos := file.positions.find(path)
+	file.diagnostics = append(file.diagnostics, Diagnostic{
+		File:    file.path,
+		Line:    pos.Line,
+		Column:  pos.Column,
+		Message: message,
+	})
+}
+
+type diagnosticsByPosition []Diagnostic
+
+func (d diagnosticsByPosition) Len() int      { return len(d) }
+func (d diagnosticsByPosition) Swap(i, j int) { d[i], d[j] = d[j], d[i] }
+func (d diagnosticsByPosition) Less(i, j int) bool {
+	if d[i].Line != d[j].Line {
+		return d[i].Line < d[j].Line
+	}
+	return d[i].Column < d[j].Column
+}
+
+// Validate checks the manifest at path, and the manifests it inherits from,
+// for everything that would stop it from being pushed: YAML syntax errors,
+// unknown keys, values of the wrong type, invalid memory and disk quotas and
+// keys that cannot be used together. Values that contain ((variables)) are
+// not checked, since they are only known at push time.
+//
+// The diagnostics are ordered by file, starting with the manifest at path,
+// and by position. An error is returned only when the manifest cannot be
+// read.
+func Validate(path string) ([]Diagnostic, error) {
+	manifestPath, err := DiskRepository{}.manifestPath(path)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", T("Error finding manifest"), err.Error())
+	}
+
+	contents, err := ioutil.ReadFile(filepath.Clean(manifestPath))
+	if err != nil {
+		return nil, err
+	}
+
+	var files []*manifestFile
+	visited := map[string]bool{}
+	file := &manifestFile{path: manifestPath}
+	for file != nil {
+		files = append(files, file)
+		visited[filepath.Clean(file.path)] = true
+		file = validateFile(file, contents, visited, &contents)
+	}
+
+	checkConflicts(files)
+
+	var diagnostics []Diagnostic
+	for _, file := range files {
+		sort.Stable(diagnosticsByPosition(file.diagnostics))
+		diagnostics = append(diagnostics, file.diagnostics...)
+	}
+	return diagnostics, nil
+}
+
+// validateFile checks the contents of a single manifest file. It returns the
+// file it inherits from, if any, and sets next to that file's contents.
+func validateFile(file *manifestFile, contents []byte, visited map[string]bool, next *[]byte) *manifestFile {
+	file.positions = findPositions(contents)
+
+	raw := map[interface{}]interface{}{}
+	err := yaml.Unmarshal(contents, &raw)
+	if err != nil {
+		line := 1
+		message := err.Error()
+		if match := yamlErrorLineRegex.FindStringSubmatch(message); match != nil {
+			// yaml.v2 counts the lines in its error messages from 0
+			line, _ = strconv.Atoi(match[1])
+			line++
+			message = strings.TrimPrefix(message, match[0])
+		}
+		file.diagnostics = append(file.diagnostics, Diagnostic{File: file.path, Line: line, Column: 1, Message: message})
+		return nil
+	}
+	if len(raw) == 0 {
+		file.report("", T("Invalid manifest. Expected a map"))
+		return nil
+	}
+	file.data = generic.NewMap(raw)
+
+	for _, key := range sortedKeys(raw) {
+		value := raw[key]
+		switch key {
+		case "applications":
+			apps, ok := value.([]interface{})
+			if !ok {
+				file.report(key, T("Expected applications to be a list"))
+				continue
+			}
+			for i, app := range apps {
+				appPath := itemPath(key, i)
+				appMap, ok := app.(map[interface{}]interface{})
+				if !ok {
+					file.report(appPath, T("Expected application to be a list of key/value pairs"))
+					continue
+				}
+				for _, appKey := range sortedKeys(appMap) {
+					validateAppKey(file, childPath(appPath, appKey), appKey, appMap[appKey], appKeys)
+				}
+			}
+		default:
+			validateAppKey(file, key, key, value, topLevelKeys)
+		}
+	}
+
+	inheritedPath, ok := raw["inherit"].(string)
+	if !ok {
+		return nil
+	}
+	if !filepath.IsAbs(inheritedPath) {
+		inheritedPath = filepath.Join(filepath.Dir(file.path), inheritedPath)
+	}
+	if visited[filepath.Clean(inheritedPath)] {
+		file.report("inherit", T("Manifest {{.Path}} is already inherited", map[string]interface{}{"Path": inheritedPath}))
+		return nil
+	}
+
+	*next, err = ioutil.ReadFile(filepath.Clean(inheritedPath))
+	if err != nil {
+		file.report("inherit", T("Error reading inherited manifest: {{.Error}}", map[string]interface{}{"Error": err.Error()}))
+		return nil
+	}
+	return &manifestFile{path: inheritedPath}
+}
+
+// validateAppKey checks that key is one of the allowed keys and that its
+// value has the type the key requires.
+func validateAppKey(file *manifestFile, path string, key string, value interface{}, allowed []string) {
+	if !containsString(allowed, key) {
+		message := T("Unknown key '{{.Key}}'", map[string]interface{}{"Key": key})
+		if suggestions := spellcheck.NewCommandSuggester(allowed).Recommend(key); len(suggestions) > 0 {
+			message += ". " + T("Did you mean '{{.Suggestion}}'?", map[string]interface{}{"Suggestion": suggestions[0]})
+		}
+		file.report(path, message)
+		return
+	}
+
+	if containsVariable(value) {
+		return
+	}
+
+	if value == nil && key != "command" && key != "buildpack" {
+		file.report(path, T("{{.PropertyName}} should not be null", map[string]interface{}{"PropertyName": key}))
+		return
+	}
+
+	var errs []error
+	yamlMap := generic.NewMap(map[interface{}]interface{}{key: value})
+	switch key {
+	case "buildpack", "command":
+		stringValOrDefault(yamlMap, key, &errs)
+	case "domain", "health-check-http-endpoint", "health-check-type", "host", "inherit", "name", "path", "stack":
+		stringVal(yamlMap, key, &errs)
+	case "depends-on", "domains", "hosts", "services":
+		sliceOrNil(yamlMap, key, &errs)
+	case "instances", "timeout":
+		if s, ok := value.(string); ok {
+			if _, err := strconv.Atoi(s); err != nil {
+				errs = append(errs, fmt.Errorf(T("Expected {{.PropertyName}} to be a number, but it was a {{.PropertyType}}.",
+					map[string]interface{}{"PropertyName": key, "PropertyType": s})))
+			}
+		} else {
+			intVal(yamlMap, key, &errs)
+		}
+	case "no-hostname", "no-route", "random-route":
+		boolVal(yamlMap, key, &errs)
+	case "app-ports":
+		intSliceVal(yamlMap, key, &errs)
+	case "disk_quota", "memory":
+		_, err := formatters.ToMegabytes(coerceToString(value))
+		if err != nil {
+			file.report(path, T("Invalid value for '{{.PropertyName}}': {{.StringVal}}. {{.Error}}",
+				map[string]interface{}{
+					"PropertyName": key,
+					"StringVal":    coerceToString(value),
+					"Error":        err.Error(),
+				}))
+		}
+	case "docker":
+		dockerImageVal(yamlMap, &errs)
+	case "env":
+		validateEnv(file, path, value)
+	case "routes":
+		validateRoutes(file, path, value)
+	}
+
+	for _, err := range errs {
+		file.report(path, err.Error())
+	}
+}
+
+func validateEnv(file *manifestFile, path string, value interface{}) {
+	envVars, ok := value.(map[interface{}]interface{})
+	if !ok {
+		file.report(path, T("Expected {{.Name}} to be a set of key => value, but it was a {{.Type}}.",
+			map[string]interface{}{"Name": "env", "Type": value}))
+		return
+	}
+
+	for _, name := range sortedKeys(envVars) {
+		if envVars[name] == nil {
+			file.report(childPath(path, name), T("env var '{{.PropertyName}}' should not be null",
+				map[string]interface{}{"PropertyName": name}))
+		}
+	}
+}
+
+func validateRoutes(file *manifestFile, path string, value interface{}) {
+	routes, ok := value.([]interface{})
+	if !ok {
+		file.report(path, T("'routes' should be a list"))
+		return
+	}
+
+	for i, route := range routes {
+		routePath := itemPath(path, i)
+		routeMap, ok := route.(map[interface{}]interface{})
+		if !ok {
+			file.report(routePath, T("each route in 'routes' must have a 'route' property"))
+			continue
+		}
+
+		if _, ok := routeMap["route"]; !ok {
+			file.report(routePath, T("each route in 'routes' must have a 'route' property"))
+		} else if _, ok := routeMap["route"].(string); !ok && !containsVariable(routeMap["route"]) {
+			file.report(childPath(routePath, "route"), T("{{.PropertyName}} must be a string value", map[string]interface{}{"PropertyName": "route"}))
+		}
+
+		for _, key := range sortedKeys(routeMap) {
+			if key != "route" {
+				file.report(childPath(routePath, key), T("Unknown key '{{.Key}}'", map[string]interface{}{"Key": key}))
+			}
+		}
+	}
+}
+
+// checkConflicts reports the keys that cannot be used together in an
+// application once the manifests are merged, and dependencies on apps that
+// are not in the manifests, at the place each key is set.
+func checkConflicts(files []*manifestFile) {
+	maps := make([]generic.Map, 0, len(files))
+	for i := len(files) - 1; i >= 0; i-- {
+		if files[i].data == nil {
+			return
+		}
+		maps = append(maps, files[i].data)
+	}
+
+	apps, err := Manifest{}.getAppMaps(generic.DeepMerge(maps...))
+	if err != nil {
+		return
+	}
+
+	names := map[interface{}]bool{}
+	for _, app := range apps {
+		names[app.Get("name")] = true
+	}
+
+	for _, app := range apps {
+		dependencies, _ := app.Get("depends-on").([]interface{})
+		for _, dependency := range dependencies {
+			if !names[dependency] {
+				file, path := locateAppKey(files, app.Get("name"), "depends-on")
+				file.report(path, T("Unknown app '{{.AppName}}' in depends-on", map[string]interface{}{"AppName": dependency}))
+			}
+		}
+
+		for _, conflict := range keyConflicts {
+			if !app.Has(conflict.key) {
+				continue
+			}
+			for _, key := range conflict.conflicts {
+				if !app.Has(key) {
+					continue
+				}
+				file, path := locateAppKey(files, app.Get("name"), key)
+				if file != nil {
+					file.report(path, T("'{{.Key}}' cannot be used together with '{{.OtherKey}}'", map[string]interface{}{"Key": key, "OtherKey": conflict.key}))
+				}
+			}
+		}
+	}
+}
+
+// locateAppKey finds where key is set for the application with the given
+// name, looking in the application's own properties before the global ones
+// and in inheriting manifests before inherited ones.
+func locateAppKey(files []*manifestFile, appName interface{}, key string) (*manifestFile, string) {
+	for _, file := range files {
+		apps, _ := file.data.Get("applications").([]interface{})
+		for i, app := range apps {
+			appMap, ok := app.(map[interface{}]interface{})
+			if !ok || appMap["name"] != appName {
+				continue
+			}
+			if _, ok := appMap[key]; ok {
+				return file, childPath(itemPath("applications", i), key)
+			}
+		}
+	}
+
+	for _, file := range files {
+		if file.data.Has(key) {
+			return file, key
+		}
+	}
+	return nil, ""
+}
+
+func containsVariable(value interface{}) bool {
+	s, ok := value.(string)
+	return ok && variableRegex.MatchString(s)
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedKeys(m map[interface{}]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, coerceToString(key))
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,226 @@
+package manifest_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"code.cloudfoundry.org/cli/cf/manifest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Validate", func() {
+	var tmpDir string
+
+	BeforeEach(func() {
+		var err error
+		tmpDir, err = ioutil.TempDir("", "validate-manifest")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(tmpDir)
+	})
+
+	writeManifest := func(name string, contents string) string {
+		path := filepath.Join(tmpDir, name)
+		Expect(ioutil.WriteFile(path, []byte(contents), 0600)).To(Succeed())
+		return path
+	}
+
+	diagnosticStrings := func(diagnostics []manifest.Diagnostic) []string {
+		var lines []string
+		for _, diagnostic := range diagnostics {
+			lines = append(lines, diagnostic.String())
+		}
+		return lines
+	}
+
+	It("returns no diagnostics for a valid manifest", func() {
+		path := writeManifest("manifest.yml", `---
+applications:
+- name: my-app
+  memory: 512M
+  disk_quota: 1G
+  instances: 2
+  host: ((host))
+  services:
+  - db
+  env:
+    LEVEL: debug
+`)
+
+		diagnostics, err := manifest.Validate(path)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(diagnostics).To(BeEmpty())
+	})
+
+	It("finds manifest.yml in a directory", func() {
+		writeManifest("manifest.yml", "applications:\n- name: my-app\n  memroy: 1G\n")
+
+		diagnostics, err := manifest.Validate(tmpDir)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(diagnosticStrings(diagnostics)).To(Equal([]string{
+			filepath.Join(tmpDir, "manifest.yml") + ":3:3: Unknown key 'memroy'. Did you mean 'memory'?",
+		}))
+	})
+
+	It("returns an error when there is no manifest", func() {
+		_, err := manifest.Validate(tmpDir)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("Error finding manifest"))
+	})
+
+	It("reports unknown keys, bad values and invalid quotas at their positions", func() {
+		path := writeManifest("manifest.yml", `---
+timeout: soon
+applications:
+- name: my-app
+  memory: 512Q
+  instances: [1]
+  no-route: maybe-not
+  env:
+    LEVEL: ~
+- name: other-app
+  disk_quota: lots
+  hosts: other
+  servics:
+  - db
+`)
+
+		diagnostics, err := manifest.Validate(path)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(diagnosticStrings(diagnostics)).To(Equal([]string{
+			path + ":2:1: Expected timeout to be a number, but it was a soon.",
+			path + ":5:3: Invalid value for 'memory': 512Q. Byte quantity must be an integer with a unit of measurement like M, MB, G, or GB",
+			path + ":6:3: Expected instances to be a number, but it was a [1].",
+			path + ":9:5: env var 'LEVEL' should not be null",
+			path + ":11:3: Invalid value for 'disk_quota': lots. Byte quantity must be an integer with a unit of measurement like M, MB, G, or GB",
+			path + ":12:3: Expected hosts to be a list of strings.",
+			path + ":13:3: Unknown key 'servics'. Did you mean 'services'?",
+		}))
+	})
+
+	It("reports YAML syntax errors with their line", func() {
+		path := writeManifest("manifest.yml", "applications:\n- name: my-app\n  memory: 1G\n memory: 2G\n")
+
+		diagnostics, err := manifest.Validate(path)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(diagnostics).To(HaveLen(1))
+		Expect(diagnostics[0].File).To(Equal(path))
+		Expect(diagnostics[0].Line).To(Equal(4))
+	})
+
+	It("reports routes without a route property", func() {
+		path := writeManifest("manifest.yml", `applications:
+- name: my-app
+  routes:
+  - route: example.com
+  - rout: other.example.com
+`)
+
+		diagnostics, err := manifest.Validate(path)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(diagnosticStrings(diagnostics)).To(Equal([]string{
+			path + ":5:3: each route in 'routes' must have a 'route' property",
+			path + ":5:5: Unknown key 'rout'",
+		}))
+	})
+
+	It("reports hosts and domains given both as a single value and as a list", func() {
+		path := writeManifest("manifest.yml", `applications:
+- name: my-app
+  host: my-app
+  hosts: [my-app-1, my-app-2]
+  domain: example.com
+  domains: [example.org]
+`)
+
+		diagnostics, err := manifest.Validate(path)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(diagnosticStrings(diagnostics)).To(Equal([]string{
+			path + ":4:3: 'hosts' cannot be used together with 'host'",
+			path + ":6:3: 'domains' cannot be used together with 'domain'",
+		}))
+	})
+
+	It("reports a host list that conflicts with an inherited or global host", func() {
+		path := writeManifest("manifest.yml", `host: shared
+applications:
+- name: my-app
+  hosts: [my-app]
+`)
+
+		diagnostics, err := manifest.Validate(path)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(diagnosticStrings(diagnostics)).To(Equal([]string{
+			path + ":4:3: 'hosts' cannot be used together with 'host'",
+		}))
+	})
+
+	It("reports dependencies on apps that are not in the manifest", func() {
+		path := writeManifest("manifest.yml", `applications:
+- name: web
+  depends-on: [api, wrker]
+- name: api
+`)
+
+		diagnostics, err := manifest.Validate(path)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(diagnosticStrings(diagnostics)).To(Equal([]string{
+			path + ":3:3: Unknown app 'wrker' in depends-on",
+		}))
+	})
+
+	Context("when the manifest inherits from another", func() {
+		var parentPath, childPath string
+
+		BeforeEach(func() {
+			parentPath = writeManifest("base.yml", `---
+domain: example.com
+memory: 1X
+`)
+			childPath = writeManifest("manifest.yml", `---
+inherit: base.yml
+applications:
+- name: my-app
+  routes:
+  - route: my-app.example.com
+  host: my-app
+`)
+		})
+
+		It("reports the problems in every file, and conflicts where each key is set", func() {
+			diagnostics, err := manifest.Validate(childPath)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(diagnosticStrings(diagnostics)).To(Equal([]string{
+				childPath + ":7:3: 'host' cannot be used together with 'routes'",
+				parentPath + ":2:1: 'domain' cannot be used together with 'routes'",
+				parentPath + ":3:1: Invalid value for 'memory': 1X. Byte quantity must be an integer with a unit of measurement like M, MB, G, or GB",
+			}))
+		})
+
+		It("reports an inherited manifest that cannot be read at the inherit key", func() {
+			Expect(os.Remove(parentPath)).To(Succeed())
+
+			diagnostics, err := manifest.Validate(childPath)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(diagnostics).To(HaveLen(2))
+			Expect(diagnostics[0].Line).To(Equal(2))
+			Expect(diagnostics[0].Message).To(ContainSubstring("Error reading inherited manifest"))
+			Expect(diagnostics[1].String()).To(Equal(childPath + ":7:3: 'host' cannot be used together with 'routes'"))
+		})
+
+		It("reports manifests that inherit from each other", func() {
+			writeManifest("base.yml", "inherit: manifest.yml\n")
+
+			diagnostics, err := manifest.Validate(childPath)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(diagnosticStrings(diagnostics)).To(Equal([]string{
+				childPath + ":7:3: 'host' cannot be used together with 'routes'",
+				parentPath + ":1:1: Manifest " + childPath + " is already inherited",
+			}))
+		})
+	})
+})
@@ -0,0 +1,134 @@
+package manifest
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	. "code.cloudfoundry.org/cli/cf/i18n"
+
+	"code.cloudfoundry.org/cli/util/generic"
+	"gopkg.in/yaml.v2"
+)
+
+var variableRegex = regexp.MustCompile(`\(\(([\w-]+)\)\)`)
+
+// LoadVariables returns the values of the ((variables)) in a manifest. The
+// vars files are YAML maps of variable names to values and are read in order,
+// so later files override earlier ones. The vars are 'name=value' pairs,
+// which override the files.
+func LoadVariables(varsFiles []string, vars []string) (map[string]interface{}, error) {
+	variables := map[string]interface{}{}
+
+	for _, path := range varsFiles {
+		fileVariables, err := readVarsFile(path)
+		if err != nil {
+			return nil, err
+		}
+		for name, value := range fileVariables {
+			variables[name] = value
+		}
+	}
+
+	for _, pair := range vars {
+		parts := strings.SplitN(pair, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return nil, fmt.Errorf(T("Invalid variable '{{.Variable}}'. Variables must be given as 'name=value'.",
+				map[string]interface{}{"Variable": pair}))
+		}
+		variables[parts[0]] = parts[1]
+	}
+
+	return variables, nil
+}
+
+func readVarsFile(path string) (map[string]interface{}, error) {
+	contents, err := ioutil.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf(T("Error reading vars file {{.Path}}: {{.Error}}",
+			map[string]interface{}{"Path": path, "Error": err.Error()}))
+	}
+
+	var fileVariables map[interface{}]interface{}
+	err = yaml.Unmarshal(contents, &fileVariables)
+	if err != nil {
+		return nil, fmt.Errorf(T("Invalid vars file {{.Path}}. Expected a map of variable names to values.",
+			map[string]interface{}{"Path": path}))
+	}
+
+	variables := make(map[string]interface{}, len(fileVariables))
+	for name, value := range fileVariables {
+		variables[coerceToString(name)] = value
+	}
+	return variables, nil
+}
+
+// interpolateVariables replaces the ((name)) placeholders in the values of
+// input with the named variables. A value that is a single placeholder takes
+// on the variable's value as is, so that it can be a number, boolean or list.
+// Placeholders within a longer string are replaced with the variable's value
+// as a string. Every variable without a value is listed in the returned
+// error.
+func interpolateVariables(input interface{}, variables map[string]interface{}) (interface{}, error) {
+	missing := map[string]bool{}
+	output := interpolate(input, variables, missing)
+
+	if len(missing) > 0 {
+		names := make([]string, 0, len(missing))
+		for name := range missing {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return nil, errors.New(T("Expected to find variables: {{.VariableNames}}",
+			map[string]interface{}{"VariableNames": strings.Join(names, ", ")}))
+	}
+
+	return output, nil
+}
+
+func interpolate(input interface{}, variables map[string]interface{}, missing map[string]bool) interface{} {
+	switch input := input.(type) {
+	case string:
+		if match := variableRegex.FindStringSubmatch(input); match != nil && match[0] == input {
+			value, ok := variables[match[1]]
+			if !ok {
+				missing[match[1]] = true
+			}
+			return value
+		}
+
+		return variableRegex.ReplaceAllStringFunc(input, func(placeholder string) string {
+			name := variableRegex.FindStringSubmatch(placeholder)[1]
+			value, ok := variables[name]
+			if !ok {
+				missing[name] = true
+				return placeholder
+			}
+			return coerceToString(value)
+		})
+	case []interface{}:
+		outputSlice := make([]interface{}, len(input))
+		for index, item := range input {
+			outputSlice[index] = interpolate(item, variables, missing)
+		}
+		return outputSlice
+	case map[interface{}]interface{}:
+		outputMap := make(map[interface{}]interface{}, len(input))
+		for key, value := range input {
+			outputMap[key] = interpolate(value, variables, missing)
+		}
+		return outputMap
+	case generic.Map:
+		outputMap := generic.NewMap()
+		generic.Each(input, func(key, value interface{}) {
+			outputMap.Set(key, interpolate(value, variables, missing))
+		})
+		return outputMap
+	default:
+		return input
+	}
+}
@@ -0,0 +1,114 @@
+package manifest_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"code.cloudfoundry.org/cli/cf/manifest"
+	"code.cloudfoundry.org/cli/util/generic"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Variables", func() {
+	Describe("LoadVariables", func() {
+		var tmpDir string
+
+		BeforeEach(func() {
+			var err error
+			tmpDir, err = ioutil.TempDir("", "vars-files")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(tmpDir)
+		})
+
+		writeVarsFile := func(name string, contents string) string {
+			path := filepath.Join(tmpDir, name)
+			Expect(ioutil.WriteFile(path, []byte(contents), 0600)).To(Succeed())
+			return path
+		}
+
+		It("combines the vars files and vars, with later values taking precedence", func() {
+			first := writeVarsFile("first.yml", "instances: 2\nhost: first-host\nservices: [db, cache]\n")
+			second := writeVarsFile("second.yml", "host: second-host\nmemory: 1G\n")
+
+			variables, err := manifest.LoadVariables([]string{first, second}, []string{"memory=2G", "command=echo a=b"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(variables).To(Equal(map[string]interface{}{
+				"instances": 2,
+				"host":      "second-host",
+				"services":  []interface{}{"db", "cache"},
+				"memory":    "2G",
+				"command":   "echo a=b",
+			}))
+		})
+
+		It("returns an error when a var is not a name=value pair", func() {
+			_, err := manifest.LoadVariables(nil, []string{"memory"})
+			Expect(err).To(MatchError("Invalid variable 'memory'. Variables must be given as 'name=value'."))
+		})
+
+		It("returns an error when a vars file cannot be read", func() {
+			_, err := manifest.LoadVariables([]string{filepath.Join(tmpDir, "missing.yml")}, nil)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Error reading vars file"))
+		})
+
+		It("returns an error when a vars file is not a map", func() {
+			path := writeVarsFile("list.yml", "- a\n- b\n")
+			_, err := manifest.LoadVariables([]string{path}, nil)
+			Expect(err).To(MatchError("Invalid vars file " + path + ". Expected a map of variable names to values."))
+		})
+	})
+
+	Describe("interpolating a manifest", func() {
+		var m *manifest.Manifest
+
+		BeforeEach(func() {
+			m = NewManifest("/some/path/manifest.yml", generic.NewMap(map[interface{}]interface{}{
+				"applications": []interface{}{
+					map[interface{}]interface{}{
+						"name":      "((name))",
+						"host":      "((name))-((env))",
+						"instances": "((instances))",
+						"no-route":  "((no-route))",
+						"services":  "((services))",
+						"env": map[interface{}]interface{}{
+							"LEVEL": "((instances))",
+						},
+					},
+				},
+			}))
+		})
+
+		It("replaces the variables before the values are read", func() {
+			m.Variables = map[string]interface{}{
+				"name":      "my-app",
+				"env":       "staging",
+				"instances": 3,
+				"no-route":  true,
+				"services":  []interface{}{"db", "cache"},
+			}
+
+			apps, err := m.Applications()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(*apps[0].Name).To(Equal("my-app"))
+			Expect(apps[0].Hosts).To(Equal([]string{"my-app-staging"}))
+			Expect(*apps[0].InstanceCount).To(Equal(3))
+			Expect(apps[0].NoRoute).To(BeTrue())
+			Expect(apps[0].ServicesToBind).To(Equal([]string{"db", "cache"}))
+			Expect(*apps[0].EnvironmentVars).To(HaveKeyWithValue("LEVEL", 3))
+		})
+
+		It("lists every variable without a value", func() {
+			m.Variables = map[string]interface{}{"name": "my-app"}
+
+			_, err := m.Applications()
+			Expect(err).To(MatchError("Expected to find variables: env, instances, no-route, services"))
+		})
+	})
+})
@@ -47,30 +47,31 @@ func (model Application) ToParams() AppParams {
 }
 
 type ApplicationFields struct {
-	GUID                 string
-	Name                 string
-	BuildpackURL         string
-	Command              string
-	Diego                bool
-	DetectedStartCommand string
-	DiskQuota            int64 // in Megabytes
-	EnvironmentVars      map[string]interface{}
-	InstanceCount        int
-	Memory               int64 // in Megabytes
-	RunningInstances     int
-	HealthCheckType      string
-	HealthCheckTimeout   int
-	State                string
-	SpaceGUID            string
-	StackGUID            string
-	PackageUpdatedAt     *time.Time
-	PackageState         string
-	StagingFailedReason  string
-	Buildpack            string
-	DetectedBuildpack    string
-	DockerImage          string
-	EnableSSH            bool
-	AppPorts             []int
+	GUID                    string
+	Name                    string
+	BuildpackURL            string
+	Command                 string
+	Diego                   bool
+	DetectedStartCommand    string
+	DiskQuota               int64 // in Megabytes
+	EnvironmentVars         map[string]interface{}
+	InstanceCount           int
+	Memory                  int64 // in Megabytes
+	RunningInstances        int
+	HealthCheckType         string
+	HealthCheckTimeout      int
+	HealthCheckHTTPEndpoint string
+	State                   string
+	SpaceGUID               string
+	StackGUID               string
+	PackageUpdatedAt        *time.Time
+	PackageState            string
+	StagingFailedReason     string
+	Buildpack               string
+	DetectedBuildpack       string
+	DockerImage             string
+	EnableSSH               bool
+	AppPorts                []int
 }
 
 const (
@@ -84,35 +85,37 @@ const (
 )
 
 type AppParams struct {
-	BuildpackURL       *string
-	Command            *string
-	DiskQuota          *int64
-	Domains            []string
-	EnvironmentVars    *map[string]interface{}
-	GUID               *string
-	HealthCheckType    *string
-	HealthCheckTimeout *int
-	DockerImage        *string
-	Diego              *bool
-	EnableSSH          *bool
-	Hosts              []string
-	RoutePath          *string
-	InstanceCount      *int
-	Memory             *int64
-	Name               *string
-	NoHostname         *bool
-	NoRoute            bool
-	UseRandomRoute     bool
-	UseRandomPort      bool
-	Path               *string
-	ServicesToBind     []string
-	SpaceGUID          *string
-	StackGUID          *string
-	StackName          *string
-	State              *string
-	PackageUpdatedAt   *time.Time
-	AppPorts           *[]int
-	Routes             []ManifestRoute
+	BuildpackURL            *string
+	Command                 *string
+	DependsOn               []string
+	DiskQuota               *int64
+	Domains                 []string
+	EnvironmentVars         *map[string]interface{}
+	GUID                    *string
+	HealthCheckType         *string
+	HealthCheckTimeout      *int
+	HealthCheckHTTPEndpoint *string
+	DockerImage             *string
+	Diego                   *bool
+	EnableSSH               *bool
+	Hosts                   []string
+	RoutePath               *string
+	InstanceCount           *int
+	Memory                  *int64
+	Name                    *string
+	NoHostname              *bool
+	NoRoute                 bool
+	UseRandomRoute          bool
+	UseRandomPort           bool
+	Path                    *string
+	ServicesToBind          []string
+	SpaceGUID               *string
+	StackGUID               *string
+	StackName               *string
+	State                   *string
+	PackageUpdatedAt        *time.Time
+	AppPorts                *[]int
+	Routes                  []ManifestRoute
 }
 
 func (app *AppParams) Merge(other *AppParams) {
@@ -149,6 +152,9 @@ func (app *AppParams) Merge(other *AppParams) {
 	if other.HealthCheckTimeout != nil {
 		app.HealthCheckTimeout = other.HealthCheckTimeout
 	}
+	if other.HealthCheckHTTPEndpoint != nil {
+		app.HealthCheckHTTPEndpoint = other.HealthCheckHTTPEndpoint
+	}
 	if other.Hosts != nil {
 		app.Hosts = other.Hosts
 	}
@@ -14,6 +14,7 @@ import (
 	"runtime"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
@@ -21,6 +22,7 @@ import (
 	. "code.cloudfoundry.org/cli/cf/i18n"
 	"code.cloudfoundry.org/cli/cf/terminal"
 	"code.cloudfoundry.org/cli/cf/trace"
+	"code.cloudfoundry.org/cli/util/responsecache"
 	"code.cloudfoundry.org/cli/version"
 )
 
@@ -48,6 +50,14 @@ type AsyncResource struct {
 
 type apiErrorHandler func(statusCode int, body []byte) error
 
+// TokenRefreshMargin is how long before its expiry an access token is
+// refreshed, so that it does not expire while a request is in flight.
+const TokenRefreshMargin = time.Minute
+
+// tokenRefreshLock serializes access token refreshes across gateways, as
+// parallel pushes can find the token expiring at the same time.
+var tokenRefreshLock sync.Mutex
+
 type tokenRefresher interface {
 	RefreshAuthToken() (string, error)
 }
@@ -55,6 +65,27 @@ type tokenRefresher interface {
 type Request struct {
 	HTTPReq      *http.Request
 	SeekableBody io.ReadSeeker
+	newBody      func() (io.ReadCloser, error)
+}
+
+// resetBody gives the request a body to read from the start, so that it can
+// be sent again.
+func (request *Request) resetBody() error {
+	switch {
+	case request.newBody != nil:
+		body, err := request.newBody()
+		if err != nil {
+			return err
+		}
+		request.HTTPReq.Body = body
+	case request.SeekableBody != nil:
+		_, err := request.SeekableBody.Seek(0, 0)
+		if err != nil {
+			return err
+		}
+		request.HTTPReq.Body = ioutil.NopCloser(request.SeekableBody)
+	}
+	return nil
 }
 
 type Gateway struct {
@@ -70,6 +101,11 @@ type Gateway struct {
 	ui              terminal.UI
 	logger          trace.Printer
 	DialTimeout     time.Duration
+	UploadRateLimit int64
+
+	// ResponseCacheDir is the directory GET responses are cached in. When it
+	// is empty responses are not cached.
+	ResponseCacheDir string
 }
 
 func (gateway *Gateway) AsyncTimeout() time.Duration {
@@ -84,6 +120,11 @@ func (gateway *Gateway) SetTokenRefresher(auth tokenRefresher) {
 	gateway.authenticator = auth
 }
 
+// SetUI sets the UI that upload progress is reported to.
+func (gateway *Gateway) SetUI(ui terminal.UI) {
+	gateway.ui = ui
+}
+
 func (gateway Gateway) GetResource(url string, resource interface{}) (err error) {
 	request, err := gateway.NewRequest("GET", url, gateway.config.AccessToken(), nil)
 	if err != nil {
@@ -230,6 +271,34 @@ func (gateway Gateway) NewRequestForFile(method, fullURL, accessToken string, bo
 	return gateway.newRequest(request, accessToken, progressReader), nil
 }
 
+// NewRequestForStream returns a request whose body is read from the stream
+// returned by newBody. The stream is sent as it is produced, without knowing
+// its size in advance, and newBody is called again to send the request again.
+// The stream is read no faster than the gateway's UploadRateLimit, in bytes
+// per second, when that is set.
+func (gateway Gateway) NewRequestForStream(method, fullURL, accessToken string, newBody func() (io.ReadCloser, error)) (*Request, error) {
+	request, err := http.NewRequest(method, fullURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", T("Error building request"), err.Error())
+	}
+	request.ContentLength = -1
+
+	streamRequest := gateway.newRequest(request, accessToken, nil)
+	streamRequest.newBody = func() (io.ReadCloser, error) {
+		body, err := newBody()
+		if err != nil {
+			return nil, err
+		}
+
+		if gateway.UploadRateLimit > 0 {
+			body = NewRateLimitedReader(body, gateway.UploadRateLimit)
+		}
+		return NewStreamProgressReader(body, gateway.ui, 5*time.Second), nil
+	}
+
+	return streamRequest, nil
+}
+
 func (gateway Gateway) NewRequest(method, path, accessToken string, body io.ReadSeeker) (*Request, error) {
 	request, err := http.NewRequest(method, path, body)
 	if err != nil {
@@ -359,8 +428,14 @@ func (gateway Gateway) waitForJob(jobURL, accessToken string, timeout time.Durat
 func (gateway Gateway) doRequestHandlingAuth(request *Request) (*http.Response, error) {
 	httpReq := request.HTTPReq
 
-	if request.SeekableBody != nil {
-		httpReq.Body = ioutil.NopCloser(request.SeekableBody)
+	err := request.resetBody()
+	if err != nil {
+		return nil, err
+	}
+
+	err = gateway.refreshExpiringToken(httpReq)
+	if err != nil {
+		return nil, err
 	}
 
 	// perform request
@@ -371,18 +446,24 @@ func (gateway Gateway) doRequestHandlingAuth(request *Request) (*http.Response,
 
 	switch err.(type) {
 	case *errors.InvalidTokenError:
+		// the Basic auth used to get a token cannot be refreshed
+		token := httpReq.Header.Get("Authorization")
+		if isBasicAuth(token) {
+			return rawResponse, err
+		}
+
 		// refresh the auth token
 		var newToken string
-		newToken, err = gateway.authenticator.RefreshAuthToken()
+		newToken, err = gateway.refreshToken(gateway.config.AccessToken())
 		if err != nil {
 			return rawResponse, err
 		}
 
 		// reset the auth token and request body
 		httpReq.Header.Set("Authorization", newToken)
-		if request.SeekableBody != nil {
-			_, _ = request.SeekableBody.Seek(0, 0)
-			httpReq.Body = ioutil.NopCloser(request.SeekableBody)
+		err = request.resetBody()
+		if err != nil {
+			return rawResponse, err
 		}
 
 		// make the request again
@@ -392,8 +473,63 @@ func (gateway Gateway) doRequestHandlingAuth(request *Request) (*http.Response,
 	return rawResponse, err
 }
 
+// refreshExpiringToken refreshes the access token of the request if it
+// expires within the TokenRefreshMargin. Only the user's access token is
+// refreshed, not other credentials such as the Basic auth used for the UAA.
+// If the refresh fails while the token is still valid, the current token is
+// used.
+func (gateway Gateway) refreshExpiringToken(httpReq *http.Request) error {
+	token := httpReq.Header.Get("Authorization")
+	if gateway.authenticator == nil || token == "" || token != gateway.config.AccessToken() {
+		return nil
+	}
+
+	tokenInfo := coreconfig.NewTokenInfo(token)
+	if tokenInfo.Expiry == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	if gateway.Clock != nil {
+		now = gateway.Clock()
+	}
+	expiresAt := time.Unix(tokenInfo.Expiry, 0)
+	if now.Add(TokenRefreshMargin).Before(expiresAt) {
+		return nil
+	}
+
+	newToken, err := gateway.refreshToken(token)
+	if err != nil {
+		if now.Before(expiresAt) {
+			return nil
+		}
+		return err
+	}
+
+	httpReq.Header.Set("Authorization", newToken)
+	return nil
+}
+
+// refreshToken returns an access token to use instead of staleToken, the
+// token in the config before waiting for the lock. Only one refresh is made at
+// a time, and a token that was refreshed while waiting is reused rather than
+// refreshed again.
+func (gateway Gateway) refreshToken(staleToken string) (string, error) {
+	tokenRefreshLock.Lock()
+	defer tokenRefreshLock.Unlock()
+
+	if token := gateway.config.AccessToken(); token != staleToken {
+		return token, nil
+	}
+	return gateway.authenticator.RefreshAuthToken()
+}
+
+func isBasicAuth(token string) bool {
+	return strings.HasPrefix(strings.ToLower(token), "basic ")
+}
+
 func (gateway Gateway) doRequestAndHandlerError(request *Request) (*http.Response, error) {
-	rawResponse, err := gateway.doRequest(request.HTTPReq)
+	rawResponse, err := gateway.doRequest(request)
 	if err != nil {
 		return rawResponse, WrapNetworkErrors(request.HTTPReq.URL.Host, err)
 	}
@@ -408,19 +544,36 @@ func (gateway Gateway) doRequestAndHandlerError(request *Request) (*http.Respons
 	return rawResponse, err
 }
 
-func (gateway Gateway) doRequest(request *http.Request) (*http.Response, error) {
+func (gateway Gateway) doRequest(cfRequest *Request) (*http.Response, error) {
 	var response *http.Response
 	var err error
 
+	request := cfRequest.HTTPReq
+
 	if gateway.transport == nil {
 		makeHTTPTransport(&gateway)
 	}
 
-	httpClient := NewHTTPClient(gateway.transport, NewRequestDumper(gateway.logger))
+	var transport http.RoundTripper = gateway.transport
+	if gateway.ResponseCacheDir != "" {
+		transport = &responsecache.Transport{
+			Cache:     gateway.responseCache(),
+			Transport: transport,
+		}
+	}
+
+	httpClient := NewHTTPClient(transport, NewRequestDumper(gateway.logger))
 
 	httpClient.DumpRequest(request)
 
 	for i := 0; i < 3; i++ {
+		if i > 0 {
+			err = cfRequest.resetBody()
+			if err != nil {
+				return nil, err
+			}
+		}
+
 		response, err = httpClient.Do(request)
 		if response == nil && err != nil {
 			continue
@@ -445,6 +598,21 @@ func (gateway Gateway) doRequest(request *http.Request) (*http.Response, error)
 	return response, err
 }
 
+// responseCache returns the cache for the current target and user, who can
+// change while a command runs.
+func (gateway Gateway) responseCache() *responsecache.Cache {
+	tokenInfo := coreconfig.NewTokenInfo(gateway.config.AccessToken())
+	user := tokenInfo.Username
+	if user == "" {
+		user = tokenInfo.ClientID
+	}
+
+	return responsecache.New(
+		responsecache.Dir(gateway.ResponseCacheDir, gateway.config.APIEndpoint(), user),
+		responsecache.DefaultTTL,
+	)
+}
+
 func makeHTTPTransport(gateway *Gateway) {
 	gateway.transport = &http.Transport{
 		Dial: (&net.Dialer{
@@ -2,7 +2,9 @@ package net_test
 
 import (
 	"crypto/tls"
+	"encoding/base64"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
@@ -11,9 +13,11 @@ import (
 	"reflect"
 	"runtime"
 	"strings"
+	"sync"
 	"time"
 
 	"code.cloudfoundry.org/cli/cf/api/authentication"
+	"code.cloudfoundry.org/cli/cf/api/authentication/authenticationfakes"
 	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
 	"code.cloudfoundry.org/cli/cf/errors"
 	. "code.cloudfoundry.org/cli/cf/net"
@@ -62,13 +66,13 @@ var _ = Describe("Gateway", func() {
 	})
 
 	Describe("Connection errors", func() {
-		var oldNewHTTPClient func(tr *http.Transport, dumper RequestDumper) HTTPClientInterface
+		var oldNewHTTPClient func(tr http.RoundTripper, dumper RequestDumper) HTTPClientInterface
 
 		BeforeEach(func() {
 			client = new(netfakes.FakeHTTPClientInterface)
 
 			oldNewHTTPClient = NewHTTPClient
-			NewHTTPClient = func(tr *http.Transport, dumper RequestDumper) HTTPClientInterface {
+			NewHTTPClient = func(tr http.RoundTripper, dumper RequestDumper) HTTPClientInterface {
 				return client
 			}
 		})
@@ -425,6 +429,73 @@ var _ = Describe("Gateway", func() {
 		})
 	})
 
+	Describe("when uploading a stream", func() {
+		var (
+			request    *Request
+			apiErr     error
+			apiServer  *httptest.Server
+			authServer *httptest.Server
+			bodyCount  int
+		)
+
+		BeforeEach(func() {
+			apiServer = httptest.NewTLSServer(refreshTokenAPIEndPoint(
+				`{ "code": 1000, "description": "Auth token is invalid" }`,
+				testnet.TestResponse{Status: http.StatusOK},
+			))
+
+			authServer = httptest.NewTLSServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+				fmt.Fprintln(
+					writer,
+					`{ "access_token": "new-access-token", "token_type": "bearer", "refresh_token": "new-refresh-token"}`)
+			}))
+
+			config, auth := createAuthenticationRepository(apiServer, authServer)
+			ccGateway.SetTokenRefresher(auth)
+			ccGateway.SetTrustedCerts(apiServer.TLS.Certificates)
+			ccGateway.UploadRateLimit = 1024 * 1024
+
+			bodyCount = 0
+			request, apiErr = ccGateway.NewRequestForStream("POST", config.APIEndpoint()+"/v2/foo", config.AccessToken(), func() (io.ReadCloser, error) {
+				bodyCount++
+				return ioutil.NopCloser(strings.NewReader("expected body")), nil
+			})
+		})
+
+		AfterEach(func() {
+			apiServer.Close()
+			authServer.Close()
+		})
+
+		It("does not set a content length", func() {
+			Expect(apiErr).NotTo(HaveOccurred())
+			Expect(request.HTTPReq.ContentLength).To(Equal(int64(-1)))
+		})
+
+		Context("when the UI is changed", func() {
+			It("reports the upload progress to that UI", func() {
+				ui := new(terminalfakes.FakeUI)
+				ccGateway.SetUI(ui)
+				request, apiErr = ccGateway.NewRequestForStream("POST", apiServer.URL+"/v2/foo", "bearer initial-access-token", func() (io.ReadCloser, error) {
+					return ioutil.NopCloser(strings.NewReader("expected body")), nil
+				})
+				Expect(apiErr).NotTo(HaveOccurred())
+
+				_, apiErr = ccGateway.PerformRequest(request)
+				Expect(apiErr).NotTo(HaveOccurred())
+				Eventually(ui.PrintCapturingNoOutputCallCount).Should(BeNumerically(">", 0))
+			})
+		})
+
+		Describe("when the access token expires during the upload", func() {
+			It("sends a new copy of the stream on the second request", func() {
+				_, apiErr = ccGateway.PerformRequest(request)
+				Expect(apiErr).NotTo(HaveOccurred())
+				Expect(bodyCount).To(Equal(2))
+			})
+		})
+	})
+
 	Describe("refreshing the auth token", func() {
 		var authServer *httptest.Server
 
@@ -583,6 +654,141 @@ var _ = Describe("Gateway", func() {
 
 	})
 
+	Describe("caching responses", func() {
+		var cacheDir string
+
+		BeforeEach(func() {
+			ccServer = ghttp.NewServer()
+			config.SetAPIEndpoint(ccServer.URL())
+
+			var err error
+			cacheDir, err = ioutil.TempDir("", "cf-response-cache")
+			Expect(err).ToNot(HaveOccurred())
+			ccGateway.ResponseCacheDir = cacheDir
+
+			ccServer.AppendHandlers(
+				ghttp.RespondWith(http.StatusOK, `{"name":"some-app"}`, http.Header{"Etag": {`"some-etag"`}}),
+				ghttp.CombineHandlers(
+					ghttp.VerifyHeaderKV("If-None-Match", `"some-etag"`),
+					ghttp.RespondWith(http.StatusNotModified, nil),
+				),
+			)
+		})
+
+		AfterEach(func() {
+			ccServer.Close()
+			os.RemoveAll(cacheDir)
+		})
+
+		It("revalidates cached GET responses", func() {
+			var resource map[string]string
+			Expect(ccGateway.GetResource(ccServer.URL()+"/v2/apps/some-app-guid", &resource)).To(Succeed())
+
+			resource = nil
+			Expect(ccGateway.GetResource(ccServer.URL()+"/v2/apps/some-app-guid", &resource)).To(Succeed())
+			Expect(resource).To(HaveKeyWithValue("name", "some-app"))
+			Expect(ccServer.ReceivedRequests()).To(HaveLen(2))
+		})
+	})
+
+	Describe("refreshing expiring tokens", func() {
+		var fakeRefresher *authenticationfakes.FakeRepository
+
+		tokenExpiringAt := func(expiry time.Time) string {
+			claims := base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf(`{"user_name":"some-user","exp":%d}`, expiry.Unix())))
+			return "bearer eyJhbGciOiJSUzI1NiJ9." + claims + ".signature"
+		}
+
+		BeforeEach(func() {
+			ccServer = ghttp.NewServer()
+			config.SetAPIEndpoint(ccServer.URL())
+
+			fakeRefresher = new(authenticationfakes.FakeRepository)
+			fakeRefresher.RefreshAuthTokenReturns("bearer refreshed-token", nil)
+			ccGateway.SetTokenRefresher(fakeRefresher)
+		})
+
+		AfterEach(func() {
+			ccServer.Close()
+		})
+
+		Context("when the token expires within the refresh margin", func() {
+			BeforeEach(func() {
+				config.SetAccessToken(tokenExpiringAt(currentTime.Add(TokenRefreshMargin / 2)))
+				ccServer.AppendHandlers(ghttp.CombineHandlers(
+					ghttp.VerifyHeaderKV("Authorization", "bearer refreshed-token"),
+					ghttp.RespondWith(http.StatusOK, `{}`),
+				))
+			})
+
+			It("refreshes the token before making the request", func() {
+				var resource map[string]string
+				Expect(ccGateway.GetResource(ccServer.URL()+"/v2/apps", &resource)).To(Succeed())
+				Expect(fakeRefresher.RefreshAuthTokenCallCount()).To(Equal(1))
+			})
+
+			Context("when the refresh fails", func() {
+				BeforeEach(func() {
+					fakeRefresher.RefreshAuthTokenReturns("", errors.New("refresh-failed"))
+					ccServer.SetHandler(0, ghttp.RespondWith(http.StatusOK, `{}`))
+				})
+
+				It("uses the current token while it is still valid", func() {
+					var resource map[string]string
+					Expect(ccGateway.GetResource(ccServer.URL()+"/v2/apps", &resource)).To(Succeed())
+					Expect(ccServer.ReceivedRequests()[0].Header.Get("Authorization")).To(Equal(config.AccessToken()))
+				})
+			})
+		})
+
+		Context("when several requests find the token expiring at once", func() {
+			BeforeEach(func() {
+				config.SetAccessToken(tokenExpiringAt(currentTime.Add(TokenRefreshMargin / 2)))
+				fakeRefresher.RefreshAuthTokenStub = func() (string, error) {
+					time.Sleep(10 * time.Millisecond)
+					config.SetAccessToken("bearer refreshed-token")
+					return "bearer refreshed-token", nil
+				}
+				ccServer.RouteToHandler("GET", "/v2/apps", ghttp.CombineHandlers(
+					ghttp.VerifyHeaderKV("Authorization", "bearer refreshed-token"),
+					ghttp.RespondWith(http.StatusOK, `{}`),
+				))
+			})
+
+			It("refreshes the token once and reuses it for the other requests", func() {
+				var wg sync.WaitGroup
+				for i := 0; i < 5; i++ {
+					wg.Add(1)
+					go func() {
+						defer GinkgoRecover()
+						defer wg.Done()
+
+						var resource map[string]string
+						Expect(ccGateway.GetResource(ccServer.URL()+"/v2/apps", &resource)).To(Succeed())
+					}()
+				}
+				wg.Wait()
+
+				Expect(fakeRefresher.RefreshAuthTokenCallCount()).To(Equal(1))
+				Expect(ccServer.ReceivedRequests()).To(HaveLen(5))
+			})
+		})
+
+		Context("when the token does not expire soon", func() {
+			BeforeEach(func() {
+				config.SetAccessToken(tokenExpiringAt(currentTime.Add(time.Hour)))
+				ccServer.AppendHandlers(ghttp.RespondWith(http.StatusOK, `{}`))
+			})
+
+			It("does not refresh the token", func() {
+				var resource map[string]string
+				Expect(ccGateway.GetResource(ccServer.URL()+"/v2/apps", &resource)).To(Succeed())
+				Expect(fakeRefresher.RefreshAuthTokenCallCount()).To(Equal(0))
+				Expect(ccServer.ReceivedRequests()[0].Header.Get("Authorization")).To(Equal(config.AccessToken()))
+			})
+		})
+	})
+
 	Describe("collecting warnings", func() {
 		var (
 			apiServer  *httptest.Server
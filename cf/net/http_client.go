@@ -11,6 +11,7 @@ import (
 
 	"code.cloudfoundry.org/cli/cf/errors"
 	. "code.cloudfoundry.org/cli/cf/i18n"
+	"code.cloudfoundry.org/cli/util/cassette"
 	"golang.org/x/net/websocket"
 )
 
@@ -28,10 +29,10 @@ type client struct {
 	dumper RequestDumper
 }
 
-var NewHTTPClient = func(tr *http.Transport, dumper RequestDumper) HTTPClientInterface {
+var NewHTTPClient = func(tr http.RoundTripper, dumper RequestDumper) HTTPClientInterface {
 	c := client{
 		&http.Client{
-			Transport: tr,
+			Transport: cassette.WrapTransport(tr),
 		},
 		dumper,
 	}
@@ -10,11 +10,18 @@ import (
 	"code.cloudfoundry.org/cli/cf/terminal"
 )
 
+// unknownSize is the total size of a stream, whose size is only known once
+// it has been read to the end.
+const unknownSize = -1
+
 type ProgressReader struct {
 	ioReadSeeker   io.ReadSeeker
+	reader         io.Reader
+	closer         io.Closer
 	bytesRead      int64
 	total          int64
 	quit           chan bool
+	done           bool
 	ui             terminal.UI
 	outputInterval time.Duration
 	mutex          sync.RWMutex
@@ -23,6 +30,22 @@ type ProgressReader struct {
 func NewProgressReader(readSeeker io.ReadSeeker, ui terminal.UI, outputInterval time.Duration) *ProgressReader {
 	return &ProgressReader{
 		ioReadSeeker:   readSeeker,
+		reader:         readSeeker,
+		ui:             ui,
+		outputInterval: outputInterval,
+		mutex:          sync.RWMutex{},
+	}
+}
+
+// NewStreamProgressReader returns a ProgressReader for a body whose size is
+// not known until it has been read, such as one that is produced while it is
+// being sent. The progress is done when the body has been read to the end.
+// Closing the ProgressReader closes the body.
+func NewStreamProgressReader(body io.ReadCloser, ui terminal.UI, outputInterval time.Duration) *ProgressReader {
+	return &ProgressReader{
+		reader:         body,
+		closer:         body,
+		total:          unknownSize,
 		ui:             ui,
 		outputInterval: outputInterval,
 		mutex:          sync.RWMutex{},
@@ -30,27 +53,31 @@ func NewProgressReader(readSeeker io.ReadSeeker, ui terminal.UI, outputInterval
 }
 
 func (progressReader *ProgressReader) Read(p []byte) (int, error) {
-	if progressReader.ioReadSeeker == nil {
+	if progressReader.reader == nil {
 		return 0, os.ErrInvalid
 	}
 
-	n, err := progressReader.ioReadSeeker.Read(p)
+	n, err := progressReader.reader.Read(p)
 
-	if progressReader.total > int64(0) {
+	if progressReader.total != int64(0) {
+		progressReader.mutex.Lock()
 		if n > 0 {
 			if progressReader.quit == nil {
 				progressReader.quit = make(chan bool)
 				go progressReader.printProgress(progressReader.quit)
 			}
 
-			progressReader.mutex.Lock()
 			progressReader.bytesRead += int64(n)
-			progressReader.mutex.Unlock()
+		}
 
-			if progressReader.total == progressReader.bytesRead {
-				progressReader.quit <- true
-				return n, err
-			}
+		var quit chan bool
+		if progressReader.total == progressReader.bytesRead || (progressReader.total == unknownSize && err == io.EOF) {
+			quit = progressReader.stopProgress()
+		}
+		progressReader.mutex.Unlock()
+
+		if quit != nil {
+			quit <- true
 		}
 	}
 
@@ -58,19 +85,52 @@ func (progressReader *ProgressReader) Read(p []byte) (int, error) {
 }
 
 func (progressReader *ProgressReader) Seek(offset int64, whence int) (int64, error) {
+	if progressReader.ioReadSeeker == nil {
+		return 0, os.ErrInvalid
+	}
 	return progressReader.ioReadSeeker.Seek(offset, whence)
 }
 
+// Close stops printing progress and closes the body of a stream. A stream that
+// is closed before it was read to the end is not reported as uploaded.
+func (progressReader *ProgressReader) Close() error {
+	progressReader.mutex.Lock()
+	quit := progressReader.stopProgress()
+	progressReader.mutex.Unlock()
+
+	if quit != nil {
+		quit <- false
+	}
+
+	if progressReader.closer != nil {
+		return progressReader.closer.Close()
+	}
+	return nil
+}
+
+// stopProgress returns the channel to stop printing progress with, or nil if
+// it is not being printed. It must be called with the mutex held.
+func (progressReader *ProgressReader) stopProgress() chan bool {
+	if progressReader.quit == nil || progressReader.done {
+		return nil
+	}
+	progressReader.done = true
+	return progressReader.quit
+}
+
 func (progressReader *ProgressReader) printProgress(quit chan bool) {
 	timer := time.NewTicker(progressReader.outputInterval)
+	defer timer.Stop()
 
 	for {
 		select {
-		case <-quit:
+		case finished := <-quit:
 			//The spaces are there to ensure we overwrite the entire line
 			//before using the terminal printer to output Done Uploading
 			progressReader.ui.PrintCapturingNoOutput("\r                             ")
-			progressReader.ui.Say("\rDone uploading")
+			if finished {
+				progressReader.ui.Say("\rDone uploading")
+			}
 			return
 		case <-timer.C:
 			progressReader.mutex.RLock()
@@ -67,4 +67,43 @@ var _ = Describe("ProgressReader", func() {
 
 		Expect(int64(bytesRead)).To(Equal(fileStat.Size()))
 	})
+
+	Context("when the size of the content is not known", func() {
+		BeforeEach(func() {
+			progressReader = NewStreamProgressReader(testFile, ui, 1*time.Millisecond)
+		})
+
+		It("prints progress until the content has been read to the end", func() {
+			bytesRead := 0
+			for {
+				time.Sleep(50 * time.Microsecond)
+				n, err := progressReader.Read(b)
+				bytesRead += n
+				if err != nil {
+					break
+				}
+			}
+
+			Expect(int64(bytesRead)).To(Equal(fileStat.Size()))
+			Eventually(ui.SayCallCount).Should(Equal(1))
+			Expect(ui.SayArgsForCall(0)).To(ContainSubstring("\rDone "))
+		})
+
+		It("does not report the upload as done when it is closed early", func() {
+			_, err := progressReader.Read(b)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(progressReader.Close()).To(Succeed())
+			Eventually(ui.PrintCapturingNoOutputCallCount).Should(BeNumerically(">", 0))
+			Consistently(ui.SayCallCount).Should(BeZero())
+
+			_, err = testFile.Read(b)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("cannot seek", func() {
+			_, err := progressReader.Seek(0, 0)
+			Expect(err).To(Equal(os.ErrInvalid))
+		})
+	})
 })
@@ -0,0 +1,48 @@
+package net
+
+import (
+	"io"
+	"time"
+)
+
+type rateLimitedReader struct {
+	body           io.ReadCloser
+	bytesPerSecond int64
+	start          time.Time
+	bytesRead      int64
+}
+
+// NewRateLimitedReader returns a reader that reads body no faster than
+// bytesPerSecond on average.
+func NewRateLimitedReader(body io.ReadCloser, bytesPerSecond int64) io.ReadCloser {
+	return &rateLimitedReader{
+		body:           body,
+		bytesPerSecond: bytesPerSecond,
+	}
+}
+
+func (reader *rateLimitedReader) Read(p []byte) (int, error) {
+	if reader.start.IsZero() {
+		reader.start = time.Now()
+	}
+
+	// Reading at most a tenth of a second's worth at a time keeps the rate
+	// smooth rather than sending a burst and then waiting.
+	if maxRead := reader.bytesPerSecond/10 + 1; int64(len(p)) > maxRead {
+		p = p[:maxRead]
+	}
+
+	n, err := reader.body.Read(p)
+	reader.bytesRead += int64(n)
+
+	expected := time.Duration(float64(reader.bytesRead) / float64(reader.bytesPerSecond) * float64(time.Second))
+	if wait := expected - time.Since(reader.start); wait > 0 {
+		time.Sleep(wait)
+	}
+
+	return n, err
+}
+
+func (reader *rateLimitedReader) Close() error {
+	return reader.body.Close()
+}
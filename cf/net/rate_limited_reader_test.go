@@ -0,0 +1,31 @@
+package net_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"time"
+
+	. "code.cloudfoundry.org/cli/cf/net"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RateLimitedReader", func() {
+	It("reads the whole body", func() {
+		body := bytes.Repeat([]byte("a"), 1000)
+		reader := NewRateLimitedReader(ioutil.NopCloser(bytes.NewReader(body)), 1024*1024)
+
+		contents, err := ioutil.ReadAll(reader)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(contents).To(Equal(body))
+	})
+
+	It("reads no faster than the given rate", func() {
+		reader := NewRateLimitedReader(ioutil.NopCloser(bytes.NewReader(make([]byte, 2000))), 4000)
+
+		start := time.Now()
+		_, err := ioutil.ReadAll(reader)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(time.Since(start)).To(BeNumerically(">=", 450*time.Millisecond))
+	})
+})
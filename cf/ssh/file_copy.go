@@ -0,0 +1,238 @@
+package sshCmd
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/sftp"
+)
+
+// CopyOptions control how files are copied to and from an app instance.
+type CopyOptions struct {
+	// Recursive copies directories and everything in them.
+	Recursive bool
+	// Preserve gives copies the permissions and modification times of the
+	// files they were copied from.
+	Preserve bool
+	// Progress, if set, is called as each file is copied with the name of the
+	// file, the number of bytes copied so far and the size of the file.
+	Progress func(name string, copied int64, size int64)
+}
+
+// CopyToRemote copies the local file or directory at localPath to remotePath
+// on the instance. As with scp, it is copied into remotePath if remotePath is
+// an existing directory.
+func CopyToRemote(client *sftp.Client, localPath string, remotePath string, opts CopyOptions) error {
+	return copyPath(localFileSystem{}, localPath, remoteFileSystem{client: client}, remotePath, opts)
+}
+
+// CopyFromRemote copies the file or directory at remotePath on the instance
+// to localPath. As with scp, it is copied into localPath if localPath is an
+// existing directory.
+func CopyFromRemote(client *sftp.Client, remotePath string, localPath string, opts CopyOptions) error {
+	return copyPath(remoteFileSystem{client: client}, remotePath, localFileSystem{}, localPath, opts)
+}
+
+type fileSystem interface {
+	Stat(name string) (os.FileInfo, error)
+	ReadDir(name string) ([]os.FileInfo, error)
+	Open(name string) (io.ReadCloser, error)
+	Create(name string) (io.WriteCloser, error)
+	Mkdir(name string) error
+	Chmod(name string, mode os.FileMode) error
+	Chtimes(name string, atime time.Time, mtime time.Time) error
+	Join(elem ...string) string
+	Base(name string) string
+}
+
+func copyPath(src fileSystem, srcPath string, dst fileSystem, dstPath string, opts CopyOptions) error {
+	info, err := src.Stat(srcPath)
+	if err != nil {
+		return err
+	}
+
+	if info.IsDir() && !opts.Recursive {
+		return fmt.Errorf("%s is a directory; directories are only copied recursively", srcPath)
+	}
+	if !info.IsDir() && !info.Mode().IsRegular() {
+		return fmt.Errorf("%s is not a regular file", srcPath)
+	}
+
+	if dstInfo, err := dst.Stat(dstPath); err == nil && dstInfo.IsDir() {
+		dstPath = dst.Join(dstPath, src.Base(srcPath))
+	}
+
+	if info.IsDir() {
+		return copyDir(src, srcPath, info, dst, dstPath, opts)
+	}
+	return copyFile(src, srcPath, info, dst, dstPath, opts)
+}
+
+// copyDir copies the regular files and directories in a directory. Links to
+// regular files are copied as files; other links, devices and so on are left
+// out.
+func copyDir(src fileSystem, srcPath string, info os.FileInfo, dst fileSystem, dstPath string, opts CopyOptions) error {
+	err := dst.Mkdir(dstPath)
+	if err != nil {
+		existing, statErr := dst.Stat(dstPath)
+		if statErr != nil || !existing.IsDir() {
+			return err
+		}
+	}
+
+	entries, err := src.ReadDir(srcPath)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		entryPath := src.Join(srcPath, entry.Name())
+		entryDstPath := dst.Join(dstPath, entry.Name())
+
+		if entry.Mode()&os.ModeSymlink != 0 {
+			entry, err = src.Stat(entryPath)
+			if err != nil || !entry.Mode().IsRegular() {
+				continue
+			}
+		}
+
+		switch {
+		case entry.IsDir():
+			err = copyDir(src, entryPath, entry, dst, entryDstPath, opts)
+		case entry.Mode().IsRegular():
+			err = copyFile(src, entryPath, entry, dst, entryDstPath, opts)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	// the times are preserved last, as copying into the directory changes them
+	return preserveAttributes(dst, dstPath, info, opts)
+}
+
+func copyFile(src fileSystem, srcPath string, info os.FileInfo, dst fileSystem, dstPath string, opts CopyOptions) error {
+	srcFile, err := src.Open(srcPath)
+	if err != nil {
+		return err
+	}
+	defer srcFile.Close()
+
+	dstFile, err := dst.Create(dstPath)
+	if err != nil {
+		return err
+	}
+
+	var reader io.Reader = srcFile
+	var writer io.Writer = dstFile
+	if opts.Progress != nil {
+		progress := &copyProgress{name: src.Base(srcPath), size: info.Size(), report: opts.Progress}
+		progress.report(progress.name, 0, progress.size)
+
+		// count the bytes on the local side, so that an SFTP file can still
+		// read or write many packets at once
+		if _, ok := dstFile.(*sftp.File); ok {
+			reader = progressReader{reader: srcFile, progress: progress}
+		} else {
+			writer = progressWriter{writer: dstFile, progress: progress}
+		}
+	}
+
+	_, err = io.Copy(writer, reader)
+	closeErr := dstFile.Close()
+	if err != nil {
+		return err
+	}
+	if closeErr != nil {
+		return closeErr
+	}
+
+	return preserveAttributes(dst, dstPath, info, opts)
+}
+
+func preserveAttributes(dst fileSystem, dstPath string, info os.FileInfo, opts CopyOptions) error {
+	if !opts.Preserve {
+		return nil
+	}
+
+	err := dst.Chmod(dstPath, info.Mode().Perm())
+	if err != nil {
+		return err
+	}
+	return dst.Chtimes(dstPath, info.ModTime(), info.ModTime())
+}
+
+type copyProgress struct {
+	name   string
+	size   int64
+	copied int64
+	report func(name string, copied int64, size int64)
+}
+
+func (p *copyProgress) add(n int) {
+	if n > 0 {
+		p.copied += int64(n)
+		p.report(p.name, p.copied, p.size)
+	}
+}
+
+type progressReader struct {
+	reader   io.Reader
+	progress *copyProgress
+}
+
+func (r progressReader) Read(b []byte) (int, error) {
+	n, err := r.reader.Read(b)
+	r.progress.add(n)
+	return n, err
+}
+
+type progressWriter struct {
+	writer   io.Writer
+	progress *copyProgress
+}
+
+func (w progressWriter) Write(b []byte) (int, error) {
+	n, err := w.writer.Write(b)
+	w.progress.add(n)
+	return n, err
+}
+
+type localFileSystem struct{}
+
+func (localFileSystem) Stat(name string) (os.FileInfo, error)      { return os.Stat(name) }
+func (localFileSystem) ReadDir(name string) ([]os.FileInfo, error) { return ioutil.ReadDir(name) }
+func (localFileSystem) Open(name string) (io.ReadCloser, error)    { return os.Open(name) }
+func (localFileSystem) Create(name string) (io.WriteCloser, error) { return os.Create(name) }
+func (localFileSystem) Mkdir(name string) error                    { return os.Mkdir(name, 0755) }
+func (localFileSystem) Chmod(name string, mode os.FileMode) error  { return os.Chmod(name, mode) }
+func (localFileSystem) Chtimes(name string, atime time.Time, mtime time.Time) error {
+	return os.Chtimes(name, atime, mtime)
+}
+func (localFileSystem) Join(elem ...string) string { return filepath.Join(elem...) }
+func (localFileSystem) Base(name string) string    { return filepath.Base(name) }
+
+type remoteFileSystem struct {
+	client *sftp.Client
+}
+
+func (fs remoteFileSystem) Stat(name string) (os.FileInfo, error) { return fs.client.Stat(name) }
+func (fs remoteFileSystem) ReadDir(name string) ([]os.FileInfo, error) {
+	return fs.client.ReadDir(name)
+}
+func (fs remoteFileSystem) Open(name string) (io.ReadCloser, error)    { return fs.client.Open(name) }
+func (fs remoteFileSystem) Create(name string) (io.WriteCloser, error) { return fs.client.Create(name) }
+func (fs remoteFileSystem) Mkdir(name string) error                    { return fs.client.Mkdir(name) }
+func (fs remoteFileSystem) Chmod(name string, mode os.FileMode) error {
+	return fs.client.Chmod(name, mode)
+}
+func (fs remoteFileSystem) Chtimes(name string, atime time.Time, mtime time.Time) error {
+	return fs.client.Chtimes(name, atime, mtime)
+}
+func (fs remoteFileSystem) Join(elem ...string) string { return path.Join(elem...) }
+func (fs remoteFileSystem) Base(name string) string    { return path.Base(name) }
@@ -0,0 +1,170 @@
+package sshCmd_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"time"
+
+	"code.cloudfoundry.org/cli/cf/ssh"
+	testsftp "code.cloudfoundry.org/cli/util/testhelpers/sftp"
+	"github.com/pkg/sftp"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("File copy", func() {
+	var (
+		client      *sftp.Client
+		closeClient func()
+
+		localDir  string
+		remoteDir string
+		opts      sshCmd.CopyOptions
+	)
+
+	writeFile := func(name string, contents string, mode os.FileMode) {
+		Expect(os.MkdirAll(filepath.Dir(name), 0755)).To(Succeed())
+		Expect(ioutil.WriteFile(name, []byte(contents), mode)).To(Succeed())
+		Expect(os.Chmod(name, mode)).To(Succeed())
+	}
+
+	expectFile := func(name string, contents string) {
+		actual, err := ioutil.ReadFile(name)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(actual)).To(Equal(contents))
+	}
+
+	BeforeEach(func() {
+		client, closeClient = testsftp.NewClient()
+
+		var err error
+		localDir, err = ioutil.TempDir("", "file-copy-local")
+		Expect(err).NotTo(HaveOccurred())
+		remoteDir, err = ioutil.TempDir("", "file-copy-remote")
+		Expect(err).NotTo(HaveOccurred())
+
+		opts = sshCmd.CopyOptions{}
+	})
+
+	AfterEach(func() {
+		closeClient()
+		os.RemoveAll(localDir)
+		os.RemoveAll(remoteDir)
+	})
+
+	Describe("CopyToRemote", func() {
+		It("copies a file to the given path", func() {
+			writeFile(filepath.Join(localDir, "file"), "contents", 0644)
+
+			err := sshCmd.CopyToRemote(client, filepath.Join(localDir, "file"), filepath.Join(remoteDir, "copy"), opts)
+			Expect(err).NotTo(HaveOccurred())
+
+			expectFile(filepath.Join(remoteDir, "copy"), "contents")
+		})
+
+		It("copies a file into an existing directory", func() {
+			writeFile(filepath.Join(localDir, "file"), "contents", 0644)
+
+			err := sshCmd.CopyToRemote(client, filepath.Join(localDir, "file"), remoteDir, opts)
+			Expect(err).NotTo(HaveOccurred())
+
+			expectFile(filepath.Join(remoteDir, "file"), "contents")
+		})
+
+		It("does not copy a directory unless the copy is recursive", func() {
+			err := sshCmd.CopyToRemote(client, localDir, remoteDir, opts)
+			Expect(err).To(MatchError(ContainSubstring("is a directory")))
+		})
+
+		It("copies directories recursively", func() {
+			writeFile(filepath.Join(localDir, "app", "file1"), "contents-1", 0644)
+			writeFile(filepath.Join(localDir, "app", "dir", "file2"), "contents-2", 0644)
+			opts.Recursive = true
+
+			err := sshCmd.CopyToRemote(client, filepath.Join(localDir, "app"), remoteDir, opts)
+			Expect(err).NotTo(HaveOccurred())
+
+			expectFile(filepath.Join(remoteDir, "app", "file1"), "contents-1")
+			expectFile(filepath.Join(remoteDir, "app", "dir", "file2"), "contents-2")
+		})
+
+		It("preserves permissions and modification times when asked to", func() {
+			if runtime.GOOS == "windows" {
+				Skip("This should not run on Windows")
+			}
+
+			writeFile(filepath.Join(localDir, "script"), "#!/bin/sh", 0750)
+			modTime := time.Date(2016, 1, 2, 3, 4, 5, 0, time.UTC)
+			Expect(os.Chtimes(filepath.Join(localDir, "script"), modTime, modTime)).To(Succeed())
+			opts.Preserve = true
+
+			err := sshCmd.CopyToRemote(client, filepath.Join(localDir, "script"), remoteDir, opts)
+			Expect(err).NotTo(HaveOccurred())
+
+			info, err := os.Stat(filepath.Join(remoteDir, "script"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(info.Mode().Perm()).To(Equal(os.FileMode(0750)))
+			Expect(info.ModTime().Equal(modTime)).To(BeTrue())
+		})
+
+		It("reports the progress of each file", func() {
+			writeFile(filepath.Join(localDir, "file"), "contents", 0644)
+
+			var reports [][]int64
+			opts.Progress = func(name string, copied int64, size int64) {
+				Expect(name).To(Equal("file"))
+				reports = append(reports, []int64{copied, size})
+			}
+
+			err := sshCmd.CopyToRemote(client, filepath.Join(localDir, "file"), remoteDir, opts)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(reports[0]).To(Equal([]int64{0, 8}))
+			Expect(reports[len(reports)-1]).To(Equal([]int64{8, 8}))
+		})
+	})
+
+	Describe("CopyFromRemote", func() {
+		It("copies a file to the given path", func() {
+			writeFile(filepath.Join(remoteDir, "file"), "contents", 0644)
+
+			err := sshCmd.CopyFromRemote(client, filepath.Join(remoteDir, "file"), filepath.Join(localDir, "copy"), opts)
+			Expect(err).NotTo(HaveOccurred())
+
+			expectFile(filepath.Join(localDir, "copy"), "contents")
+		})
+
+		It("copies directories recursively into an existing directory", func() {
+			writeFile(filepath.Join(remoteDir, "logs", "app.log"), "log", 0644)
+			writeFile(filepath.Join(remoteDir, "logs", "old", "app.log.1"), "old log", 0644)
+			opts.Recursive = true
+
+			err := sshCmd.CopyFromRemote(client, filepath.Join(remoteDir, "logs"), localDir, opts)
+			Expect(err).NotTo(HaveOccurred())
+
+			expectFile(filepath.Join(localDir, "logs", "app.log"), "log")
+			expectFile(filepath.Join(localDir, "logs", "old", "app.log.1"), "old log")
+		})
+
+		It("reports the progress of each file", func() {
+			writeFile(filepath.Join(remoteDir, "file"), "contents", 0644)
+
+			var lastCopied int64
+			opts.Progress = func(name string, copied int64, size int64) {
+				lastCopied = copied
+			}
+
+			err := sshCmd.CopyFromRemote(client, filepath.Join(remoteDir, "file"), localDir, opts)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(lastCopied).To(Equal(int64(8)))
+		})
+
+		It("returns an error when the file does not exist", func() {
+			err := sshCmd.CopyFromRemote(client, filepath.Join(remoteDir, "missing"), localDir, opts)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
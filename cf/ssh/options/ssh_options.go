@@ -29,6 +29,14 @@ type SSHOptions struct {
 	SkipRemoteExecution bool
 	TerminalRequest     TTYRequest
 	ForwardSpecs        []ForwardSpec
+
+	// RemoteForwardSpecs listen on the app instance and connect from the
+	// local machine.
+	RemoteForwardSpecs []ForwardSpec
+
+	// DynamicForwardAddresses are the local addresses of SOCKS proxies that
+	// connect from the app instance.
+	DynamicForwardAddresses []string
 }
 
 func NewSSHOptions(fc flags.FlagContext) (*SSHOptions, error) {
@@ -50,6 +58,26 @@ func NewSSHOptions(fc flags.FlagContext) (*SSHOptions, error) {
 		}
 	}
 
+	if fc.IsSet("R") {
+		for _, arg := range fc.StringSlice("R") {
+			forwardSpec, err := sshOptions.parseRemoteForwardingSpec(arg)
+			if err != nil {
+				return sshOptions, err
+			}
+			sshOptions.RemoteForwardSpecs = append(sshOptions.RemoteForwardSpecs, *forwardSpec)
+		}
+	}
+
+	if fc.IsSet("D") {
+		for _, arg := range fc.StringSlice("D") {
+			address, err := sshOptions.parseDynamicForwardingSpec(arg)
+			if err != nil {
+				return sshOptions, err
+			}
+			sshOptions.DynamicForwardAddresses = append(sshOptions.DynamicForwardAddresses, address)
+		}
+	}
+
 	if fc.IsSet("t") && fc.Bool("t") {
 		sshOptions.TerminalRequest = RequestTTYYes
 	}
@@ -68,15 +96,9 @@ func NewSSHOptions(fc flags.FlagContext) (*SSHOptions, error) {
 func (o *SSHOptions) parseLocalForwardingSpec(arg string) (*ForwardSpec, error) {
 	arg = strings.TrimSpace(arg)
 
-	parts := []string{}
-	for remainder := arg; remainder != ""; {
-		part, r, err := tokenizeForward(remainder)
-		if err != nil {
-			return nil, err
-		}
-
-		parts = append(parts, part)
-		remainder = r
+	parts, err := splitForward(arg)
+	if err != nil {
+		return nil, err
 	}
 
 	forwardSpec := &ForwardSpec{}
@@ -97,6 +119,72 @@ func (o *SSHOptions) parseLocalForwardingSpec(arg string) (*ForwardSpec, error)
 	return forwardSpec, nil
 }
 
+// parseRemoteForwardingSpec parses [bind_address:]port:host:hostport, where
+// bind_address and port are on the app instance. The SSH server is asked to
+// listen on a specific address, so * is all IPv4 addresses.
+func (o *SSHOptions) parseRemoteForwardingSpec(arg string) (*ForwardSpec, error) {
+	arg = strings.TrimSpace(arg)
+
+	parts, err := splitForward(arg)
+	if err != nil {
+		return nil, err
+	}
+
+	forwardSpec := &ForwardSpec{}
+	switch len(parts) {
+	case 4:
+		if parts[0] == "*" || parts[0] == "" {
+			parts[0] = "0.0.0.0"
+		}
+		forwardSpec.ListenAddress = fmt.Sprintf("%s:%s", parts[0], parts[1])
+		forwardSpec.ConnectAddress = fmt.Sprintf("%s:%s", parts[2], parts[3])
+	case 3:
+		forwardSpec.ListenAddress = fmt.Sprintf("localhost:%s", parts[0])
+		forwardSpec.ConnectAddress = fmt.Sprintf("%s:%s", parts[1], parts[2])
+	default:
+		return nil, fmt.Errorf("Unable to parse remote forwarding argument: %q", arg)
+	}
+
+	return forwardSpec, nil
+}
+
+// parseDynamicForwardingSpec parses [bind_address:]port and returns the local
+// address to listen on.
+func (o *SSHOptions) parseDynamicForwardingSpec(arg string) (string, error) {
+	arg = strings.TrimSpace(arg)
+
+	parts, err := splitForward(arg)
+	if err != nil {
+		return "", err
+	}
+
+	switch len(parts) {
+	case 2:
+		if parts[0] == "*" {
+			parts[0] = ""
+		}
+		return fmt.Sprintf("%s:%s", parts[0], parts[1]), nil
+	case 1:
+		return fmt.Sprintf("localhost:%s", parts[0]), nil
+	default:
+		return "", fmt.Errorf("Unable to parse dynamic forwarding argument: %q", arg)
+	}
+}
+
+func splitForward(arg string) ([]string, error) {
+	parts := []string{}
+	for remainder := arg; remainder != ""; {
+		part, r, err := tokenizeForward(remainder)
+		if err != nil {
+			return nil, err
+		}
+
+		parts = append(parts, part)
+		remainder = r
+	}
+	return parts, nil
+}
+
 func tokenizeForward(arg string) (string, string, error) {
 	switch arg[0] {
 	case ':':
@@ -20,6 +20,8 @@ var _ = Describe("SSHOptions", func() {
 		BeforeEach(func() {
 			fc = flags.New()
 			fc.NewStringSliceFlag("L", "", "")
+			fc.NewStringSliceFlag("R", "", "")
+			fc.NewStringSliceFlag("D", "", "")
 			fc.NewStringSliceFlag("command", "c", "")
 			fc.NewIntFlag("app-instance-index", "i", "")
 			fc.NewBoolFlag("skip-host-validation", "k", "")
@@ -281,6 +283,119 @@ var _ = Describe("SSHOptions", func() {
 			})
 		})
 
+		Context("when remote port forwarding is requested", func() {
+			BeforeEach(func() {
+				args = append(args, "app-name")
+			})
+
+			Context("without an explicit bind address", func() {
+				BeforeEach(func() {
+					args = append(args, "-R", "9999:localhost:8888")
+				})
+
+				It("listens on the loopback address of the instance", func() {
+					Expect(parseError).NotTo(HaveOccurred())
+					Expect(opts.RemoteForwardSpecs).To(ConsistOf(options.ForwardSpec{ListenAddress: "localhost:9999", ConnectAddress: "localhost:8888"}))
+				})
+			})
+
+			Context("with an explicit bind address", func() {
+				BeforeEach(func() {
+					args = append(args, "-R", "10.0.0.1:9999:localhost:8888")
+				})
+
+				It("sets the forward spec", func() {
+					Expect(parseError).NotTo(HaveOccurred())
+					Expect(opts.RemoteForwardSpecs).To(ConsistOf(options.ForwardSpec{ListenAddress: "10.0.0.1:9999", ConnectAddress: "localhost:8888"}))
+				})
+			})
+
+			Context("with * as the bind address", func() {
+				BeforeEach(func() {
+					args = append(args, "-R", "*:9999:localhost:8888")
+				})
+
+				It("listens on all addresses of the instance", func() {
+					Expect(parseError).NotTo(HaveOccurred())
+					Expect(opts.RemoteForwardSpecs).To(ConsistOf(options.ForwardSpec{ListenAddress: "0.0.0.0:9999", ConnectAddress: "localhost:8888"}))
+				})
+			})
+
+			Context("with too few parts", func() {
+				BeforeEach(func() {
+					args = append(args, "-R", "9999:localhost")
+				})
+
+				It("returns an error", func() {
+					Expect(parseError).To(MatchError(`Unable to parse remote forwarding argument: "9999:localhost"`))
+				})
+			})
+
+			Context("when multiple remote port forward options are specified", func() {
+				BeforeEach(func() {
+					args = append(args, "-R", "9999:localhost:8888")
+					args = append(args, "-R", "5005:[::1]:5005")
+				})
+
+				It("sets the forward specs", func() {
+					Expect(parseError).NotTo(HaveOccurred())
+					Expect(opts.RemoteForwardSpecs).To(ConsistOf(
+						options.ForwardSpec{ListenAddress: "localhost:9999", ConnectAddress: "localhost:8888"},
+						options.ForwardSpec{ListenAddress: "localhost:5005", ConnectAddress: "[::1]:5005"},
+					))
+				})
+			})
+		})
+
+		Context("when dynamic port forwarding is requested", func() {
+			BeforeEach(func() {
+				args = append(args, "app-name")
+			})
+
+			Context("without an explicit bind address", func() {
+				BeforeEach(func() {
+					args = append(args, "-D", "1080")
+				})
+
+				It("listens on the local loopback address", func() {
+					Expect(parseError).NotTo(HaveOccurred())
+					Expect(opts.DynamicForwardAddresses).To(ConsistOf("localhost:1080"))
+				})
+			})
+
+			Context("with an explicit ipv6 bind address", func() {
+				BeforeEach(func() {
+					args = append(args, "-D", "[::1]:1080")
+				})
+
+				It("sets the listen address", func() {
+					Expect(parseError).NotTo(HaveOccurred())
+					Expect(opts.DynamicForwardAddresses).To(ConsistOf("[::1]:1080"))
+				})
+			})
+
+			Context("with * as the bind address", func() {
+				BeforeEach(func() {
+					args = append(args, "-D", "*:1080")
+				})
+
+				It("listens on all local addresses", func() {
+					Expect(parseError).NotTo(HaveOccurred())
+					Expect(opts.DynamicForwardAddresses).To(ConsistOf(":1080"))
+				})
+			})
+
+			Context("with a connect address", func() {
+				BeforeEach(func() {
+					args = append(args, "-D", "1080:remote:80")
+				})
+
+				It("returns an error", func() {
+					Expect(parseError).To(MatchError(`Unable to parse dynamic forwarding argument: "1080:remote:80"`))
+				})
+			})
+		})
+
 		Context("when -N is specified", func() {
 			BeforeEach(func() {
 				args = append(args, "app-name", "-N")
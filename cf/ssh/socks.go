@@ -0,0 +1,120 @@
+package sshCmd
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+)
+
+// The parts of SOCKS5 (RFC 1928) that a dynamic port forward needs: clients
+// that do not authenticate, asking to CONNECT to an address.
+const (
+	socksVersion = 5
+
+	socksNoAuthentication   = 0
+	socksNoAcceptableMethod = 0xff
+
+	socksConnect = 1
+
+	socksIPv4Address   = 1
+	socksDomainAddress = 3
+	socksIPv6Address   = 4
+
+	socksSucceeded               = 0
+	socksHostUnreachable         = 4
+	socksCommandNotSupported     = 7
+	socksAddressTypeNotSupported = 8
+)
+
+// readSOCKSRequest negotiates with a SOCKS5 client and returns the address
+// it wants to connect to. A successful request must be answered with
+// writeSOCKSReply.
+func readSOCKSRequest(conn io.ReadWriter) (string, error) {
+	header := make([]byte, 2)
+	_, err := io.ReadFull(conn, header)
+	if err != nil {
+		return "", err
+	}
+	if header[0] != socksVersion {
+		return "", fmt.Errorf("unsupported SOCKS version %d", header[0])
+	}
+
+	methods := make([]byte, header[1])
+	_, err = io.ReadFull(conn, methods)
+	if err != nil {
+		return "", err
+	}
+
+	method := byte(socksNoAcceptableMethod)
+	for _, m := range methods {
+		if m == socksNoAuthentication {
+			method = socksNoAuthentication
+		}
+	}
+	_, err = conn.Write([]byte{socksVersion, method})
+	if err != nil {
+		return "", err
+	}
+	if method == socksNoAcceptableMethod {
+		return "", fmt.Errorf("SOCKS client requires authentication")
+	}
+
+	request := make([]byte, 4)
+	_, err = io.ReadFull(conn, request)
+	if err != nil {
+		return "", err
+	}
+	if request[0] != socksVersion {
+		return "", fmt.Errorf("unsupported SOCKS version %d", request[0])
+	}
+	if request[1] != socksConnect {
+		_ = writeSOCKSReply(conn, socksCommandNotSupported)
+		return "", fmt.Errorf("unsupported SOCKS command %d", request[1])
+	}
+
+	var host string
+	switch request[3] {
+	case socksIPv4Address, socksIPv6Address:
+		ip := make(net.IP, net.IPv4len)
+		if request[3] == socksIPv6Address {
+			ip = make(net.IP, net.IPv6len)
+		}
+		_, err = io.ReadFull(conn, ip)
+		if err != nil {
+			return "", err
+		}
+		host = ip.String()
+	case socksDomainAddress:
+		length := make([]byte, 1)
+		_, err = io.ReadFull(conn, length)
+		if err != nil {
+			return "", err
+		}
+		domain := make([]byte, length[0])
+		_, err = io.ReadFull(conn, domain)
+		if err != nil {
+			return "", err
+		}
+		host = string(domain)
+	default:
+		_ = writeSOCKSReply(conn, socksAddressTypeNotSupported)
+		return "", fmt.Errorf("unsupported SOCKS address type %d", request[3])
+	}
+
+	port := make([]byte, 2)
+	_, err = io.ReadFull(conn, port)
+	if err != nil {
+		return "", err
+	}
+
+	return net.JoinHostPort(host, strconv.Itoa(int(binary.BigEndian.Uint16(port)))), nil
+}
+
+// writeSOCKSReply answers a SOCKS5 request. The bound address is left empty,
+// as it is the address of the connection from the app instance.
+func writeSOCKSReply(conn io.Writer, status byte) error {
+	_, err := conn.Write([]byte{socksVersion, status, 0, socksIPv4Address, 0, 0, 0, 0, 0, 0})
+	return err
+}
@@ -22,6 +22,7 @@ import (
 	"code.cloudfoundry.org/cli/cf/ssh/sigwinch"
 	"code.cloudfoundry.org/cli/cf/ssh/terminal"
 	"github.com/docker/docker/pkg/term"
+	"github.com/pkg/sftp"
 )
 
 const (
@@ -35,6 +36,9 @@ type SecureShell interface {
 	Connect(opts *options.SSHOptions) error
 	InteractiveSession() error
 	LocalPortForward() error
+	RemotePortForward() error
+	DynamicPortForward() error
+	SFTPClient() (*sftp.Client, error)
 	Wait() error
 	Close() error
 }
@@ -51,6 +55,7 @@ type SecureClient interface {
 	NewSession() (SecureSession, error)
 	Conn() ssh.Conn
 	Dial(network, address string) (net.Conn, error)
+	Listen(network, address string) (net.Listener, error)
 	Wait() error
 	Close() error
 }
@@ -65,6 +70,7 @@ type ListenerFactory interface {
 
 type SecureSession interface {
 	RequestPty(term string, height, width int, termModes ssh.TerminalModes) error
+	RequestSubsystem(subsystem string) error
 	SendRequest(name string, wantReply bool, payload []byte) (bool, error)
 	StdinPipe() (io.WriteCloser, error)
 	StdoutPipe() (io.Reader, error)
@@ -87,7 +93,10 @@ type secureShell struct {
 	secureClient           SecureClient
 	opts                   *options.SSHOptions
 
-	localListeners []net.Listener
+	localListeners  []net.Listener
+	remoteListeners []net.Listener
+	sftpSessions    []SecureSession
+	sftpKeepalive   chan struct{}
 }
 
 func NewSecureShell(
@@ -101,11 +110,11 @@ func NewSecureShell(
 	token string,
 ) SecureShell {
 	return &secureShell{
-		secureDialer:      secureDialer,
-		terminalHelper:    terminalHelper,
-		listenerFactory:   listenerFactory,
-		keepAliveInterval: keepAliveInterval,
-		app:               app,
+		secureDialer:           secureDialer,
+		terminalHelper:         terminalHelper,
+		listenerFactory:        listenerFactory,
+		keepAliveInterval:      keepAliveInterval,
+		app:                    app,
 		sshEndpointFingerprint: sshEndpointFingerprint,
 		sshEndpoint:            sshEndpoint,
 		token:                  token,
@@ -141,6 +150,16 @@ func (c *secureShell) Close() error {
 	for _, listener := range c.localListeners {
 		_ = listener.Close()
 	}
+	for _, listener := range c.remoteListeners {
+		_ = listener.Close()
+	}
+	for _, session := range c.sftpSessions {
+		_ = session.Close()
+	}
+	if c.sftpKeepalive != nil {
+		close(c.sftpKeepalive)
+		c.sftpKeepalive = nil
+	}
 	return c.secureClient.Close()
 }
 
@@ -152,13 +171,51 @@ func (c *secureShell) LocalPortForward() error {
 		}
 		c.localListeners = append(c.localListeners, listener)
 
-		go c.localForwardAcceptLoop(listener, forwardSpec.ConnectAddress)
+		connectAddress := forwardSpec.ConnectAddress
+		go acceptLoop(listener, func(conn net.Conn) {
+			c.handleForwardConnection(conn, connectAddress)
+		})
+	}
+
+	return nil
+}
+
+// RemotePortForward asks the SSH server to listen on the app instance and
+// connects each connection made there to an address on the local machine.
+func (c *secureShell) RemotePortForward() error {
+	for _, forwardSpec := range c.opts.RemoteForwardSpecs {
+		listener, err := c.secureClient.Listen("tcp", forwardSpec.ListenAddress)
+		if err != nil {
+			return err
+		}
+		c.remoteListeners = append(c.remoteListeners, listener)
+
+		connectAddress := forwardSpec.ConnectAddress
+		go acceptLoop(listener, func(conn net.Conn) {
+			c.handleRemoteForwardConnection(conn, connectAddress)
+		})
+	}
+
+	return nil
+}
+
+// DynamicPortForward runs a SOCKS5 proxy on each local address, which
+// connects to the requested addresses from the app instance.
+func (c *secureShell) DynamicPortForward() error {
+	for _, address := range c.opts.DynamicForwardAddresses {
+		listener, err := c.listenerFactory.Listen("tcp", address)
+		if err != nil {
+			return err
+		}
+		c.localListeners = append(c.localListeners, listener)
+
+		go acceptLoop(listener, c.handleSOCKSConnection)
 	}
 
 	return nil
 }
 
-func (c *secureShell) localForwardAcceptLoop(listener net.Listener, addr string) {
+func acceptLoop(listener net.Listener, handleConnection func(net.Conn)) {
 	defer listener.Close()
 
 	for {
@@ -171,7 +228,7 @@ func (c *secureShell) localForwardAcceptLoop(listener net.Listener, addr string)
 			return
 		}
 
-		go c.handleForwardConnection(conn, addr)
+		go handleConnection(conn)
 	}
 }
 
@@ -185,6 +242,48 @@ func (c *secureShell) handleForwardConnection(conn net.Conn, targetAddr string)
 	}
 	defer target.Close()
 
+	pipeConnections(conn, target)
+}
+
+func (c *secureShell) handleRemoteForwardConnection(conn net.Conn, targetAddr string) {
+	defer conn.Close()
+
+	target, err := net.Dial("tcp", targetAddr)
+	if err != nil {
+		fmt.Printf("connect to %s failed: %s\n", targetAddr, err.Error())
+		return
+	}
+	defer target.Close()
+
+	pipeConnections(conn, target)
+}
+
+func (c *secureShell) handleSOCKSConnection(conn net.Conn) {
+	defer conn.Close()
+
+	targetAddr, err := readSOCKSRequest(conn)
+	if err != nil {
+		fmt.Printf("SOCKS request failed: %s\n", err.Error())
+		return
+	}
+
+	target, err := c.secureClient.Dial("tcp", targetAddr)
+	if err != nil {
+		_ = writeSOCKSReply(conn, socksHostUnreachable)
+		fmt.Printf("connect to %s failed: %s\n", targetAddr, err.Error())
+		return
+	}
+	defer target.Close()
+
+	err = writeSOCKSReply(conn, socksSucceeded)
+	if err != nil {
+		return
+	}
+
+	pipeConnections(conn, target)
+}
+
+func pipeConnections(conn net.Conn, target net.Conn) {
 	wg := &sync.WaitGroup{}
 	wg.Add(2)
 
@@ -310,6 +409,47 @@ func (c *secureShell) InteractiveSession() error {
 	return result
 }
 
+// SFTPClient opens the SFTP subsystem on the connected instance. The client
+// stays usable until the shell is closed.
+func (c *secureShell) SFTPClient() (*sftp.Client, error) {
+	session, err := c.secureClient.NewSession()
+	if err != nil {
+		return nil, fmt.Errorf("SSH session allocation failed: %s", err.Error())
+	}
+
+	inPipe, err := session.StdinPipe()
+	if err != nil {
+		_ = session.Close()
+		return nil, err
+	}
+
+	outPipe, err := session.StdoutPipe()
+	if err != nil {
+		_ = session.Close()
+		return nil, err
+	}
+
+	err = session.RequestSubsystem("sftp")
+	if err != nil {
+		_ = session.Close()
+		return nil, fmt.Errorf("SFTP subsystem request failed: %s", err.Error())
+	}
+
+	client, err := sftp.NewClientPipe(outPipe, inPipe)
+	if err != nil {
+		_ = session.Close()
+		return nil, err
+	}
+	c.sftpSessions = append(c.sftpSessions, session)
+
+	if c.sftpKeepalive == nil {
+		c.sftpKeepalive = make(chan struct{})
+		go keepalive(c.secureClient.Conn(), time.NewTicker(c.keepAliveInterval), c.sftpKeepalive)
+	}
+
+	return client, nil
+}
+
 func (c *secureShell) Wait() error {
 	keepaliveStopCh := make(chan struct{})
 	defer close(keepaliveStopCh)
@@ -469,6 +609,9 @@ func (sc *secureClient) Wait() error    { return sc.client.Wait() }
 func (sc *secureClient) Dial(n, addr string) (net.Conn, error) {
 	return sc.client.Dial(n, addr)
 }
+func (sc *secureClient) Listen(n, addr string) (net.Listener, error) {
+	return sc.client.Listen(n, addr)
+}
 func (sc *secureClient) NewSession() (SecureSession, error) {
 	return sc.client.NewSession()
 }
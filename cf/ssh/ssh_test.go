@@ -30,6 +30,7 @@ import (
 	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/docker/docker/pkg/term"
 	"github.com/kr/pty"
+	"github.com/pkg/sftp"
 	"golang.org/x/crypto/ssh"
 
 	. "github.com/onsi/ginkgo"
@@ -1123,6 +1124,422 @@ var _ = Describe("SSH", func() {
 		})
 	})
 
+	Describe("RemotePortForward", func() {
+		var (
+			opts               *options.SSHOptions
+			remoteForwardError error
+
+			echoAddress  string
+			echoListener net.Listener
+
+			remoteListener net.Listener
+		)
+
+		BeforeEach(func() {
+			var err error
+			echoListener, err = net.Listen("tcp", "127.0.0.1:0")
+			Expect(err).NotTo(HaveOccurred())
+			echoAddress = echoListener.Addr().String()
+
+			go func(listener net.Listener) {
+				for {
+					conn, err := listener.Accept()
+					if err != nil {
+						return
+					}
+					go func() {
+						io.Copy(conn, conn)
+						conn.Close()
+					}()
+				}
+			}(echoListener)
+
+			// a local listener stands in for the one on the app instance
+			remoteListener, err = net.Listen("tcp", "127.0.0.1:0")
+			Expect(err).NotTo(HaveOccurred())
+			fakeSecureClient.ListenReturns(remoteListener, nil)
+
+			opts = &options.SSHOptions{
+				AppName: "app-1",
+				RemoteForwardSpecs: []options.ForwardSpec{{
+					ListenAddress:  "localhost:9999",
+					ConnectAddress: echoAddress,
+				}},
+			}
+
+			currentApp.State = "STARTED"
+			currentApp.Diego = true
+		})
+
+		JustBeforeEach(func() {
+			connectErr := secureShell.Connect(opts)
+			Expect(connectErr).NotTo(HaveOccurred())
+
+			remoteForwardError = secureShell.RemotePortForward()
+		})
+
+		AfterEach(func() {
+			err := secureShell.Close()
+			Expect(err).NotTo(HaveOccurred())
+
+			echoListener.Close()
+			remoteListener.Close()
+		})
+
+		It("asks the server to listen on the remote address", func() {
+			Expect(remoteForwardError).NotTo(HaveOccurred())
+
+			Expect(fakeSecureClient.ListenCallCount()).To(Equal(1))
+			network, addr := fakeSecureClient.ListenArgsForCall(0)
+			Expect(network).To(Equal("tcp"))
+			Expect(addr).To(Equal("localhost:9999"))
+		})
+
+		It("copies data between the remote and local connections", func() {
+			conn, err := net.Dial("tcp", remoteListener.Addr().String())
+			Expect(err).NotTo(HaveOccurred())
+			defer conn.Close()
+
+			msg := "Hello from the app\n"
+			_, err = conn.Write([]byte(msg))
+			Expect(err).NotTo(HaveOccurred())
+
+			response := make([]byte, len(msg))
+			_, err = io.ReadFull(conn, response)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(response)).To(Equal(msg))
+		})
+
+		Context("when the local address cannot be reached", func() {
+			BeforeEach(func() {
+				echoListener.Close()
+			})
+
+			It("closes the remote connection", func() {
+				conn, err := net.Dial("tcp", remoteListener.Addr().String())
+				Expect(err).NotTo(HaveOccurred())
+				defer conn.Close()
+
+				_, err = conn.Read(make([]byte, 1))
+				Expect(err).To(Equal(io.EOF))
+			})
+		})
+
+		Context("when listen fails", func() {
+			BeforeEach(func() {
+				fakeSecureClient.ListenReturns(nil, errors.New("tcpip-forward request denied by peer"))
+			})
+
+			It("returns the error", func() {
+				Expect(remoteForwardError).To(MatchError("tcpip-forward request denied by peer"))
+			})
+		})
+
+		Context("when the secure shell is closed", func() {
+			var fakeRemoteListener *fake_net.FakeListener
+
+			BeforeEach(func() {
+				fakeRemoteListener = &fake_net.FakeListener{}
+				fakeRemoteListener.AcceptReturns(nil, errors.New("not accepting connections"))
+				fakeSecureClient.ListenReturns(fakeRemoteListener, nil)
+			})
+
+			It("closes the remote listener", func() {
+				Eventually(fakeRemoteListener.AcceptCallCount).Should(Equal(1))
+
+				originalCloseCount := fakeRemoteListener.CloseCallCount()
+				err := secureShell.Close()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(fakeRemoteListener.CloseCallCount()).Should(Equal(originalCloseCount + 1))
+			})
+		})
+	})
+
+	Describe("DynamicPortForward", func() {
+		var (
+			opts                *options.SSHOptions
+			dynamicForwardError error
+
+			echoAddress  string
+			echoListener net.Listener
+
+			localListener net.Listener
+			proxyConn     net.Conn
+		)
+
+		socksRequest := func(command byte, address []byte, port int) []byte {
+			request := []byte{5, command, 0}
+			request = append(request, address...)
+			return append(request, byte(port>>8), byte(port))
+		}
+
+		BeforeEach(func() {
+			var err error
+			echoListener, err = net.Listen("tcp", "127.0.0.1:0")
+			Expect(err).NotTo(HaveOccurred())
+			echoAddress = echoListener.Addr().String()
+
+			go func(listener net.Listener) {
+				for {
+					conn, err := listener.Accept()
+					if err != nil {
+						return
+					}
+					go func() {
+						io.Copy(conn, conn)
+						conn.Close()
+					}()
+				}
+			}(echoListener)
+
+			localListener, err = net.Listen("tcp", "127.0.0.1:0")
+			Expect(err).NotTo(HaveOccurred())
+			fakeListenerFactory.ListenReturns(localListener, nil)
+
+			fakeSecureClient.DialStub = net.Dial
+
+			opts = &options.SSHOptions{
+				AppName:                 "app-1",
+				DynamicForwardAddresses: []string{"localhost:1080"},
+			}
+
+			currentApp.State = "STARTED"
+			currentApp.Diego = true
+		})
+
+		JustBeforeEach(func() {
+			connectErr := secureShell.Connect(opts)
+			Expect(connectErr).NotTo(HaveOccurred())
+
+			dynamicForwardError = secureShell.DynamicPortForward()
+			if dynamicForwardError == nil {
+				var err error
+				proxyConn, err = net.Dial("tcp", localListener.Addr().String())
+				Expect(err).NotTo(HaveOccurred())
+			}
+		})
+
+		AfterEach(func() {
+			if proxyConn != nil {
+				proxyConn.Close()
+				proxyConn = nil
+			}
+
+			err := secureShell.Close()
+			Expect(err).NotTo(HaveOccurred())
+
+			echoListener.Close()
+			localListener.Close()
+		})
+
+		negotiate := func(methods ...byte) []byte {
+			_, err := proxyConn.Write(append([]byte{5, byte(len(methods))}, methods...))
+			Expect(err).NotTo(HaveOccurred())
+
+			reply := make([]byte, 2)
+			_, err = io.ReadFull(proxyConn, reply)
+			Expect(err).NotTo(HaveOccurred())
+			return reply
+		}
+
+		connect := func(request []byte) []byte {
+			_, err := proxyConn.Write(request)
+			Expect(err).NotTo(HaveOccurred())
+
+			reply := make([]byte, 10)
+			_, err = io.ReadFull(proxyConn, reply)
+			Expect(err).NotTo(HaveOccurred())
+			return reply
+		}
+
+		echoPort := func() int {
+			return echoListener.Addr().(*net.TCPAddr).Port
+		}
+
+		It("listens on the local address", func() {
+			Expect(dynamicForwardError).NotTo(HaveOccurred())
+
+			Expect(fakeListenerFactory.ListenCallCount()).To(Equal(1))
+			network, addr := fakeListenerFactory.ListenArgsForCall(0)
+			Expect(network).To(Equal("tcp"))
+			Expect(addr).To(Equal("localhost:1080"))
+		})
+
+		It("connects to the requested address from the app instance", func() {
+			Expect(negotiate(0)).To(Equal([]byte{5, 0}))
+
+			reply := connect(socksRequest(1, []byte{1, 127, 0, 0, 1}, echoPort()))
+			Expect(reply[:2]).To(Equal([]byte{5, 0}))
+
+			Expect(fakeSecureClient.DialCallCount()).To(Equal(1))
+			network, addr := fakeSecureClient.DialArgsForCall(0)
+			Expect(network).To(Equal("tcp"))
+			Expect(addr).To(Equal(echoAddress))
+
+			msg := "Hello through the proxy\n"
+			_, err := proxyConn.Write([]byte(msg))
+			Expect(err).NotTo(HaveOccurred())
+
+			response := make([]byte, len(msg))
+			_, err = io.ReadFull(proxyConn, response)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(response)).To(Equal(msg))
+		})
+
+		It("resolves domain names on the app instance", func() {
+			Expect(negotiate(0)).To(Equal([]byte{5, 0}))
+
+			address := append([]byte{3, byte(len("localhost"))}, "localhost"...)
+			reply := connect(socksRequest(1, address, echoPort()))
+			Expect(reply[:2]).To(Equal([]byte{5, 0}))
+
+			_, addr := fakeSecureClient.DialArgsForCall(0)
+			Expect(addr).To(Equal(fmt.Sprintf("localhost:%d", echoPort())))
+		})
+
+		Context("when the client requires authentication", func() {
+			It("refuses the client", func() {
+				Expect(negotiate(2)).To(Equal([]byte{5, 0xff}))
+				Expect(fakeSecureClient.DialCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the client asks for something other than a connection", func() {
+			It("replies that the command is not supported", func() {
+				Expect(negotiate(0)).To(Equal([]byte{5, 0}))
+
+				reply := connect(socksRequest(2, []byte{1, 127, 0, 0, 1}, echoPort()))
+				Expect(reply[:2]).To(Equal([]byte{5, 7}))
+				Expect(fakeSecureClient.DialCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the requested address cannot be reached", func() {
+			BeforeEach(func() {
+				fakeSecureClient.DialStub = nil
+				fakeSecureClient.DialReturns(nil, errors.New("connect failed"))
+			})
+
+			It("replies that the host is unreachable", func() {
+				Expect(negotiate(0)).To(Equal([]byte{5, 0}))
+
+				reply := connect(socksRequest(1, []byte{1, 127, 0, 0, 1}, echoPort()))
+				Expect(reply[:2]).To(Equal([]byte{5, 4}))
+			})
+		})
+
+		Context("when listen fails", func() {
+			BeforeEach(func() {
+				fakeListenerFactory.ListenReturns(nil, errors.New("address in use"))
+			})
+
+			It("returns the error", func() {
+				Expect(dynamicForwardError).To(MatchError("address in use"))
+			})
+		})
+	})
+
+	Describe("SFTPClient", func() {
+		var (
+			opts         *options.SSHOptions
+			client       *sftp.Client
+			sftpErr      error
+			sftpServer   *sftp.Server
+			serverReader *io.PipeReader
+			serverWriter *io.PipeWriter
+		)
+
+		BeforeEach(func() {
+			opts = &options.SSHOptions{
+				AppName: "app-1",
+			}
+
+			currentApp.State = "STARTED"
+			currentApp.Diego = true
+
+			clientReader, pipeWriter := io.Pipe()
+			pipeReader, clientWriter := io.Pipe()
+			serverReader, serverWriter = pipeReader, pipeWriter
+
+			fakeSecureSession.StdinPipeReturns(clientWriter, nil)
+			fakeSecureSession.StdoutPipeReturns(clientReader, nil)
+			fakeSecureSession.RequestSubsystemStub = func(subsystem string) error {
+				var err error
+				sftpServer, err = sftp.NewServer(struct {
+					io.Reader
+					io.WriteCloser
+				}{serverReader, serverWriter})
+				Expect(err).NotTo(HaveOccurred())
+				go sftpServer.Serve()
+				return nil
+			}
+		})
+
+		JustBeforeEach(func() {
+			connectErr := secureShell.Connect(opts)
+			Expect(connectErr).NotTo(HaveOccurred())
+
+			client, sftpErr = secureShell.SFTPClient()
+		})
+
+		AfterEach(func() {
+			if sftpServer != nil {
+				_ = sftpServer.Close()
+			}
+			if client != nil {
+				_ = client.Close()
+			}
+			_ = secureShell.Close()
+		})
+
+		It("opens the sftp subsystem on a new session", func() {
+			Expect(sftpErr).NotTo(HaveOccurred())
+			Expect(client).NotTo(BeNil())
+
+			Expect(fakeSecureClient.NewSessionCallCount()).To(Equal(1))
+			Expect(fakeSecureSession.RequestSubsystemCallCount()).To(Equal(1))
+			Expect(fakeSecureSession.RequestSubsystemArgsForCall(0)).To(Equal("sftp"))
+		})
+
+		It("returns a client that talks to the sftp server", func() {
+			Expect(sftpErr).NotTo(HaveOccurred())
+
+			wd, err := os.Getwd()
+			Expect(err).NotTo(HaveOccurred())
+
+			info, err := client.Stat(wd)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(info.IsDir()).To(BeTrue())
+		})
+
+		It("closes the session when the secure shell is closed", func() {
+			Expect(secureShell.Close()).To(Succeed())
+			Expect(fakeSecureSession.CloseCallCount()).To(Equal(1))
+		})
+
+		Context("when session allocation fails", func() {
+			BeforeEach(func() {
+				fakeSecureClient.NewSessionReturns(nil, errors.New("woops"))
+			})
+
+			It("returns an error", func() {
+				Expect(sftpErr).To(MatchError("SSH session allocation failed: woops"))
+			})
+		})
+
+		Context("when the sftp subsystem request fails", func() {
+			BeforeEach(func() {
+				fakeSecureSession.RequestSubsystemStub = nil
+				fakeSecureSession.RequestSubsystemReturns(errors.New("woops"))
+			})
+
+			It("closes the session and returns an error", func() {
+				Expect(sftpErr).To(MatchError("SFTP subsystem request failed: woops"))
+				Expect(fakeSecureSession.CloseCallCount()).To(Equal(1))
+			})
+		})
+	})
+
 	Describe("Wait", func() {
 		var opts *options.SSHOptions
 		var waitErr error
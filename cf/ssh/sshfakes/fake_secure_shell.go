@@ -6,6 +6,7 @@ import (
 
 	"code.cloudfoundry.org/cli/cf/ssh"
 	"code.cloudfoundry.org/cli/cf/ssh/options"
+	"github.com/pkg/sftp"
 )
 
 type FakeSecureShell struct {
@@ -29,6 +30,25 @@ type FakeSecureShell struct {
 	localPortForwardReturns     struct {
 		result1 error
 	}
+	RemotePortForwardStub        func() error
+	remotePortForwardMutex       sync.RWMutex
+	remotePortForwardArgsForCall []struct{}
+	remotePortForwardReturns     struct {
+		result1 error
+	}
+	DynamicPortForwardStub        func() error
+	dynamicPortForwardMutex       sync.RWMutex
+	dynamicPortForwardArgsForCall []struct{}
+	dynamicPortForwardReturns     struct {
+		result1 error
+	}
+	SFTPClientStub        func() (*sftp.Client, error)
+	sFTPClientMutex       sync.RWMutex
+	sFTPClientArgsForCall []struct{}
+	sFTPClientReturns     struct {
+		result1 *sftp.Client
+		result2 error
+	}
 	WaitStub        func() error
 	waitMutex       sync.RWMutex
 	waitArgsForCall []struct{}
@@ -128,6 +148,82 @@ func (fake *FakeSecureShell) LocalPortForwardReturns(result1 error) {
 	}{result1}
 }
 
+func (fake *FakeSecureShell) RemotePortForward() error {
+	fake.remotePortForwardMutex.Lock()
+	fake.remotePortForwardArgsForCall = append(fake.remotePortForwardArgsForCall, struct{}{})
+	fake.recordInvocation("RemotePortForward", []interface{}{})
+	fake.remotePortForwardMutex.Unlock()
+	if fake.RemotePortForwardStub != nil {
+		return fake.RemotePortForwardStub()
+	} else {
+		return fake.remotePortForwardReturns.result1
+	}
+}
+
+func (fake *FakeSecureShell) RemotePortForwardCallCount() int {
+	fake.remotePortForwardMutex.RLock()
+	defer fake.remotePortForwardMutex.RUnlock()
+	return len(fake.remotePortForwardArgsForCall)
+}
+
+func (fake *FakeSecureShell) RemotePortForwardReturns(result1 error) {
+	fake.RemotePortForwardStub = nil
+	fake.remotePortForwardReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeSecureShell) DynamicPortForward() error {
+	fake.dynamicPortForwardMutex.Lock()
+	fake.dynamicPortForwardArgsForCall = append(fake.dynamicPortForwardArgsForCall, struct{}{})
+	fake.recordInvocation("DynamicPortForward", []interface{}{})
+	fake.dynamicPortForwardMutex.Unlock()
+	if fake.DynamicPortForwardStub != nil {
+		return fake.DynamicPortForwardStub()
+	} else {
+		return fake.dynamicPortForwardReturns.result1
+	}
+}
+
+func (fake *FakeSecureShell) DynamicPortForwardCallCount() int {
+	fake.dynamicPortForwardMutex.RLock()
+	defer fake.dynamicPortForwardMutex.RUnlock()
+	return len(fake.dynamicPortForwardArgsForCall)
+}
+
+func (fake *FakeSecureShell) DynamicPortForwardReturns(result1 error) {
+	fake.DynamicPortForwardStub = nil
+	fake.dynamicPortForwardReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeSecureShell) SFTPClient() (*sftp.Client, error) {
+	fake.sFTPClientMutex.Lock()
+	fake.sFTPClientArgsForCall = append(fake.sFTPClientArgsForCall, struct{}{})
+	fake.recordInvocation("SFTPClient", []interface{}{})
+	fake.sFTPClientMutex.Unlock()
+	if fake.SFTPClientStub != nil {
+		return fake.SFTPClientStub()
+	} else {
+		return fake.sFTPClientReturns.result1, fake.sFTPClientReturns.result2
+	}
+}
+
+func (fake *FakeSecureShell) SFTPClientCallCount() int {
+	fake.sFTPClientMutex.RLock()
+	defer fake.sFTPClientMutex.RUnlock()
+	return len(fake.sFTPClientArgsForCall)
+}
+
+func (fake *FakeSecureShell) SFTPClientReturns(result1 *sftp.Client, result2 error) {
+	fake.SFTPClientStub = nil
+	fake.sFTPClientReturns = struct {
+		result1 *sftp.Client
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeSecureShell) Wait() error {
 	fake.waitMutex.Lock()
 	fake.waitArgsForCall = append(fake.waitArgsForCall, struct{}{})
@@ -187,6 +283,12 @@ func (fake *FakeSecureShell) Invocations() map[string][][]interface{} {
 	defer fake.interactiveSessionMutex.RUnlock()
 	fake.localPortForwardMutex.RLock()
 	defer fake.localPortForwardMutex.RUnlock()
+	fake.remotePortForwardMutex.RLock()
+	defer fake.remotePortForwardMutex.RUnlock()
+	fake.dynamicPortForwardMutex.RLock()
+	defer fake.dynamicPortForwardMutex.RUnlock()
+	fake.sFTPClientMutex.RLock()
+	defer fake.sFTPClientMutex.RUnlock()
 	fake.waitMutex.RLock()
 	defer fake.waitMutex.RUnlock()
 	fake.closeMutex.RLock()
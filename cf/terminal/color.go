@@ -139,6 +139,14 @@ func LogSysHeaderColor(message string) string {
 	return ColorizeBold(message, cyan)
 }
 
+var logAppNameColors = []color.Attribute{cyan, magenta, yellow, green, color.FgBlue, red}
+
+// LogAppNameColor colors the name of an app in logs that are shown for
+// several apps, picking a different color for each index.
+func LogAppNameColor(message string, index int) string {
+	return ColorizeBold(message, logAppNameColors[index%len(logAppNameColors)])
+}
+
 func isTerminal() bool {
 	return terminal.IsTerminal(int(os.Stdout.Fd()))
 }
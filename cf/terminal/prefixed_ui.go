@@ -0,0 +1,93 @@
+package terminal
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	. "code.cloudfoundry.org/cli/cf/i18n"
+)
+
+type prefixedUI struct {
+	UI
+	prefix string
+	mutex  *sync.Mutex
+}
+
+// NewPrefixedUI returns a UI that writes its output to ui with prefix at the
+// start of every line. The UIs that share a mutex write one message at a
+// time, so that the output of several operations running at once can share
+// a terminal without lines being mixed together.
+func NewPrefixedUI(ui UI, prefix string, mutex *sync.Mutex) UI {
+	return &prefixedUI{
+		UI:     ui,
+		prefix: prefix,
+		mutex:  mutex,
+	}
+}
+
+func (ui *prefixedUI) addPrefix(message string) string {
+	lines := strings.Split(message, "\n")
+	for i, line := range lines {
+		lines[i] = ui.prefix + line
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (ui *prefixedUI) PrintPaginator(rows []string, err error) {
+	if err != nil {
+		ui.Failed(err.Error())
+		return
+	}
+
+	for _, row := range rows {
+		ui.Say(row)
+	}
+}
+
+func (ui *prefixedUI) PrintCapturingNoOutput(message string, args ...interface{}) {
+	if len(args) > 0 {
+		message = fmt.Sprintf(message, args...)
+	}
+
+	ui.mutex.Lock()
+	defer ui.mutex.Unlock()
+	ui.UI.PrintCapturingNoOutput("%s", ui.addPrefix(message))
+}
+
+func (ui *prefixedUI) Say(message string, args ...interface{}) {
+	if len(args) > 0 {
+		message = fmt.Sprintf(message, args...)
+	}
+
+	ui.mutex.Lock()
+	defer ui.mutex.Unlock()
+	ui.UI.Say("%s", ui.addPrefix(message))
+}
+
+func (ui *prefixedUI) Warn(message string, args ...interface{}) {
+	ui.Say(WarningColor(fmt.Sprintf(message, args...)))
+}
+
+func (ui *prefixedUI) Ok() {
+	ui.Say(SuccessColor(T("OK")))
+}
+
+func (ui *prefixedUI) Failed(message string, args ...interface{}) {
+	ui.mutex.Lock()
+	defer ui.mutex.Unlock()
+	ui.UI.Failed("%s", ui.addPrefix(fmt.Sprintf(message, args...)))
+}
+
+func (ui *prefixedUI) LoadingIndication() {
+	ui.mutex.Lock()
+	defer ui.mutex.Unlock()
+	ui.UI.LoadingIndication()
+}
+
+func (ui *prefixedUI) Table(headers []string) *UITable {
+	return &UITable{
+		UI:    ui,
+		Table: NewTable(headers),
+	}
+}
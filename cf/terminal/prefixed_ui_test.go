@@ -0,0 +1,64 @@
+package terminal_test
+
+import (
+	"sync"
+
+	. "code.cloudfoundry.org/cli/cf/terminal"
+	"code.cloudfoundry.org/cli/cf/terminal/terminalfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("PrefixedUI", func() {
+	var (
+		fakeUI *terminalfakes.FakeUI
+		ui     UI
+	)
+
+	BeforeEach(func() {
+		fakeUI = new(terminalfakes.FakeUI)
+		ui = NewPrefixedUI(fakeUI, "[my-app] ", new(sync.Mutex))
+	})
+
+	It("prefixes every line that is said", func() {
+		ui.Say("Hello %s\nand goodbye", "World")
+
+		Expect(fakeUI.SayCallCount()).To(Equal(1))
+		message, args := fakeUI.SayArgsForCall(0)
+		Expect(message).To(Equal("%s"))
+		Expect(args).To(Equal([]interface{}{"[my-app] Hello World\n[my-app] and goodbye"}))
+	})
+
+	It("does not format messages without arguments", func() {
+		ui.Say("100%")
+
+		_, args := fakeUI.SayArgsForCall(0)
+		Expect(args).To(Equal([]interface{}{"[my-app] 100%"}))
+	})
+
+	It("prefixes the message of a failure", func() {
+		ui.Failed("Something went wrong: %s", "boom")
+
+		Expect(fakeUI.FailedCallCount()).To(Equal(1))
+		message, args := fakeUI.FailedArgsForCall(0)
+		Expect(message).To(Equal("%s"))
+		Expect(args).To(Equal([]interface{}{"[my-app] Something went wrong: boom"}))
+	})
+
+	It("prints tables through the prefixed UI", func() {
+		table := ui.Table([]string{"name", "state"})
+		table.Add("my-app", "started")
+		Expect(table.Print()).To(Succeed())
+
+		_, args := fakeUI.SayArgsForCall(0)
+		Expect(args[0]).To(ContainSubstring("[my-app] name"))
+		Expect(args[0]).To(ContainSubstring("[my-app] my-app"))
+	})
+
+	It("passes prompts through unchanged", func() {
+		fakeUI.AskReturns("yes")
+
+		Expect(ui.Ask("Really?")).To(Equal("yes"))
+		Expect(fakeUI.AskArgsForCall(0)).To(Equal("Really?"))
+	})
+})
@@ -22,6 +22,12 @@ type FakeConfig struct {
 	accessTokenReturns     struct {
 		result1 string
 	}
+	AuthorizationEndpointStub        func() string
+	authorizationEndpointMutex       sync.RWMutex
+	authorizationEndpointArgsForCall []struct{}
+	authorizationEndpointReturns     struct {
+		result1 string
+	}
 	BinaryBuildDateStub        func() string
 	binaryBuildDateMutex       sync.RWMutex
 	binaryBuildDateArgsForCall []struct{}
@@ -46,6 +52,14 @@ type FakeConfig struct {
 	colorEnabledReturns     struct {
 		result1 configv3.ColorSetting
 	}
+	CreateProfileStub        func(name string) error
+	createProfileMutex       sync.RWMutex
+	createProfileArgsForCall []struct {
+		name string
+	}
+	createProfileReturns struct {
+		result1 error
+	}
 	CurrentUserStub        func() (configv3.User, error)
 	currentUserMutex       sync.RWMutex
 	currentUserArgsForCall []struct{}
@@ -53,6 +67,14 @@ type FakeConfig struct {
 		result1 configv3.User
 		result2 error
 	}
+	DeleteProfileStub        func(name string) error
+	deleteProfileMutex       sync.RWMutex
+	deleteProfileArgsForCall []struct {
+		name string
+	}
+	deleteProfileReturns struct {
+		result1 error
+	}
 	DialTimeoutStub        func() time.Duration
 	dialTimeoutMutex       sync.RWMutex
 	dialTimeoutArgsForCall []struct{}
@@ -77,17 +99,80 @@ type FakeConfig struct {
 	pluginsReturns     struct {
 		result1 map[string]configv3.Plugin
 	}
+	ProfileNameStub        func() string
+	profileNameMutex       sync.RWMutex
+	profileNameArgsForCall []struct{}
+	profileNameReturns     struct {
+		result1 string
+	}
+	ProfilesStub        func() []configv3.ProfileInfo
+	profilesMutex       sync.RWMutex
+	profilesArgsForCall []struct{}
+	profilesReturns     struct {
+		result1 []configv3.ProfileInfo
+	}
 	RefreshTokenStub        func() string
 	refreshTokenMutex       sync.RWMutex
 	refreshTokenArgsForCall []struct{}
 	refreshTokenReturns     struct {
 		result1 string
 	}
+	RenameProfileStub        func(oldName string, newName string) error
+	renameProfileMutex       sync.RWMutex
+	renameProfileArgsForCall []struct {
+		oldName string
+		newName string
+	}
+	renameProfileReturns struct {
+		result1 error
+	}
+	ResponseCacheDirStub        func() string
+	responseCacheDirMutex       sync.RWMutex
+	responseCacheDirArgsForCall []struct{}
+	responseCacheDirReturns     struct {
+		result1 string
+	}
+	ResponseCacheEnabledStub        func() bool
+	responseCacheEnabledMutex       sync.RWMutex
+	responseCacheEnabledArgsForCall []struct{}
+	responseCacheEnabledReturns     struct {
+		result1 bool
+	}
+	RetryMaxAttemptsStub        func() int
+	retryMaxAttemptsMutex       sync.RWMutex
+	retryMaxAttemptsArgsForCall []struct{}
+	retryMaxAttemptsReturns     struct {
+		result1 int
+	}
+	RetryMaxElapsedTimeStub        func() time.Duration
+	retryMaxElapsedTimeMutex       sync.RWMutex
+	retryMaxElapsedTimeArgsForCall []struct{}
+	retryMaxElapsedTimeReturns     struct {
+		result1 time.Duration
+	}
 	SetAccessTokenStub        func(token string)
 	setAccessTokenMutex       sync.RWMutex
 	setAccessTokenArgsForCall []struct {
 		token string
 	}
+	SetOrganizationInformationStub        func(guid string, name string)
+	setOrganizationInformationMutex       sync.RWMutex
+	setOrganizationInformationArgsForCall []struct {
+		guid string
+		name string
+	}
+	SetRefreshTokenStub        func(token string)
+	setRefreshTokenMutex       sync.RWMutex
+	setRefreshTokenArgsForCall []struct {
+		token string
+	}
+	SetSpaceInformationStub        func(guid string, name string, allowSSH bool)
+	setSpaceInformationMutex       sync.RWMutex
+	setSpaceInformationArgsForCall []struct {
+		guid     string
+		name     string
+		allowSSH bool
+	}
 	SetTargetInformationStub        func(api string, apiVersion string, auth string, loggregator string, doppler string, uaa string, routing string, skipSSLValidation bool)
 	setTargetInformationMutex       sync.RWMutex
 	setTargetInformationArgsForCall []struct {
@@ -107,12 +192,31 @@ type FakeConfig struct {
 		refreshToken   string
 		sshOAuthClient string
 	}
+	SetUAAClientCredentialsStub        func(client string, clientSecret string)
+	setUAAClientCredentialsMutex       sync.RWMutex
+	setUAAClientCredentialsArgsForCall []struct {
+		client       string
+		clientSecret string
+	}
+	SetUAAGrantTypeStub        func(uaaGrantType string)
+	setUAAGrantTypeMutex       sync.RWMutex
+	setUAAGrantTypeArgsForCall []struct {
+		uaaGrantType string
+	}
 	SkipSSLValidationStub        func() bool
 	skipSSLValidationMutex       sync.RWMutex
 	skipSSLValidationArgsForCall []struct{}
 	skipSSLValidationReturns     struct {
 		result1 bool
 	}
+	SwitchProfileStub        func(name string) error
+	switchProfileMutex       sync.RWMutex
+	switchProfileArgsForCall []struct {
+		name string
+	}
+	switchProfileReturns struct {
+		result1 error
+	}
 	TargetStub        func() string
 	targetMutex       sync.RWMutex
 	targetArgsForCall []struct{}
@@ -131,6 +235,12 @@ type FakeConfig struct {
 	targetedSpaceReturns     struct {
 		result1 configv3.Space
 	}
+	UAAGrantTypeStub        func() string
+	uAAGrantTypeMutex       sync.RWMutex
+	uAAGrantTypeArgsForCall []struct{}
+	uAAGrantTypeReturns     struct {
+		result1 string
+	}
 	UAAOAuthClientStub        func() string
 	uAAOAuthClientMutex       sync.RWMutex
 	uAAOAuthClientArgsForCall []struct{}
@@ -143,10 +253,13 @@ type FakeConfig struct {
 	uAAOAuthClientSecretReturns     struct {
 		result1 string
 	}
-	VerboseStub        func() (bool, []string)
-	verboseMutex       sync.RWMutex
-	verboseArgsForCall []struct{}
-	verboseReturns     struct {
+	UnsetUserInformationStub        func()
+	unsetUserInformationMutex       sync.RWMutex
+	unsetUserInformationArgsForCall []struct{}
+	VerboseStub                     func() (bool, []string)
+	verboseMutex                    sync.RWMutex
+	verboseArgsForCall              []struct{}
+	verboseReturns                  struct {
 		result1 bool
 		result2 []string
 	}
@@ -204,6 +317,31 @@ func (fake *FakeConfig) AccessTokenReturns(result1 string) {
 	}{result1}
 }
 
+func (fake *FakeConfig) AuthorizationEndpoint() string {
+	fake.authorizationEndpointMutex.Lock()
+	fake.authorizationEndpointArgsForCall = append(fake.authorizationEndpointArgsForCall, struct{}{})
+	fake.recordInvocation("AuthorizationEndpoint", []interface{}{})
+	fake.authorizationEndpointMutex.Unlock()
+	if fake.AuthorizationEndpointStub != nil {
+		return fake.AuthorizationEndpointStub()
+	} else {
+		return fake.authorizationEndpointReturns.result1
+	}
+}
+
+func (fake *FakeConfig) AuthorizationEndpointCallCount() int {
+	fake.authorizationEndpointMutex.RLock()
+	defer fake.authorizationEndpointMutex.RUnlock()
+	return len(fake.authorizationEndpointArgsForCall)
+}
+
+func (fake *FakeConfig) AuthorizationEndpointReturns(result1 string) {
+	fake.AuthorizationEndpointStub = nil
+	fake.authorizationEndpointReturns = struct {
+		result1 string
+	}{result1}
+}
+
 func (fake *FakeConfig) BinaryBuildDate() string {
 	fake.binaryBuildDateMutex.Lock()
 	fake.binaryBuildDateArgsForCall = append(fake.binaryBuildDateArgsForCall, struct{}{})
@@ -304,6 +442,39 @@ func (fake *FakeConfig) ColorEnabledReturns(result1 configv3.ColorSetting) {
 	}{result1}
 }
 
+func (fake *FakeConfig) CreateProfile(name string) error {
+	fake.createProfileMutex.Lock()
+	fake.createProfileArgsForCall = append(fake.createProfileArgsForCall, struct {
+		name string
+	}{name})
+	fake.recordInvocation("CreateProfile", []interface{}{name})
+	fake.createProfileMutex.Unlock()
+	if fake.CreateProfileStub != nil {
+		return fake.CreateProfileStub(name)
+	} else {
+		return fake.createProfileReturns.result1
+	}
+}
+
+func (fake *FakeConfig) CreateProfileCallCount() int {
+	fake.createProfileMutex.RLock()
+	defer fake.createProfileMutex.RUnlock()
+	return len(fake.createProfileArgsForCall)
+}
+
+func (fake *FakeConfig) CreateProfileArgsForCall(i int) string {
+	fake.createProfileMutex.RLock()
+	defer fake.createProfileMutex.RUnlock()
+	return fake.createProfileArgsForCall[i].name
+}
+
+func (fake *FakeConfig) CreateProfileReturns(result1 error) {
+	fake.CreateProfileStub = nil
+	fake.createProfileReturns = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeConfig) CurrentUser() (configv3.User, error) {
 	fake.currentUserMutex.Lock()
 	fake.currentUserArgsForCall = append(fake.currentUserArgsForCall, struct{}{})
@@ -330,6 +501,39 @@ func (fake *FakeConfig) CurrentUserReturns(result1 configv3.User, result2 error)
 	}{result1, result2}
 }
 
+func (fake *FakeConfig) DeleteProfile(name string) error {
+	fake.deleteProfileMutex.Lock()
+	fake.deleteProfileArgsForCall = append(fake.deleteProfileArgsForCall, struct {
+		name string
+	}{name})
+	fake.recordInvocation("DeleteProfile", []interface{}{name})
+	fake.deleteProfileMutex.Unlock()
+	if fake.DeleteProfileStub != nil {
+		return fake.DeleteProfileStub(name)
+	} else {
+		return fake.deleteProfileReturns.result1
+	}
+}
+
+func (fake *FakeConfig) DeleteProfileCallCount() int {
+	fake.deleteProfileMutex.RLock()
+	defer fake.deleteProfileMutex.RUnlock()
+	return len(fake.deleteProfileArgsForCall)
+}
+
+func (fake *FakeConfig) DeleteProfileArgsForCall(i int) string {
+	fake.deleteProfileMutex.RLock()
+	defer fake.deleteProfileMutex.RUnlock()
+	return fake.deleteProfileArgsForCall[i].name
+}
+
+func (fake *FakeConfig) DeleteProfileReturns(result1 error) {
+	fake.DeleteProfileStub = nil
+	fake.deleteProfileReturns = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeConfig) DialTimeout() time.Duration {
 	fake.dialTimeoutMutex.Lock()
 	fake.dialTimeoutArgsForCall = append(fake.dialTimeoutArgsForCall, struct{}{})
@@ -430,6 +634,56 @@ func (fake *FakeConfig) PluginsReturns(result1 map[string]configv3.Plugin) {
 	}{result1}
 }
 
+func (fake *FakeConfig) ProfileName() string {
+	fake.profileNameMutex.Lock()
+	fake.profileNameArgsForCall = append(fake.profileNameArgsForCall, struct{}{})
+	fake.recordInvocation("ProfileName", []interface{}{})
+	fake.profileNameMutex.Unlock()
+	if fake.ProfileNameStub != nil {
+		return fake.ProfileNameStub()
+	} else {
+		return fake.profileNameReturns.result1
+	}
+}
+
+func (fake *FakeConfig) ProfileNameCallCount() int {
+	fake.profileNameMutex.RLock()
+	defer fake.profileNameMutex.RUnlock()
+	return len(fake.profileNameArgsForCall)
+}
+
+func (fake *FakeConfig) ProfileNameReturns(result1 string) {
+	fake.ProfileNameStub = nil
+	fake.profileNameReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeConfig) Profiles() []configv3.ProfileInfo {
+	fake.profilesMutex.Lock()
+	fake.profilesArgsForCall = append(fake.profilesArgsForCall, struct{}{})
+	fake.recordInvocation("Profiles", []interface{}{})
+	fake.profilesMutex.Unlock()
+	if fake.ProfilesStub != nil {
+		return fake.ProfilesStub()
+	} else {
+		return fake.profilesReturns.result1
+	}
+}
+
+func (fake *FakeConfig) ProfilesCallCount() int {
+	fake.profilesMutex.RLock()
+	defer fake.profilesMutex.RUnlock()
+	return len(fake.profilesArgsForCall)
+}
+
+func (fake *FakeConfig) ProfilesReturns(result1 []configv3.ProfileInfo) {
+	fake.ProfilesStub = nil
+	fake.profilesReturns = struct {
+		result1 []configv3.ProfileInfo
+	}{result1}
+}
+
 func (fake *FakeConfig) RefreshToken() string {
 	fake.refreshTokenMutex.Lock()
 	fake.refreshTokenArgsForCall = append(fake.refreshTokenArgsForCall, struct{}{})
@@ -455,6 +709,140 @@ func (fake *FakeConfig) RefreshTokenReturns(result1 string) {
 	}{result1}
 }
 
+func (fake *FakeConfig) RenameProfile(oldName string, newName string) error {
+	fake.renameProfileMutex.Lock()
+	fake.renameProfileArgsForCall = append(fake.renameProfileArgsForCall, struct {
+		oldName string
+		newName string
+	}{oldName, newName})
+	fake.recordInvocation("RenameProfile", []interface{}{oldName, newName})
+	fake.renameProfileMutex.Unlock()
+	if fake.RenameProfileStub != nil {
+		return fake.RenameProfileStub(oldName, newName)
+	} else {
+		return fake.renameProfileReturns.result1
+	}
+}
+
+func (fake *FakeConfig) RenameProfileCallCount() int {
+	fake.renameProfileMutex.RLock()
+	defer fake.renameProfileMutex.RUnlock()
+	return len(fake.renameProfileArgsForCall)
+}
+
+func (fake *FakeConfig) RenameProfileArgsForCall(i int) (string, string) {
+	fake.renameProfileMutex.RLock()
+	defer fake.renameProfileMutex.RUnlock()
+	return fake.renameProfileArgsForCall[i].oldName, fake.renameProfileArgsForCall[i].newName
+}
+
+func (fake *FakeConfig) RenameProfileReturns(result1 error) {
+	fake.RenameProfileStub = nil
+	fake.renameProfileReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeConfig) ResponseCacheDir() string {
+	fake.responseCacheDirMutex.Lock()
+	fake.responseCacheDirArgsForCall = append(fake.responseCacheDirArgsForCall, struct{}{})
+	fake.recordInvocation("ResponseCacheDir", []interface{}{})
+	fake.responseCacheDirMutex.Unlock()
+	if fake.ResponseCacheDirStub != nil {
+		return fake.ResponseCacheDirStub()
+	} else {
+		return fake.responseCacheDirReturns.result1
+	}
+}
+
+func (fake *FakeConfig) ResponseCacheDirCallCount() int {
+	fake.responseCacheDirMutex.RLock()
+	defer fake.responseCacheDirMutex.RUnlock()
+	return len(fake.responseCacheDirArgsForCall)
+}
+
+func (fake *FakeConfig) ResponseCacheDirReturns(result1 string) {
+	fake.ResponseCacheDirStub = nil
+	fake.responseCacheDirReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeConfig) ResponseCacheEnabled() bool {
+	fake.responseCacheEnabledMutex.Lock()
+	fake.responseCacheEnabledArgsForCall = append(fake.responseCacheEnabledArgsForCall, struct{}{})
+	fake.recordInvocation("ResponseCacheEnabled", []interface{}{})
+	fake.responseCacheEnabledMutex.Unlock()
+	if fake.ResponseCacheEnabledStub != nil {
+		return fake.ResponseCacheEnabledStub()
+	} else {
+		return fake.responseCacheEnabledReturns.result1
+	}
+}
+
+func (fake *FakeConfig) ResponseCacheEnabledCallCount() int {
+	fake.responseCacheEnabledMutex.RLock()
+	defer fake.responseCacheEnabledMutex.RUnlock()
+	return len(fake.responseCacheEnabledArgsForCall)
+}
+
+func (fake *FakeConfig) ResponseCacheEnabledReturns(result1 bool) {
+	fake.ResponseCacheEnabledStub = nil
+	fake.responseCacheEnabledReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeConfig) RetryMaxAttempts() int {
+	fake.retryMaxAttemptsMutex.Lock()
+	fake.retryMaxAttemptsArgsForCall = append(fake.retryMaxAttemptsArgsForCall, struct{}{})
+	fake.recordInvocation("RetryMaxAttempts", []interface{}{})
+	fake.retryMaxAttemptsMutex.Unlock()
+	if fake.RetryMaxAttemptsStub != nil {
+		return fake.RetryMaxAttemptsStub()
+	} else {
+		return fake.retryMaxAttemptsReturns.result1
+	}
+}
+
+func (fake *FakeConfig) RetryMaxAttemptsCallCount() int {
+	fake.retryMaxAttemptsMutex.RLock()
+	defer fake.retryMaxAttemptsMutex.RUnlock()
+	return len(fake.retryMaxAttemptsArgsForCall)
+}
+
+func (fake *FakeConfig) RetryMaxAttemptsReturns(result1 int) {
+	fake.RetryMaxAttemptsStub = nil
+	fake.retryMaxAttemptsReturns = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeConfig) RetryMaxElapsedTime() time.Duration {
+	fake.retryMaxElapsedTimeMutex.Lock()
+	fake.retryMaxElapsedTimeArgsForCall = append(fake.retryMaxElapsedTimeArgsForCall, struct{}{})
+	fake.recordInvocation("RetryMaxElapsedTime", []interface{}{})
+	fake.retryMaxElapsedTimeMutex.Unlock()
+	if fake.RetryMaxElapsedTimeStub != nil {
+		return fake.RetryMaxElapsedTimeStub()
+	} else {
+		return fake.retryMaxElapsedTimeReturns.result1
+	}
+}
+
+func (fake *FakeConfig) RetryMaxElapsedTimeCallCount() int {
+	fake.retryMaxElapsedTimeMutex.RLock()
+	defer fake.retryMaxElapsedTimeMutex.RUnlock()
+	return len(fake.retryMaxElapsedTimeArgsForCall)
+}
+
+func (fake *FakeConfig) RetryMaxElapsedTimeReturns(result1 time.Duration) {
+	fake.RetryMaxElapsedTimeStub = nil
+	fake.retryMaxElapsedTimeReturns = struct {
+		result1 time.Duration
+	}{result1}
+}
+
 func (fake *FakeConfig) SetAccessToken(token string) {
 	fake.setAccessTokenMutex.Lock()
 	fake.setAccessTokenArgsForCall = append(fake.setAccessTokenArgsForCall, struct {
@@ -479,6 +867,81 @@ func (fake *FakeConfig) SetAccessTokenArgsForCall(i int) string {
 	return fake.setAccessTokenArgsForCall[i].token
 }
 
+func (fake *FakeConfig) SetOrganizationInformation(guid string, name string) {
+	fake.setOrganizationInformationMutex.Lock()
+	fake.setOrganizationInformationArgsForCall = append(fake.setOrganizationInformationArgsForCall, struct {
+		guid string
+		name string
+	}{guid, name})
+	fake.recordInvocation("SetOrganizationInformation", []interface{}{guid, name})
+	fake.setOrganizationInformationMutex.Unlock()
+	if fake.SetOrganizationInformationStub != nil {
+		fake.SetOrganizationInformationStub(guid, name)
+	}
+}
+
+func (fake *FakeConfig) SetOrganizationInformationCallCount() int {
+	fake.setOrganizationInformationMutex.RLock()
+	defer fake.setOrganizationInformationMutex.RUnlock()
+	return len(fake.setOrganizationInformationArgsForCall)
+}
+
+func (fake *FakeConfig) SetOrganizationInformationArgsForCall(i int) (string, string) {
+	fake.setOrganizationInformationMutex.RLock()
+	defer fake.setOrganizationInformationMutex.RUnlock()
+	return fake.setOrganizationInformationArgsForCall[i].guid, fake.setOrganizationInformationArgsForCall[i].name
+}
+
+func (fake *FakeConfig) SetRefreshToken(token string) {
+	fake.setRefreshTokenMutex.Lock()
+	fake.setRefreshTokenArgsForCall = append(fake.setRefreshTokenArgsForCall, struct {
+		token string
+	}{token})
+	fake.recordInvocation("SetRefreshToken", []interface{}{token})
+	fake.setRefreshTokenMutex.Unlock()
+	if fake.SetRefreshTokenStub != nil {
+		fake.SetRefreshTokenStub(token)
+	}
+}
+
+func (fake *FakeConfig) SetRefreshTokenCallCount() int {
+	fake.setRefreshTokenMutex.RLock()
+	defer fake.setRefreshTokenMutex.RUnlock()
+	return len(fake.setRefreshTokenArgsForCall)
+}
+
+func (fake *FakeConfig) SetRefreshTokenArgsForCall(i int) string {
+	fake.setRefreshTokenMutex.RLock()
+	defer fake.setRefreshTokenMutex.RUnlock()
+	return fake.setRefreshTokenArgsForCall[i].token
+}
+
+func (fake *FakeConfig) SetSpaceInformation(guid string, name string, allowSSH bool) {
+	fake.setSpaceInformationMutex.Lock()
+	fake.setSpaceInformationArgsForCall = append(fake.setSpaceInformationArgsForCall, struct {
+		guid     string
+		name     string
+		allowSSH bool
+	}{guid, name, allowSSH})
+	fake.recordInvocation("SetSpaceInformation", []interface{}{guid, name, allowSSH})
+	fake.setSpaceInformationMutex.Unlock()
+	if fake.SetSpaceInformationStub != nil {
+		fake.SetSpaceInformationStub(guid, name, allowSSH)
+	}
+}
+
+func (fake *FakeConfig) SetSpaceInformationCallCount() int {
+	fake.setSpaceInformationMutex.RLock()
+	defer fake.setSpaceInformationMutex.RUnlock()
+	return len(fake.setSpaceInformationArgsForCall)
+}
+
+func (fake *FakeConfig) SetSpaceInformationArgsForCall(i int) (string, string, bool) {
+	fake.setSpaceInformationMutex.RLock()
+	defer fake.setSpaceInformationMutex.RUnlock()
+	return fake.setSpaceInformationArgsForCall[i].guid, fake.setSpaceInformationArgsForCall[i].name, fake.setSpaceInformationArgsForCall[i].allowSSH
+}
+
 func (fake *FakeConfig) SetTargetInformation(api string, apiVersion string, auth string, loggregator string, doppler string, uaa string, routing string, skipSSLValidation bool) {
 	fake.setTargetInformationMutex.Lock()
 	fake.setTargetInformationArgsForCall = append(fake.setTargetInformationArgsForCall, struct {
@@ -536,6 +999,55 @@ func (fake *FakeConfig) SetTokenInformationArgsForCall(i int) (string, string, s
 	return fake.setTokenInformationArgsForCall[i].accessToken, fake.setTokenInformationArgsForCall[i].refreshToken, fake.setTokenInformationArgsForCall[i].sshOAuthClient
 }
 
+func (fake *FakeConfig) SetUAAClientCredentials(client string, clientSecret string) {
+	fake.setUAAClientCredentialsMutex.Lock()
+	fake.setUAAClientCredentialsArgsForCall = append(fake.setUAAClientCredentialsArgsForCall, struct {
+		client       string
+		clientSecret string
+	}{client, clientSecret})
+	fake.recordInvocation("SetUAAClientCredentials", []interface{}{client, clientSecret})
+	fake.setUAAClientCredentialsMutex.Unlock()
+	if fake.SetUAAClientCredentialsStub != nil {
+		fake.SetUAAClientCredentialsStub(client, clientSecret)
+	}
+}
+
+func (fake *FakeConfig) SetUAAClientCredentialsCallCount() int {
+	fake.setUAAClientCredentialsMutex.RLock()
+	defer fake.setUAAClientCredentialsMutex.RUnlock()
+	return len(fake.setUAAClientCredentialsArgsForCall)
+}
+
+func (fake *FakeConfig) SetUAAClientCredentialsArgsForCall(i int) (string, string) {
+	fake.setUAAClientCredentialsMutex.RLock()
+	defer fake.setUAAClientCredentialsMutex.RUnlock()
+	return fake.setUAAClientCredentialsArgsForCall[i].client, fake.setUAAClientCredentialsArgsForCall[i].clientSecret
+}
+
+func (fake *FakeConfig) SetUAAGrantType(uaaGrantType string) {
+	fake.setUAAGrantTypeMutex.Lock()
+	fake.setUAAGrantTypeArgsForCall = append(fake.setUAAGrantTypeArgsForCall, struct {
+		uaaGrantType string
+	}{uaaGrantType})
+	fake.recordInvocation("SetUAAGrantType", []interface{}{uaaGrantType})
+	fake.setUAAGrantTypeMutex.Unlock()
+	if fake.SetUAAGrantTypeStub != nil {
+		fake.SetUAAGrantTypeStub(uaaGrantType)
+	}
+}
+
+func (fake *FakeConfig) SetUAAGrantTypeCallCount() int {
+	fake.setUAAGrantTypeMutex.RLock()
+	defer fake.setUAAGrantTypeMutex.RUnlock()
+	return len(fake.setUAAGrantTypeArgsForCall)
+}
+
+func (fake *FakeConfig) SetUAAGrantTypeArgsForCall(i int) string {
+	fake.setUAAGrantTypeMutex.RLock()
+	defer fake.setUAAGrantTypeMutex.RUnlock()
+	return fake.setUAAGrantTypeArgsForCall[i].uaaGrantType
+}
+
 func (fake *FakeConfig) SkipSSLValidation() bool {
 	fake.skipSSLValidationMutex.Lock()
 	fake.skipSSLValidationArgsForCall = append(fake.skipSSLValidationArgsForCall, struct{}{})
@@ -561,6 +1073,39 @@ func (fake *FakeConfig) SkipSSLValidationReturns(result1 bool) {
 	}{result1}
 }
 
+func (fake *FakeConfig) SwitchProfile(name string) error {
+	fake.switchProfileMutex.Lock()
+	fake.switchProfileArgsForCall = append(fake.switchProfileArgsForCall, struct {
+		name string
+	}{name})
+	fake.recordInvocation("SwitchProfile", []interface{}{name})
+	fake.switchProfileMutex.Unlock()
+	if fake.SwitchProfileStub != nil {
+		return fake.SwitchProfileStub(name)
+	} else {
+		return fake.switchProfileReturns.result1
+	}
+}
+
+func (fake *FakeConfig) SwitchProfileCallCount() int {
+	fake.switchProfileMutex.RLock()
+	defer fake.switchProfileMutex.RUnlock()
+	return len(fake.switchProfileArgsForCall)
+}
+
+func (fake *FakeConfig) SwitchProfileArgsForCall(i int) string {
+	fake.switchProfileMutex.RLock()
+	defer fake.switchProfileMutex.RUnlock()
+	return fake.switchProfileArgsForCall[i].name
+}
+
+func (fake *FakeConfig) SwitchProfileReturns(result1 error) {
+	fake.SwitchProfileStub = nil
+	fake.switchProfileReturns = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeConfig) Target() string {
 	fake.targetMutex.Lock()
 	fake.targetArgsForCall = append(fake.targetArgsForCall, struct{}{})
@@ -636,6 +1181,31 @@ func (fake *FakeConfig) TargetedSpaceReturns(result1 configv3.Space) {
 	}{result1}
 }
 
+func (fake *FakeConfig) UAAGrantType() string {
+	fake.uAAGrantTypeMutex.Lock()
+	fake.uAAGrantTypeArgsForCall = append(fake.uAAGrantTypeArgsForCall, struct{}{})
+	fake.recordInvocation("UAAGrantType", []interface{}{})
+	fake.uAAGrantTypeMutex.Unlock()
+	if fake.UAAGrantTypeStub != nil {
+		return fake.UAAGrantTypeStub()
+	} else {
+		return fake.uAAGrantTypeReturns.result1
+	}
+}
+
+func (fake *FakeConfig) UAAGrantTypeCallCount() int {
+	fake.uAAGrantTypeMutex.RLock()
+	defer fake.uAAGrantTypeMutex.RUnlock()
+	return len(fake.uAAGrantTypeArgsForCall)
+}
+
+func (fake *FakeConfig) UAAGrantTypeReturns(result1 string) {
+	fake.UAAGrantTypeStub = nil
+	fake.uAAGrantTypeReturns = struct {
+		result1 string
+	}{result1}
+}
+
 func (fake *FakeConfig) UAAOAuthClient() string {
 	fake.uAAOAuthClientMutex.Lock()
 	fake.uAAOAuthClientArgsForCall = append(fake.uAAOAuthClientArgsForCall, struct{}{})
@@ -686,6 +1256,22 @@ func (fake *FakeConfig) UAAOAuthClientSecretReturns(result1 string) {
 	}{result1}
 }
 
+func (fake *FakeConfig) UnsetUserInformation() {
+	fake.unsetUserInformationMutex.Lock()
+	fake.unsetUserInformationArgsForCall = append(fake.unsetUserInformationArgsForCall, struct{}{})
+	fake.recordInvocation("UnsetUserInformation", []interface{}{})
+	fake.unsetUserInformationMutex.Unlock()
+	if fake.UnsetUserInformationStub != nil {
+		fake.UnsetUserInformationStub()
+	}
+}
+
+func (fake *FakeConfig) UnsetUserInformationCallCount() int {
+	fake.unsetUserInformationMutex.RLock()
+	defer fake.unsetUserInformationMutex.RUnlock()
+	return len(fake.unsetUserInformationArgsForCall)
+}
+
 func (fake *FakeConfig) Verbose() (bool, []string) {
 	fake.verboseMutex.Lock()
 	fake.verboseArgsForCall = append(fake.verboseArgsForCall, struct{}{})
@@ -719,6 +1305,8 @@ func (fake *FakeConfig) Invocations() map[string][][]interface{} {
 	defer fake.aPIVersionMutex.RUnlock()
 	fake.accessTokenMutex.RLock()
 	defer fake.accessTokenMutex.RUnlock()
+	fake.authorizationEndpointMutex.RLock()
+	defer fake.authorizationEndpointMutex.RUnlock()
 	fake.binaryBuildDateMutex.RLock()
 	defer fake.binaryBuildDateMutex.RUnlock()
 	fake.binaryNameMutex.RLock()
@@ -727,8 +1315,12 @@ func (fake *FakeConfig) Invocations() map[string][][]interface{} {
 	defer fake.binaryVersionMutex.RUnlock()
 	fake.colorEnabledMutex.RLock()
 	defer fake.colorEnabledMutex.RUnlock()
+	fake.createProfileMutex.RLock()
+	defer fake.createProfileMutex.RUnlock()
 	fake.currentUserMutex.RLock()
 	defer fake.currentUserMutex.RUnlock()
+	fake.deleteProfileMutex.RLock()
+	defer fake.deleteProfileMutex.RUnlock()
 	fake.dialTimeoutMutex.RLock()
 	defer fake.dialTimeoutMutex.RUnlock()
 	fake.experimentalMutex.RLock()
@@ -737,26 +1329,56 @@ func (fake *FakeConfig) Invocations() map[string][][]interface{} {
 	defer fake.localeMutex.RUnlock()
 	fake.pluginsMutex.RLock()
 	defer fake.pluginsMutex.RUnlock()
+	fake.profileNameMutex.RLock()
+	defer fake.profileNameMutex.RUnlock()
+	fake.profilesMutex.RLock()
+	defer fake.profilesMutex.RUnlock()
 	fake.refreshTokenMutex.RLock()
 	defer fake.refreshTokenMutex.RUnlock()
+	fake.renameProfileMutex.RLock()
+	defer fake.renameProfileMutex.RUnlock()
+	fake.responseCacheDirMutex.RLock()
+	defer fake.responseCacheDirMutex.RUnlock()
+	fake.responseCacheEnabledMutex.RLock()
+	defer fake.responseCacheEnabledMutex.RUnlock()
+	fake.retryMaxAttemptsMutex.RLock()
+	defer fake.retryMaxAttemptsMutex.RUnlock()
+	fake.retryMaxElapsedTimeMutex.RLock()
+	defer fake.retryMaxElapsedTimeMutex.RUnlock()
 	fake.setAccessTokenMutex.RLock()
 	defer fake.setAccessTokenMutex.RUnlock()
+	fake.setOrganizationInformationMutex.RLock()
+	defer fake.setOrganizationInformationMutex.RUnlock()
+	fake.setRefreshTokenMutex.RLock()
+	defer fake.setRefreshTokenMutex.RUnlock()
+	fake.setSpaceInformationMutex.RLock()
+	defer fake.setSpaceInformationMutex.RUnlock()
 	fake.setTargetInformationMutex.RLock()
 	defer fake.setTargetInformationMutex.RUnlock()
 	fake.setTokenInformationMutex.RLock()
 	defer fake.setTokenInformationMutex.RUnlock()
+	fake.setUAAClientCredentialsMutex.RLock()
+	defer fake.setUAAClientCredentialsMutex.RUnlock()
+	fake.setUAAGrantTypeMutex.RLock()
+	defer fake.setUAAGrantTypeMutex.RUnlock()
 	fake.skipSSLValidationMutex.RLock()
 	defer fake.skipSSLValidationMutex.RUnlock()
+	fake.switchProfileMutex.RLock()
+	defer fake.switchProfileMutex.RUnlock()
 	fake.targetMutex.RLock()
 	defer fake.targetMutex.RUnlock()
 	fake.targetedOrganizationMutex.RLock()
 	defer fake.targetedOrganizationMutex.RUnlock()
 	fake.targetedSpaceMutex.RLock()
 	defer fake.targetedSpaceMutex.RUnlock()
+	fake.uAAGrantTypeMutex.RLock()
+	defer fake.uAAGrantTypeMutex.RUnlock()
 	fake.uAAOAuthClientMutex.RLock()
 	defer fake.uAAOAuthClientMutex.RUnlock()
 	fake.uAAOAuthClientSecretMutex.RLock()
 	defer fake.uAAOAuthClientSecretMutex.RUnlock()
+	fake.unsetUserInformationMutex.RLock()
+	defer fake.unsetUserInformationMutex.RUnlock()
 	fake.verboseMutex.RLock()
 	defer fake.verboseMutex.RUnlock()
 	return fake.invocations
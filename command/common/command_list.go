@@ -9,6 +9,9 @@ var Commands commandList
 
 type commandList struct {
 	VerboseOrVersion                   bool                                         `short:"v" long:"version" description:"verbose and version flag"`
+	Output                             string                                       `long:"output" description:"Display command results as a single document in the given format: text, json or yaml"`
+	NoCache                            bool                                         `long:"no-cache" description:"Do not read or write cached Cloud Controller responses"`
+	Profile                            string                                       `long:"profile" description:"Use the given profile's target and credentials instead of the active profile's"`
 	App                                v2.AppCommand                                `command:"app" description:"Display health and status for app"`
 	Help                               HelpCommand                                  `command:"help" alias:"h" description:"Show help"`
 	Version                            VersionCommand                               `command:"version" description:"Print the version"`
@@ -18,6 +21,11 @@ type commandList struct {
 	Target                             v2.TargetCommand                             `command:"target" alias:"t" description:"Set or view the targeted org or space"`
 	Api                                v2.ApiCommand                                `command:"api" description:"Set or view target api url"`
 	Auth                               v2.AuthCommand                               `command:"auth" description:"Authenticate user non-interactively"`
+	Profiles                           v2.ProfilesCommand                           `command:"profiles" description:"List all profiles"`
+	CreateProfile                      v2.CreateProfileCommand                      `command:"create-profile" description:"Create a profile to hold a separate target and credentials"`
+	SwitchProfile                      v2.SwitchProfileCommand                      `command:"switch-profile" description:"Make a profile the active profile"`
+	RenameProfile                      v2.RenameProfileCommand                      `command:"rename-profile" description:"Rename a profile"`
+	DeleteProfile                      v2.DeleteProfileCommand                      `command:"delete-profile" description:"Delete a profile"`
 	Apps                               v2.AppsCommand                               `command:"apps" alias:"a" description:"List all apps in the target space"`
 	Push                               v2.PushCommand                               `command:"push" alias:"p" description:"Push a new app or sync changes to an existing app"`
 	Scale                              v2.ScaleCommand                              `command:"scale" description:"Change or view the instance count, disk space limit, and memory limit for an app"`
@@ -31,6 +39,7 @@ type commandList struct {
 	Events                             v2.EventsCommand                             `command:"events" description:"Show recent app events"`
 	Files                              v2.FilesCommand                              `command:"files" alias:"f" description:"Print out a list of files in a directory or the contents of a specific file of an app running on the DEA backend"`
 	Logs                               v2.LogsCommand                               `command:"logs" description:"Tail or show recent logs for an app"`
+	AppTraffic                         v2.AppTrafficCommand                         `command:"app-traffic" description:"Summarize the HTTP requests to an app from its router logs"`
 	Env                                v2.EnvCommand                                `command:"env" alias:"e" description:"Show all env variables for an app"`
 	SetEnv                             v2.SetEnvCommand                             `command:"set-env" alias:"se" description:"Set an env variable for an app"`
 	UnsetEnv                           v2.UnsetEnvCommand                           `command:"unset-env" description:"Remove an env variable"`
@@ -38,12 +47,14 @@ type commandList struct {
 	Stack                              v2.StackCommand                              `command:"stack" description:"Show information for a stack (a stack is a pre-built file system, including an operating system, that can run apps)"`
 	CopySource                         v2.CopySourceCommand                         `command:"copy-source" description:"Copies the source code of an application to another existing application (and restarts that application)"`
 	CreateAppManifest                  v2.CreateAppManifestCommand                  `command:"create-app-manifest" description:"Create an app manifest for an app that has been pushed successfully"`
+	ValidateManifest                   v2.ValidateManifestCommand                   `command:"validate-manifest" description:"Check a manifest, and the manifests it inherits from, for problems"`
 	GetHealthCheck                     v2.GetHealthCheckCommand                     `command:"get-health-check" description:"Get the health_check_type value of an app"`
 	SetHealthCheck                     v2.SetHealthCheckCommand                     `command:"set-health-check" description:"Set health_check_type flag to either 'port' or 'none'"`
 	EnableSSH                          v2.EnableSSHCommand                          `command:"enable-ssh" description:"Enable ssh for the application"`
 	DisableSSH                         v2.DisableSSHCommand                         `command:"disable-ssh" description:"Disable ssh for the application"`
 	SSHEnabled                         v2.SSHEnabledCommand                         `command:"ssh-enabled" description:"Reports whether SSH is enabled on an application container instance"`
 	SSH                                v2.SSHCommand                                `command:"ssh" description:"SSH to an application container instance"`
+	SCP                                v2.SCPCommand                                `command:"scp" description:"Copy files to or from an application container instance"`
 	Marketplace                        v2.MarketplaceCommand                        `command:"marketplace" alias:"m" description:"List available offerings in the marketplace"`
 	Services                           v2.ServicesCommand                           `command:"services" alias:"s" description:"List all service instances in the target space"`
 	Service                            v2.ServiceCommand                            `command:"service" description:"Show service instance info"`
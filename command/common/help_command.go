@@ -163,6 +163,11 @@ func (cmd HelpCommand) displayCommonCommands() {
 			"ENVName":     "-v",
 			"Description": cmd.UI.TranslateText("Print API request diagnostics to stdout"),
 		})
+	cmd.UI.DisplayText(prefix+"{{.ENVName}}                         {{.Description}}",
+		map[string]interface{}{
+			"ENVName":     "--no-cache",
+			"Description": cmd.UI.TranslateText("Do not read or write cached Cloud Controller responses"),
+		})
 	cmd.UI.DisplayNewline()
 	cmd.UI.DisplayText("'cf help -a' lists all commands with short descriptions. See 'cf help <command>' to read about a specific command.")
 }
@@ -202,6 +207,11 @@ func (cmd HelpCommand) displayAllCommands() {
 
 func (cmd HelpCommand) displayHelpFooter() {
 	cmd.UI.DisplayHelpHeader("ENVIRONMENT VARIABLES:")
+	cmd.UI.DisplayText("   {{.ENVName}}                      {{.Description}}",
+		map[string]interface{}{
+			"ENVName":     "CF_CACHE=true",
+			"Description": cmd.UI.TranslateText("Cache Cloud Controller responses on disk and revalidate them"),
+		})
 	cmd.UI.DisplayText("   {{.ENVName}}                     {{.Description}}",
 		map[string]interface{}{
 			"ENVName":     "CF_COLOR=false",
@@ -250,6 +260,11 @@ func (cmd HelpCommand) displayHelpFooter() {
 			"ENVName":     "-v",
 			"Description": cmd.UI.TranslateText("Print API request diagnostics to stdout"),
 		})
+	cmd.UI.DisplayText("   {{.ENVName}}                         {{.Description}}",
+		map[string]interface{}{
+			"ENVName":     "--no-cache",
+			"Description": cmd.UI.TranslateText("Do not read or write cached Cloud Controller responses"),
+		})
 }
 
 func (cmd HelpCommand) displayCommand() error {
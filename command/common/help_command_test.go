@@ -516,12 +516,14 @@ var _ = Describe("Help Command", func() {
 				Expect(fakeUI.Out).To(Say("enable-diego\\s+enable Diego support for an app"))
 
 				Expect(fakeUI.Out).To(Say("ENVIRONMENT VARIABLES:"))
+				Expect(fakeUI.Out).To(Say("CF_CACHE=true\\s+Cache Cloud Controller responses on disk and revalidate them"))
 				Expect(fakeUI.Out).To(Say("CF_COLOR=false\\s+Do not colorize output"))
 				Expect(fakeUI.Out).To(Say("CF_DIAL_TIMEOUT=5\\s+Max wait time to establish a connection, including name resolution, in seconds"))
 				Expect(fakeUI.Out).To(Say("CF_TRACE=true"))
 
 				Expect(fakeUI.Out).To(Say("GLOBAL OPTIONS:"))
 				Expect(fakeUI.Out).To(Say("--help, -h\\s+Show help"))
+				Expect(fakeUI.Out).To(Say("--no-cache\\s+Do not read or write cached Cloud Controller responses"))
 			})
 
 			Context("when there are multiple installed plugins", func() {
@@ -8,6 +8,12 @@ var HelpCategoryList = []HelpCategory{
 			{"api", "auth"},
 		},
 	},
+	{
+		CategoryName: "PROFILES:",
+		CommandList: [][]string{
+			{"profiles", "create-profile", "switch-profile", "rename-profile", "delete-profile"},
+		},
+	},
 	{
 		CategoryName: "APPS:",
 		CommandList: [][]string{
@@ -15,11 +21,11 @@ var HelpCategoryList = []HelpCategory{
 			{"push", "scale", "delete", "rename"},
 			{"start", "stop", "restart", "restage", "restart-app-instance"},
 			{"run-task", "tasks", "terminate-task"},
-			{"events", "files", "logs"},
+			{"events", "files", "logs", "app-traffic"},
 			{"env", "set-env", "unset-env"},
 			{"stacks", "stack"},
-			{"copy-source", "create-app-manifest"},
-			{"get-health-check", "set-health-check", "enable-ssh", "disable-ssh", "ssh-enabled", "ssh"},
+			{"copy-source", "create-app-manifest", "validate-manifest"},
+			{"get-health-check", "set-health-check", "enable-ssh", "disable-ssh", "ssh-enabled", "ssh", "scp"},
 		},
 	},
 	{
@@ -12,24 +12,42 @@ import (
 type Config interface {
 	APIVersion() string
 	AccessToken() string
+	AuthorizationEndpoint() string
 	BinaryBuildDate() string
 	BinaryName() string
 	BinaryVersion() string
 	ColorEnabled() configv3.ColorSetting
+	CreateProfile(name string) error
 	CurrentUser() (configv3.User, error)
+	DeleteProfile(name string) error
 	DialTimeout() time.Duration
 	Experimental() bool
 	Locale() string
 	Plugins() map[string]configv3.Plugin
+	ProfileName() string
+	Profiles() []configv3.ProfileInfo
 	RefreshToken() string
+	RenameProfile(oldName string, newName string) error
+	ResponseCacheDir() string
+	ResponseCacheEnabled() bool
+	RetryMaxAttempts() int
+	RetryMaxElapsedTime() time.Duration
 	SetAccessToken(token string)
+	SetOrganizationInformation(guid string, name string)
+	SetRefreshToken(token string)
+	SetSpaceInformation(guid string, name string, allowSSH bool)
 	SetTargetInformation(api string, apiVersion string, auth string, loggregator string, doppler string, uaa string, routing string, skipSSLValidation bool)
 	SetTokenInformation(accessToken string, refreshToken string, sshOAuthClient string)
+	SetUAAClientCredentials(client string, clientSecret string)
+	SetUAAGrantType(uaaGrantType string)
 	SkipSSLValidation() bool
+	SwitchProfile(name string) error
 	Target() string
 	TargetedOrganization() configv3.Organization
 	TargetedSpace() configv3.Space
+	UAAGrantType() string
 	UAAOAuthClient() string
 	UAAOAuthClientSecret() string
+	UnsetUserInformation()
 	Verbose() (bool, []string)
 }
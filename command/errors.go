@@ -1,6 +1,10 @@
 package command
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+	"time"
+)
 
 type APIRequestError struct {
 	Err error
@@ -115,6 +119,34 @@ func (e ServiceInstanceNotFoundError) Translate(translate func(string, ...interf
 	})
 }
 
+type OrganizationNotFoundError struct {
+	Name string
+}
+
+func (e OrganizationNotFoundError) Error() string {
+	return "Organization {{.OrgName}} not found"
+}
+
+func (e OrganizationNotFoundError) Translate(translate func(string, ...interface{}) string) string {
+	return translate(e.Error(), map[string]interface{}{
+		"OrgName": e.Name,
+	})
+}
+
+type SpaceNotFoundError struct {
+	Name string
+}
+
+func (e SpaceNotFoundError) Error() string {
+	return "Space {{.SpaceName}} not found"
+}
+
+func (e SpaceNotFoundError) Translate(translate func(string, ...interface{}) string) string {
+	return translate(e.Error(), map[string]interface{}{
+		"SpaceName": e.Name,
+	})
+}
+
 type APINotFoundError struct {
 	URL string
 }
@@ -129,6 +161,44 @@ func (e APINotFoundError) Translate(translate func(string, ...interface{}) strin
 	})
 }
 
+type BadCredentialsError struct{}
+
+func (e BadCredentialsError) Error() string {
+	return "Credentials were rejected, please try again."
+}
+
+func (e BadCredentialsError) Translate(translate func(string, ...interface{}) string) string {
+	return translate(e.Error(), map[string]interface{}{})
+}
+
+type AuthorizationTimeoutError struct {
+	Timeout time.Duration
+}
+
+func (e AuthorizationTimeoutError) Error() string {
+	return "Timed out after {{.Timeout}} waiting for the browser to authorize the CLI."
+}
+
+func (e AuthorizationTimeoutError) Translate(translate func(string, ...interface{}) string) string {
+	return translate(e.Error(), map[string]interface{}{
+		"Timeout": e.Timeout,
+	})
+}
+
+type UnsupportedOutputFormatError struct {
+	Format string
+}
+
+func (e UnsupportedOutputFormatError) Error() string {
+	return "Incorrect usage: Output format {{.Format}} is not supported, use one of: text, json, yaml"
+}
+
+func (e UnsupportedOutputFormatError) Translate(translate func(string, ...interface{}) string) string {
+	return translate(e.Error(), map[string]interface{}{
+		"Format": e.Format,
+	})
+}
+
 type ParseArgumentError struct {
 	ArgumentName string
 	ExpectedType string
@@ -144,3 +214,59 @@ func (e ParseArgumentError) Translate(translate func(string, ...interface{}) str
 		"ExpectedType": e.ExpectedType,
 	})
 }
+
+type ArgumentCombinationError struct {
+	Args []string
+}
+
+func (e ArgumentCombinationError) Error() string {
+	return "Incorrect usage: The following arguments cannot be used together: {{.Args}}"
+}
+
+func (e ArgumentCombinationError) Translate(translate func(string, ...interface{}) string) string {
+	return translate(e.Error(), map[string]interface{}{
+		"Args": strings.Join(e.Args, ", "),
+	})
+}
+
+type ProfileNotFoundError struct {
+	Name string
+}
+
+func (e ProfileNotFoundError) Error() string {
+	return "Profile {{.Name}} does not exist."
+}
+
+func (e ProfileNotFoundError) Translate(translate func(string, ...interface{}) string) string {
+	return translate(e.Error(), map[string]interface{}{
+		"Name": e.Name,
+	})
+}
+
+type ProfileAlreadyExistsError struct {
+	Name string
+}
+
+func (e ProfileAlreadyExistsError) Error() string {
+	return "Profile {{.Name}} already exists."
+}
+
+func (e ProfileAlreadyExistsError) Translate(translate func(string, ...interface{}) string) string {
+	return translate(e.Error(), map[string]interface{}{
+		"Name": e.Name,
+	})
+}
+
+type DefaultProfileError struct {
+	Name string
+}
+
+func (e DefaultProfileError) Error() string {
+	return "The {{.Name}} profile cannot be renamed or deleted."
+}
+
+func (e DefaultProfileError) Translate(translate func(string, ...interface{}) string) string {
+	return translate(e.Error(), map[string]interface{}{
+		"Name": e.Name,
+	})
+}
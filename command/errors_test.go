@@ -43,11 +43,24 @@ var _ = Describe("Translatable Errors", func() {
 		Entry("InvalidSSLCertError", InvalidSSLCertError{}),
 		Entry("APINotFoundError", APINotFoundError{}),
 
+		// UAA errors.
+		Entry("BadCredentialsError", BadCredentialsError{}),
+		Entry("AuthorizationTimeoutError", AuthorizationTimeoutError{}),
+
 		// Actor errors.
 		Entry("ApplicationNotFoundError", ApplicationNotFoundError{}),
 		Entry("ServiceInstanceNotFoundError", ServiceInstanceNotFoundError{}),
+		Entry("OrganizationNotFoundError", OrganizationNotFoundError{}),
+		Entry("SpaceNotFoundError", SpaceNotFoundError{}),
+
+		// Config errors.
+		Entry("ProfileNotFoundError", ProfileNotFoundError{}),
+		Entry("ProfileAlreadyExistsError", ProfileAlreadyExistsError{}),
+		Entry("DefaultProfileError", DefaultProfileError{}),
 
 		// Parse errors.
 		Entry("ParseArgumentError", ParseArgumentError{}),
+		Entry("ArgumentCombinationError", ArgumentCombinationError{}),
+		Entry("UnsupportedOutputFormatError", UnsupportedOutputFormatError{}),
 	)
 })
@@ -4,6 +4,14 @@ type AppName struct {
 	AppName string `positional-arg-name:"APP_NAME" required:"true" description:"The application name"`
 }
 
+type OptionalAppName struct {
+	AppName string `positional-arg-name:"APP_NAME" description:"The application name"`
+}
+
+type AppNames struct {
+	AppNames []string `positional-arg-name:"APP_NAME" description:"The application names"`
+}
+
 type Buildpack struct {
 	Buildpack string `positional-arg-name:"BUILDPACK" required:"true" description:"The buildpack"`
 }
@@ -40,6 +48,10 @@ type APIPath struct {
 	Path string `positional-arg-name:"PATH" required:"true" description:"The API endpoint"`
 }
 
+type ManifestPath struct {
+	Path string `positional-arg-name:"PATH" description:"The manifest file, or a directory containing manifest.yml"`
+}
+
 type PluginRepoName struct {
 	PluginRepoName string `positional-arg-name:"REPO_NAME" required:"true" description:"The plugin repo name"`
 }
@@ -95,6 +107,11 @@ type OrgSpace struct {
 	Space        string `positional-arg-name:"SPACE" required:"true" description:"The space"`
 }
 
+type CopySourceTarget struct {
+	Source string `positional-arg-name:"SOURCE" required:"true" description:"The file or directory to copy, written as APP_NAME:PATH if it is on the app"`
+	Target string `positional-arg-name:"TARGET" required:"true" description:"Where to copy it to, written as APP_NAME:PATH if it is on the app"`
+}
+
 type ServiceInstanceKey struct {
 	ServiceInstance string `positional-arg-name:"SERVICE_INSTANCE" required:"true" description:"The service instance"`
 	ServiceKey      string `positional-arg-name:"SERVICE_KEY" required:"true" description:"The service key"`
@@ -284,3 +301,12 @@ type TerminateTaskArgs struct {
 	AppName    string `positional-arg-name:"APP_NAME" required:"true" description:"The application name"`
 	SequenceID string `positional-arg-name:"TASK_ID" required:"true" description:"The task's unique sequence ID"`
 }
+
+type ProfileName struct {
+	ProfileName string `positional-arg-name:"PROFILE_NAME" required:"true" description:"The profile name"`
+}
+
+type RenameProfileArgs struct {
+	OldProfileName string `positional-arg-name:"PROFILE_NAME" required:"true" description:"The old profile name"`
+	NewProfileName string `positional-arg-name:"NEW_PROFILE_NAME" required:"true" description:"The new profile name"`
+}
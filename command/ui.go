@@ -7,11 +7,14 @@ type UI interface {
 	DisplayBoolPrompt(prompt string, defaultResponse bool) (bool, error)
 	DisplayError(err error)
 	DisplayHelpHeader(text string)
+	DisplayKeyValueTable(prefix string, table [][]string, padding int, rawValues ...interface{}) error
 	DisplayNewline()
 	DisplayOK()
+	DisplayPasswordPrompt(prompt string) (string, error)
 	DisplayPair(attribute string, formattedString string, keys ...map[string]interface{})
-	DisplayTable(prefix string, table [][]string, padding int) error
+	DisplayTable(prefix string, table [][]string, padding int, rawValues ...[]interface{}) error
 	DisplayText(template string, data ...map[string]interface{})
+	DisplayTextPrompt(prompt string) (string, error)
 	DisplayTextWithFlavor(text string, keys ...map[string]interface{})
 	DisplayWarning(formattedString string, keys ...map[string]interface{})
 	DisplayWarnings(warnings []string)
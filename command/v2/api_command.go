@@ -66,9 +66,9 @@ func (cmd *ApiCommand) Execute(args []string) error {
 		return nil
 	}
 
-	cmd.UI.DisplayTable("", [][]string{
-		{cmd.UI.TranslateText("API endpoint:"), cmd.Config.Target()},
-		{cmd.UI.TranslateText("API version:"), cmd.Config.APIVersion()},
+	cmd.UI.DisplayKeyValueTable("", [][]string{
+		{"API endpoint:", cmd.Config.Target()},
+		{"API version:", cmd.Config.APIVersion()},
 	}, 3)
 
 	user, err := cmd.Config.CurrentUser()
@@ -0,0 +1,26 @@
+package v2
+
+import (
+	"os"
+
+	"code.cloudfoundry.org/cli/cf/cmd"
+	"code.cloudfoundry.org/cli/command"
+	"code.cloudfoundry.org/cli/command/flag"
+)
+
+type AppTrafficCommand struct {
+	RequiredArgs    flag.AppName `positional-args:"yes"`
+	Recent          bool         `long:"recent" description:"Summarize recent requests instead of watching live traffic"`
+	Window          string       `long:"window" description:"How far back the summary looks, such as 30s or 5m (Default: 1m)"`
+	usage           interface{}  `usage:"CF_NAME app-traffic APP_NAME [--recent] [--window DURATION] [--output json]"`
+	relatedCommands interface{}  `related_commands:"app, logs"`
+}
+
+func (_ AppTrafficCommand) Setup(config command.Config, ui command.UI) error {
+	return nil
+}
+
+func (_ AppTrafficCommand) Execute(args []string) error {
+	cmd.Main(os.Getenv("CF_TRACE"), os.Args)
+	return nil
+}
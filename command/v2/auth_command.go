@@ -3,22 +3,73 @@ package v2
 import (
 	"os"
 
-	"code.cloudfoundry.org/cli/cf/cmd"
+	"code.cloudfoundry.org/cli/actor/v2action"
+	oldCmd "code.cloudfoundry.org/cli/cf/cmd"
 	"code.cloudfoundry.org/cli/command"
 	"code.cloudfoundry.org/cli/command/flag"
+	"code.cloudfoundry.org/cli/command/v2/shared"
 )
 
+//go:generate counterfeiter . AuthActor
+
+type AuthActor interface {
+	Authenticate(config v2action.Config, username string, password string) error
+	AuthenticateClientCredentials(config v2action.Config, clientID string, clientSecret string) error
+}
+
 type AuthCommand struct {
-	RequiredArgs    flag.Authentication `positional-args:"yes"`
-	usage           interface{}         `usage:"CF_NAME auth USERNAME PASSWORD\n\nWARNING:\n   Providing your password as a command line option is highly discouraged\n   Your password may be visible to others and may be recorded in your shell history\n\nEXAMPLES:\n   CF_NAME auth name@example.com \"my password\" (use quotes for passwords with a space)\n   CF_NAME auth name@example.com \"\\\"password\\\"\" (escape quotes if used in password)"`
-	relatedCommands interface{}         `related_commands:"api, login, target"`
+	RequiredArgs      flag.Authentication `positional-args:"yes"`
+	ClientCredentials bool                `long:"client-credentials" description:"Use (non-user) service account (also called client) credentials"`
+	usage             interface{}         `usage:"CF_NAME auth USERNAME PASSWORD\n   CF_NAME auth CLIENT_ID CLIENT_SECRET --client-credentials\n\nWARNING:\n   Providing your password as a command line option is highly discouraged\n   Your password may be visible to others and may be recorded in your shell history\n\nEXAMPLES:\n   CF_NAME auth name@example.com \"my password\" (use quotes for passwords with a space)\n   CF_NAME auth name@example.com \"\\\"password\\\"\" (escape quotes if used in password)\n   CF_NAME auth my-client my-client-secret --client-credentials (authenticate as a service account)"`
+	relatedCommands   interface{}         `related_commands:"api, login, target"`
+
+	UI     command.UI
+	Actor  AuthActor
+	Config command.Config
 }
 
-func (_ AuthCommand) Setup(config command.Config, ui command.UI) error {
+func (cmd *AuthCommand) Setup(config command.Config, ui command.UI) error {
+	cmd.UI = ui
+	cmd.Config = config
+
+	ccClient, uaaClient, err := shared.NewClients(config, ui)
+	if err != nil {
+		return err
+	}
+	cmd.Actor = v2action.NewActor(ccClient, uaaClient)
+
 	return nil
 }
 
-func (_ AuthCommand) Execute(args []string) error {
-	cmd.Main(os.Getenv("CF_TRACE"), os.Args)
+func (cmd *AuthCommand) Execute(args []string) error {
+	if cmd.Config.Experimental() == false {
+		oldCmd.Main(os.Getenv("CF_TRACE"), os.Args)
+		return nil
+	}
+
+	cmd.UI.DisplayText(command.ExperimentalWarning)
+	cmd.UI.DisplayNewline()
+
+	cmd.UI.DisplayText("API endpoint: {{.Endpoint}}", map[string]interface{}{
+		"Endpoint": cmd.Config.Target(),
+	})
+	cmd.UI.DisplayText("Authenticating...")
+
+	var err error
+	if cmd.ClientCredentials {
+		err = cmd.Actor.AuthenticateClientCredentials(cmd.Config, cmd.RequiredArgs.Username, cmd.RequiredArgs.Password)
+	} else {
+		err = cmd.Actor.Authenticate(cmd.Config, cmd.RequiredArgs.Username, cmd.RequiredArgs.Password)
+	}
+	if err != nil {
+		return shared.HandleError(err)
+	}
+
+	cmd.UI.DisplayOK()
+	cmd.UI.DisplayNewline()
+	cmd.UI.DisplayText("Use '{{.Command}}' to view or set your target org and space.", map[string]interface{}{
+		"Command": cmd.Config.BinaryName() + " target",
+	})
+
 	return nil
 }
@@ -0,0 +1,109 @@
+package v2_test
+
+import (
+	"errors"
+
+	"code.cloudfoundry.org/cli/api/uaa"
+	"code.cloudfoundry.org/cli/command"
+	"code.cloudfoundry.org/cli/command/commandfakes"
+	. "code.cloudfoundry.org/cli/command/v2"
+	"code.cloudfoundry.org/cli/command/v2/v2fakes"
+	"code.cloudfoundry.org/cli/util/ui"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+)
+
+var _ = Describe("Auth Command", func() {
+	var (
+		cmd        AuthCommand
+		fakeUI     *ui.UI
+		fakeActor  *v2fakes.FakeAuthActor
+		fakeConfig *commandfakes.FakeConfig
+		executeErr error
+	)
+
+	BeforeEach(func() {
+		out := NewBuffer()
+		fakeUI = ui.NewTestUI(nil, out, out)
+		fakeActor = new(v2fakes.FakeAuthActor)
+		fakeConfig = new(commandfakes.FakeConfig)
+		fakeConfig.ExperimentalReturns(true)
+		fakeConfig.BinaryNameReturns("faceman")
+		fakeConfig.TargetReturns("https://api.example.com")
+
+		cmd = AuthCommand{
+			UI:     fakeUI,
+			Actor:  fakeActor,
+			Config: fakeConfig,
+		}
+		cmd.RequiredArgs.Username = "some-user"
+		cmd.RequiredArgs.Password = "some-password"
+	})
+
+	JustBeforeEach(func() {
+		executeErr = cmd.Execute(nil)
+	})
+
+	It("displays the experimental warning message", func() {
+		Expect(fakeUI.Out).To(Say(command.ExperimentalWarning))
+	})
+
+	Context("when the user is authenticated", func() {
+		It("authenticates with the username and password", func() {
+			Expect(executeErr).ToNot(HaveOccurred())
+
+			Expect(fakeActor.AuthenticateCallCount()).To(Equal(1))
+			config, username, password := fakeActor.AuthenticateArgsForCall(0)
+			Expect(config).To(Equal(fakeConfig))
+			Expect(username).To(Equal("some-user"))
+			Expect(password).To(Equal("some-password"))
+
+			Expect(fakeUI.Out).To(Say("API endpoint: https://api.example.com"))
+			Expect(fakeUI.Out).To(Say("Authenticating..."))
+			Expect(fakeUI.Out).To(Say("OK"))
+			Expect(fakeUI.Out).To(Say("Use 'faceman target' to view or set your target org and space."))
+		})
+	})
+
+	Context("when --client-credentials is passed", func() {
+		BeforeEach(func() {
+			cmd.ClientCredentials = true
+		})
+
+		It("authenticates as a client", func() {
+			Expect(executeErr).ToNot(HaveOccurred())
+
+			Expect(fakeActor.AuthenticateCallCount()).To(Equal(0))
+			Expect(fakeActor.AuthenticateClientCredentialsCallCount()).To(Equal(1))
+			_, clientID, clientSecret := fakeActor.AuthenticateClientCredentialsArgsForCall(0)
+			Expect(clientID).To(Equal("some-user"))
+			Expect(clientSecret).To(Equal("some-password"))
+		})
+	})
+
+	Context("when the credentials are rejected", func() {
+		BeforeEach(func() {
+			fakeActor.AuthenticateReturns(uaa.BadCredentialsError{Message: "Bad credentials"})
+		})
+
+		It("returns a BadCredentialsError", func() {
+			Expect(executeErr).To(MatchError(command.BadCredentialsError{}))
+			Expect(fakeUI.Out).ToNot(Say("OK"))
+		})
+	})
+
+	Context("when authentication fails with another error", func() {
+		var expectedErr error
+
+		BeforeEach(func() {
+			expectedErr = errors.New("some-error")
+			fakeActor.AuthenticateReturns(expectedErr)
+		})
+
+		It("returns the error", func() {
+			Expect(executeErr).To(MatchError(expectedErr))
+		})
+	})
+})
@@ -9,10 +9,12 @@ import (
 )
 
 type CreateAppManifestCommand struct {
-	RequiredArgs    flag.AppName `positional-args:"yes"`
-	FilePath        string       `short:"p" description:"Specify a path for file creation. If path not specified, manifest file is created in current working directory."`
-	usage           interface{}  `usage:"CF_NAME create-app-manifest APP_NAME [-p /path/to/<app-name>-manifest.yml]"`
-	relatedCommands interface{}  `related_commands:"apps, push"`
+	OptionalArgs    flag.OptionalAppName `positional-args:"yes"`
+	FilePath        string               `short:"p" description:"Specify a path for file creation. If path not specified, manifest file is created in current working directory."`
+	AllApps         bool                 `long:"all-apps" description:"Create one manifest for all apps in the targeted space"`
+	MaskSecrets     bool                 `long:"mask-secrets" description:"Replace the values of env vars that look like secrets, such as passwords and tokens, with ((variables)) to give with --var or --vars-file when pushing"`
+	usage           interface{}          `usage:"CF_NAME create-app-manifest APP_NAME [-p /path/to/<app-name>-manifest.yml] [--mask-secrets]\n   CF_NAME create-app-manifest --all-apps [-p /path/to/<space-name>-manifest.yml] [--mask-secrets]"`
+	relatedCommands interface{}          `related_commands:"apps, push"`
 }
 
 func (_ CreateAppManifestCommand) Setup(config command.Config, ui command.UI) error {
@@ -0,0 +1,40 @@
+package v2
+
+import (
+	"code.cloudfoundry.org/cli/command"
+	"code.cloudfoundry.org/cli/command/flag"
+	"code.cloudfoundry.org/cli/command/v2/shared"
+)
+
+type CreateProfileCommand struct {
+	RequiredArgs    flag.ProfileName `positional-args:"yes"`
+	usage           interface{}      `usage:"CF_NAME create-profile PROFILE_NAME\n\nEXAMPLES:\n   CF_NAME create-profile staging\n   CF_NAME --profile staging login -a api.staging.example.com"`
+	relatedCommands interface{}      `related_commands:"profiles, switch-profile"`
+
+	UI     command.UI
+	Config command.Config
+}
+
+func (cmd *CreateProfileCommand) Setup(config command.Config, ui command.UI) error {
+	cmd.UI = ui
+	cmd.Config = config
+	return nil
+}
+
+func (cmd *CreateProfileCommand) Execute(args []string) error {
+	cmd.UI.DisplayText("Creating profile {{.ProfileName}}...", map[string]interface{}{
+		"ProfileName": cmd.RequiredArgs.ProfileName,
+	})
+
+	err := cmd.Config.CreateProfile(cmd.RequiredArgs.ProfileName)
+	if err != nil {
+		return shared.HandleError(err)
+	}
+
+	cmd.UI.DisplayOK()
+	cmd.UI.DisplayNewline()
+	cmd.UI.DisplayText("TIP: Use '{{.Command}}' to make it the active profile.", map[string]interface{}{
+		"Command": cmd.Config.BinaryName() + " switch-profile " + cmd.RequiredArgs.ProfileName,
+	})
+	return nil
+}
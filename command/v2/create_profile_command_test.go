@@ -0,0 +1,58 @@
+package v2_test
+
+import (
+	"code.cloudfoundry.org/cli/command"
+	"code.cloudfoundry.org/cli/command/commandfakes"
+	. "code.cloudfoundry.org/cli/command/v2"
+	"code.cloudfoundry.org/cli/util/configv3"
+	"code.cloudfoundry.org/cli/util/ui"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+)
+
+var _ = Describe("Create Profile Command", func() {
+	var (
+		cmd        CreateProfileCommand
+		fakeUI     *ui.UI
+		fakeConfig *commandfakes.FakeConfig
+		executeErr error
+	)
+
+	BeforeEach(func() {
+		out := NewBuffer()
+		fakeUI = ui.NewTestUI(nil, out, out)
+		fakeConfig = new(commandfakes.FakeConfig)
+		fakeConfig.BinaryNameReturns("faceman")
+
+		cmd = CreateProfileCommand{
+			UI:     fakeUI,
+			Config: fakeConfig,
+		}
+		cmd.RequiredArgs.ProfileName = "staging"
+	})
+
+	JustBeforeEach(func() {
+		executeErr = cmd.Execute(nil)
+	})
+
+	It("creates the profile", func() {
+		Expect(executeErr).ToNot(HaveOccurred())
+
+		Expect(fakeConfig.CreateProfileArgsForCall(0)).To(Equal("staging"))
+		Expect(fakeUI.Out).To(Say("Creating profile staging..."))
+		Expect(fakeUI.Out).To(Say("OK"))
+		Expect(fakeUI.Out).To(Say("TIP: Use 'faceman switch-profile staging' to make it the active profile."))
+	})
+
+	Context("when the profile already exists", func() {
+		BeforeEach(func() {
+			fakeConfig.CreateProfileReturns(configv3.ProfileAlreadyExistsError{Name: "staging"})
+		})
+
+		It("returns a ProfileAlreadyExistsError", func() {
+			Expect(executeErr).To(MatchError(command.ProfileAlreadyExistsError{Name: "staging"}))
+		})
+	})
+})
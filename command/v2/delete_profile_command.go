@@ -0,0 +1,60 @@
+package v2
+
+import (
+	"code.cloudfoundry.org/cli/command"
+	"code.cloudfoundry.org/cli/command/flag"
+	"code.cloudfoundry.org/cli/command/v2/shared"
+	"code.cloudfoundry.org/cli/util/configv3"
+)
+
+type DeleteProfileCommand struct {
+	RequiredArgs    flag.ProfileName `positional-args:"yes"`
+	Force           bool             `short:"f" description:"Force deletion without confirmation"`
+	usage           interface{}      `usage:"CF_NAME delete-profile PROFILE_NAME [-f]"`
+	relatedCommands interface{}      `related_commands:"profiles"`
+
+	UI     command.UI
+	Config command.Config
+}
+
+func (cmd *DeleteProfileCommand) Setup(config command.Config, ui command.UI) error {
+	cmd.UI = ui
+	cmd.Config = config
+	return nil
+}
+
+func (cmd *DeleteProfileCommand) Execute(args []string) error {
+	if !cmd.Force {
+		deleteProfile, err := cmd.UI.DisplayBoolPrompt(cmd.UI.TranslateText("Really delete the profile {{.ProfileName}}?", map[string]interface{}{
+			"ProfileName": cmd.RequiredArgs.ProfileName,
+		}), false)
+		if err != nil {
+			return err
+		}
+
+		if !deleteProfile {
+			cmd.UI.DisplayText("Delete cancelled")
+			return nil
+		}
+	}
+
+	cmd.UI.DisplayText("Deleting profile {{.ProfileName}}...", map[string]interface{}{
+		"ProfileName": cmd.RequiredArgs.ProfileName,
+	})
+
+	wasCurrent := cmd.Config.ProfileName() == cmd.RequiredArgs.ProfileName
+	err := cmd.Config.DeleteProfile(cmd.RequiredArgs.ProfileName)
+	if err != nil {
+		return shared.HandleError(err)
+	}
+
+	cmd.UI.DisplayOK()
+
+	if wasCurrent {
+		cmd.UI.DisplayNewline()
+		cmd.UI.DisplayText("Using the {{.ProfileName}} profile.", map[string]interface{}{
+			"ProfileName": configv3.DefaultProfileName,
+		})
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+package v2_test
+
+import (
+	"code.cloudfoundry.org/cli/command"
+	"code.cloudfoundry.org/cli/command/commandfakes"
+	. "code.cloudfoundry.org/cli/command/v2"
+	"code.cloudfoundry.org/cli/util/configv3"
+	"code.cloudfoundry.org/cli/util/ui"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+)
+
+var _ = Describe("Delete Profile Command", func() {
+	var (
+		cmd        DeleteProfileCommand
+		input      *Buffer
+		fakeUI     *ui.UI
+		fakeConfig *commandfakes.FakeConfig
+		executeErr error
+	)
+
+	BeforeEach(func() {
+		input = NewBuffer()
+		out := NewBuffer()
+		fakeUI = ui.NewTestUI(input, out, out)
+		fakeConfig = new(commandfakes.FakeConfig)
+		fakeConfig.ProfileNameReturns("default")
+
+		cmd = DeleteProfileCommand{
+			UI:     fakeUI,
+			Config: fakeConfig,
+		}
+		cmd.RequiredArgs.ProfileName = "staging"
+	})
+
+	JustBeforeEach(func() {
+		executeErr = cmd.Execute(nil)
+	})
+
+	Context("when the user confirms the deletion", func() {
+		BeforeEach(func() {
+			input.Write([]byte("y\n"))
+		})
+
+		It("deletes the profile", func() {
+			Expect(executeErr).ToNot(HaveOccurred())
+
+			Expect(fakeUI.Out).To(Say(`Really delete the profile staging\?`))
+			Expect(fakeConfig.DeleteProfileArgsForCall(0)).To(Equal("staging"))
+			Expect(fakeUI.Out).To(Say("Deleting profile staging..."))
+			Expect(fakeUI.Out).To(Say("OK"))
+			Expect(fakeUI.Out).ToNot(Say("Using the default profile."))
+		})
+	})
+
+	Context("when the user cancels the deletion", func() {
+		BeforeEach(func() {
+			input.Write([]byte("n\n"))
+		})
+
+		It("does not delete the profile", func() {
+			Expect(executeErr).ToNot(HaveOccurred())
+			Expect(fakeUI.Out).To(Say("Delete cancelled"))
+			Expect(fakeConfig.DeleteProfileCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when -f is passed", func() {
+		BeforeEach(func() {
+			cmd.Force = true
+		})
+
+		It("deletes the profile without asking", func() {
+			Expect(executeErr).ToNot(HaveOccurred())
+			Expect(fakeUI.Out).ToNot(Say("Really delete"))
+			Expect(fakeConfig.DeleteProfileCallCount()).To(Equal(1))
+		})
+
+		Context("when the current profile is deleted", func() {
+			BeforeEach(func() {
+				fakeConfig.ProfileNameStub = func() string {
+					if fakeConfig.DeleteProfileCallCount() == 0 {
+						return "staging"
+					}
+					return "default"
+				}
+			})
+
+			It("tells the user the default profile is used", func() {
+				Expect(executeErr).ToNot(HaveOccurred())
+				Expect(fakeUI.Out).To(Say("Using the default profile."))
+			})
+		})
+
+		Context("when the profile does not exist", func() {
+			BeforeEach(func() {
+				fakeConfig.DeleteProfileReturns(configv3.ProfileNotFoundError{Name: "staging"})
+			})
+
+			It("returns a ProfileNotFoundError", func() {
+				Expect(executeErr).To(MatchError(command.ProfileNotFoundError{Name: "staging"}))
+			})
+		})
+	})
+})
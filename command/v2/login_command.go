@@ -2,11 +2,53 @@ package v2
 
 import (
 	"os"
+	"time"
 
-	"code.cloudfoundry.org/cli/cf/cmd"
+	"code.cloudfoundry.org/cli/actor/configaction"
+	"code.cloudfoundry.org/cli/actor/v2action"
+	"code.cloudfoundry.org/cli/api/cloudcontroller/ccv2"
+	"code.cloudfoundry.org/cli/api/uaa"
+	oldCmd "code.cloudfoundry.org/cli/cf/cmd"
 	"code.cloudfoundry.org/cli/command"
+	"code.cloudfoundry.org/cli/command/v2/shared"
 )
 
+// maxLoginAttempts is the number of times the user is asked for their
+// password when it is not passed as a flag.
+const maxLoginAttempts = 3
+
+// browserLoginTimeout is how long the CLI waits for the user to authorize it
+// in a browser.
+const browserLoginTimeout = 5 * time.Minute
+
+//go:generate counterfeiter . LoginActor
+
+type LoginActor interface {
+	Authenticate(config v2action.Config, username string, password string) error
+	AuthenticatePasscode(config v2action.Config, passcode string) error
+	AuthenticateWithBrowser(config v2action.Config, authorizationEndpoint string, timeout time.Duration, openURL func(authorizationURL string) error) error
+	GetOrganizationByName(name string) (v2action.Organization, v2action.Warnings, error)
+	GetSpaceByOrganizationAndName(orgGUID string, name string) (v2action.Space, v2action.Warnings, error)
+}
+
+//go:generate counterfeiter . LoginActorMaker
+
+// LoginActorMaker creates the LoginActor once the API endpoint is known,
+// since the endpoint can be set by the login command itself.
+type LoginActorMaker interface {
+	NewActor(config command.Config, ui command.UI) (LoginActor, error)
+}
+
+type loginActorMaker struct{}
+
+func (loginActorMaker) NewActor(config command.Config, ui command.UI) (LoginActor, error) {
+	ccClient, uaaClient, err := shared.NewClients(config, ui)
+	if err != nil {
+		return nil, err
+	}
+	return v2action.NewActor(ccClient, uaaClient), nil
+}
+
 type LoginCommand struct {
 	APIEndpoint       string      `short:"a" description:"API endpoint (e.g. https://api.example.com)"`
 	Organization      string      `short:"o" description:"Org"`
@@ -14,16 +56,218 @@ type LoginCommand struct {
 	Space             string      `short:"s" description:"Space"`
 	SkipSSLValidation bool        `long:"skip-ssl-validation" description:"Skip verification of the API endpoint. Not recommended!"`
 	SSO               bool        `long:"sso" description:"Use a one-time password to login"`
+	SSOPasscode       string      `long:"sso-passcode" description:"One-time password"`
+	Browser           bool        `long:"browser" description:"Authorize the CLI in a browser"`
 	Username          string      `short:"u" description:"Username"`
-	usage             interface{} `usage:"CF_NAME login [-a API_URL] [-u USERNAME] [-p PASSWORD] [-o ORG] [-s SPACE]\n\nWARNING:\n   Providing your password as a command line option is highly discouraged\n   Your password may be visible to others and may be recorded in your shell history\n\nEXAMPLES:\n   CF_NAME login (omit username and password to login interactively -- CF_NAME will prompt for both)\n   CF_NAME login -u name@example.com -p pa55woRD (specify username and password as arguments)\n   CF_NAME login -u name@example.com -p \"my password\" (use quotes for passwords with a space)\n   CF_NAME login -u name@example.com -p \"\\\"password\\\"\" (escape quotes if used in password)\n   CF_NAME login --sso (CF_NAME will provide a url to obtain a one-time password to login)"`
+	usage             interface{} `usage:"CF_NAME login [-a API_URL] [-u USERNAME] [-p PASSWORD] [-o ORG] [-s SPACE] [--sso | --sso-passcode PASSCODE | --browser]\n\nWARNING:\n   Providing your password as a command line option is highly discouraged\n   Your password may be visible to others and may be recorded in your shell history\n\nEXAMPLES:\n   CF_NAME login (omit username and password to login interactively -- CF_NAME will prompt for both)\n   CF_NAME login -u name@example.com -p pa55woRD (specify username and password as arguments)\n   CF_NAME login -u name@example.com -p \"my password\" (use quotes for passwords with a space)\n   CF_NAME login -u name@example.com -p \"\\\"password\\\"\" (escape quotes if used in password)\n   CF_NAME login --sso (CF_NAME will provide a url to obtain a one-time password to login)\n   CF_NAME login --browser (CF_NAME will provide a url to authorize the CLI in a browser)"`
 	relatedCommands   interface{} `related_commands:"api, auth, target"`
+
+	UI         command.UI
+	APIActor   APIConfigActor
+	ActorMaker LoginActorMaker
+	Config     command.Config
+}
+
+func (cmd *LoginCommand) Setup(config command.Config, ui command.UI) error {
+	cmd.APIActor = configaction.NewActor(config, ccv2.NewClient(config.BinaryName(), config.BinaryVersion()))
+	cmd.ActorMaker = loginActorMaker{}
+	cmd.UI = ui
+	cmd.Config = config
+	return nil
+}
+
+func (cmd *LoginCommand) Execute(args []string) error {
+	if cmd.Config.Experimental() == false {
+		oldCmd.Main(os.Getenv("CF_TRACE"), os.Args)
+		return nil
+	}
+
+	cmd.UI.DisplayText(command.ExperimentalWarning)
+	cmd.UI.DisplayNewline()
+
+	if cmd.SSO && cmd.Browser || cmd.SSOPasscode != "" && (cmd.SSO || cmd.Browser) {
+		return command.ArgumentCombinationError{
+			Args: []string{"--sso", "--sso-passcode", "--browser"},
+		}
+	}
+
+	err := cmd.setAPI()
+	if err != nil {
+		return err
+	}
+
+	actor, err := cmd.ActorMaker.NewActor(cmd.Config, cmd.UI)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case cmd.SSO || cmd.SSOPasscode != "":
+		err = cmd.authenticatePasscode(actor)
+	case cmd.Browser:
+		err = cmd.authenticateWithBrowser(actor)
+	default:
+		err = cmd.authenticate(actor)
+	}
+	if err != nil {
+		return shared.HandleError(err)
+	}
+
+	cmd.UI.DisplayOK()
+	cmd.UI.DisplayNewline()
+
+	err = cmd.setTarget(actor)
+	if err != nil {
+		return shared.HandleError(err)
+	}
+
+	user, err := cmd.Config.CurrentUser()
+	if err != nil {
+		return err
+	}
+	cmd.UI.DisplayKeyValueTable("", [][]string{
+		{"API endpoint:", cmd.Config.Target()},
+		{"User:", user.Name},
+		{"Org:", cmd.Config.TargetedOrganization().Name},
+		{"Space:", cmd.Config.TargetedSpace().Name},
+	}, 3)
+
+	if cmd.Config.TargetedSpace().GUID == "" {
+		cmd.UI.DisplayNewline()
+		cmd.UI.DisplayText("Use '{{.Command}}' to view or set your target org and space.", map[string]interface{}{
+			"Command": cmd.Config.BinaryName() + " target",
+		})
+	}
+	return nil
 }
 
-func (_ LoginCommand) Setup(config command.Config, ui command.UI) error {
+// setTarget targets the org and space given with -o and -s. A space can only
+// be targeted along with its org, as logging in clears the previous target.
+func (cmd *LoginCommand) setTarget(actor LoginActor) error {
+	if cmd.Organization == "" {
+		if cmd.Space != "" {
+			cmd.UI.DisplayWarning("Space {{.SpaceName}} was not targeted as no org was given.", map[string]interface{}{
+				"SpaceName": cmd.Space,
+			})
+			cmd.UI.DisplayNewline()
+		}
+		return nil
+	}
+
+	org, warnings, err := actor.GetOrganizationByName(cmd.Organization)
+	cmd.UI.DisplayWarnings(warnings)
+	if err != nil {
+		return err
+	}
+	cmd.Config.SetOrganizationInformation(org.GUID, org.Name)
+	cmd.UI.DisplayText("Targeted org {{.OrgName}}", map[string]interface{}{
+		"OrgName": org.Name,
+	})
+	cmd.UI.DisplayNewline()
+
+	if cmd.Space == "" {
+		return nil
+	}
+
+	space, warnings, err := actor.GetSpaceByOrganizationAndName(org.GUID, cmd.Space)
+	cmd.UI.DisplayWarnings(warnings)
+	if err != nil {
+		return err
+	}
+	cmd.Config.SetSpaceInformation(space.GUID, space.Name, space.AllowSSH)
+	cmd.UI.DisplayText("Targeted space {{.SpaceName}}", map[string]interface{}{
+		"SpaceName": space.Name,
+	})
+	cmd.UI.DisplayNewline()
 	return nil
 }
 
-func (_ LoginCommand) Execute(args []string) error {
-	cmd.Main(os.Getenv("CF_TRACE"), os.Args)
+func (cmd *LoginCommand) setAPI() error {
+	apiEndpoint := cmd.APIEndpoint
+	if apiEndpoint == "" && cmd.Config.Target() == "" {
+		var err error
+		apiEndpoint, err = cmd.UI.DisplayTextPrompt("API endpoint")
+		if err != nil {
+			return err
+		}
+	}
+
+	if apiEndpoint != "" {
+		_, err := cmd.APIActor.SetTarget(configaction.TargetSettings{
+			URL:               processURL(apiEndpoint),
+			SkipSSLValidation: cmd.SkipSSLValidation,
+			DialTimeout:       cmd.Config.DialTimeout(),
+		})
+		if err != nil {
+			return shared.HandleError(err)
+		}
+	}
+
+	cmd.UI.DisplayText("API endpoint: {{.Endpoint}}", map[string]interface{}{
+		"Endpoint": cmd.Config.Target(),
+	})
+	cmd.UI.DisplayNewline()
 	return nil
 }
+
+// authenticate logs in with a username and password. When the password is
+// prompted for, the user gets maxLoginAttempts tries to enter it correctly.
+func (cmd *LoginCommand) authenticate(actor LoginActor) error {
+	username := cmd.Username
+	if username == "" {
+		var err error
+		username, err = cmd.UI.DisplayTextPrompt("Email")
+		if err != nil {
+			return err
+		}
+	}
+
+	if cmd.Password != "" {
+		cmd.UI.DisplayText("Authenticating...")
+		return actor.Authenticate(cmd.Config, username, cmd.Password)
+	}
+
+	var err error
+	for attempt := 0; attempt < maxLoginAttempts; attempt++ {
+		var password string
+		password, err = cmd.UI.DisplayPasswordPrompt("Password")
+		if err != nil {
+			return err
+		}
+
+		cmd.UI.DisplayText("Authenticating...")
+		err = actor.Authenticate(cmd.Config, username, password)
+		if _, ok := err.(uaa.BadCredentialsError); !ok {
+			return err
+		}
+		cmd.UI.DisplayWarning(command.BadCredentialsError{}.Error())
+		cmd.UI.DisplayNewline()
+	}
+	return err
+}
+
+func (cmd *LoginCommand) authenticatePasscode(actor LoginActor) error {
+	passcode := cmd.SSOPasscode
+	if passcode == "" {
+		var err error
+		passcode, err = cmd.UI.DisplayPasswordPrompt(cmd.UI.TranslateText("Temporary Authentication Code ( Get one at {{.URL}}/passcode )", map[string]interface{}{
+			"URL": cmd.Config.AuthorizationEndpoint(),
+		}))
+		if err != nil {
+			return err
+		}
+	}
+
+	cmd.UI.DisplayText("Authenticating...")
+	return actor.AuthenticatePasscode(cmd.Config, passcode)
+}
+
+func (cmd *LoginCommand) authenticateWithBrowser(actor LoginActor) error {
+	return actor.AuthenticateWithBrowser(cmd.Config, cmd.Config.AuthorizationEndpoint(), browserLoginTimeout, func(authorizationURL string) error {
+		cmd.UI.DisplayText("Open the following URL in a browser to log in:")
+		cmd.UI.DisplayText(authorizationURL)
+		cmd.UI.DisplayNewline()
+		cmd.UI.DisplayText("Waiting for authorization...")
+		return nil
+	})
+}
@@ -0,0 +1,319 @@
+package v2_test
+
+import (
+	"errors"
+	"time"
+
+	"code.cloudfoundry.org/cli/actor/configaction"
+	"code.cloudfoundry.org/cli/actor/v2action"
+	"code.cloudfoundry.org/cli/api/uaa"
+	"code.cloudfoundry.org/cli/command"
+	"code.cloudfoundry.org/cli/command/commandfakes"
+	. "code.cloudfoundry.org/cli/command/v2"
+	"code.cloudfoundry.org/cli/command/v2/v2fakes"
+	"code.cloudfoundry.org/cli/util/configv3"
+	"code.cloudfoundry.org/cli/util/ui"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+)
+
+var _ = Describe("Login Command", func() {
+	var (
+		cmd            LoginCommand
+		fakeUI         *ui.UI
+		input          *Buffer
+		fakeAPIActor   *v2fakes.FakeAPIConfigActor
+		fakeActor      *v2fakes.FakeLoginActor
+		fakeActorMaker *v2fakes.FakeLoginActorMaker
+		fakeConfig     *commandfakes.FakeConfig
+		executeErr     error
+	)
+
+	BeforeEach(func() {
+		input = NewBuffer()
+		out := NewBuffer()
+		fakeUI = ui.NewTestUI(input, out, out)
+		fakeAPIActor = new(v2fakes.FakeAPIConfigActor)
+		fakeActor = new(v2fakes.FakeLoginActor)
+		fakeActorMaker = new(v2fakes.FakeLoginActorMaker)
+		fakeActorMaker.NewActorReturns(fakeActor, nil)
+		fakeConfig = new(commandfakes.FakeConfig)
+		fakeConfig.ExperimentalReturns(true)
+		fakeConfig.BinaryNameReturns("faceman")
+		fakeConfig.TargetReturns("https://api.example.com")
+		fakeConfig.AuthorizationEndpointReturns("https://login.example.com")
+		fakeConfig.CurrentUserReturns(configv3.User{Name: "some-user"}, nil)
+
+		cmd = LoginCommand{
+			UI:         fakeUI,
+			APIActor:   fakeAPIActor,
+			ActorMaker: fakeActorMaker,
+			Config:     fakeConfig,
+		}
+	})
+
+	JustBeforeEach(func() {
+		executeErr = cmd.Execute(nil)
+	})
+
+	It("displays the experimental warning message", func() {
+		Expect(fakeUI.Out).To(Say(command.ExperimentalWarning))
+	})
+
+	Context("when the API endpoint is passed", func() {
+		BeforeEach(func() {
+			cmd.APIEndpoint = "api.example.com"
+			cmd.SkipSSLValidation = true
+			cmd.Username = "some-user"
+			cmd.Password = "some-password"
+		})
+
+		It("targets the API before authenticating", func() {
+			Expect(executeErr).ToNot(HaveOccurred())
+
+			Expect(fakeAPIActor.SetTargetCallCount()).To(Equal(1))
+			Expect(fakeAPIActor.SetTargetArgsForCall(0)).To(Equal(configaction.TargetSettings{
+				URL:               "https://api.example.com",
+				SkipSSLValidation: true,
+			}))
+			Expect(fakeActorMaker.NewActorCallCount()).To(Equal(1))
+		})
+
+		Context("when targeting the API fails", func() {
+			var expectedErr error
+
+			BeforeEach(func() {
+				expectedErr = errors.New("some-error")
+				fakeAPIActor.SetTargetReturns(nil, expectedErr)
+			})
+
+			It("returns the error without authenticating", func() {
+				Expect(executeErr).To(MatchError(expectedErr))
+				Expect(fakeActorMaker.NewActorCallCount()).To(Equal(0))
+			})
+		})
+	})
+
+	Context("when no API endpoint is set", func() {
+		BeforeEach(func() {
+			fakeConfig.TargetReturns("")
+			cmd.Username = "some-user"
+			cmd.Password = "some-password"
+			input.Write([]byte("api.example.com\n"))
+		})
+
+		It("prompts for the API endpoint", func() {
+			Expect(executeErr).ToNot(HaveOccurred())
+			Expect(fakeUI.Out).To(Say("API endpoint>>"))
+			Expect(fakeAPIActor.SetTargetArgsForCall(0).URL).To(Equal("https://api.example.com"))
+		})
+	})
+
+	Context("when the username and password are passed", func() {
+		BeforeEach(func() {
+			cmd.Username = "some-user"
+			cmd.Password = "some-password"
+		})
+
+		It("authenticates the user and displays the target", func() {
+			Expect(executeErr).ToNot(HaveOccurred())
+
+			Expect(fakeAPIActor.SetTargetCallCount()).To(Equal(0))
+			Expect(fakeActor.AuthenticateCallCount()).To(Equal(1))
+			_, username, password := fakeActor.AuthenticateArgsForCall(0)
+			Expect(username).To(Equal("some-user"))
+			Expect(password).To(Equal("some-password"))
+
+			Expect(fakeUI.Out).To(Say("API endpoint: https://api.example.com"))
+			Expect(fakeUI.Out).To(Say("Authenticating..."))
+			Expect(fakeUI.Out).To(Say("OK"))
+			Expect(fakeUI.Out).To(Say(`API endpoint:\s+https://api.example.com`))
+			Expect(fakeUI.Out).To(Say(`User:\s+some-user`))
+			Expect(fakeUI.Out).To(Say("Use 'faceman target' to view or set your target org and space."))
+		})
+
+		Context("when the credentials are rejected", func() {
+			BeforeEach(func() {
+				fakeActor.AuthenticateReturns(uaa.BadCredentialsError{})
+			})
+
+			It("does not retry", func() {
+				Expect(executeErr).To(MatchError(command.BadCredentialsError{}))
+				Expect(fakeActor.AuthenticateCallCount()).To(Equal(1))
+			})
+		})
+
+		Context("when an org and space are passed", func() {
+			BeforeEach(func() {
+				cmd.Organization = "some-org"
+				cmd.Space = "some-space"
+				fakeActor.GetOrganizationByNameReturns(v2action.Organization{GUID: "some-org-guid", Name: "some-org"}, v2action.Warnings{"org-warning"}, nil)
+				fakeActor.GetSpaceByOrganizationAndNameReturns(v2action.Space{GUID: "some-space-guid", Name: "some-space", AllowSSH: true}, v2action.Warnings{"space-warning"}, nil)
+				fakeConfig.TargetedOrganizationReturns(configv3.Organization{GUID: "some-org-guid", Name: "some-org"})
+				fakeConfig.TargetedSpaceReturns(configv3.Space{GUID: "some-space-guid", Name: "some-space"})
+			})
+
+			It("targets the org and space after authenticating", func() {
+				Expect(executeErr).ToNot(HaveOccurred())
+
+				Expect(fakeActor.GetOrganizationByNameArgsForCall(0)).To(Equal("some-org"))
+				Expect(fakeConfig.SetOrganizationInformationCallCount()).To(Equal(1))
+				guid, name := fakeConfig.SetOrganizationInformationArgsForCall(0)
+				Expect(guid).To(Equal("some-org-guid"))
+				Expect(name).To(Equal("some-org"))
+
+				orgGUID, spaceName := fakeActor.GetSpaceByOrganizationAndNameArgsForCall(0)
+				Expect(orgGUID).To(Equal("some-org-guid"))
+				Expect(spaceName).To(Equal("some-space"))
+				Expect(fakeConfig.SetSpaceInformationCallCount()).To(Equal(1))
+				guid, name, allowSSH := fakeConfig.SetSpaceInformationArgsForCall(0)
+				Expect(guid).To(Equal("some-space-guid"))
+				Expect(name).To(Equal("some-space"))
+				Expect(allowSSH).To(BeTrue())
+
+				Expect(fakeUI.Err).To(Say("org-warning"))
+				Expect(fakeUI.Out).To(Say("Targeted org some-org"))
+				Expect(fakeUI.Err).To(Say("space-warning"))
+				Expect(fakeUI.Out).To(Say("Targeted space some-space"))
+				Expect(fakeUI.Out).To(Say(`Org:\s+some-org`))
+				Expect(fakeUI.Out).To(Say(`Space:\s+some-space`))
+				Expect(fakeUI.Out).ToNot(Say("Use 'faceman target'"))
+			})
+
+			Context("when the org cannot be found", func() {
+				BeforeEach(func() {
+					fakeActor.GetOrganizationByNameReturns(v2action.Organization{}, nil, v2action.OrganizationNotFoundError{Name: "some-org"})
+				})
+
+				It("returns an OrganizationNotFoundError", func() {
+					Expect(executeErr).To(MatchError(command.OrganizationNotFoundError{Name: "some-org"}))
+					Expect(fakeConfig.SetOrganizationInformationCallCount()).To(Equal(0))
+					Expect(fakeActor.GetSpaceByOrganizationAndNameCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when the space cannot be found", func() {
+				BeforeEach(func() {
+					fakeActor.GetSpaceByOrganizationAndNameReturns(v2action.Space{}, nil, v2action.SpaceNotFoundError{Name: "some-space"})
+				})
+
+				It("returns a SpaceNotFoundError with the org targeted", func() {
+					Expect(executeErr).To(MatchError(command.SpaceNotFoundError{Name: "some-space"}))
+					Expect(fakeConfig.SetOrganizationInformationCallCount()).To(Equal(1))
+					Expect(fakeConfig.SetSpaceInformationCallCount()).To(Equal(0))
+				})
+			})
+		})
+
+		Context("when only a space is passed", func() {
+			BeforeEach(func() {
+				cmd.Space = "some-space"
+			})
+
+			It("warns that the space was not targeted", func() {
+				Expect(executeErr).ToNot(HaveOccurred())
+				Expect(fakeActor.GetSpaceByOrganizationAndNameCallCount()).To(Equal(0))
+				Expect(fakeUI.Err).To(Say("Space some-space was not targeted as no org was given."))
+			})
+		})
+	})
+
+	Context("when the username and password are not passed", func() {
+		BeforeEach(func() {
+			input.Write([]byte("some-user\nbad-password\nsome-password\n"))
+			fakeActor.AuthenticateStub = func(_ v2action.Config, _ string, password string) error {
+				if password == "bad-password" {
+					return uaa.BadCredentialsError{}
+				}
+				return nil
+			}
+		})
+
+		It("prompts for them and retries rejected passwords", func() {
+			Expect(executeErr).ToNot(HaveOccurred())
+			Expect(fakeUI.Out).To(Say("Email>>"))
+			Expect(fakeUI.Out).To(Say("Password>>"))
+			Expect(fakeUI.Out).To(Say("Credentials were rejected, please try again."))
+			Expect(fakeUI.Out).To(Say("Password>>"))
+
+			Expect(fakeActor.AuthenticateCallCount()).To(Equal(2))
+			_, username, password := fakeActor.AuthenticateArgsForCall(1)
+			Expect(username).To(Equal("some-user"))
+			Expect(password).To(Equal("some-password"))
+		})
+	})
+
+	Context("when the password is rejected too many times", func() {
+		BeforeEach(func() {
+			input.Write([]byte("some-user\nbad-1\nbad-2\nbad-3\n"))
+			fakeActor.AuthenticateReturns(uaa.BadCredentialsError{})
+		})
+
+		It("gives up after 3 attempts", func() {
+			Expect(executeErr).To(MatchError(command.BadCredentialsError{}))
+			Expect(fakeActor.AuthenticateCallCount()).To(Equal(3))
+		})
+	})
+
+	Context("when --sso is passed", func() {
+		BeforeEach(func() {
+			cmd.SSO = true
+			input.Write([]byte("some-passcode\n"))
+		})
+
+		It("prompts for a passcode and authenticates with it", func() {
+			Expect(executeErr).ToNot(HaveOccurred())
+			Expect(fakeUI.Out).To(Say(`Temporary Authentication Code \( Get one at https://login.example.com/passcode \)`))
+
+			_, passcode := fakeActor.AuthenticatePasscodeArgsForCall(0)
+			Expect(passcode).To(Equal("some-passcode"))
+		})
+	})
+
+	Context("when --sso-passcode is passed", func() {
+		BeforeEach(func() {
+			cmd.SSOPasscode = "some-passcode"
+		})
+
+		It("authenticates with the passcode", func() {
+			Expect(executeErr).ToNot(HaveOccurred())
+			_, passcode := fakeActor.AuthenticatePasscodeArgsForCall(0)
+			Expect(passcode).To(Equal("some-passcode"))
+		})
+	})
+
+	Context("when --browser is passed", func() {
+		BeforeEach(func() {
+			cmd.Browser = true
+			fakeActor.AuthenticateWithBrowserStub = func(_ v2action.Config, _ string, _ time.Duration, openURL func(string) error) error {
+				return openURL("https://login.example.com/oauth/authorize?some-query")
+			}
+		})
+
+		It("displays the URL to authorize the CLI at", func() {
+			Expect(executeErr).ToNot(HaveOccurred())
+
+			_, endpoint, _, _ := fakeActor.AuthenticateWithBrowserArgsForCall(0)
+			Expect(endpoint).To(Equal("https://login.example.com"))
+			Expect(fakeUI.Out).To(Say("Open the following URL in a browser to log in:"))
+			Expect(fakeUI.Out).To(Say(`https://login.example.com/oauth/authorize\?some-query`))
+			Expect(fakeUI.Out).To(Say("OK"))
+		})
+	})
+
+	Context("when conflicting authentication flags are passed", func() {
+		BeforeEach(func() {
+			cmd.SSO = true
+			cmd.Browser = true
+		})
+
+		It("returns an ArgumentCombinationError", func() {
+			Expect(executeErr).To(MatchError(command.ArgumentCombinationError{
+				Args: []string{"--sso", "--sso-passcode", "--browser"},
+			}))
+			Expect(fakeActorMaker.NewActorCallCount()).To(Equal(0))
+		})
+	})
+})
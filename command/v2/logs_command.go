@@ -9,10 +9,19 @@ import (
 )
 
 type LogsCommand struct {
-	RequiredArgs    flag.AppName `positional-args:"yes"`
-	Recent          bool         `long:"recent" description:"Dump recent logs instead of tailing"`
-	usage           interface{}  `usage:"CF_NAME logs APP_NAME"`
-	relatedCommands interface{}  `related_commands:"app, apps, ssh"`
+	OptionalArgs    flag.AppNames `positional-args:"yes"`
+	Recent          bool          `long:"recent" description:"Dump recent logs instead of tailing"`
+	Space           bool          `long:"space" description:"Show the logs of all apps in the targeted space"`
+	Source          []string      `long:"source" description:"Only show logs from this source type, such as APP, RTR, STG, CELL or API (can be given more than once)"`
+	Instance        string        `long:"instance" description:"Only show logs from the app instance with this index"`
+	Stdout          bool          `long:"stdout" description:"Only show logs written to stdout"`
+	Stderr          bool          `long:"stderr" description:"Only show logs written to stderr"`
+	Match           string        `long:"match" description:"Only show logs whose message matches this regular expression"`
+	Since           string        `long:"since" description:"Only show recent logs written after this time, given as RFC 3339 or as a duration ago such as 30m"`
+	Until           string        `long:"until" description:"Only show recent logs written before this time, given as RFC 3339 or as a duration ago such as 30m"`
+	Output          string        `long:"output" description:"Format of the logs: text, or json to show each log as a line of JSON with timestamp, app, source, instance, type and message fields"`
+	usage           interface{}   `usage:"CF_NAME logs APP_NAME [APP_NAME...] [--recent] [--since TIME] [--until TIME] [--source SOURCE] [--instance INDEX] [--stdout | --stderr] [--match REGEX] [--output FORMAT]\n   CF_NAME logs --space [--recent] ..."`
+	relatedCommands interface{}   `related_commands:"app, apps, ssh"`
 }
 
 func (_ LogsCommand) Setup(config command.Config, ui command.UI) error {
@@ -2,21 +2,119 @@ package v2
 
 import (
 	"os"
+	"strings"
+	"time"
 
-	"code.cloudfoundry.org/cli/cf/cmd"
+	"code.cloudfoundry.org/cli/actor/v2action"
+	oldCmd "code.cloudfoundry.org/cli/cf/cmd"
 	"code.cloudfoundry.org/cli/command"
+	"code.cloudfoundry.org/cli/command/v2/shared"
 )
 
+//go:generate counterfeiter . OauthTokenActor
+
+type OauthTokenActor interface {
+	RefreshAccessToken() (string, error)
+	GetAccessTokenInfo(accessToken string) (v2action.AccessTokenInfo, error)
+}
+
 type OauthTokenCommand struct {
-	usage           interface{} `usage:"CF_NAME oauth-token"`
+	Decode          bool        `long:"decode" description:"Display the claims, scopes and remaining lifetime of the current token instead of refreshing it"`
+	usage           interface{} `usage:"CF_NAME oauth-token [--decode]"`
 	relatedCommands interface{} `related_commands:"curl"`
+
+	UI     command.UI
+	Actor  OauthTokenActor
+	Config command.Config
 }
 
-func (_ OauthTokenCommand) Setup(config command.Config, ui command.UI) error {
+func (cmd *OauthTokenCommand) Setup(config command.Config, ui command.UI) error {
+	cmd.UI = ui
+	cmd.Config = config
+
+	ccClient, uaaClient, err := shared.NewClients(config, ui)
+	if err != nil {
+		return err
+	}
+	cmd.Actor = v2action.NewActor(ccClient, uaaClient)
+
 	return nil
 }
 
-func (_ OauthTokenCommand) Execute(args []string) error {
-	cmd.Main(os.Getenv("CF_TRACE"), os.Args)
+// Execute runs the legacy command unless the user is in experimental mode or
+// asks for the decoded token, which only this implementation supports.
+func (cmd *OauthTokenCommand) Execute(args []string) error {
+	if cmd.Config.Experimental() == false && !cmd.Decode {
+		oldCmd.Main(os.Getenv("CF_TRACE"), os.Args)
+		return nil
+	}
+
+	if cmd.Config.Experimental() {
+		cmd.UI.DisplayText(command.ExperimentalWarning)
+		cmd.UI.DisplayNewline()
+	}
+
+	err := command.CheckTarget(cmd.Config, false, false)
+	if err != nil {
+		return err
+	}
+
+	if cmd.Decode {
+		return cmd.displayTokenInfo()
+	}
+
+	token, err := cmd.Actor.RefreshAccessToken()
+	if err != nil {
+		return shared.HandleError(err)
+	}
+
+	cmd.UI.DisplayText("{{.Token}}", map[string]interface{}{
+		"Token": token,
+	})
+	return nil
+}
+
+func (cmd *OauthTokenCommand) displayTokenInfo() error {
+	info, err := cmd.Actor.GetAccessTokenInfo(cmd.Config.AccessToken())
+	if err != nil {
+		return shared.HandleError(err)
+	}
+
+	expiresAt := ""
+	lifetime := cmd.UI.TranslateText("does not expire")
+	var rawExpiresAt, rawLifetime interface{}
+	if !info.ExpiresAt.IsZero() {
+		expiresAt = info.ExpiresAt.Format(time.RFC1123)
+		rawExpiresAt = info.ExpiresAt
+		remaining := info.ExpiresAt.Sub(time.Now())
+		if remaining > 0 {
+			lifetime = (remaining / time.Second * time.Second).String()
+			rawLifetime = int64(remaining / time.Second)
+		} else {
+			lifetime = cmd.UI.TranslateText("expired")
+			rawLifetime = 0
+		}
+	}
+
+	cmd.UI.DisplayKeyValueTable("", [][]string{
+		{"user:", info.UserName},
+		{"client:", info.ClientID},
+		{"grant type:", info.GrantType},
+		{"issuer:", info.Issuer},
+		{"issued at:", info.IssuedAt.Format(time.RFC1123)},
+		{"expires at:", expiresAt},
+		{"remaining lifetime:", lifetime},
+		{"scopes:", strings.Join(info.Scopes, ", ")},
+	}, 3,
+		info.UserName,
+		info.ClientID,
+		info.GrantType,
+		info.Issuer,
+		info.IssuedAt,
+		rawExpiresAt,
+		rawLifetime,
+		info.Scopes,
+	)
+
 	return nil
 }
@@ -0,0 +1,145 @@
+package v2_test
+
+import (
+	"errors"
+	"time"
+
+	"code.cloudfoundry.org/cli/actor/v2action"
+	"code.cloudfoundry.org/cli/command"
+	"code.cloudfoundry.org/cli/command/commandfakes"
+	. "code.cloudfoundry.org/cli/command/v2"
+	"code.cloudfoundry.org/cli/command/v2/v2fakes"
+	"code.cloudfoundry.org/cli/util/ui"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+)
+
+var _ = Describe("OauthToken Command", func() {
+	var (
+		cmd        OauthTokenCommand
+		fakeUI     *ui.UI
+		fakeActor  *v2fakes.FakeOauthTokenActor
+		fakeConfig *commandfakes.FakeConfig
+		executeErr error
+	)
+
+	BeforeEach(func() {
+		out := NewBuffer()
+		fakeUI = ui.NewTestUI(nil, out, out)
+		fakeActor = new(v2fakes.FakeOauthTokenActor)
+		fakeConfig = new(commandfakes.FakeConfig)
+		fakeConfig.ExperimentalReturns(true)
+		fakeConfig.BinaryNameReturns("faceman")
+		fakeConfig.AccessTokenReturns("bearer some-access-token")
+
+		cmd = OauthTokenCommand{
+			UI:     fakeUI,
+			Actor:  fakeActor,
+			Config: fakeConfig,
+		}
+	})
+
+	JustBeforeEach(func() {
+		executeErr = cmd.Execute(nil)
+	})
+
+	It("displays the experimental warning message", func() {
+		Expect(fakeUI.Out).To(Say(command.ExperimentalWarning))
+	})
+
+	Context("when the user is not logged in", func() {
+		BeforeEach(func() {
+			fakeConfig.AccessTokenReturns("")
+		})
+
+		It("returns a NotLoggedInError", func() {
+			Expect(executeErr).To(MatchError(command.NotLoggedInError{BinaryName: "faceman"}))
+			Expect(fakeActor.RefreshAccessTokenCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when the token is refreshed", func() {
+		BeforeEach(func() {
+			fakeActor.RefreshAccessTokenReturns("bearer some-new-token", nil)
+		})
+
+		It("displays the new token", func() {
+			Expect(executeErr).ToNot(HaveOccurred())
+			Expect(fakeUI.Out).To(Say("bearer some-new-token"))
+		})
+	})
+
+	Context("when refreshing the token fails", func() {
+		var expectedErr error
+
+		BeforeEach(func() {
+			expectedErr = errors.New("some-error")
+			fakeActor.RefreshAccessTokenReturns("", expectedErr)
+		})
+
+		It("returns the error", func() {
+			Expect(executeErr).To(MatchError(expectedErr))
+		})
+	})
+
+	Context("when --decode is passed", func() {
+		BeforeEach(func() {
+			cmd.Decode = true
+			fakeConfig.ExperimentalReturns(false)
+			fakeActor.GetAccessTokenInfoReturns(v2action.AccessTokenInfo{
+				UserName:  "some-user",
+				ClientID:  "cf",
+				GrantType: "password",
+				Issuer:    "https://uaa.example.com/oauth/token",
+				Scopes:    []string{"cloud_controller.read", "openid"},
+				IssuedAt:  time.Now().Add(-time.Minute),
+				ExpiresAt: time.Now().Add(10 * time.Minute),
+			}, nil)
+		})
+
+		It("displays the claims of the current token without refreshing it", func() {
+			Expect(executeErr).ToNot(HaveOccurred())
+			Expect(fakeUI.Out).ToNot(Say(command.ExperimentalWarning))
+
+			Expect(fakeActor.RefreshAccessTokenCallCount()).To(Equal(0))
+			Expect(fakeActor.GetAccessTokenInfoArgsForCall(0)).To(Equal("bearer some-access-token"))
+
+			Expect(fakeUI.Out).To(Say(`user:\s+some-user`))
+			Expect(fakeUI.Out).To(Say(`client:\s+cf`))
+			Expect(fakeUI.Out).To(Say(`grant type:\s+password`))
+			Expect(fakeUI.Out).To(Say(`issuer:\s+https://uaa.example.com/oauth/token`))
+			Expect(fakeUI.Out).To(Say(`issued at:\s+\w+`))
+			Expect(fakeUI.Out).To(Say(`expires at:\s+\w+`))
+			Expect(fakeUI.Out).To(Say(`remaining lifetime:\s+9m5\ds`))
+			Expect(fakeUI.Out).To(Say(`scopes:\s+cloud_controller.read, openid`))
+		})
+
+		Context("when the token has expired", func() {
+			BeforeEach(func() {
+				fakeActor.GetAccessTokenInfoReturns(v2action.AccessTokenInfo{
+					ExpiresAt: time.Now().Add(-time.Minute),
+				}, nil)
+			})
+
+			It("displays that the token has expired", func() {
+				Expect(executeErr).ToNot(HaveOccurred())
+				Expect(fakeUI.Out).To(Say(`remaining lifetime:\s+expired`))
+			})
+		})
+
+		Context("when the token cannot be decoded", func() {
+			var expectedErr error
+
+			BeforeEach(func() {
+				expectedErr = errors.New("some-error")
+				fakeActor.GetAccessTokenInfoReturns(v2action.AccessTokenInfo{}, expectedErr)
+			})
+
+			It("returns the error", func() {
+				Expect(executeErr).To(MatchError(expectedErr))
+			})
+		})
+	})
+})
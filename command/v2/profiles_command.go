@@ -0,0 +1,51 @@
+package v2
+
+import (
+	"code.cloudfoundry.org/cli/command"
+)
+
+type ProfilesCommand struct {
+	usage           interface{} `usage:"CF_NAME profiles"`
+	relatedCommands interface{} `related_commands:"create-profile, switch-profile"`
+
+	UI     command.UI
+	Config command.Config
+}
+
+func (cmd *ProfilesCommand) Setup(config command.Config, ui command.UI) error {
+	cmd.UI = ui
+	cmd.Config = config
+	return nil
+}
+
+func (cmd *ProfilesCommand) Execute(args []string) error {
+	cmd.UI.DisplayText("Getting profiles...")
+	cmd.UI.DisplayNewline()
+
+	table := [][]string{
+		{
+			"",
+			cmd.UI.TranslateText("name"),
+			cmd.UI.TranslateText("api endpoint"),
+			cmd.UI.TranslateText("user"),
+			cmd.UI.TranslateText("org"),
+			cmd.UI.TranslateText("space"),
+		},
+	}
+	for _, profile := range cmd.Config.Profiles() {
+		current := ""
+		if profile.Current {
+			current = "*"
+		}
+		table = append(table, []string{
+			current,
+			profile.Name,
+			profile.Target,
+			profile.User,
+			profile.Organization,
+			profile.Space,
+		})
+	}
+
+	return cmd.UI.DisplayTable("", table, 3)
+}
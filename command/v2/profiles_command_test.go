@@ -0,0 +1,49 @@
+package v2_test
+
+import (
+	"code.cloudfoundry.org/cli/command/commandfakes"
+	. "code.cloudfoundry.org/cli/command/v2"
+	"code.cloudfoundry.org/cli/util/configv3"
+	"code.cloudfoundry.org/cli/util/ui"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+)
+
+var _ = Describe("Profiles Command", func() {
+	var (
+		cmd        ProfilesCommand
+		fakeUI     *ui.UI
+		fakeConfig *commandfakes.FakeConfig
+		executeErr error
+	)
+
+	BeforeEach(func() {
+		out := NewBuffer()
+		fakeUI = ui.NewTestUI(nil, out, out)
+		fakeConfig = new(commandfakes.FakeConfig)
+		fakeConfig.ProfilesReturns([]configv3.ProfileInfo{
+			{Name: "default", Target: "https://api.default.com"},
+			{Name: "staging", Target: "https://api.staging.com", User: "some-user", Organization: "some-org", Space: "some-space", Current: true},
+		})
+
+		cmd = ProfilesCommand{
+			UI:     fakeUI,
+			Config: fakeConfig,
+		}
+	})
+
+	JustBeforeEach(func() {
+		executeErr = cmd.Execute(nil)
+	})
+
+	It("lists the profiles and marks the current one", func() {
+		Expect(executeErr).ToNot(HaveOccurred())
+
+		Expect(fakeUI.Out).To(Say("Getting profiles..."))
+		Expect(fakeUI.Out).To(Say(`name\s+api endpoint\s+user\s+org\s+space`))
+		Expect(fakeUI.Out).To(Say(`default\s+https://api.default.com`))
+		Expect(fakeUI.Out).To(Say(`\*\s+staging\s+https://api.staging.com\s+some-user\s+some-org\s+some-space`))
+	})
+})
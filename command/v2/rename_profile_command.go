@@ -0,0 +1,37 @@
+package v2
+
+import (
+	"code.cloudfoundry.org/cli/command"
+	"code.cloudfoundry.org/cli/command/flag"
+	"code.cloudfoundry.org/cli/command/v2/shared"
+)
+
+type RenameProfileCommand struct {
+	RequiredArgs    flag.RenameProfileArgs `positional-args:"yes"`
+	usage           interface{}            `usage:"CF_NAME rename-profile PROFILE_NAME NEW_PROFILE_NAME"`
+	relatedCommands interface{}            `related_commands:"profiles"`
+
+	UI     command.UI
+	Config command.Config
+}
+
+func (cmd *RenameProfileCommand) Setup(config command.Config, ui command.UI) error {
+	cmd.UI = ui
+	cmd.Config = config
+	return nil
+}
+
+func (cmd *RenameProfileCommand) Execute(args []string) error {
+	cmd.UI.DisplayText("Renaming profile {{.OldProfileName}} to {{.NewProfileName}}...", map[string]interface{}{
+		"OldProfileName": cmd.RequiredArgs.OldProfileName,
+		"NewProfileName": cmd.RequiredArgs.NewProfileName,
+	})
+
+	err := cmd.Config.RenameProfile(cmd.RequiredArgs.OldProfileName, cmd.RequiredArgs.NewProfileName)
+	if err != nil {
+		return shared.HandleError(err)
+	}
+
+	cmd.UI.DisplayOK()
+	return nil
+}
@@ -0,0 +1,59 @@
+package v2_test
+
+import (
+	"code.cloudfoundry.org/cli/command"
+	"code.cloudfoundry.org/cli/command/commandfakes"
+	. "code.cloudfoundry.org/cli/command/v2"
+	"code.cloudfoundry.org/cli/util/configv3"
+	"code.cloudfoundry.org/cli/util/ui"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+)
+
+var _ = Describe("Rename Profile Command", func() {
+	var (
+		cmd        RenameProfileCommand
+		fakeUI     *ui.UI
+		fakeConfig *commandfakes.FakeConfig
+		executeErr error
+	)
+
+	BeforeEach(func() {
+		out := NewBuffer()
+		fakeUI = ui.NewTestUI(nil, out, out)
+		fakeConfig = new(commandfakes.FakeConfig)
+
+		cmd = RenameProfileCommand{
+			UI:     fakeUI,
+			Config: fakeConfig,
+		}
+		cmd.RequiredArgs.OldProfileName = "staging"
+		cmd.RequiredArgs.NewProfileName = "qa"
+	})
+
+	JustBeforeEach(func() {
+		executeErr = cmd.Execute(nil)
+	})
+
+	It("renames the profile", func() {
+		Expect(executeErr).ToNot(HaveOccurred())
+
+		oldName, newName := fakeConfig.RenameProfileArgsForCall(0)
+		Expect(oldName).To(Equal("staging"))
+		Expect(newName).To(Equal("qa"))
+		Expect(fakeUI.Out).To(Say("Renaming profile staging to qa..."))
+		Expect(fakeUI.Out).To(Say("OK"))
+	})
+
+	Context("when the default profile is renamed", func() {
+		BeforeEach(func() {
+			fakeConfig.RenameProfileReturns(configv3.DefaultProfileError{})
+		})
+
+		It("returns a DefaultProfileError", func() {
+			Expect(executeErr).To(MatchError(command.DefaultProfileError{Name: "default"}))
+		})
+	})
+})
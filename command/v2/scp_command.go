@@ -0,0 +1,29 @@
+package v2
+
+import (
+	"os"
+
+	"code.cloudfoundry.org/cli/cf/cmd"
+	"code.cloudfoundry.org/cli/command"
+	"code.cloudfoundry.org/cli/command/flag"
+)
+
+type SCPCommand struct {
+	RequiredArgs       flag.CopySourceTarget `positional-args:"yes"`
+	AppInstanceIndex   int                   `long:"app-instance-index" short:"i" description:"Application instance index"`
+	Preserve           bool                  `short:"p" description:"Preserve the permissions and modification times of copied files"`
+	Quiet              bool                  `short:"q" description:"Do not show the progress of copied files"`
+	Recursive          bool                  `short:"r" description:"Recursively copy directories"`
+	SkipHostValidation bool                  `long:"skip-host-validation" short:"k" description:"Skip host key validation"`
+	usage              interface{}           `usage:"CF_NAME scp [-r] [-p] [-q] [-i app-instance-index] [--skip-host-validation] SOURCE TARGET\n\n   Exactly one of SOURCE and TARGET is a path on the app instance, written\n   as APP_NAME:PATH. A relative PATH is relative to the home directory of\n   the app's user.\n\nEXAMPLES:\n   CF_NAME scp my-app:app/logs/app.log .\n   CF_NAME scp -r ./config my-app:app/\n   CF_NAME scp -i 2 -p my-app:/tmp/heap.hprof ./heap.hprof"`
+	relatedCommands    interface{}           `related_commands:"ssh, ssh-code"`
+}
+
+func (_ SCPCommand) Setup(config command.Config, ui command.UI) error {
+	return nil
+}
+
+func (_ SCPCommand) Execute(args []string) error {
+	cmd.Main(os.Getenv("CF_TRACE"), os.Args)
+	return nil
+}
@@ -3,7 +3,9 @@ package shared
 import (
 	"code.cloudfoundry.org/cli/actor/v2action"
 	"code.cloudfoundry.org/cli/api/cloudcontroller"
+	"code.cloudfoundry.org/cli/api/uaa"
 	"code.cloudfoundry.org/cli/command"
+	"code.cloudfoundry.org/cli/util/configv3"
 )
 
 func HandleError(err error) error {
@@ -15,10 +17,30 @@ func HandleError(err error) error {
 	case cloudcontroller.APINotFoundError:
 		return command.APINotFoundError{URL: e.URL}
 
+	case uaa.RequestError:
+		return command.APIRequestError{Err: e.Err}
+	case uaa.UnverifiedServerError:
+		return command.InvalidSSLCertError{API: e.URL}
+	case uaa.BadCredentialsError:
+		return command.BadCredentialsError{}
+	case uaa.AuthorizationTimeoutError:
+		return command.AuthorizationTimeoutError{Timeout: e.Timeout}
+
 	case v2action.ApplicationNotFoundError:
 		return command.ApplicationNotFoundError{Name: e.Name}
 	case v2action.ServiceInstanceNotFoundError:
 		return command.ServiceInstanceNotFoundError{Name: e.Name}
+	case v2action.OrganizationNotFoundError:
+		return command.OrganizationNotFoundError{Name: e.Name}
+	case v2action.SpaceNotFoundError:
+		return command.SpaceNotFoundError{Name: e.Name}
+
+	case configv3.ProfileNotFoundError:
+		return command.ProfileNotFoundError{Name: e.Name}
+	case configv3.ProfileAlreadyExistsError:
+		return command.ProfileAlreadyExistsError{Name: e.Name}
+	case configv3.DefaultProfileError:
+		return command.DefaultProfileError{Name: configv3.DefaultProfileName}
 	}
 
 	return err
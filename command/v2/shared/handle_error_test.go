@@ -2,11 +2,14 @@ package shared_test
 
 import (
 	"errors"
+	"time"
 
 	"code.cloudfoundry.org/cli/actor/v2action"
 	"code.cloudfoundry.org/cli/api/cloudcontroller"
+	"code.cloudfoundry.org/cli/api/uaa"
 	"code.cloudfoundry.org/cli/command"
 	. "code.cloudfoundry.org/cli/command/v2/shared"
+	"code.cloudfoundry.org/cli/util/configv3"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/ginkgo/extensions/table"
@@ -40,6 +43,28 @@ var _ = Describe("HandleError", func() {
 			URL: "some-url",
 		}),
 
+		Entry("uaa.RequestError -> APIRequestError", uaa.RequestError{
+			Err: err,
+		}, command.APIRequestError{
+			Err: err,
+		}),
+
+		Entry("uaa.UnverifiedServerError -> InvalidSSLCertError", uaa.UnverifiedServerError{
+			URL: "some-url",
+		}, command.InvalidSSLCertError{
+			API: "some-url",
+		}),
+
+		Entry("uaa.BadCredentialsError -> BadCredentialsError", uaa.BadCredentialsError{
+			Message: "Bad credentials",
+		}, command.BadCredentialsError{}),
+
+		Entry("uaa.AuthorizationTimeoutError -> AuthorizationTimeoutError", uaa.AuthorizationTimeoutError{
+			Timeout: time.Minute,
+		}, command.AuthorizationTimeoutError{
+			Timeout: time.Minute,
+		}),
+
 		Entry("v2action.ApplicationNotFoundError -> ApplicationNotFoundError", v2action.ApplicationNotFoundError{
 			Name: "some-app",
 		}, command.ApplicationNotFoundError{
@@ -52,6 +77,35 @@ var _ = Describe("HandleError", func() {
 			Name: "some-service-instance",
 		}),
 
+		Entry("v2action.OrganizationNotFoundError -> OrganizationNotFoundError", v2action.OrganizationNotFoundError{
+			Name: "some-org",
+		}, command.OrganizationNotFoundError{
+			Name: "some-org",
+		}),
+
+		Entry("v2action.SpaceNotFoundError -> SpaceNotFoundError", v2action.SpaceNotFoundError{
+			Name: "some-space",
+		}, command.SpaceNotFoundError{
+			Name: "some-space",
+		}),
+
+		Entry("configv3.ProfileNotFoundError -> ProfileNotFoundError", configv3.ProfileNotFoundError{
+			Name: "some-profile",
+		}, command.ProfileNotFoundError{
+			Name: "some-profile",
+		}),
+
+		Entry("configv3.ProfileAlreadyExistsError -> ProfileAlreadyExistsError", configv3.ProfileAlreadyExistsError{
+			Name: "some-profile",
+		}, command.ProfileAlreadyExistsError{
+			Name: "some-profile",
+		}),
+
+		Entry("configv3.DefaultProfileError -> DefaultProfileError", configv3.DefaultProfileError{},
+			command.DefaultProfileError{
+				Name: "default",
+			}),
+
 		Entry("default case -> original error", err, err),
 	)
 })
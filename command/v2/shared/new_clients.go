@@ -5,6 +5,7 @@ import (
 	"code.cloudfoundry.org/cli/api/cloudcontroller/wrapper"
 	"code.cloudfoundry.org/cli/api/uaa"
 	"code.cloudfoundry.org/cli/command"
+	"code.cloudfoundry.org/cli/util/responsecache"
 )
 
 // NewClients creates a new V2 Cloud Controller client and UAA client using the
@@ -42,7 +43,13 @@ func NewClients(config command.Config, ui command.UI) (*ccv2.Client, *uaa.Client
 	}
 
 	ccClient.WrapConnection(wrapper.NewUAAAuthentication(uaaClient))
-	ccClient.WrapConnection(wrapper.NewRetryRequest(2))
+	ccClient.WrapConnection(wrapper.NewRetryRequest(wrapper.RetryPolicy{
+		MaxAttempts:    config.RetryMaxAttempts(),
+		MaxElapsedTime: config.RetryMaxElapsedTime(),
+	}))
+	if config.ResponseCacheEnabled() {
+		ccClient.WrapConnection(wrapper.NewResponseCache(config.ResponseCacheDir(), responsecache.DefaultTTL))
+	}
 
 	return ccClient, uaaClient, err
 }
@@ -14,12 +14,14 @@ type SSHCommand struct {
 	Command             string       `long:"command" short:"c" description:"Command to run. This flag can be defined more than once."`
 	DisablePseudoTTY    bool         `long:"disable-pseudo-tty" short:"T" description:"Disable pseudo-tty allocation"`
 	ForcePseudoTTY      bool         `long:"force-pseudo-tty" short:"F" description:"Force pseudo-tty allocation"`
+	DynamicPort         string       `short:"D" description:"Dynamic port forward specification, which starts a local SOCKS5 proxy. This flag can be defined more than once."`
 	LocalPort           string       `short:"L" description:"Local port forward specification. This flag can be defined more than once."`
+	RemotePort          string       `short:"R" description:"Remote port forward specification. This flag can be defined more than once."`
 	RemotePseudoTTY     bool         `long:"request-pseudo-tty" short:"t" description:"Request pseudo-tty allocation"`
 	SkipHostValidation  bool         `long:"skip-host-validation" short:"k" description:"Skip host key validation"`
 	SkipRemoteExecution bool         `long:"skip-remote-execution" short:"N" description:"Do not execute a remote command"`
-	usage               interface{}  `usage:"CF_NAME ssh APP_NAME [-i app-instance-index] [-c command] [-L [bind_address:]port:host:hostport] [--skip-host-validation] [--skip-remote-execution] [--request-pseudo-tty] [--force-pseudo-tty] [--disable-pseudo-tty]"`
-	relatedCommands     interface{}  `related_commands:"allow-space-ssh, enable-ssh, space-ssh-allowed, ssh-code, ssh-enabled"`
+	usage               interface{}  `usage:"CF_NAME ssh APP_NAME [-i app-instance-index] [-c command] [-L [bind_address:]port:host:hostport] [-R [bind_address:]port:host:hostport] [-D [bind_address:]port] [--skip-host-validation] [--skip-remote-execution] [--request-pseudo-tty] [--force-pseudo-tty] [--disable-pseudo-tty]"`
+	relatedCommands     interface{}  `related_commands:"allow-space-ssh, enable-ssh, scp, space-ssh-allowed, ssh-code, ssh-enabled"`
 }
 
 func (_ SSHCommand) Setup(config command.Config, ui command.UI) error {
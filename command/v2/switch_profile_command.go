@@ -0,0 +1,43 @@
+package v2
+
+import (
+	"code.cloudfoundry.org/cli/command"
+	"code.cloudfoundry.org/cli/command/flag"
+	"code.cloudfoundry.org/cli/command/v2/shared"
+)
+
+type SwitchProfileCommand struct {
+	RequiredArgs    flag.ProfileName `positional-args:"yes"`
+	usage           interface{}      `usage:"CF_NAME switch-profile PROFILE_NAME"`
+	relatedCommands interface{}      `related_commands:"create-profile, profiles, target"`
+
+	UI     command.UI
+	Config command.Config
+}
+
+func (cmd *SwitchProfileCommand) Setup(config command.Config, ui command.UI) error {
+	cmd.UI = ui
+	cmd.Config = config
+	return nil
+}
+
+func (cmd *SwitchProfileCommand) Execute(args []string) error {
+	err := cmd.Config.SwitchProfile(cmd.RequiredArgs.ProfileName)
+	if err != nil {
+		return shared.HandleError(err)
+	}
+
+	user, err := cmd.Config.CurrentUser()
+	if err != nil {
+		return err
+	}
+
+	cmd.UI.DisplayKeyValueTable("", [][]string{
+		{"profile:", cmd.Config.ProfileName()},
+		{"api endpoint:", cmd.Config.Target()},
+		{"user:", user.Name},
+		{"org:", cmd.Config.TargetedOrganization().Name},
+		{"space:", cmd.Config.TargetedSpace().Name},
+	}, 3)
+	return nil
+}
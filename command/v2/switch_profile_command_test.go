@@ -0,0 +1,64 @@
+package v2_test
+
+import (
+	"code.cloudfoundry.org/cli/command"
+	"code.cloudfoundry.org/cli/command/commandfakes"
+	. "code.cloudfoundry.org/cli/command/v2"
+	"code.cloudfoundry.org/cli/util/configv3"
+	"code.cloudfoundry.org/cli/util/ui"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+)
+
+var _ = Describe("Switch Profile Command", func() {
+	var (
+		cmd        SwitchProfileCommand
+		fakeUI     *ui.UI
+		fakeConfig *commandfakes.FakeConfig
+		executeErr error
+	)
+
+	BeforeEach(func() {
+		out := NewBuffer()
+		fakeUI = ui.NewTestUI(nil, out, out)
+		fakeConfig = new(commandfakes.FakeConfig)
+		fakeConfig.ProfileNameReturns("staging")
+		fakeConfig.TargetReturns("https://api.staging.com")
+		fakeConfig.CurrentUserReturns(configv3.User{Name: "some-user"}, nil)
+		fakeConfig.TargetedOrganizationReturns(configv3.Organization{Name: "some-org"})
+		fakeConfig.TargetedSpaceReturns(configv3.Space{Name: "some-space"})
+
+		cmd = SwitchProfileCommand{
+			UI:     fakeUI,
+			Config: fakeConfig,
+		}
+		cmd.RequiredArgs.ProfileName = "staging"
+	})
+
+	JustBeforeEach(func() {
+		executeErr = cmd.Execute(nil)
+	})
+
+	It("switches to the profile and displays its target", func() {
+		Expect(executeErr).ToNot(HaveOccurred())
+
+		Expect(fakeConfig.SwitchProfileArgsForCall(0)).To(Equal("staging"))
+		Expect(fakeUI.Out).To(Say(`profile:\s+staging`))
+		Expect(fakeUI.Out).To(Say(`api endpoint:\s+https://api.staging.com`))
+		Expect(fakeUI.Out).To(Say(`user:\s+some-user`))
+		Expect(fakeUI.Out).To(Say(`org:\s+some-org`))
+		Expect(fakeUI.Out).To(Say(`space:\s+some-space`))
+	})
+
+	Context("when the profile does not exist", func() {
+		BeforeEach(func() {
+			fakeConfig.SwitchProfileReturns(configv3.ProfileNotFoundError{Name: "staging"})
+		})
+
+		It("returns a ProfileNotFoundError", func() {
+			Expect(executeErr).To(MatchError(command.ProfileNotFoundError{Name: "staging"}))
+		})
+	})
+})
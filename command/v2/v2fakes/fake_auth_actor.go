@@ -0,0 +1,128 @@
+// This file was generated by counterfeiter
+package v2fakes
+
+import (
+	"sync"
+
+	"code.cloudfoundry.org/cli/actor/v2action"
+	"code.cloudfoundry.org/cli/command/v2"
+)
+
+type FakeAuthActor struct {
+	AuthenticateStub        func(config v2action.Config, username string, password string) error
+	authenticateMutex       sync.RWMutex
+	authenticateArgsForCall []struct {
+		config   v2action.Config
+		username string
+		password string
+	}
+	authenticateReturns struct {
+		result1 error
+	}
+	AuthenticateClientCredentialsStub        func(config v2action.Config, clientID string, clientSecret string) error
+	authenticateClientCredentialsMutex       sync.RWMutex
+	authenticateClientCredentialsArgsForCall []struct {
+		config       v2action.Config
+		clientID     string
+		clientSecret string
+	}
+	authenticateClientCredentialsReturns struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeAuthActor) Authenticate(config v2action.Config, username string, password string) error {
+	fake.authenticateMutex.Lock()
+	fake.authenticateArgsForCall = append(fake.authenticateArgsForCall, struct {
+		config   v2action.Config
+		username string
+		password string
+	}{config, username, password})
+	fake.recordInvocation("Authenticate", []interface{}{config, username, password})
+	fake.authenticateMutex.Unlock()
+	if fake.AuthenticateStub != nil {
+		return fake.AuthenticateStub(config, username, password)
+	} else {
+		return fake.authenticateReturns.result1
+	}
+}
+
+func (fake *FakeAuthActor) AuthenticateCallCount() int {
+	fake.authenticateMutex.RLock()
+	defer fake.authenticateMutex.RUnlock()
+	return len(fake.authenticateArgsForCall)
+}
+
+func (fake *FakeAuthActor) AuthenticateArgsForCall(i int) (v2action.Config, string, string) {
+	fake.authenticateMutex.RLock()
+	defer fake.authenticateMutex.RUnlock()
+	return fake.authenticateArgsForCall[i].config, fake.authenticateArgsForCall[i].username, fake.authenticateArgsForCall[i].password
+}
+
+func (fake *FakeAuthActor) AuthenticateReturns(result1 error) {
+	fake.AuthenticateStub = nil
+	fake.authenticateReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeAuthActor) AuthenticateClientCredentials(config v2action.Config, clientID string, clientSecret string) error {
+	fake.authenticateClientCredentialsMutex.Lock()
+	fake.authenticateClientCredentialsArgsForCall = append(fake.authenticateClientCredentialsArgsForCall, struct {
+		config       v2action.Config
+		clientID     string
+		clientSecret string
+	}{config, clientID, clientSecret})
+	fake.recordInvocation("AuthenticateClientCredentials", []interface{}{config, clientID, clientSecret})
+	fake.authenticateClientCredentialsMutex.Unlock()
+	if fake.AuthenticateClientCredentialsStub != nil {
+		return fake.AuthenticateClientCredentialsStub(config, clientID, clientSecret)
+	} else {
+		return fake.authenticateClientCredentialsReturns.result1
+	}
+}
+
+func (fake *FakeAuthActor) AuthenticateClientCredentialsCallCount() int {
+	fake.authenticateClientCredentialsMutex.RLock()
+	defer fake.authenticateClientCredentialsMutex.RUnlock()
+	return len(fake.authenticateClientCredentialsArgsForCall)
+}
+
+func (fake *FakeAuthActor) AuthenticateClientCredentialsArgsForCall(i int) (v2action.Config, string, string) {
+	fake.authenticateClientCredentialsMutex.RLock()
+	defer fake.authenticateClientCredentialsMutex.RUnlock()
+	return fake.authenticateClientCredentialsArgsForCall[i].config, fake.authenticateClientCredentialsArgsForCall[i].clientID, fake.authenticateClientCredentialsArgsForCall[i].clientSecret
+}
+
+func (fake *FakeAuthActor) AuthenticateClientCredentialsReturns(result1 error) {
+	fake.AuthenticateClientCredentialsStub = nil
+	fake.authenticateClientCredentialsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeAuthActor) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.authenticateMutex.RLock()
+	defer fake.authenticateMutex.RUnlock()
+	fake.authenticateClientCredentialsMutex.RLock()
+	defer fake.authenticateClientCredentialsMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeAuthActor) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ v2.AuthActor = new(FakeAuthActor)
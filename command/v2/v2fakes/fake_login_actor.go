@@ -0,0 +1,272 @@
+// This file was generated by counterfeiter
+package v2fakes
+
+import (
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/cli/actor/v2action"
+	"code.cloudfoundry.org/cli/command/v2"
+)
+
+type FakeLoginActor struct {
+	AuthenticateStub        func(config v2action.Config, username string, password string) error
+	authenticateMutex       sync.RWMutex
+	authenticateArgsForCall []struct {
+		config   v2action.Config
+		username string
+		password string
+	}
+	authenticateReturns struct {
+		result1 error
+	}
+	AuthenticatePasscodeStub        func(config v2action.Config, passcode string) error
+	authenticatePasscodeMutex       sync.RWMutex
+	authenticatePasscodeArgsForCall []struct {
+		config   v2action.Config
+		passcode string
+	}
+	authenticatePasscodeReturns struct {
+		result1 error
+	}
+	AuthenticateWithBrowserStub        func(config v2action.Config, authorizationEndpoint string, timeout time.Duration, openURL func(authorizationURL string) error) error
+	authenticateWithBrowserMutex       sync.RWMutex
+	authenticateWithBrowserArgsForCall []struct {
+		config                v2action.Config
+		authorizationEndpoint string
+		timeout               time.Duration
+		openURL               func(authorizationURL string) error
+	}
+	authenticateWithBrowserReturns struct {
+		result1 error
+	}
+	GetOrganizationByNameStub        func(name string) (v2action.Organization, v2action.Warnings, error)
+	getOrganizationByNameMutex       sync.RWMutex
+	getOrganizationByNameArgsForCall []struct {
+		name string
+	}
+	getOrganizationByNameReturns struct {
+		result1 v2action.Organization
+		result2 v2action.Warnings
+		result3 error
+	}
+	GetSpaceByOrganizationAndNameStub        func(orgGUID string, name string) (v2action.Space, v2action.Warnings, error)
+	getSpaceByOrganizationAndNameMutex       sync.RWMutex
+	getSpaceByOrganizationAndNameArgsForCall []struct {
+		orgGUID string
+		name    string
+	}
+	getSpaceByOrganizationAndNameReturns struct {
+		result1 v2action.Space
+		result2 v2action.Warnings
+		result3 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeLoginActor) Authenticate(config v2action.Config, username string, password string) error {
+	fake.authenticateMutex.Lock()
+	fake.authenticateArgsForCall = append(fake.authenticateArgsForCall, struct {
+		config   v2action.Config
+		username string
+		password string
+	}{config, username, password})
+	fake.recordInvocation("Authenticate", []interface{}{config, username, password})
+	fake.authenticateMutex.Unlock()
+	if fake.AuthenticateStub != nil {
+		return fake.AuthenticateStub(config, username, password)
+	} else {
+		return fake.authenticateReturns.result1
+	}
+}
+
+func (fake *FakeLoginActor) AuthenticateCallCount() int {
+	fake.authenticateMutex.RLock()
+	defer fake.authenticateMutex.RUnlock()
+	return len(fake.authenticateArgsForCall)
+}
+
+func (fake *FakeLoginActor) AuthenticateArgsForCall(i int) (v2action.Config, string, string) {
+	fake.authenticateMutex.RLock()
+	defer fake.authenticateMutex.RUnlock()
+	return fake.authenticateArgsForCall[i].config, fake.authenticateArgsForCall[i].username, fake.authenticateArgsForCall[i].password
+}
+
+func (fake *FakeLoginActor) AuthenticateReturns(result1 error) {
+	fake.AuthenticateStub = nil
+	fake.authenticateReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeLoginActor) AuthenticatePasscode(config v2action.Config, passcode string) error {
+	fake.authenticatePasscodeMutex.Lock()
+	fake.authenticatePasscodeArgsForCall = append(fake.authenticatePasscodeArgsForCall, struct {
+		config   v2action.Config
+		passcode string
+	}{config, passcode})
+	fake.recordInvocation("AuthenticatePasscode", []interface{}{config, passcode})
+	fake.authenticatePasscodeMutex.Unlock()
+	if fake.AuthenticatePasscodeStub != nil {
+		return fake.AuthenticatePasscodeStub(config, passcode)
+	} else {
+		return fake.authenticatePasscodeReturns.result1
+	}
+}
+
+func (fake *FakeLoginActor) AuthenticatePasscodeCallCount() int {
+	fake.authenticatePasscodeMutex.RLock()
+	defer fake.authenticatePasscodeMutex.RUnlock()
+	return len(fake.authenticatePasscodeArgsForCall)
+}
+
+func (fake *FakeLoginActor) AuthenticatePasscodeArgsForCall(i int) (v2action.Config, string) {
+	fake.authenticatePasscodeMutex.RLock()
+	defer fake.authenticatePasscodeMutex.RUnlock()
+	return fake.authenticatePasscodeArgsForCall[i].config, fake.authenticatePasscodeArgsForCall[i].passcode
+}
+
+func (fake *FakeLoginActor) AuthenticatePasscodeReturns(result1 error) {
+	fake.AuthenticatePasscodeStub = nil
+	fake.authenticatePasscodeReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeLoginActor) AuthenticateWithBrowser(config v2action.Config, authorizationEndpoint string, timeout time.Duration, openURL func(authorizationURL string) error) error {
+	fake.authenticateWithBrowserMutex.Lock()
+	fake.authenticateWithBrowserArgsForCall = append(fake.authenticateWithBrowserArgsForCall, struct {
+		config                v2action.Config
+		authorizationEndpoint string
+		timeout               time.Duration
+		openURL               func(authorizationURL string) error
+	}{config, authorizationEndpoint, timeout, openURL})
+	fake.recordInvocation("AuthenticateWithBrowser", []interface{}{config, authorizationEndpoint, timeout, openURL})
+	fake.authenticateWithBrowserMutex.Unlock()
+	if fake.AuthenticateWithBrowserStub != nil {
+		return fake.AuthenticateWithBrowserStub(config, authorizationEndpoint, timeout, openURL)
+	} else {
+		return fake.authenticateWithBrowserReturns.result1
+	}
+}
+
+func (fake *FakeLoginActor) AuthenticateWithBrowserCallCount() int {
+	fake.authenticateWithBrowserMutex.RLock()
+	defer fake.authenticateWithBrowserMutex.RUnlock()
+	return len(fake.authenticateWithBrowserArgsForCall)
+}
+
+func (fake *FakeLoginActor) AuthenticateWithBrowserArgsForCall(i int) (v2action.Config, string, time.Duration, func(authorizationURL string) error) {
+	fake.authenticateWithBrowserMutex.RLock()
+	defer fake.authenticateWithBrowserMutex.RUnlock()
+	return fake.authenticateWithBrowserArgsForCall[i].config, fake.authenticateWithBrowserArgsForCall[i].authorizationEndpoint, fake.authenticateWithBrowserArgsForCall[i].timeout, fake.authenticateWithBrowserArgsForCall[i].openURL
+}
+
+func (fake *FakeLoginActor) AuthenticateWithBrowserReturns(result1 error) {
+	fake.AuthenticateWithBrowserStub = nil
+	fake.authenticateWithBrowserReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeLoginActor) GetOrganizationByName(name string) (v2action.Organization, v2action.Warnings, error) {
+	fake.getOrganizationByNameMutex.Lock()
+	fake.getOrganizationByNameArgsForCall = append(fake.getOrganizationByNameArgsForCall, struct {
+		name string
+	}{name})
+	fake.recordInvocation("GetOrganizationByName", []interface{}{name})
+	fake.getOrganizationByNameMutex.Unlock()
+	if fake.GetOrganizationByNameStub != nil {
+		return fake.GetOrganizationByNameStub(name)
+	} else {
+		return fake.getOrganizationByNameReturns.result1, fake.getOrganizationByNameReturns.result2, fake.getOrganizationByNameReturns.result3
+	}
+}
+
+func (fake *FakeLoginActor) GetOrganizationByNameCallCount() int {
+	fake.getOrganizationByNameMutex.RLock()
+	defer fake.getOrganizationByNameMutex.RUnlock()
+	return len(fake.getOrganizationByNameArgsForCall)
+}
+
+func (fake *FakeLoginActor) GetOrganizationByNameArgsForCall(i int) string {
+	fake.getOrganizationByNameMutex.RLock()
+	defer fake.getOrganizationByNameMutex.RUnlock()
+	return fake.getOrganizationByNameArgsForCall[i].name
+}
+
+func (fake *FakeLoginActor) GetOrganizationByNameReturns(result1 v2action.Organization, result2 v2action.Warnings, result3 error) {
+	fake.GetOrganizationByNameStub = nil
+	fake.getOrganizationByNameReturns = struct {
+		result1 v2action.Organization
+		result2 v2action.Warnings
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeLoginActor) GetSpaceByOrganizationAndName(orgGUID string, name string) (v2action.Space, v2action.Warnings, error) {
+	fake.getSpaceByOrganizationAndNameMutex.Lock()
+	fake.getSpaceByOrganizationAndNameArgsForCall = append(fake.getSpaceByOrganizationAndNameArgsForCall, struct {
+		orgGUID string
+		name    string
+	}{orgGUID, name})
+	fake.recordInvocation("GetSpaceByOrganizationAndName", []interface{}{orgGUID, name})
+	fake.getSpaceByOrganizationAndNameMutex.Unlock()
+	if fake.GetSpaceByOrganizationAndNameStub != nil {
+		return fake.GetSpaceByOrganizationAndNameStub(orgGUID, name)
+	} else {
+		return fake.getSpaceByOrganizationAndNameReturns.result1, fake.getSpaceByOrganizationAndNameReturns.result2, fake.getSpaceByOrganizationAndNameReturns.result3
+	}
+}
+
+func (fake *FakeLoginActor) GetSpaceByOrganizationAndNameCallCount() int {
+	fake.getSpaceByOrganizationAndNameMutex.RLock()
+	defer fake.getSpaceByOrganizationAndNameMutex.RUnlock()
+	return len(fake.getSpaceByOrganizationAndNameArgsForCall)
+}
+
+func (fake *FakeLoginActor) GetSpaceByOrganizationAndNameArgsForCall(i int) (string, string) {
+	fake.getSpaceByOrganizationAndNameMutex.RLock()
+	defer fake.getSpaceByOrganizationAndNameMutex.RUnlock()
+	return fake.getSpaceByOrganizationAndNameArgsForCall[i].orgGUID, fake.getSpaceByOrganizationAndNameArgsForCall[i].name
+}
+
+func (fake *FakeLoginActor) GetSpaceByOrganizationAndNameReturns(result1 v2action.Space, result2 v2action.Warnings, result3 error) {
+	fake.GetSpaceByOrganizationAndNameStub = nil
+	fake.getSpaceByOrganizationAndNameReturns = struct {
+		result1 v2action.Space
+		result2 v2action.Warnings
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeLoginActor) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.authenticateMutex.RLock()
+	defer fake.authenticateMutex.RUnlock()
+	fake.authenticatePasscodeMutex.RLock()
+	defer fake.authenticatePasscodeMutex.RUnlock()
+	fake.authenticateWithBrowserMutex.RLock()
+	defer fake.authenticateWithBrowserMutex.RUnlock()
+	fake.getOrganizationByNameMutex.RLock()
+	defer fake.getOrganizationByNameMutex.RUnlock()
+	fake.getSpaceByOrganizationAndNameMutex.RLock()
+	defer fake.getSpaceByOrganizationAndNameMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeLoginActor) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ v2.LoginActor = new(FakeLoginActor)
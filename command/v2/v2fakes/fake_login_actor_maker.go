@@ -0,0 +1,81 @@
+// This file was generated by counterfeiter
+package v2fakes
+
+import (
+	"sync"
+
+	"code.cloudfoundry.org/cli/command"
+	"code.cloudfoundry.org/cli/command/v2"
+)
+
+type FakeLoginActorMaker struct {
+	NewActorStub        func(config command.Config, ui command.UI) (v2.LoginActor, error)
+	newActorMutex       sync.RWMutex
+	newActorArgsForCall []struct {
+		config command.Config
+		ui     command.UI
+	}
+	newActorReturns struct {
+		result1 v2.LoginActor
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeLoginActorMaker) NewActor(config command.Config, ui command.UI) (v2.LoginActor, error) {
+	fake.newActorMutex.Lock()
+	fake.newActorArgsForCall = append(fake.newActorArgsForCall, struct {
+		config command.Config
+		ui     command.UI
+	}{config, ui})
+	fake.recordInvocation("NewActor", []interface{}{config, ui})
+	fake.newActorMutex.Unlock()
+	if fake.NewActorStub != nil {
+		return fake.NewActorStub(config, ui)
+	} else {
+		return fake.newActorReturns.result1, fake.newActorReturns.result2
+	}
+}
+
+func (fake *FakeLoginActorMaker) NewActorCallCount() int {
+	fake.newActorMutex.RLock()
+	defer fake.newActorMutex.RUnlock()
+	return len(fake.newActorArgsForCall)
+}
+
+func (fake *FakeLoginActorMaker) NewActorArgsForCall(i int) (command.Config, command.UI) {
+	fake.newActorMutex.RLock()
+	defer fake.newActorMutex.RUnlock()
+	return fake.newActorArgsForCall[i].config, fake.newActorArgsForCall[i].ui
+}
+
+func (fake *FakeLoginActorMaker) NewActorReturns(result1 v2.LoginActor, result2 error) {
+	fake.NewActorStub = nil
+	fake.newActorReturns = struct {
+		result1 v2.LoginActor
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeLoginActorMaker) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.newActorMutex.RLock()
+	defer fake.newActorMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeLoginActorMaker) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ v2.LoginActorMaker = new(FakeLoginActorMaker)
@@ -0,0 +1,114 @@
+// This file was generated by counterfeiter
+package v2fakes
+
+import (
+	"sync"
+
+	"code.cloudfoundry.org/cli/actor/v2action"
+	"code.cloudfoundry.org/cli/command/v2"
+)
+
+type FakeOauthTokenActor struct {
+	RefreshAccessTokenStub        func() (string, error)
+	refreshAccessTokenMutex       sync.RWMutex
+	refreshAccessTokenArgsForCall []struct{}
+	refreshAccessTokenReturns     struct {
+		result1 string
+		result2 error
+	}
+	GetAccessTokenInfoStub        func(accessToken string) (v2action.AccessTokenInfo, error)
+	getAccessTokenInfoMutex       sync.RWMutex
+	getAccessTokenInfoArgsForCall []struct {
+		accessToken string
+	}
+	getAccessTokenInfoReturns struct {
+		result1 v2action.AccessTokenInfo
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeOauthTokenActor) RefreshAccessToken() (string, error) {
+	fake.refreshAccessTokenMutex.Lock()
+	fake.refreshAccessTokenArgsForCall = append(fake.refreshAccessTokenArgsForCall, struct{}{})
+	fake.recordInvocation("RefreshAccessToken", []interface{}{})
+	fake.refreshAccessTokenMutex.Unlock()
+	if fake.RefreshAccessTokenStub != nil {
+		return fake.RefreshAccessTokenStub()
+	} else {
+		return fake.refreshAccessTokenReturns.result1, fake.refreshAccessTokenReturns.result2
+	}
+}
+
+func (fake *FakeOauthTokenActor) RefreshAccessTokenCallCount() int {
+	fake.refreshAccessTokenMutex.RLock()
+	defer fake.refreshAccessTokenMutex.RUnlock()
+	return len(fake.refreshAccessTokenArgsForCall)
+}
+
+func (fake *FakeOauthTokenActor) RefreshAccessTokenReturns(result1 string, result2 error) {
+	fake.RefreshAccessTokenStub = nil
+	fake.refreshAccessTokenReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeOauthTokenActor) GetAccessTokenInfo(accessToken string) (v2action.AccessTokenInfo, error) {
+	fake.getAccessTokenInfoMutex.Lock()
+	fake.getAccessTokenInfoArgsForCall = append(fake.getAccessTokenInfoArgsForCall, struct {
+		accessToken string
+	}{accessToken})
+	fake.recordInvocation("GetAccessTokenInfo", []interface{}{accessToken})
+	fake.getAccessTokenInfoMutex.Unlock()
+	if fake.GetAccessTokenInfoStub != nil {
+		return fake.GetAccessTokenInfoStub(accessToken)
+	} else {
+		return fake.getAccessTokenInfoReturns.result1, fake.getAccessTokenInfoReturns.result2
+	}
+}
+
+func (fake *FakeOauthTokenActor) GetAccessTokenInfoCallCount() int {
+	fake.getAccessTokenInfoMutex.RLock()
+	defer fake.getAccessTokenInfoMutex.RUnlock()
+	return len(fake.getAccessTokenInfoArgsForCall)
+}
+
+func (fake *FakeOauthTokenActor) GetAccessTokenInfoArgsForCall(i int) string {
+	fake.getAccessTokenInfoMutex.RLock()
+	defer fake.getAccessTokenInfoMutex.RUnlock()
+	return fake.getAccessTokenInfoArgsForCall[i].accessToken
+}
+
+func (fake *FakeOauthTokenActor) GetAccessTokenInfoReturns(result1 v2action.AccessTokenInfo, result2 error) {
+	fake.GetAccessTokenInfoStub = nil
+	fake.getAccessTokenInfoReturns = struct {
+		result1 v2action.AccessTokenInfo
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeOauthTokenActor) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.refreshAccessTokenMutex.RLock()
+	defer fake.refreshAccessTokenMutex.RUnlock()
+	fake.getAccessTokenInfoMutex.RLock()
+	defer fake.getAccessTokenInfoMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeOauthTokenActor) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ v2.OauthTokenActor = new(FakeOauthTokenActor)
@@ -0,0 +1,24 @@
+package v2
+
+import (
+	"os"
+
+	"code.cloudfoundry.org/cli/cf/cmd"
+	"code.cloudfoundry.org/cli/command"
+	"code.cloudfoundry.org/cli/command/flag"
+)
+
+type ValidateManifestCommand struct {
+	OptionalArgs    flag.ManifestPath `positional-args:"yes"`
+	usage           interface{}       `usage:"CF_NAME validate-manifest [PATH]\n\n   PATH is a manifest file or a directory containing manifest.yml. The current directory is used if PATH is not given."`
+	relatedCommands interface{}       `related_commands:"create-app-manifest, push"`
+}
+
+func (_ ValidateManifestCommand) Setup(config command.Config, ui command.UI) error {
+	return nil
+}
+
+func (_ ValidateManifestCommand) Execute(args []string) error {
+	cmd.Main(os.Getenv("CF_TRACE"), os.Args)
+	return nil
+}
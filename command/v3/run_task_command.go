@@ -1,28 +1,44 @@
 package v3
 
 import (
+	"os"
+	"os/signal"
+	"time"
+
 	"code.cloudfoundry.org/cli/actor/v3action"
 	"code.cloudfoundry.org/cli/command"
 	"code.cloudfoundry.org/cli/command/flag"
 	"code.cloudfoundry.org/cli/command/v3/shared"
 )
 
+// DefaultTaskPollInterval is how often the state of a task is checked when
+// waiting for it to complete.
+const DefaultTaskPollInterval = 2 * time.Second
+
 //go:generate counterfeiter . RunTaskActor
 
 type RunTaskActor interface {
 	GetApplicationByNameAndSpace(appName string, spaceGUID string) (v3action.Application, v3action.Warnings, error)
+	GetStreamingTaskLogs(appGUID string, taskName string, client v3action.NOAAClient, config v3action.Config) (<-chan v3action.LogMessage, <-chan error)
+	GetTaskBySequenceIDAndApplication(sequenceID int, appGUID string) (v3action.Task, v3action.Warnings, error)
 	RunTask(appGUID string, command string, name string) (v3action.Task, v3action.Warnings, error)
+	TerminateTask(taskGUID string) (v3action.Task, v3action.Warnings, error)
 }
 
 type RunTaskCommand struct {
 	RequiredArgs    flag.RunTaskArgs `positional-args:"yes"`
 	Name            string           `long:"name" description:"Name to give the task (generated if omitted)"`
-	usage           interface{}      `usage:"CF_NAME run-task APP_NAME COMMAND [--name TASK_NAME]\n\nEXAMPLES:\n   CF_NAME run-task my-app \"bundle exec rake db:migrate\" --name migrate"`
+	Wait            bool             `long:"wait" description:"Wait for the task to complete while displaying its logs, and fail if the task fails"`
+	Timeout         int              `long:"timeout" description:"Maximum number of minutes to wait for the task to complete when used with --wait (default: no limit)"`
+	usage           interface{}      `usage:"CF_NAME run-task APP_NAME COMMAND [--name TASK_NAME] [--wait [--timeout MINUTES]]\n\nEXAMPLES:\n   CF_NAME run-task my-app \"bundle exec rake db:migrate\" --name migrate\n   CF_NAME run-task my-app \"bundle exec rake db:migrate\" --name migrate --wait --timeout 30"`
 	relatedCommands interface{}      `related_commands:"tasks, terminate-task"`
 
-	UI     command.UI
-	Actor  RunTaskActor
-	Config command.Config
+	UI           command.UI
+	Actor        RunTaskActor
+	Config       command.Config
+	NOAAClient   v3action.NOAAClient
+	PollInterval time.Duration
+	Interrupt    <-chan os.Signal
 }
 
 func (cmd *RunTaskCommand) Setup(config command.Config, ui command.UI) error {
@@ -35,6 +51,11 @@ func (cmd *RunTaskCommand) Setup(config command.Config, ui command.UI) error {
 	}
 	cmd.Actor = v3action.NewActor(client)
 
+	if cmd.Wait {
+		cmd.NOAAClient = shared.NewNOAAClient(client.Logging(), client.UAA(), config)
+		cmd.PollInterval = DefaultTaskPollInterval
+	}
+
 	return nil
 }
 
@@ -77,5 +98,108 @@ func (cmd RunTaskCommand) Execute(args []string) error {
 			"TaskSequenceID": task.SequenceID,
 		})
 
-	return nil
+	if !cmd.Wait {
+		return nil
+	}
+
+	return cmd.waitForTask(application.GUID, task)
+}
+
+// waitForTask displays the task's logs and polls its state until it has
+// finished, the timeout has passed or the user interrupts the CLI. An
+// interrupt terminates the task.
+func (cmd RunTaskCommand) waitForTask(appGUID string, task v3action.Task) error {
+	interrupt := cmd.Interrupt
+	if interrupt == nil {
+		signals := make(chan os.Signal, 1)
+		signal.Notify(signals, os.Interrupt)
+		defer signal.Stop(signals)
+		interrupt = signals
+	}
+
+	var timeout <-chan time.Time
+	if cmd.Timeout > 0 {
+		timeout = time.After(time.Duration(cmd.Timeout) * time.Minute)
+	}
+
+	cmd.UI.DisplayNewline()
+	cmd.UI.DisplayTextWithFlavor("Waiting for task {{.TaskSequenceID}} to complete...", map[string]interface{}{
+		"TaskSequenceID": task.SequenceID,
+	})
+	cmd.UI.DisplayNewline()
+
+	messages, logErrs := cmd.Actor.GetStreamingTaskLogs(appGUID, task.Name, cmd.NOAAClient, cmd.Config)
+	defer cmd.NOAAClient.Close()
+
+	poll := time.NewTicker(cmd.PollInterval)
+	defer poll.Stop()
+
+	terminating := false
+	for {
+		select {
+		case message, ok := <-messages:
+			if !ok {
+				messages = nil
+				continue
+			}
+			cmd.UI.DisplayText("{{.Timestamp}} [{{.SourceType}}/{{.SourceInstance}}] {{.Type}} {{.Message}}", map[string]interface{}{
+				"Timestamp":      message.Timestamp.Format("2006-01-02T15:04:05.00-0700"),
+				"SourceType":     message.SourceType,
+				"SourceInstance": message.SourceInstance,
+				"Type":           message.Type,
+				"Message":        message.Message,
+			})
+		case logErr, ok := <-logErrs:
+			if !ok {
+				logErrs = nil
+				continue
+			}
+			cmd.UI.DisplayWarning("Failed to retrieve logs: {{.Error}}", map[string]interface{}{
+				"Error": logErr.Error(),
+			})
+		case <-interrupt:
+			if terminating {
+				continue
+			}
+			terminating = true
+
+			cmd.UI.DisplayNewline()
+			cmd.UI.DisplayTextWithFlavor("Terminating task {{.TaskSequenceID}}...", map[string]interface{}{
+				"TaskSequenceID": task.SequenceID,
+			})
+			_, warnings, err := cmd.Actor.TerminateTask(task.GUID)
+			cmd.UI.DisplayWarnings(warnings)
+			if err != nil {
+				return shared.HandleError(err)
+			}
+		case <-timeout:
+			return shared.TaskTimeoutError{
+				SequenceID: task.SequenceID,
+				Timeout:    cmd.Timeout,
+			}
+		case <-poll.C:
+			currentTask, warnings, err := cmd.Actor.GetTaskBySequenceIDAndApplication(task.SequenceID, appGUID)
+			cmd.UI.DisplayWarnings(warnings)
+			if err != nil {
+				return shared.HandleError(err)
+			}
+
+			if !currentTask.Finished() {
+				continue
+			}
+
+			if currentTask.State == v3action.TaskFailed {
+				return shared.TaskFailedError{
+					SequenceID:    currentTask.SequenceID,
+					FailureReason: currentTask.Result.FailureReason,
+				}
+			}
+
+			cmd.UI.DisplayNewline()
+			cmd.UI.DisplayText("Task {{.TaskSequenceID}} has completed successfully.", map[string]interface{}{
+				"TaskSequenceID": currentTask.SequenceID,
+			})
+			return nil
+		}
+	}
 }
@@ -2,12 +2,16 @@ package v3_test
 
 import (
 	"errors"
+	"os"
+	"time"
 
 	"code.cloudfoundry.org/cli/actor/v3action"
+	"code.cloudfoundry.org/cli/actor/v3action/v3actionfakes"
 	"code.cloudfoundry.org/cli/api/cloudcontroller"
 	"code.cloudfoundry.org/cli/command"
 	"code.cloudfoundry.org/cli/command/commandfakes"
 	"code.cloudfoundry.org/cli/command/v3"
+	"code.cloudfoundry.org/cli/command/v3/shared"
 	"code.cloudfoundry.org/cli/command/v3/v3fakes"
 	"code.cloudfoundry.org/cli/util/configv3"
 	"code.cloudfoundry.org/cli/util/ui"
@@ -182,6 +186,132 @@ Task 3 has been submitted successfully for execution.`,
 						))
 					})
 				})
+
+				Context("when waiting for the task to complete", func() {
+					var (
+						fakeNOAAClient *v3actionfakes.FakeNOAAClient
+						interrupt      chan os.Signal
+						messages       chan v3action.LogMessage
+						logErrs        chan error
+					)
+
+					BeforeEach(func() {
+						fakeNOAAClient = new(v3actionfakes.FakeNOAAClient)
+						interrupt = make(chan os.Signal, 1)
+						messages = make(chan v3action.LogMessage, 1)
+						logErrs = make(chan error, 1)
+
+						cmd.Wait = true
+						cmd.NOAAClient = fakeNOAAClient
+						cmd.PollInterval = time.Millisecond
+						cmd.Interrupt = interrupt
+
+						fakeActor.RunTaskReturns(v3action.Task{
+							GUID:       "task-3-guid",
+							SequenceID: 3,
+							Name:       "some-task-name",
+						}, nil, nil)
+						fakeActor.GetStreamingTaskLogsReturns(messages, logErrs)
+					})
+
+					Context("when the task succeeds", func() {
+						BeforeEach(func() {
+							messages <- v3action.LogMessage{
+								Message:        "some-log-message",
+								Type:           "OUT",
+								Timestamp:      time.Date(2016, time.November, 8, 22, 26, 2, 0, time.UTC),
+								SourceType:     "APP/TASK/some-task-name",
+								SourceInstance: "0",
+							}
+							logErrs <- errors.New("some-log-error")
+
+							fakeActor.GetTaskBySequenceIDAndApplicationStub = func(sequenceID int, appGUID string) (v3action.Task, v3action.Warnings, error) {
+								if fakeActor.GetTaskBySequenceIDAndApplicationCallCount() < 3 {
+									return v3action.Task{SequenceID: 3, State: "RUNNING"}, v3action.Warnings{"get-task-warning"}, nil
+								}
+								return v3action.Task{SequenceID: 3, State: v3action.TaskSucceeded}, nil, nil
+							}
+						})
+
+						It("streams the task's logs and polls until the task completes", func() {
+							Expect(executeErr).ToNot(HaveOccurred())
+
+							Expect(fakeActor.GetStreamingTaskLogsCallCount()).To(Equal(1))
+							appGUID, taskName, noaaClient, config := fakeActor.GetStreamingTaskLogsArgsForCall(0)
+							Expect(appGUID).To(Equal("some-app-guid"))
+							Expect(taskName).To(Equal("some-task-name"))
+							Expect(noaaClient).To(Equal(fakeNOAAClient))
+							Expect(config).To(Equal(fakeConfig))
+
+							Expect(fakeActor.GetTaskBySequenceIDAndApplicationCallCount()).To(Equal(3))
+							sequenceID, appGUID := fakeActor.GetTaskBySequenceIDAndApplicationArgsForCall(0)
+							Expect(sequenceID).To(Equal(3))
+							Expect(appGUID).To(Equal("some-app-guid"))
+
+							output := string(fakeUI.Out.(*Buffer).Contents())
+							Expect(output).To(ContainSubstring("Waiting for task 3 to complete..."))
+							Expect(output).To(ContainSubstring("2016-11-08T22:26:02.00+0000 [APP/TASK/some-task-name/0] OUT some-log-message"))
+							Expect(output).To(ContainSubstring("Failed to retrieve logs: some-log-error"))
+							Expect(output).To(ContainSubstring("get-task-warning"))
+							Expect(fakeUI.Out).To(Say("Task 3 has completed successfully."))
+
+							Expect(fakeNOAAClient.CloseCallCount()).To(Equal(1))
+							Expect(fakeActor.TerminateTaskCallCount()).To(Equal(0))
+						})
+					})
+
+					Context("when the task fails", func() {
+						BeforeEach(func() {
+							failedTask := v3action.Task{SequenceID: 3, State: v3action.TaskFailed}
+							failedTask.Result.FailureReason = "Exited with status 1"
+							fakeActor.GetTaskBySequenceIDAndApplicationReturns(failedTask, nil, nil)
+						})
+
+						It("returns a TaskFailedError", func() {
+							Expect(executeErr).To(MatchError(shared.TaskFailedError{
+								SequenceID:    3,
+								FailureReason: "Exited with status 1",
+							}))
+						})
+					})
+
+					Context("when the user interrupts the CLI", func() {
+						BeforeEach(func() {
+							interrupt <- os.Interrupt
+
+							fakeActor.TerminateTaskReturns(v3action.Task{}, v3action.Warnings{"terminate-warning"}, nil)
+							fakeActor.GetTaskBySequenceIDAndApplicationStub = func(sequenceID int, appGUID string) (v3action.Task, v3action.Warnings, error) {
+								if fakeActor.TerminateTaskCallCount() == 0 {
+									return v3action.Task{SequenceID: 3, State: "RUNNING"}, nil, nil
+								}
+								return v3action.Task{SequenceID: 3, State: v3action.TaskFailed}, nil, nil
+							}
+						})
+
+						It("terminates the task and waits for it to fail", func() {
+							Expect(executeErr).To(MatchError(shared.TaskFailedError{SequenceID: 3}))
+
+							Expect(fakeActor.TerminateTaskCallCount()).To(Equal(1))
+							Expect(fakeActor.TerminateTaskArgsForCall(0)).To(Equal("task-3-guid"))
+							Expect(fakeUI.Out).To(Say("Terminating task 3..."))
+							Expect(fakeUI.Err).To(Say("terminate-warning"))
+						})
+					})
+
+					Context("when polling the task returns an error", func() {
+						var expectedErr error
+
+						BeforeEach(func() {
+							expectedErr = errors.New("get task error")
+							fakeActor.GetTaskBySequenceIDAndApplicationReturns(v3action.Task{}, v3action.Warnings{"get-task-warning"}, expectedErr)
+						})
+
+						It("returns the error and all warnings", func() {
+							Expect(executeErr).To(MatchError(expectedErr))
+							Expect(fakeUI.Err).To(Say("get-task-warning"))
+						})
+					})
+				})
 			})
 
 			Context("when there are errors", func() {
@@ -13,3 +13,35 @@ func (e RunTaskError) Translate(translate func(string, ...interface{}) string) s
 		"CloudControllerMessage": e.Message,
 	})
 }
+
+type TaskFailedError struct {
+	SequenceID    int
+	FailureReason string
+}
+
+func (e TaskFailedError) Error() string {
+	return "Task {{.TaskSequenceID}} failed: {{.FailureReason}}"
+}
+
+func (e TaskFailedError) Translate(translate func(string, ...interface{}) string) string {
+	return translate(e.Error(), map[string]interface{}{
+		"TaskSequenceID": e.SequenceID,
+		"FailureReason":  e.FailureReason,
+	})
+}
+
+type TaskTimeoutError struct {
+	SequenceID int
+	Timeout    int
+}
+
+func (e TaskTimeoutError) Error() string {
+	return "Timed out after {{.Timeout}} minute(s) waiting for task {{.TaskSequenceID}} to complete"
+}
+
+func (e TaskTimeoutError) Translate(translate func(string, ...interface{}) string) string {
+	return translate(e.Error(), map[string]interface{}{
+		"TaskSequenceID": e.SequenceID,
+		"Timeout":        e.Timeout,
+	})
+}
@@ -38,5 +38,7 @@ var _ = Describe("Translatable Errors", func() {
 
 		// Actor errors.
 		Entry("RunTaskError", RunTaskError{}),
+		Entry("TaskFailedError", TaskFailedError{}),
+		Entry("TaskTimeoutError", TaskTimeoutError{}),
 	)
 })
@@ -0,0 +1,51 @@
+package shared
+
+import (
+	"crypto/tls"
+	"net/http"
+
+	"code.cloudfoundry.org/cli/api/uaa"
+	"code.cloudfoundry.org/cli/command"
+	"github.com/cloudfoundry/noaa/consumer"
+)
+
+// NewNOAAClient creates a new NOAA client for streaming logs from the
+// provided Doppler URL. Expired access tokens are refreshed through the UAA
+// at the provided UAA URL.
+func NewNOAAClient(dopplerURL string, uaaURL string, config command.Config) *consumer.Consumer {
+	client := consumer.New(
+		dopplerURL,
+		&tls.Config{
+			InsecureSkipVerify: config.SkipSSLValidation(),
+		},
+		http.ProxyFromEnvironment,
+	)
+
+	client.RefreshTokenFrom(tokenRefresher{
+		uaaClient: uaa.NewClient(uaa.Config{
+			AppName:           config.BinaryName(),
+			AppVersion:        config.BinaryVersion(),
+			DialTimeout:       config.DialTimeout(),
+			SkipSSLValidation: config.SkipSSLValidation(),
+			Store:             config,
+			URL:               uaaURL,
+		}),
+	})
+
+	return client
+}
+
+// tokenRefresher refreshes the NOAA client's access token through the UAA.
+type tokenRefresher struct {
+	uaaClient *uaa.Client
+}
+
+// RefreshAuthToken refreshes the access token and returns the new one.
+func (t tokenRefresher) RefreshAuthToken() (string, error) {
+	err := t.uaaClient.RefreshToken()
+	if err != nil {
+		return "", err
+	}
+
+	return t.uaaClient.AccessToken(), nil
+}
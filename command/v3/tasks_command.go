@@ -1,8 +1,8 @@
 package v3
 
 import (
-	"net/url"
 	"strconv"
+	"strings"
 	"time"
 
 	"code.cloudfoundry.org/cli/actor/v3action"
@@ -19,16 +19,23 @@ const (
 	succeededState = "SUCCEEDED"
 )
 
+var validTaskStates = []string{"PENDING", "RUNNING", "CANCELING", "SUCCEEDED", "FAILED"}
+
 //go:generate counterfeiter . TasksActor
 
 type TasksActor interface {
 	GetApplicationByNameAndSpace(appName string, spaceGUID string) (v3action.Application, v3action.Warnings, error)
-	GetApplicationTasks(appGUID string, sortOrder v3action.SortOrder) ([]v3action.Task, v3action.Warnings, error)
+	GetApplicationTasks(appGUID string, sortOrder v3action.SortOrder, filter v3action.TaskFilter) ([]v3action.Task, v3action.Warnings, error)
 }
 
 type TasksCommand struct {
 	RequiredArgs    flag.AppName `positional-args:"yes"`
-	usage           interface{}  `usage:"CF_NAME tasks APP_NAME"`
+	State           string       `long:"state" description:"Only show tasks in the given states, comma separated (PENDING, RUNNING, CANCELING, SUCCEEDED, FAILED)"`
+	Name            string       `long:"name" description:"Only show tasks with the given name"`
+	CreatedAfter    string       `long:"created-after" description:"Only show tasks created after the given time (e.g. 2017-01-02 or 2017-01-02T15:04:05Z)"`
+	CreatedBefore   string       `long:"created-before" description:"Only show tasks created before the given time (e.g. 2017-01-02 or 2017-01-02T15:04:05Z)"`
+	Limit           int          `long:"limit" description:"Maximum number of tasks to show, most recent first"`
+	usage           interface{}  `usage:"CF_NAME tasks APP_NAME [--state STATES] [--name TASK_NAME] [--created-after TIME] [--created-before TIME] [--limit NUMBER]\n\nEXAMPLES:\n   CF_NAME tasks my-app --state RUNNING,PENDING\n   CF_NAME tasks my-app --name migrate --created-after 2017-01-02 --limit 10"`
 	relatedCommands interface{}  `related_commands:"apps, run-task, terminate-task"`
 
 	UI     command.UI
@@ -50,7 +57,12 @@ func (cmd *TasksCommand) Setup(config command.Config, ui command.UI) error {
 }
 
 func (cmd TasksCommand) Execute(args []string) error {
-	err := command.CheckTarget(cmd.Config, true, true)
+	filter, err := cmd.taskFilter()
+	if err != nil {
+		return err
+	}
+
+	err = command.CheckTarget(cmd.Config, true, true)
 	if err != nil {
 		return err
 	}
@@ -75,9 +87,7 @@ func (cmd TasksCommand) Execute(args []string) error {
 		"CurrentUser": user.Name,
 	})
 
-	query := url.Values{}
-	query.Add("order_by", "-created_at")
-	tasks, warnings, err := cmd.Actor.GetApplicationTasks(application.GUID, v3action.Descending)
+	tasks, warnings, err := cmd.Actor.GetApplicationTasks(application.GUID, v3action.Descending, filter)
 	cmd.UI.DisplayWarnings(warnings)
 	if err != nil {
 		return shared.HandleError(err)
@@ -87,12 +97,21 @@ func (cmd TasksCommand) Execute(args []string) error {
 	cmd.UI.DisplayNewline()
 
 	table := [][]string{{"id", "name", "state", "start time", "command"}}
+	var rawValues [][]interface{}
 	for _, task := range tasks {
 		t, err := time.Parse(time.RFC3339, task.CreatedAt)
 		if err != nil {
 			return err
 		}
 
+		rawValues = append(rawValues, []interface{}{
+			task.SequenceID,
+			task.Name,
+			task.State,
+			t,
+			task.Command,
+		})
+
 		if task.Command == "" {
 			task.Command = "[hidden]"
 		}
@@ -106,7 +125,75 @@ func (cmd TasksCommand) Execute(args []string) error {
 		})
 	}
 
-	cmd.UI.DisplayTable("", table, 3)
+	cmd.UI.DisplayTable("", table, 3, rawValues...)
 
 	return nil
 }
+
+func (cmd TasksCommand) taskFilter() (v3action.TaskFilter, error) {
+	var filter v3action.TaskFilter
+
+	if cmd.State != "" {
+		for _, state := range strings.Split(cmd.State, ",") {
+			state = strings.ToUpper(strings.TrimSpace(state))
+			if !isValidTaskState(state) {
+				return v3action.TaskFilter{}, command.ParseArgumentError{
+					ArgumentName: "--state",
+					ExpectedType: "a comma separated list of PENDING, RUNNING, CANCELING, SUCCEEDED or FAILED",
+				}
+			}
+			filter.States = append(filter.States, state)
+		}
+	}
+
+	if cmd.Name != "" {
+		filter.Names = []string{cmd.Name}
+	}
+
+	var err error
+	filter.CreatedAfter, err = parseTaskTime("--created-after", cmd.CreatedAfter)
+	if err != nil {
+		return v3action.TaskFilter{}, err
+	}
+
+	filter.CreatedBefore, err = parseTaskTime("--created-before", cmd.CreatedBefore)
+	if err != nil {
+		return v3action.TaskFilter{}, err
+	}
+
+	if cmd.Limit < 0 {
+		return v3action.TaskFilter{}, command.ParseArgumentError{
+			ArgumentName: "--limit",
+			ExpectedType: "a positive integer",
+		}
+	}
+	filter.Limit = cmd.Limit
+
+	return filter, nil
+}
+
+func isValidTaskState(state string) bool {
+	for _, validState := range validTaskStates {
+		if state == validState {
+			return true
+		}
+	}
+	return false
+}
+
+func parseTaskTime(argumentName string, value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	for _, layout := range []string{time.RFC3339, "2006-01-02"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, command.ParseArgumentError{
+		ArgumentName: argumentName,
+		ExpectedType: "a date or timestamp (e.g. 2017-01-02 or 2017-01-02T15:04:05Z)",
+	}
+}
@@ -2,6 +2,7 @@ package v3_test
 
 import (
 	"errors"
+	"time"
 
 	"code.cloudfoundry.org/cli/actor/v3action"
 	"code.cloudfoundry.org/cli/api/cloudcontroller"
@@ -43,6 +44,61 @@ var _ = Describe("Tasks Command", func() {
 		executeErr = cmd.Execute(nil)
 	})
 
+	Context("when the filter flags are invalid", func() {
+		Context("when an unknown state is provided", func() {
+			BeforeEach(func() {
+				cmd.State = "RUNNING,SLEEPING"
+			})
+
+			It("returns a ParseArgumentError", func() {
+				Expect(executeErr).To(MatchError(command.ParseArgumentError{
+					ArgumentName: "--state",
+					ExpectedType: "a comma separated list of PENDING, RUNNING, CANCELING, SUCCEEDED or FAILED",
+				}))
+				Expect(fakeActor.GetApplicationTasksCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when created-after is not a time", func() {
+			BeforeEach(func() {
+				cmd.CreatedAfter = "yesterday"
+			})
+
+			It("returns a ParseArgumentError", func() {
+				Expect(executeErr).To(MatchError(command.ParseArgumentError{
+					ArgumentName: "--created-after",
+					ExpectedType: "a date or timestamp (e.g. 2017-01-02 or 2017-01-02T15:04:05Z)",
+				}))
+			})
+		})
+
+		Context("when created-before is not a time", func() {
+			BeforeEach(func() {
+				cmd.CreatedBefore = "01/02/2017"
+			})
+
+			It("returns a ParseArgumentError", func() {
+				Expect(executeErr).To(MatchError(command.ParseArgumentError{
+					ArgumentName: "--created-before",
+					ExpectedType: "a date or timestamp (e.g. 2017-01-02 or 2017-01-02T15:04:05Z)",
+				}))
+			})
+		})
+
+		Context("when the limit is negative", func() {
+			BeforeEach(func() {
+				cmd.Limit = -1
+			})
+
+			It("returns a ParseArgumentError", func() {
+				Expect(executeErr).To(MatchError(command.ParseArgumentError{
+					ArgumentName: "--limit",
+					ExpectedType: "a positive integer",
+				}))
+			})
+		})
+	})
+
 	Context("when the user is not logged in", func() {
 		It("returns a NotLoggedInError", func() {
 			Expect(executeErr).To(MatchError(command.NotLoggedInError{}))
@@ -163,9 +219,10 @@ var _ = Describe("Tasks Command", func() {
 					Expect(spaceGUID).To(Equal("some-space-guid"))
 
 					Expect(fakeActor.GetApplicationTasksCallCount()).To(Equal(1))
-					guid, order := fakeActor.GetApplicationTasksArgsForCall(0)
+					guid, order, filter := fakeActor.GetApplicationTasksArgsForCall(0)
 					Expect(guid).To(Equal("some-app-guid"))
 					Expect(order).To(Equal(v3action.Descending))
+					Expect(filter).To(Equal(v3action.TaskFilter{}))
 
 					Expect(fakeUI.Out).To(Say(`get-application-warning-1
 get-application-warning-2
@@ -180,6 +237,29 @@ id   name     state       start time                      command
 					))
 				})
 
+				Context("when filters are provided", func() {
+					BeforeEach(func() {
+						cmd.State = "running, Failed"
+						cmd.Name = "some-task"
+						cmd.CreatedAfter = "2016-11-08"
+						cmd.CreatedBefore = "2016-11-09T10:00:00+01:00"
+						cmd.Limit = 2
+					})
+
+					It("passes the filters to the actor", func() {
+						Expect(executeErr).ToNot(HaveOccurred())
+
+						Expect(fakeActor.GetApplicationTasksCallCount()).To(Equal(1))
+						_, order, filter := fakeActor.GetApplicationTasksArgsForCall(0)
+						Expect(order).To(Equal(v3action.Descending))
+						Expect(filter.States).To(Equal([]string{"RUNNING", "FAILED"}))
+						Expect(filter.Names).To(Equal([]string{"some-task"}))
+						Expect(filter.CreatedAfter).To(BeTemporally("==", time.Date(2016, 11, 8, 0, 0, 0, 0, time.UTC)))
+						Expect(filter.CreatedBefore).To(BeTemporally("==", time.Date(2016, 11, 9, 9, 0, 0, 0, time.UTC)))
+						Expect(filter.Limit).To(Equal(2))
+					})
+				})
+
 				Context("when the tasks' command fields are returned as empty strings", func() {
 					BeforeEach(func() {
 						fakeActor.GetApplicationTasksReturns(
@@ -20,6 +20,29 @@ type FakeRunTaskActor struct {
 		result2 v3action.Warnings
 		result3 error
 	}
+	GetStreamingTaskLogsStub        func(appGUID string, taskName string, client v3action.NOAAClient, config v3action.Config) (<-chan v3action.LogMessage, <-chan error)
+	getStreamingTaskLogsMutex       sync.RWMutex
+	getStreamingTaskLogsArgsForCall []struct {
+		appGUID  string
+		taskName string
+		client   v3action.NOAAClient
+		config   v3action.Config
+	}
+	getStreamingTaskLogsReturns struct {
+		result1 <-chan v3action.LogMessage
+		result2 <-chan error
+	}
+	GetTaskBySequenceIDAndApplicationStub        func(sequenceID int, appGUID string) (v3action.Task, v3action.Warnings, error)
+	getTaskBySequenceIDAndApplicationMutex       sync.RWMutex
+	getTaskBySequenceIDAndApplicationArgsForCall []struct {
+		sequenceID int
+		appGUID    string
+	}
+	getTaskBySequenceIDAndApplicationReturns struct {
+		result1 v3action.Task
+		result2 v3action.Warnings
+		result3 error
+	}
 	RunTaskStub        func(appGUID string, command string, name string) (v3action.Task, v3action.Warnings, error)
 	runTaskMutex       sync.RWMutex
 	runTaskArgsForCall []struct {
@@ -32,6 +55,16 @@ type FakeRunTaskActor struct {
 		result2 v3action.Warnings
 		result3 error
 	}
+	TerminateTaskStub        func(taskGUID string) (v3action.Task, v3action.Warnings, error)
+	terminateTaskMutex       sync.RWMutex
+	terminateTaskArgsForCall []struct {
+		taskGUID string
+	}
+	terminateTaskReturns struct {
+		result1 v3action.Task
+		result2 v3action.Warnings
+		result3 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -72,6 +105,79 @@ func (fake *FakeRunTaskActor) GetApplicationByNameAndSpaceReturns(result1 v3acti
 	}{result1, result2, result3}
 }
 
+func (fake *FakeRunTaskActor) GetStreamingTaskLogs(appGUID string, taskName string, client v3action.NOAAClient, config v3action.Config) (<-chan v3action.LogMessage, <-chan error) {
+	fake.getStreamingTaskLogsMutex.Lock()
+	fake.getStreamingTaskLogsArgsForCall = append(fake.getStreamingTaskLogsArgsForCall, struct {
+		appGUID  string
+		taskName string
+		client   v3action.NOAAClient
+		config   v3action.Config
+	}{appGUID, taskName, client, config})
+	fake.recordInvocation("GetStreamingTaskLogs", []interface{}{appGUID, taskName, client, config})
+	fake.getStreamingTaskLogsMutex.Unlock()
+	if fake.GetStreamingTaskLogsStub != nil {
+		return fake.GetStreamingTaskLogsStub(appGUID, taskName, client, config)
+	} else {
+		return fake.getStreamingTaskLogsReturns.result1, fake.getStreamingTaskLogsReturns.result2
+	}
+}
+
+func (fake *FakeRunTaskActor) GetStreamingTaskLogsCallCount() int {
+	fake.getStreamingTaskLogsMutex.RLock()
+	defer fake.getStreamingTaskLogsMutex.RUnlock()
+	return len(fake.getStreamingTaskLogsArgsForCall)
+}
+
+func (fake *FakeRunTaskActor) GetStreamingTaskLogsArgsForCall(i int) (string, string, v3action.NOAAClient, v3action.Config) {
+	fake.getStreamingTaskLogsMutex.RLock()
+	defer fake.getStreamingTaskLogsMutex.RUnlock()
+	return fake.getStreamingTaskLogsArgsForCall[i].appGUID, fake.getStreamingTaskLogsArgsForCall[i].taskName, fake.getStreamingTaskLogsArgsForCall[i].client, fake.getStreamingTaskLogsArgsForCall[i].config
+}
+
+func (fake *FakeRunTaskActor) GetStreamingTaskLogsReturns(result1 <-chan v3action.LogMessage, result2 <-chan error) {
+	fake.GetStreamingTaskLogsStub = nil
+	fake.getStreamingTaskLogsReturns = struct {
+		result1 <-chan v3action.LogMessage
+		result2 <-chan error
+	}{result1, result2}
+}
+
+func (fake *FakeRunTaskActor) GetTaskBySequenceIDAndApplication(sequenceID int, appGUID string) (v3action.Task, v3action.Warnings, error) {
+	fake.getTaskBySequenceIDAndApplicationMutex.Lock()
+	fake.getTaskBySequenceIDAndApplicationArgsForCall = append(fake.getTaskBySequenceIDAndApplicationArgsForCall, struct {
+		sequenceID int
+		appGUID    string
+	}{sequenceID, appGUID})
+	fake.recordInvocation("GetTaskBySequenceIDAndApplication", []interface{}{sequenceID, appGUID})
+	fake.getTaskBySequenceIDAndApplicationMutex.Unlock()
+	if fake.GetTaskBySequenceIDAndApplicationStub != nil {
+		return fake.GetTaskBySequenceIDAndApplicationStub(sequenceID, appGUID)
+	} else {
+		return fake.getTaskBySequenceIDAndApplicationReturns.result1, fake.getTaskBySequenceIDAndApplicationReturns.result2, fake.getTaskBySequenceIDAndApplicationReturns.result3
+	}
+}
+
+func (fake *FakeRunTaskActor) GetTaskBySequenceIDAndApplicationCallCount() int {
+	fake.getTaskBySequenceIDAndApplicationMutex.RLock()
+	defer fake.getTaskBySequenceIDAndApplicationMutex.RUnlock()
+	return len(fake.getTaskBySequenceIDAndApplicationArgsForCall)
+}
+
+func (fake *FakeRunTaskActor) GetTaskBySequenceIDAndApplicationArgsForCall(i int) (int, string) {
+	fake.getTaskBySequenceIDAndApplicationMutex.RLock()
+	defer fake.getTaskBySequenceIDAndApplicationMutex.RUnlock()
+	return fake.getTaskBySequenceIDAndApplicationArgsForCall[i].sequenceID, fake.getTaskBySequenceIDAndApplicationArgsForCall[i].appGUID
+}
+
+func (fake *FakeRunTaskActor) GetTaskBySequenceIDAndApplicationReturns(result1 v3action.Task, result2 v3action.Warnings, result3 error) {
+	fake.GetTaskBySequenceIDAndApplicationStub = nil
+	fake.getTaskBySequenceIDAndApplicationReturns = struct {
+		result1 v3action.Task
+		result2 v3action.Warnings
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeRunTaskActor) RunTask(appGUID string, command string, name string) (v3action.Task, v3action.Warnings, error) {
 	fake.runTaskMutex.Lock()
 	fake.runTaskArgsForCall = append(fake.runTaskArgsForCall, struct {
@@ -109,13 +215,54 @@ func (fake *FakeRunTaskActor) RunTaskReturns(result1 v3action.Task, result2 v3ac
 	}{result1, result2, result3}
 }
 
+func (fake *FakeRunTaskActor) TerminateTask(taskGUID string) (v3action.Task, v3action.Warnings, error) {
+	fake.terminateTaskMutex.Lock()
+	fake.terminateTaskArgsForCall = append(fake.terminateTaskArgsForCall, struct {
+		taskGUID string
+	}{taskGUID})
+	fake.recordInvocation("TerminateTask", []interface{}{taskGUID})
+	fake.terminateTaskMutex.Unlock()
+	if fake.TerminateTaskStub != nil {
+		return fake.TerminateTaskStub(taskGUID)
+	} else {
+		return fake.terminateTaskReturns.result1, fake.terminateTaskReturns.result2, fake.terminateTaskReturns.result3
+	}
+}
+
+func (fake *FakeRunTaskActor) TerminateTaskCallCount() int {
+	fake.terminateTaskMutex.RLock()
+	defer fake.terminateTaskMutex.RUnlock()
+	return len(fake.terminateTaskArgsForCall)
+}
+
+func (fake *FakeRunTaskActor) TerminateTaskArgsForCall(i int) string {
+	fake.terminateTaskMutex.RLock()
+	defer fake.terminateTaskMutex.RUnlock()
+	return fake.terminateTaskArgsForCall[i].taskGUID
+}
+
+func (fake *FakeRunTaskActor) TerminateTaskReturns(result1 v3action.Task, result2 v3action.Warnings, result3 error) {
+	fake.TerminateTaskStub = nil
+	fake.terminateTaskReturns = struct {
+		result1 v3action.Task
+		result2 v3action.Warnings
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeRunTaskActor) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
 	fake.getApplicationByNameAndSpaceMutex.RLock()
 	defer fake.getApplicationByNameAndSpaceMutex.RUnlock()
+	fake.getStreamingTaskLogsMutex.RLock()
+	defer fake.getStreamingTaskLogsMutex.RUnlock()
+	fake.getTaskBySequenceIDAndApplicationMutex.RLock()
+	defer fake.getTaskBySequenceIDAndApplicationMutex.RUnlock()
 	fake.runTaskMutex.RLock()
 	defer fake.runTaskMutex.RUnlock()
+	fake.terminateTaskMutex.RLock()
+	defer fake.terminateTaskMutex.RUnlock()
 	return fake.invocations
 }
 
@@ -110,6 +110,9 @@ func parse(args []string) {
 		}
 	} else if err == ErrFailed {
 		os.Exit(1)
+	} else if _, ok := err.(configv3.ProfileNotFoundError); ok {
+		fmt.Fprintf(os.Stderr, "%s\n", err.Error())
+		os.Exit(1)
 	} else if err == ParseErr {
 		fmt.Println()
 		parse([]string{"help", args[0]})
@@ -133,9 +136,12 @@ func isOption(s string) bool {
 	return strings.HasPrefix(s, "-")
 }
 
-func executionWrapper(cmd flags.Commander, args []string) error {
+func executionWrapper(cmd flags.Commander, args []string) (err error) {
 	cfConfig, err := configv3.LoadConfig(configv3.FlagOverride{
 		Verbose: common.Commands.VerboseOrVersion,
+		Output:  common.Commands.Output,
+		NoCache: common.Commands.NoCache,
+		Profile: common.Commands.Profile,
 	})
 	if err != nil {
 		return err
@@ -143,9 +149,19 @@ func executionWrapper(cmd flags.Commander, args []string) error {
 	defer configv3.WriteConfig(cfConfig)
 
 	if extendedCmd, ok := cmd.(command.ExtendedCommander); ok {
-		commandUI, err := ui.NewUI(cfConfig)
-		if err != nil {
-			return err
+		commandUI, uiErr := ui.NewUI(cfConfig)
+		if uiErr != nil {
+			return uiErr
+		}
+		defer func() {
+			flushErr := commandUI.Flush()
+			if err == nil {
+				err = flushErr
+			}
+		}()
+
+		if format := cfConfig.OutputFormat(); !format.IsValid() {
+			return handleError(command.UnsupportedOutputFormatError{Format: string(format)}, commandUI)
 		}
 
 		err = extendedCmd.Setup(cfConfig, commandUI)
@@ -0,0 +1,338 @@
+// Package cassette records the HTTP exchanges the CLI makes to a file and
+// replays them later, so that commands can be run without a live Cloud
+// Foundry.
+//
+// Recording is enabled by setting $CF_CASSETTE_RECORD to the path of the
+// cassette file, replaying by setting $CF_CASSETTE_REPLAY. Tokens and
+// credentials are redacted before they are written to the cassette.
+package cassette
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+)
+
+const (
+	// RecordEnvKey is the environment variable holding the path of the
+	// cassette to record to.
+	RecordEnvKey = "CF_CASSETTE_RECORD"
+
+	// ReplayEnvKey is the environment variable holding the path of the
+	// cassette to replay from. It takes precedence over RecordEnvKey.
+	ReplayEnvKey = "CF_CASSETTE_REPLAY"
+
+	// RedactedValue replaces private data in a cassette.
+	RedactedValue = "[PRIVATE DATA HIDDEN]"
+)
+
+// Mode is whether a Cassette records or replays exchanges.
+type Mode string
+
+const (
+	Record Mode = "record"
+	Replay Mode = "replay"
+)
+
+// UnmatchedRequestError is returned when replaying and no recorded
+// interaction matches the request.
+type UnmatchedRequestError struct {
+	Method string
+	URL    string
+}
+
+func (e UnmatchedRequestError) Error() string {
+	return fmt.Sprintf("cassette: no recorded response for %s %s", e.Method, e.URL)
+}
+
+// Request is a recorded HTTP request.
+type Request struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+// Response is a recorded HTTP response.
+type Response struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// Interaction is a single recorded request and its response.
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+
+	replayed bool
+}
+
+// Cassette holds the interactions recorded to, or replayed from, a file. It
+// is safe for concurrent use.
+type Cassette struct {
+	Path         string
+	Mode         Mode
+	Interactions []*Interaction
+
+	mutex sync.Mutex
+}
+
+// New returns a cassette for the given file. In Replay mode the file is read
+// immediately; in Record mode it is written after every interaction.
+func New(path string, mode Mode) (*Cassette, error) {
+	cassette := &Cassette{
+		Path: path,
+		Mode: mode,
+	}
+
+	if mode == Replay {
+		raw, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		var file cassetteFile
+		if err := json.Unmarshal(raw, &file); err != nil {
+			return nil, err
+		}
+		cassette.Interactions = file.Interactions
+	}
+
+	return cassette, nil
+}
+
+var (
+	environmentOnce     sync.Once
+	environmentCassette *Cassette
+	environmentErr      error
+)
+
+// FromEnvironment returns the cassette configured by $CF_CASSETTE_REPLAY or
+// $CF_CASSETTE_RECORD. It returns nil if neither is set. The same cassette is
+// returned for the lifetime of the process, so that every client records to,
+// and replays from, one sequence of interactions.
+func FromEnvironment() (*Cassette, error) {
+	environmentOnce.Do(func() {
+		if path := os.Getenv(ReplayEnvKey); path != "" {
+			environmentCassette, environmentErr = New(path, Replay)
+		} else if path := os.Getenv(RecordEnvKey); path != "" {
+			environmentCassette, environmentErr = New(path, Record)
+		}
+	})
+	return environmentCassette, environmentErr
+}
+
+type cassetteFile struct {
+	Interactions []*Interaction `json:"interactions"`
+}
+
+func (cassette *Cassette) record(request *http.Request, requestBody []byte, response *http.Response, responseBody []byte) error {
+	cassette.mutex.Lock()
+	defer cassette.mutex.Unlock()
+
+	cassette.Interactions = append(cassette.Interactions, &Interaction{
+		Request: Request{
+			Method: request.Method,
+			URL:    request.URL.String(),
+			Header: redactHeader(request.Header),
+			Body:   redactBody(request.Header.Get("Content-Type"), requestBody),
+		},
+		Response: Response{
+			StatusCode: response.StatusCode,
+			Header:     redactHeader(response.Header),
+			Body:       redactBody(response.Header.Get("Content-Type"), responseBody),
+		},
+	})
+
+	raw, err := json.MarshalIndent(cassetteFile{Interactions: cassette.Interactions}, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(cassette.Path, raw, 0600)
+}
+
+// match returns the first interaction that has not been replayed yet and has
+// the same method, path, query and body as the request. Credentials in the
+// bodies are ignored, as they are likely to differ between runs.
+func (cassette *Cassette) match(request *http.Request, requestBody []byte) (*Interaction, error) {
+	cassette.mutex.Lock()
+	defer cassette.mutex.Unlock()
+
+	body := redactBody(request.Header.Get("Content-Type"), requestBody)
+	for _, interaction := range cassette.Interactions {
+		if interaction.replayed || interaction.Request.Method != request.Method {
+			continue
+		}
+
+		recordedURL, err := url.Parse(interaction.Request.URL)
+		if err != nil {
+			continue
+		}
+		if recordedURL.Path != request.URL.Path || !sameQuery(recordedURL.Query(), request.URL.Query()) {
+			continue
+		}
+		recordedBody := redactBody(interaction.Request.Header.Get("Content-Type"), []byte(interaction.Request.Body))
+		if recordedBody != body {
+			continue
+		}
+
+		interaction.replayed = true
+		return interaction, nil
+	}
+
+	return nil, UnmatchedRequestError{Method: request.Method, URL: request.URL.String()}
+}
+
+func sameQuery(a url.Values, b url.Values) bool {
+	return a.Encode() == b.Encode()
+}
+
+func (interaction *Interaction) httpResponse(request *http.Request) *http.Response {
+	header := http.Header{}
+	for key, values := range interaction.Response.Header {
+		header[key] = values
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", interaction.Response.StatusCode, http.StatusText(interaction.Response.StatusCode)),
+		StatusCode:    interaction.Response.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          ioutil.NopCloser(bytes.NewBufferString(interaction.Response.Body)),
+		ContentLength: int64(len(interaction.Response.Body)),
+		Request:       request,
+	}
+}
+
+var privateHeaders = []string{"Authorization", "Cookie", "Set-Cookie"}
+
+// privateFields are the form fields and JSON string values that are
+// redacted.
+var privateFields = []string{"access_token", "refresh_token", "id_token", "password", "client_secret", "passcode", "code", "private_key"}
+
+// privateDocuments are the JSON values that are redacted whatever their type:
+// service binding and service key credentials, and app environments.
+var privateDocuments = []string{"credentials", "environment_json", "system_env_json", "VCAP_SERVICES"}
+
+// placeholderToken replaces JSON Web Tokens in a cassette. It is a token
+// that does not expire and has no claims about the user who recorded the
+// cassette, so that the CLI can still decode the tokens it replays.
+var placeholderToken = encodeTokenPart(`{"alg":"RS256","typ":"JWT"}`) + "." +
+	encodeTokenPart(`{"user_name":"cassette","exp":4102444800}`) + "."
+
+func encodeTokenPart(part string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(part))
+}
+
+func redactHeader(header http.Header) http.Header {
+	if len(header) == 0 {
+		return nil
+	}
+
+	redacted := http.Header{}
+	for key, values := range header {
+		redacted[key] = values
+	}
+	for _, key := range privateHeaders {
+		if _, ok := redacted[key]; ok {
+			redacted[key] = []string{RedactedValue}
+		}
+	}
+	return redacted
+}
+
+// redactBody hides credentials in form and JSON bodies.
+func redactBody(contentType string, body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	if strings.HasPrefix(contentType, "application/x-www-form-urlencoded") {
+		values, err := url.ParseQuery(string(body))
+		if err != nil {
+			return string(body)
+		}
+		for _, field := range privateFields {
+			if value := values.Get(field); value != "" {
+				values.Set(field, redactToken(value))
+			}
+		}
+		return values.Encode()
+	}
+
+	var document interface{}
+	if err := json.Unmarshal(body, &document); err != nil {
+		return string(body)
+	}
+
+	document, redacted := redactJSON(document)
+	if !redacted {
+		return string(body)
+	}
+
+	raw, err := json.Marshal(document)
+	if err != nil {
+		return string(body)
+	}
+	return string(raw)
+}
+
+// redactJSON replaces private values anywhere in a decoded JSON document, and
+// returns whether any were found.
+func redactJSON(value interface{}) (interface{}, bool) {
+	redacted := false
+	switch typedValue := value.(type) {
+	case map[string]interface{}:
+		for key, fieldValue := range typedValue {
+			if contains(privateDocuments, key) {
+				typedValue[key] = RedactedValue
+				redacted = true
+				continue
+			}
+			if token, ok := fieldValue.(string); ok && contains(privateFields, key) {
+				typedValue[key] = redactToken(token)
+				redacted = true
+				continue
+			}
+
+			var fieldRedacted bool
+			typedValue[key], fieldRedacted = redactJSON(fieldValue)
+			redacted = redacted || fieldRedacted
+		}
+	case []interface{}:
+		for i, element := range typedValue {
+			var elementRedacted bool
+			typedValue[i], elementRedacted = redactJSON(element)
+			redacted = redacted || elementRedacted
+		}
+	}
+	return value, redacted
+}
+
+func contains(fields []string, key string) bool {
+	for _, field := range fields {
+		if key == field {
+			return true
+		}
+	}
+	return false
+}
+
+// redactToken replaces a JSON Web Token with the placeholder token and any
+// other value with RedactedValue.
+func redactToken(value string) string {
+	if len(strings.Split(value, ".")) == 3 {
+		return placeholderToken
+	}
+	return RedactedValue
+}
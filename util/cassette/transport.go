@@ -0,0 +1,83 @@
+package cassette
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+)
+
+// Transport is an http.RoundTripper that records the exchanges made through
+// it to a Cassette, or replays them from one.
+type Transport struct {
+	Cassette  *Cassette
+	Transport http.RoundTripper
+}
+
+// WrapTransport returns transport wrapped in a Transport for the cassette
+// configured in the environment. If no cassette is configured, transport is
+// returned unchanged.
+func WrapTransport(transport http.RoundTripper) http.RoundTripper {
+	cassette, err := FromEnvironment()
+	if err != nil {
+		return errorTransport{err: err}
+	}
+	if cassette == nil {
+		return transport
+	}
+
+	return &Transport{
+		Cassette:  cassette,
+		Transport: transport,
+	}
+}
+
+// RoundTrip records or replays a single HTTP exchange.
+func (transport *Transport) RoundTrip(request *http.Request) (*http.Response, error) {
+	var requestBody []byte
+	if request.Body != nil {
+		var err error
+		requestBody, err = ioutil.ReadAll(request.Body)
+		request.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		request.Body = ioutil.NopCloser(bytes.NewBuffer(requestBody))
+	}
+
+	if transport.Cassette.Mode == Replay {
+		interaction, err := transport.Cassette.match(request, requestBody)
+		if err != nil {
+			return nil, err
+		}
+		return interaction.httpResponse(request), nil
+	}
+
+	response, err := transport.Transport.RoundTrip(request)
+	if err != nil {
+		return nil, err
+	}
+
+	responseBody, err := ioutil.ReadAll(response.Body)
+	response.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	response.Body = ioutil.NopCloser(bytes.NewBuffer(responseBody))
+
+	err = transport.Cassette.record(request, requestBody, response, responseBody)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// errorTransport fails every request with the error that prevented the
+// cassette from being loaded.
+type errorTransport struct {
+	err error
+}
+
+func (transport errorTransport) RoundTrip(*http.Request) (*http.Response, error) {
+	return nil, transport.err
+}
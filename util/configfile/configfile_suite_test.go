@@ -0,0 +1,13 @@
+package configfile_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestConfigfile(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Configfile Suite")
+}
@@ -0,0 +1,72 @@
+// Package configfile safely updates configuration files that are shared by
+// several CLI processes, such as build agents running cf commands under one
+// $CF_HOME. Writers hold an advisory lock while they read, merge and replace
+// the file, and the file is replaced atomically so readers never see a
+// partially written file.
+package configfile
+
+import (
+	"fmt"
+	"os"
+	"time"
+)
+
+// LockTimeout is how long LockFile waits for another process to release the
+// lock.
+const LockTimeout = 10 * time.Second
+
+const lockRetryInterval = 50 * time.Millisecond
+
+// LockTimeoutError is returned when the lock is not released by another
+// process within the LockTimeout.
+type LockTimeoutError struct {
+	Path string
+}
+
+func (e LockTimeoutError) Error() string {
+	return fmt.Sprintf("timed out waiting for the lock on %s", e.Path)
+}
+
+// Lock is an advisory lock held on a file.
+type Lock struct {
+	file *os.File
+}
+
+// LockFile acquires an exclusive advisory lock for the file at path. The lock
+// is taken on a separate '.lock' file, since the locked file itself is
+// replaced when it is written.
+func LockFile(path string) (*Lock, error) {
+	lockPath := path + ".lock"
+	file, err := os.OpenFile(lockPath, os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, err
+	}
+
+	deadline := time.Now().Add(LockTimeout)
+	for {
+		locked, err := tryLock(file)
+		if err != nil {
+			file.Close()
+			return nil, err
+		}
+		if locked {
+			return &Lock{file: file}, nil
+		}
+
+		if time.Now().After(deadline) {
+			file.Close()
+			return nil, LockTimeoutError{Path: lockPath}
+		}
+		time.Sleep(lockRetryInterval)
+	}
+}
+
+// Unlock releases the lock.
+func (lock *Lock) Unlock() error {
+	err := unlock(lock.file)
+	closeErr := lock.file.Close()
+	if err != nil {
+		return err
+	}
+	return closeErr
+}
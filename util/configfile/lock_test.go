@@ -0,0 +1,58 @@
+package configfile_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "code.cloudfoundry.org/cli/util/configfile"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("LockFile", func() {
+	var (
+		tempDir string
+		path    string
+	)
+
+	BeforeEach(func() {
+		var err error
+		tempDir, err = ioutil.TempDir("", "configfile-lock")
+		Expect(err).ToNot(HaveOccurred())
+		path = filepath.Join(tempDir, "config.json")
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(tempDir)
+	})
+
+	It("locks a separate lock file", func() {
+		lock, err := LockFile(path)
+		Expect(err).ToNot(HaveOccurred())
+		defer lock.Unlock()
+
+		Expect(filepath.Join(tempDir, "config.json.lock")).To(BeAnExistingFile())
+	})
+
+	It("waits for the lock to be released", func() {
+		lock, err := LockFile(path)
+		Expect(err).ToNot(HaveOccurred())
+
+		acquired := make(chan *Lock)
+		go func() {
+			defer GinkgoRecover()
+			otherLock, otherErr := LockFile(path)
+			Expect(otherErr).ToNot(HaveOccurred())
+			acquired <- otherLock
+		}()
+
+		Consistently(acquired, "200ms").ShouldNot(Receive())
+		Expect(lock.Unlock()).To(Succeed())
+
+		var otherLock *Lock
+		Eventually(acquired).Should(Receive(&otherLock))
+		Expect(otherLock.Unlock()).To(Succeed())
+	})
+})
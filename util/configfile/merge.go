@@ -0,0 +1,86 @@
+package configfile
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+)
+
+// MergeJSON merges the changes two processes made to the same JSON document.
+// base is the document both processes started from, ours is this process's
+// version and theirs is the version currently on disk. Values this process
+// changed, added or removed are taken from ours and every other value from
+// theirs, so that, for example, a token refresh does not undo another
+// process's change of targeted space. Objects are merged key by key; all
+// other values are replaced as a whole.
+func MergeJSON(base []byte, ours []byte, theirs []byte) ([]byte, error) {
+	baseValue, err := decode(base)
+	if err != nil {
+		return nil, err
+	}
+	oursValue, err := decode(ours)
+	if err != nil {
+		return nil, err
+	}
+	theirsValue, err := decode(theirs)
+	if err != nil {
+		return nil, err
+	}
+
+	merged, _ := merge(baseValue, oursValue, theirsValue, true, true, true)
+	return json.Marshal(merged)
+}
+
+// merge returns the merged value and whether it is present.
+func merge(base interface{}, ours interface{}, theirs interface{}, inBase bool, inOurs bool, inTheirs bool) (interface{}, bool) {
+	baseObject, baseIsObject := base.(map[string]interface{})
+	oursObject, oursIsObject := ours.(map[string]interface{})
+	theirsObject, theirsIsObject := theirs.(map[string]interface{})
+
+	if baseIsObject && oursIsObject && theirsIsObject {
+		merged := map[string]interface{}{}
+		for _, key := range keys(baseObject, oursObject, theirsObject) {
+			baseChild, inBaseChild := baseObject[key]
+			oursChild, inOursChild := oursObject[key]
+			theirsChild, inTheirsChild := theirsObject[key]
+
+			value, present := merge(baseChild, oursChild, theirsChild, inBaseChild, inOursChild, inTheirsChild)
+			if present {
+				merged[key] = value
+			}
+		}
+		return merged, true
+	}
+
+	if inOurs != inBase || !reflect.DeepEqual(ours, base) {
+		return ours, inOurs
+	}
+	return theirs, inTheirs
+}
+
+func keys(objects ...map[string]interface{}) []string {
+	seen := map[string]bool{}
+	var allKeys []string
+	for _, object := range objects {
+		for key := range object {
+			if !seen[key] {
+				seen[key] = true
+				allKeys = append(allKeys, key)
+			}
+		}
+	}
+	return allKeys
+}
+
+func decode(raw []byte) (interface{}, error) {
+	if len(bytes.TrimSpace(raw)) == 0 {
+		return map[string]interface{}{}, nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+
+	var value interface{}
+	err := decoder.Decode(&value)
+	return value, err
+}
@@ -0,0 +1,90 @@
+package configfile_test
+
+import (
+	. "code.cloudfoundry.org/cli/util/configfile"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("MergeJSON", func() {
+	var (
+		base   string
+		ours   string
+		theirs string
+
+		merged     []byte
+		executeErr error
+	)
+
+	JustBeforeEach(func() {
+		merged, executeErr = MergeJSON([]byte(base), []byte(ours), []byte(theirs))
+	})
+
+	Context("when the processes changed different values", func() {
+		BeforeEach(func() {
+			base = `{"AccessToken": "old-token", "SpaceFields": {"GUID": "old-space-guid", "Name": "old-space"}, "AsyncTimeout": 0}`
+			ours = `{"AccessToken": "new-token", "SpaceFields": {"GUID": "old-space-guid", "Name": "old-space"}, "AsyncTimeout": 0}`
+			theirs = `{"AccessToken": "old-token", "SpaceFields": {"GUID": "new-space-guid", "Name": "new-space"}, "AsyncTimeout": 10}`
+		})
+
+		It("keeps both changes", func() {
+			Expect(executeErr).ToNot(HaveOccurred())
+			Expect(merged).To(MatchJSON(`{"AccessToken": "new-token", "SpaceFields": {"GUID": "new-space-guid", "Name": "new-space"}, "AsyncTimeout": 10}`))
+		})
+	})
+
+	Context("when both processes changed the same value", func() {
+		BeforeEach(func() {
+			base = `{"AccessToken": "old-token"}`
+			ours = `{"AccessToken": "our-token"}`
+			theirs = `{"AccessToken": "their-token"}`
+		})
+
+		It("keeps our change", func() {
+			Expect(executeErr).ToNot(HaveOccurred())
+			Expect(merged).To(MatchJSON(`{"AccessToken": "our-token"}`))
+		})
+	})
+
+	Context("when keys are added and removed", func() {
+		BeforeEach(func() {
+			base = `{"Profiles": {"staging": {"Target": "a"}, "qa": {"Target": "b"}}, "PluginRepos": [{"Name": "a"}]}`
+			ours = `{"Profiles": {"staging": {"Target": "a"}, "production": {"Target": "c"}}, "PluginRepos": [{"Name": "a"}]}`
+			theirs = `{"Profiles": {"staging": {"Target": "a"}, "qa": {"Target": "b"}, "development": {"Target": "d"}}, "PluginRepos": [{"Name": "a"}, {"Name": "b"}]}`
+		})
+
+		It("keeps every addition and removal", func() {
+			Expect(executeErr).ToNot(HaveOccurred())
+			Expect(merged).To(MatchJSON(`{
+				"Profiles": {"staging": {"Target": "a"}, "production": {"Target": "c"}, "development": {"Target": "d"}},
+				"PluginRepos": [{"Name": "a"}, {"Name": "b"}]
+			}`))
+		})
+	})
+
+	Context("when there was no base document", func() {
+		BeforeEach(func() {
+			base = ""
+			ours = `{"AccessToken": "our-token"}`
+			theirs = `{"AccessToken": "their-token", "Locale": "fr_FR"}`
+		})
+
+		It("keeps every value of ours", func() {
+			Expect(executeErr).ToNot(HaveOccurred())
+			Expect(merged).To(MatchJSON(`{"AccessToken": "our-token", "Locale": "fr_FR"}`))
+		})
+	})
+
+	Context("when a document is not JSON", func() {
+		BeforeEach(func() {
+			base = `{}`
+			ours = `{}`
+			theirs = `{"not json`
+		})
+
+		It("returns an error", func() {
+			Expect(executeErr).To(HaveOccurred())
+		})
+	})
+})
@@ -0,0 +1,43 @@
+package configfile
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+)
+
+// WriteAtomically replaces the file at path with data. The data is written
+// to a temporary file in the same directory, synced to disk and then renamed
+// over the file, so the file is either entirely old or entirely new.
+func WriteAtomically(path string, data []byte, perm os.FileMode) error {
+	tempFile, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path)+".tmp")
+	if err != nil {
+		return err
+	}
+	tempPath := tempFile.Name()
+
+	err = writeAndSync(tempFile, data)
+	if closeErr := tempFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil && runtime.GOOS != "windows" {
+		err = os.Chmod(tempPath, perm)
+	}
+	if err == nil {
+		err = os.Rename(tempPath, path)
+	}
+
+	if err != nil {
+		_ = os.Remove(tempPath)
+	}
+	return err
+}
+
+func writeAndSync(file *os.File, data []byte) error {
+	_, err := file.Write(data)
+	if err != nil {
+		return err
+	}
+	return file.Sync()
+}
@@ -0,0 +1,64 @@
+package configfile_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	. "code.cloudfoundry.org/cli/util/configfile"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("WriteAtomically", func() {
+	var (
+		tempDir string
+		path    string
+	)
+
+	BeforeEach(func() {
+		var err error
+		tempDir, err = ioutil.TempDir("", "configfile-write")
+		Expect(err).ToNot(HaveOccurred())
+		path = filepath.Join(tempDir, "config.json")
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(tempDir)
+	})
+
+	It("replaces the file without leaving temporary files behind", func() {
+		Expect(ioutil.WriteFile(path, []byte("old contents that are longer"), 0600)).To(Succeed())
+
+		Expect(WriteAtomically(path, []byte("new contents"), 0600)).To(Succeed())
+
+		contents, err := ioutil.ReadFile(path)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(contents)).To(Equal("new contents"))
+
+		files, err := ioutil.ReadDir(tempDir)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(files).To(HaveLen(1))
+	})
+
+	It("sets the file's permissions", func() {
+		if runtime.GOOS == "windows" {
+			Skip("file modes are not supported on Windows")
+		}
+
+		Expect(WriteAtomically(path, []byte("contents"), 0600)).To(Succeed())
+
+		info, err := os.Stat(path)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(info.Mode().Perm()).To(Equal(os.FileMode(0600)))
+	})
+
+	Context("when the directory does not exist", func() {
+		It("returns an error", func() {
+			err := WriteAtomically(filepath.Join(tempDir, "missing", "config.json"), []byte("contents"), 0600)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
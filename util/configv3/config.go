@@ -2,6 +2,7 @@
 package configv3
 
 import (
+	"bytes"
 	"encoding/json"
 	"io/ioutil"
 	"os"
@@ -9,6 +10,9 @@ import (
 	"strconv"
 	"time"
 
+	"code.cloudfoundry.org/cli/api/uaa"
+	"code.cloudfoundry.org/cli/util/configfile"
+	"code.cloudfoundry.org/cli/util/responsecache"
 	"code.cloudfoundry.org/cli/version"
 )
 
@@ -23,6 +27,14 @@ const (
 	// DefaultDialTimeout is the default timeout for the dail.
 	DefaultDialTimeout = 5 * time.Second
 
+	// DefaultRetryMaxAttempts is the default number of times a failed Cloud
+	// Controller request is attempted.
+	DefaultRetryMaxAttempts = 3
+
+	// DefaultRetryMaxElapsedTime is the default maximum time spent retrying a
+	// failed Cloud Controller request.
+	DefaultRetryMaxElapsedTime = time.Minute
+
 	// DefaultTarget is the default CFConfig value for Target.
 	DefaultTarget = ""
 
@@ -78,6 +90,7 @@ func LoadConfig(flags ...FlagOverride) (*Config, error) {
 		if err != nil {
 			return nil, err
 		}
+		config.loadedConfigFile = file
 
 		if config.ConfigFile.UAAOAuthClient == "" {
 			config.ConfigFile.UAAOAuthClient = DefaultUAAOAuthClient
@@ -97,6 +110,11 @@ func LoadConfig(flags ...FlagOverride) (*Config, error) {
 		LCAll:            os.Getenv("LC_ALL"),
 		Experimental:     os.Getenv("CF_CLI_EXPERIMENTAL"),
 		CFDialTimeout:    os.Getenv("CF_DIAL_TIMEOUT"),
+
+		CFCache:               os.Getenv("CF_CACHE"),
+		CFRetryMaxAttempts:    os.Getenv("CF_RETRY_MAX_ATTEMPTS"),
+		CFRetryMaxElapsedTime: os.Getenv("CF_RETRY_MAX_ELAPSED_TIME"),
+		CFProfile:             os.Getenv("CF_PROFILE"),
 	}
 
 	pluginFilePath := filepath.Join(config.PluginHome(), "config.json")
@@ -118,14 +136,23 @@ func LoadConfig(flags ...FlagOverride) (*Config, error) {
 		config.Flags = flags[0]
 	}
 
+	err := config.selectProfile()
+	if err != nil {
+		return nil, err
+	}
+
 	return &config, nil
 }
 
 // WriteConfig creates the .cf directory and then writes the config.json. The
 // location of .cf directory is written in the same way LoadConfig reads .cf
 // directory.
+//
+// The config.json is locked while it is written. If another process changed
+// it since it was loaded, only the values changed by this process are
+// written over the other process's changes. The file is replaced atomically.
 func WriteConfig(c *Config) error {
-	rawConfig, err := json.MarshalIndent(c.ConfigFile, "", "  ")
+	rawConfig, err := json.MarshalIndent(c.fileContents(), "", "  ")
 	if err != nil {
 		return err
 	}
@@ -135,7 +162,38 @@ func WriteConfig(c *Config) error {
 		return err
 	}
 
-	return ioutil.WriteFile(ConfigFilePath(), rawConfig, 0600)
+	filePath := ConfigFilePath()
+	lock, err := configfile.LockFile(filePath)
+	if err != nil {
+		return err
+	}
+	defer lock.Unlock()
+
+	currentConfig, err := ioutil.ReadFile(filePath)
+	if err == nil && !bytes.Equal(currentConfig, c.loadedConfigFile) {
+		rawConfig, err = mergeConfig(c.loadedConfigFile, rawConfig, currentConfig)
+		if err != nil {
+			return err
+		}
+	}
+
+	return configfile.WriteAtomically(filePath, rawConfig, 0600)
+}
+
+// mergeConfig merges this process's changes into the config file written by
+// another process, keeping the layout of the CFConfig.
+func mergeConfig(loadedConfig []byte, config []byte, currentConfig []byte) ([]byte, error) {
+	mergedConfig, err := configfile.MergeJSON(loadedConfig, config, currentConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	var file CFConfig
+	err = json.Unmarshal(mergedConfig, &file)
+	if err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(file, "", "  ")
 }
 
 // Config combines the settings taken from the .cf/config.json, os.ENV, and the
@@ -151,6 +209,17 @@ type Config struct {
 	Flags FlagOverride
 
 	pluginConfig PluginsConfig
+
+	// profileName is the name of the profile whose settings are at the top
+	// level of the ConfigFile. It is empty for the default profile.
+	profileName string
+
+	// defaultSettings stores the default profile's settings while another
+	// profile is in use.
+	defaultSettings Profile
+
+	// loadedConfigFile is the contents of the config.json when it was loaded.
+	loadedConfigFile []byte
 }
 
 // CFConfig represents .cf/config.json
@@ -167,6 +236,7 @@ type CFConfig struct {
 	SSHOAuthClient           string        `json:"SSHOAuthClient"`
 	UAAOAuthClient           string        `json:"UAAOAuthClient"`
 	UAAOAuthClientSecret     string        `json:"UAAOAuthClientSecret"`
+	UAAGrantType             string        `json:"UAAGrantType"`
 	RefreshToken             string        `json:"RefreshToken"`
 	TargetedOrganization     Organization  `json:"OrganizationFields"`
 	TargetedSpace            Space         `json:"SpaceFields"`
@@ -178,6 +248,12 @@ type CFConfig struct {
 	PluginRepos              []PluginRepos `json:"PluginRepos"`
 	MinCLIVersion            string        `json:"MinCLIVersion"`
 	MinRecommendedCLIVersion string        `json:"MinRecommendedCLIVersion"`
+
+	// ActiveProfile is the name of the profile used when neither the
+	// '--profile' flag nor $CF_PROFILE is set. It is empty for the default
+	// profile.
+	ActiveProfile string             `json:"ActiveProfile,omitempty"`
+	Profiles      map[string]Profile `json:"Profiles,omitempty"`
 }
 
 // Organization contains basic information about the targeted organization
@@ -221,11 +297,19 @@ type EnvOverride struct {
 	LCAll            string
 	Experimental     string
 	CFDialTimeout    string
+
+	CFCache               string
+	CFRetryMaxAttempts    string
+	CFRetryMaxElapsedTime string
+	CFProfile             string
 }
 
 // FlagOverride represents all the global flags passed to the CF CLI
 type FlagOverride struct {
 	Verbose bool
+	Output  string
+	NoCache bool
+	Profile string
 }
 
 // Target returns the CC API URL
@@ -259,6 +343,18 @@ func (config *Config) UAAOAuthClientSecret() string {
 	return config.ConfigFile.UAAOAuthClientSecret
 }
 
+// UAAGrantType returns the grant type the CLI authenticated with. It is
+// empty for users, and "client_credentials" for clients.
+func (config *Config) UAAGrantType() string {
+	return config.ConfigFile.UAAGrantType
+}
+
+// AuthorizationEndpoint returns the URL of the login server, where users
+// authorize the CLI in a browser and obtain one-time passcodes
+func (config *Config) AuthorizationEndpoint() string {
+	return config.ConfigFile.AuthorizationEndpoint
+}
+
 // APIVersion returns the CC API Version
 func (config *Config) APIVersion() string {
 	return config.ConfigFile.APIVersion
@@ -336,6 +432,34 @@ func (config *Config) Experimental() bool {
 	return false
 }
 
+// ResponseCacheEnabled returns whether or not Cloud Controller responses
+// should be cached on disk. This is based off of:
+//   1. The --no-cache flag if passed
+//   2. The $CF_CACHE environment variable if set
+//   3. Defaults to false
+func (config *Config) ResponseCacheEnabled() bool {
+	if config.Flags.NoCache {
+		return false
+	}
+
+	if config.ENV.CFCache != "" {
+		envVal, err := strconv.ParseBool(config.ENV.CFCache)
+		if err == nil {
+			return envVal
+		}
+	}
+
+	return false
+}
+
+// ResponseCacheDir returns the directory Cloud Controller responses are cached
+// in. Every target and user combination has its own directory in the '.cf'
+// directory's cache folder.
+func (config *Config) ResponseCacheDir() string {
+	user, _ := config.CurrentUser()
+	return responsecache.Dir(filepath.Join(filepath.Dir(ConfigFilePath()), "cache"), config.Target(), user.Name)
+}
+
 // Verbose returns true if verbose should be displayed to terminal and a
 // location to log to. This is based off of:
 //   1. The $CF_TRACE enviroment variable if set (true/false/file path)
@@ -383,6 +507,36 @@ func (config *Config) DialTimeout() time.Duration {
 	return DefaultDialTimeout
 }
 
+// RetryMaxAttempts returns the number of times a failed Cloud Controller
+// request is attempted. This is based off of:
+//   1. The $CF_RETRY_MAX_ATTEMPTS environment variable if set
+//   2. Defaults to DefaultRetryMaxAttempts
+func (config *Config) RetryMaxAttempts() int {
+	if config.ENV.CFRetryMaxAttempts != "" {
+		envVal, err := strconv.Atoi(config.ENV.CFRetryMaxAttempts)
+		if err == nil && envVal > 0 {
+			return envVal
+		}
+	}
+
+	return DefaultRetryMaxAttempts
+}
+
+// RetryMaxElapsedTime returns the maximum time spent retrying a failed Cloud
+// Controller request. This is based off of:
+//   1. The $CF_RETRY_MAX_ELAPSED_TIME environment variable (in seconds) if set
+//   2. Defaults to DefaultRetryMaxElapsedTime
+func (config *Config) RetryMaxElapsedTime() time.Duration {
+	if config.ENV.CFRetryMaxElapsedTime != "" {
+		envVal, err := strconv.ParseInt(config.ENV.CFRetryMaxElapsedTime, 10, 64)
+		if err == nil && envVal >= 0 {
+			return time.Duration(envVal) * time.Second
+		}
+	}
+
+	return DefaultRetryMaxElapsedTime
+}
+
 func (config *Config) BinaryVersion() string {
 	return version.BinaryVersion
 }
@@ -432,3 +586,34 @@ func (config *Config) SetTokenInformation(accessToken string, refreshToken strin
 func (config *Config) SetAccessToken(accessToken string) {
 	config.ConfigFile.AccessToken = accessToken
 }
+
+// SetRefreshToken sets the current refresh token
+func (config *Config) SetRefreshToken(refreshToken string) {
+	config.ConfigFile.RefreshToken = refreshToken
+}
+
+// SetUAAGrantType sets the grant type the CLI authenticated with
+func (config *Config) SetUAAGrantType(uaaGrantType string) {
+	config.ConfigFile.UAAGrantType = uaaGrantType
+}
+
+// SetUAAClientCredentials sets the client ID and secret used to communicate
+// with the UAA
+func (config *Config) SetUAAClientCredentials(client string, clientSecret string) {
+	config.ConfigFile.UAAOAuthClient = client
+	config.ConfigFile.UAAOAuthClientSecret = clientSecret
+}
+
+// UnsetUserInformation resets the tokens, the UAA grant type and the
+// targeted organization and space. If a client was logged in, the UAA client
+// credentials are reset to the defaults as well
+func (config *Config) UnsetUserInformation() {
+	if config.UAAGrantType() == uaa.GrantTypeClientCredentials {
+		config.SetUAAClientCredentials(DefaultUAAOAuthClient, DefaultUAAOAuthClientSecret)
+	}
+	config.SetAccessToken("")
+	config.SetRefreshToken("")
+	config.SetUAAGrantType("")
+	config.SetOrganizationInformation("", "")
+	config.SetSpaceInformation("", "", false)
+}
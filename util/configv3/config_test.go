@@ -419,6 +419,72 @@ var _ = Describe("Config", func() {
 			})
 		})
 
+		Describe("RetryMaxAttempts", func() {
+			It("defaults to DefaultRetryMaxAttempts", func() {
+				config := Config{}
+				Expect(config.RetryMaxAttempts()).To(Equal(DefaultRetryMaxAttempts))
+			})
+
+			It("returns $CF_RETRY_MAX_ATTEMPTS when set", func() {
+				config := Config{ENV: EnvOverride{CFRetryMaxAttempts: "5"}}
+				Expect(config.RetryMaxAttempts()).To(Equal(5))
+			})
+
+			It("ignores an invalid $CF_RETRY_MAX_ATTEMPTS", func() {
+				config := Config{ENV: EnvOverride{CFRetryMaxAttempts: "0"}}
+				Expect(config.RetryMaxAttempts()).To(Equal(DefaultRetryMaxAttempts))
+			})
+		})
+
+		Describe("RetryMaxElapsedTime", func() {
+			It("defaults to DefaultRetryMaxElapsedTime", func() {
+				config := Config{}
+				Expect(config.RetryMaxElapsedTime()).To(Equal(DefaultRetryMaxElapsedTime))
+			})
+
+			It("returns $CF_RETRY_MAX_ELAPSED_TIME in seconds when set", func() {
+				config := Config{ENV: EnvOverride{CFRetryMaxElapsedTime: "90"}}
+				Expect(config.RetryMaxElapsedTime()).To(Equal(90 * time.Second))
+			})
+
+			It("ignores an invalid $CF_RETRY_MAX_ELAPSED_TIME", func() {
+				config := Config{ENV: EnvOverride{CFRetryMaxElapsedTime: "soon"}}
+				Expect(config.RetryMaxElapsedTime()).To(Equal(DefaultRetryMaxElapsedTime))
+			})
+		})
+
+		Describe("ResponseCacheEnabled", func() {
+			It("defaults to false", func() {
+				config := Config{}
+				Expect(config.ResponseCacheEnabled()).To(BeFalse())
+			})
+
+			It("returns true when $CF_CACHE is true", func() {
+				config := Config{ENV: EnvOverride{CFCache: "true"}}
+				Expect(config.ResponseCacheEnabled()).To(BeTrue())
+			})
+
+			It("returns false when --no-cache is passed", func() {
+				config := Config{Flags: FlagOverride{NoCache: true}, ENV: EnvOverride{CFCache: "true"}}
+				Expect(config.ResponseCacheEnabled()).To(BeFalse())
+			})
+
+			It("ignores an invalid $CF_CACHE", func() {
+				config := Config{ENV: EnvOverride{CFCache: "sometimes"}}
+				Expect(config.ResponseCacheEnabled()).To(BeFalse())
+			})
+		})
+
+		Describe("ResponseCacheDir", func() {
+			It("is a different directory for every target", func() {
+				config := Config{ConfigFile: CFConfig{Target: "https://api.foo.com"}}
+				otherConfig := Config{ConfigFile: CFConfig{Target: "https://api.bar.com"}}
+
+				Expect(filepath.Dir(config.ResponseCacheDir())).To(Equal(filepath.Join(filepath.Dir(ConfigFilePath()), "cache")))
+				Expect(config.ResponseCacheDir()).ToNot(Equal(otherConfig.ResponseCacheDir()))
+			})
+		})
+
 		Describe("BinaryVersion", func() {
 			It("returns back version.BinaryVersion", func() {
 				conf := Config{}
@@ -465,6 +531,58 @@ var _ = Describe("Config", func() {
 			Expect(writtenCFConfig.Target).To(Equal(config.ConfigFile.Target))
 			Expect(writtenCFConfig.ColorEnabled).To(Equal(config.ConfigFile.ColorEnabled))
 		})
+
+		It("does not leave the lock or temporary files behind in the config", func() {
+			err := WriteConfig(config)
+			Expect(err).ToNot(HaveOccurred())
+
+			files, err := ioutil.ReadDir(filepath.Join(homeDir, ".cf"))
+			Expect(err).ToNot(HaveOccurred())
+			var names []string
+			for _, file := range files {
+				names = append(names, file.Name())
+			}
+			Expect(names).To(ConsistOf("config.json", "config.json.lock"))
+		})
+
+		Context("when another process changed the config after it was loaded", func() {
+			BeforeEach(func() {
+				setConfig(homeDir, `{
+					"ConfigVersion": 3,
+					"Target": "https://api.foo.com",
+					"AccessToken": "old-token",
+					"SpaceFields": {"GUID": "old-space-guid", "Name": "old-space"}
+				}`)
+
+				var err error
+				config, err = LoadConfig()
+				Expect(err).ToNot(HaveOccurred())
+
+				setConfig(homeDir, `{
+					"ConfigVersion": 3,
+					"Target": "https://api.foo.com",
+					"AccessToken": "old-token",
+					"SpaceFields": {"GUID": "new-space-guid", "Name": "new-space"}
+				}`)
+			})
+
+			It("keeps the other process's changes", func() {
+				config.SetAccessToken("new-token")
+				err := WriteConfig(config)
+				Expect(err).ToNot(HaveOccurred())
+
+				file, err := ioutil.ReadFile(filepath.Join(homeDir, ".cf", "config.json"))
+				Expect(err).ToNot(HaveOccurred())
+
+				var writtenCFConfig CFConfig
+				err = json.Unmarshal(file, &writtenCFConfig)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(writtenCFConfig.AccessToken).To(Equal("new-token"))
+				Expect(writtenCFConfig.TargetedSpace.Name).To(Equal("new-space"))
+				Expect(writtenCFConfig.TargetedSpace.GUID).To(Equal("new-space-guid"))
+			})
+		})
 	})
 
 	Describe("setter functions", func() {
@@ -530,6 +648,51 @@ var _ = Describe("Config", func() {
 			})
 		})
 
+		Describe("SetUAAClientCredentials", func() {
+			It("sets the UAA client ID and secret", func() {
+				var config Config
+				config.SetUAAClientCredentials("some-client", "some-secret")
+				Expect(config.UAAOAuthClient()).To(Equal("some-client"))
+				Expect(config.UAAOAuthClientSecret()).To(Equal("some-secret"))
+			})
+		})
+
+		Describe("UnsetUserInformation", func() {
+			var config Config
+
+			BeforeEach(func() {
+				config = Config{}
+				config.SetTokenInformation("some-access-token", "some-refresh-token", "ssh-client")
+				config.SetUAAClientCredentials("some-client", "some-secret")
+				config.SetOrganizationInformation("some-org-guid", "some-org")
+				config.SetSpaceInformation("some-space-guid", "some-space", true)
+			})
+
+			It("resets the tokens, organization and space", func() {
+				config.UnsetUserInformation()
+
+				Expect(config.AccessToken()).To(BeEmpty())
+				Expect(config.RefreshToken()).To(BeEmpty())
+				Expect(config.TargetedOrganization()).To(Equal(Organization{}))
+				Expect(config.TargetedSpace()).To(Equal(Space{}))
+				Expect(config.UAAOAuthClient()).To(Equal("some-client"))
+			})
+
+			Context("when a client was logged in", func() {
+				BeforeEach(func() {
+					config.SetUAAGrantType("client_credentials")
+				})
+
+				It("resets the grant type and UAA client credentials", func() {
+					config.UnsetUserInformation()
+
+					Expect(config.UAAGrantType()).To(BeEmpty())
+					Expect(config.UAAOAuthClient()).To(Equal(DefaultUAAOAuthClient))
+					Expect(config.UAAOAuthClientSecret()).To(Equal(DefaultUAAOAuthClientSecret))
+				})
+			})
+		})
+
 		Describe("SetOrganizationInformation", func() {
 			It("sets the organization GUID and name", func() {
 				config := Config{}
@@ -0,0 +1,38 @@
+package configv3
+
+import "strings"
+
+const (
+	// OutputText means that commands display human readable text.
+	OutputText OutputFormat = ""
+
+	// OutputJSON means that commands display a single JSON document.
+	OutputJSON OutputFormat = "json"
+
+	// OutputYAML means that commands display a single YAML document.
+	OutputYAML OutputFormat = "yaml"
+)
+
+// OutputFormat represents the format that commands display their results in.
+type OutputFormat string
+
+// OutputFormat returns the output format based off:
+//   1. The '--output' global flag if set (text/json/yaml)
+//   2. Defaults to OutputText if nothing is set
+// Unrecognized values are returned as is so that the caller can report them.
+func (config *Config) OutputFormat() OutputFormat {
+	format := strings.ToLower(config.Flags.Output)
+	if format == "text" {
+		return OutputText
+	}
+	return OutputFormat(format)
+}
+
+// IsValid returns true if the format is one that the CLI can display.
+func (format OutputFormat) IsValid() bool {
+	switch format {
+	case OutputText, OutputJSON, OutputYAML:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,39 @@
+package configv3_test
+
+import (
+	. "code.cloudfoundry.org/cli/util/configv3"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Config", func() {
+	var homeDir string
+
+	BeforeEach(func() {
+		homeDir = setup()
+	})
+
+	AfterEach(func() {
+		teardown(homeDir)
+	})
+
+	DescribeTable("OutputFormat",
+		func(flagVal string, expected OutputFormat, valid bool) {
+			config, err := LoadConfig(FlagOverride{
+				Output: flagVal,
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(config).ToNot(BeNil())
+
+			Expect(config.OutputFormat()).To(Equal(expected))
+			Expect(config.OutputFormat().IsValid()).To(Equal(valid))
+		},
+		Entry("flag=unset falls back to text", "", OutputText, true),
+		Entry("flag=text", "text", OutputText, true),
+		Entry("flag=json", "json", OutputJSON, true),
+		Entry("flag=JSON", "JSON", OutputJSON, true),
+		Entry("flag=yaml", "yaml", OutputYAML, true),
+		Entry("flag=xml is returned but invalid", "xml", OutputFormat("xml"), false),
+	)
+})
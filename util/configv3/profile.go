@@ -0,0 +1,323 @@
+package configv3
+
+import (
+	"fmt"
+	"sort"
+)
+
+// DefaultProfileName is the name of the profile stored at the top level of
+// the config file. It is used when no other profile is active.
+const DefaultProfileName = "default"
+
+// Profile holds the target, credentials and targeted organization and space
+// of a single Cloud Foundry environment.
+type Profile struct {
+	Target                   string       `json:"Target"`
+	APIVersion               string       `json:"APIVersion"`
+	AuthorizationEndpoint    string       `json:"AuthorizationEndpoint"`
+	LoggregatorEndpoint      string       `json:"LoggregatorEndPoint"`
+	DopplerEndpoint          string       `json:"DopplerEndPoint"`
+	UAAEndpoint              string       `json:"UaaEndpoint"`
+	RoutingEndpoint          string       `json:"RoutingAPIEndpoint"`
+	AccessToken              string       `json:"AccessToken"`
+	SSHOAuthClient           string       `json:"SSHOAuthClient"`
+	UAAOAuthClient           string       `json:"UAAOAuthClient"`
+	UAAOAuthClientSecret     string       `json:"UAAOAuthClientSecret"`
+	UAAGrantType             string       `json:"UAAGrantType"`
+	RefreshToken             string       `json:"RefreshToken"`
+	TargetedOrganization     Organization `json:"OrganizationFields"`
+	TargetedSpace            Space        `json:"SpaceFields"`
+	SkipSSLValidation        bool         `json:"SSLDisabled"`
+	MinCLIVersion            string       `json:"MinCLIVersion"`
+	MinRecommendedCLIVersion string       `json:"MinRecommendedCLIVersion"`
+}
+
+// ProfileInfo summarizes a profile for display.
+type ProfileInfo struct {
+	Name         string
+	Target       string
+	User         string
+	Organization string
+	Space        string
+
+	// Current is true for the profile used by this invocation of the CLI.
+	Current bool
+}
+
+// ProfileNotFoundError is returned when a profile does not exist.
+type ProfileNotFoundError struct {
+	Name string
+}
+
+func (e ProfileNotFoundError) Error() string {
+	return fmt.Sprintf("Profile '%s' does not exist.", e.Name)
+}
+
+// ProfileAlreadyExistsError is returned when creating or renaming to a
+// profile that already exists.
+type ProfileAlreadyExistsError struct {
+	Name string
+}
+
+func (e ProfileAlreadyExistsError) Error() string {
+	return fmt.Sprintf("Profile '%s' already exists.", e.Name)
+}
+
+// DefaultProfileError is returned when renaming or deleting the default
+// profile.
+type DefaultProfileError struct{}
+
+func (e DefaultProfileError) Error() string {
+	return fmt.Sprintf("The '%s' profile cannot be renamed or deleted.", DefaultProfileName)
+}
+
+// ProfileName returns the name of the profile used by this invocation of the
+// CLI. This is based off of:
+//   1. The '--profile' global flag
+//   2. The $CF_PROFILE environment variable if set
+//   3. The config file's active profile
+//   4. Defaults to DefaultProfileName
+func (config *Config) ProfileName() string {
+	if config.profileName == "" {
+		return DefaultProfileName
+	}
+	return config.profileName
+}
+
+// Profiles returns a summary of every profile, sorted by name with the
+// default profile first.
+func (config *Config) Profiles() []ProfileInfo {
+	profiles := map[string]Profile{
+		DefaultProfileName: config.defaultProfile(),
+	}
+	for name, profile := range config.ConfigFile.Profiles {
+		profiles[name] = profile
+	}
+	profiles[config.ProfileName()] = config.ConfigFile.profile()
+
+	names := make([]string, 0, len(profiles))
+	for name := range profiles {
+		if name != DefaultProfileName {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	names = append([]string{DefaultProfileName}, names...)
+
+	infos := make([]ProfileInfo, 0, len(names))
+	for _, name := range names {
+		profile := profiles[name]
+		user, _ := decodeUserFromJWT(profile.AccessToken)
+		infos = append(infos, ProfileInfo{
+			Name:         name,
+			Target:       profile.Target,
+			User:         user.Name,
+			Organization: profile.TargetedOrganization.Name,
+			Space:        profile.TargetedSpace.Name,
+			Current:      name == config.ProfileName(),
+		})
+	}
+	return infos
+}
+
+// CreateProfile adds an empty profile with the default UAA client settings.
+func (config *Config) CreateProfile(name string) error {
+	if config.profileExists(name) {
+		return ProfileAlreadyExistsError{Name: name}
+	}
+
+	if config.ConfigFile.Profiles == nil {
+		config.ConfigFile.Profiles = map[string]Profile{}
+	}
+	config.ConfigFile.Profiles[name] = Profile{
+		UAAOAuthClient:       DefaultUAAOAuthClient,
+		UAAOAuthClientSecret: DefaultUAAOAuthClientSecret,
+	}
+	return nil
+}
+
+// SwitchProfile makes the named profile the active profile, both for this
+// invocation and for the ones that follow.
+func (config *Config) SwitchProfile(name string) error {
+	err := config.useProfile(name)
+	if err != nil {
+		return err
+	}
+
+	if name == DefaultProfileName {
+		config.ConfigFile.ActiveProfile = ""
+	} else {
+		config.ConfigFile.ActiveProfile = name
+	}
+	return nil
+}
+
+// RenameProfile renames a profile. The default profile cannot be renamed.
+func (config *Config) RenameProfile(oldName string, newName string) error {
+	if oldName == DefaultProfileName {
+		return DefaultProfileError{}
+	}
+	if !config.profileExists(oldName) {
+		return ProfileNotFoundError{Name: oldName}
+	}
+	if config.profileExists(newName) {
+		return ProfileAlreadyExistsError{Name: newName}
+	}
+
+	config.ConfigFile.Profiles[newName] = config.ConfigFile.Profiles[oldName]
+	delete(config.ConfigFile.Profiles, oldName)
+
+	if config.ConfigFile.ActiveProfile == oldName {
+		config.ConfigFile.ActiveProfile = newName
+	}
+	if config.profileName == oldName {
+		config.profileName = newName
+	}
+	return nil
+}
+
+// DeleteProfile removes a profile. The default profile cannot be deleted. If
+// the deleted profile is active, the default profile becomes active.
+func (config *Config) DeleteProfile(name string) error {
+	if name == DefaultProfileName {
+		return DefaultProfileError{}
+	}
+	if !config.profileExists(name) {
+		return ProfileNotFoundError{Name: name}
+	}
+
+	if config.profileName == name {
+		err := config.useProfile(DefaultProfileName)
+		if err != nil {
+			return err
+		}
+	}
+	delete(config.ConfigFile.Profiles, name)
+
+	if config.ConfigFile.ActiveProfile == name {
+		config.ConfigFile.ActiveProfile = ""
+	}
+	return nil
+}
+
+// selectProfile loads the profile this invocation should use into the top
+// level of the ConfigFile.
+func (config *Config) selectProfile() error {
+	name := config.ConfigFile.ActiveProfile
+	if config.ENV.CFProfile != "" {
+		name = config.ENV.CFProfile
+	}
+	if config.Flags.Profile != "" {
+		name = config.Flags.Profile
+	}
+
+	if name == "" {
+		return nil
+	}
+	return config.useProfile(name)
+}
+
+// useProfile stores the settings of the profile currently in use and loads
+// the named profile's settings in their place. The settings of a named
+// profile are kept at the top level of the ConfigFile while it is in use, so
+// that they can be read and changed like those of the default profile.
+func (config *Config) useProfile(name string) error {
+	if !config.profileExists(name) {
+		return ProfileNotFoundError{Name: name}
+	}
+	if name == config.ProfileName() {
+		return nil
+	}
+
+	if config.profileName == "" {
+		config.defaultSettings = config.ConfigFile.profile()
+	} else {
+		config.ConfigFile.Profiles[config.profileName] = config.ConfigFile.profile()
+	}
+
+	if name == DefaultProfileName {
+		config.ConfigFile.setProfile(config.defaultSettings)
+		config.profileName = ""
+		config.defaultSettings = Profile{}
+	} else {
+		config.ConfigFile.setProfile(config.ConfigFile.Profiles[name])
+		config.profileName = name
+	}
+	return nil
+}
+
+// fileContents returns the ConfigFile as it is stored on disk, with the
+// default profile at the top level and every named profile in Profiles.
+func (config *Config) fileContents() CFConfig {
+	if config.profileName == "" {
+		return config.ConfigFile
+	}
+
+	file := config.ConfigFile
+	file.Profiles = map[string]Profile{}
+	for name, profile := range config.ConfigFile.Profiles {
+		file.Profiles[name] = profile
+	}
+	file.Profiles[config.profileName] = config.ConfigFile.profile()
+	file.setProfile(config.defaultSettings)
+	return file
+}
+
+func (config *Config) defaultProfile() Profile {
+	if config.profileName == "" {
+		return config.ConfigFile.profile()
+	}
+	return config.defaultSettings
+}
+
+func (config *Config) profileExists(name string) bool {
+	if name == DefaultProfileName || name == config.profileName {
+		return true
+	}
+	_, ok := config.ConfigFile.Profiles[name]
+	return ok
+}
+
+func (file CFConfig) profile() Profile {
+	return Profile{
+		Target:                   file.Target,
+		APIVersion:               file.APIVersion,
+		AuthorizationEndpoint:    file.AuthorizationEndpoint,
+		LoggregatorEndpoint:      file.LoggregatorEndpoint,
+		DopplerEndpoint:          file.DopplerEndpoint,
+		UAAEndpoint:              file.UAAEndpoint,
+		RoutingEndpoint:          file.RoutingEndpoint,
+		AccessToken:              file.AccessToken,
+		SSHOAuthClient:           file.SSHOAuthClient,
+		UAAOAuthClient:           file.UAAOAuthClient,
+		UAAOAuthClientSecret:     file.UAAOAuthClientSecret,
+		UAAGrantType:             file.UAAGrantType,
+		RefreshToken:             file.RefreshToken,
+		TargetedOrganization:     file.TargetedOrganization,
+		TargetedSpace:            file.TargetedSpace,
+		SkipSSLValidation:        file.SkipSSLValidation,
+		MinCLIVersion:            file.MinCLIVersion,
+		MinRecommendedCLIVersion: file.MinRecommendedCLIVersion,
+	}
+}
+
+func (file *CFConfig) setProfile(profile Profile) {
+	file.Target = profile.Target
+	file.APIVersion = profile.APIVersion
+	file.AuthorizationEndpoint = profile.AuthorizationEndpoint
+	file.LoggregatorEndpoint = profile.LoggregatorEndpoint
+	file.DopplerEndpoint = profile.DopplerEndpoint
+	file.UAAEndpoint = profile.UAAEndpoint
+	file.RoutingEndpoint = profile.RoutingEndpoint
+	file.AccessToken = profile.AccessToken
+	file.SSHOAuthClient = profile.SSHOAuthClient
+	file.UAAOAuthClient = profile.UAAOAuthClient
+	file.UAAOAuthClientSecret = profile.UAAOAuthClientSecret
+	file.UAAGrantType = profile.UAAGrantType
+	file.RefreshToken = profile.RefreshToken
+	file.TargetedOrganization = profile.TargetedOrganization
+	file.TargetedSpace = profile.TargetedSpace
+	file.SkipSSLValidation = profile.SkipSSLValidation
+	file.MinCLIVersion = profile.MinCLIVersion
+	file.MinRecommendedCLIVersion = profile.MinRecommendedCLIVersion
+}
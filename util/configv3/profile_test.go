@@ -0,0 +1,273 @@
+package configv3_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "code.cloudfoundry.org/cli/util/configv3"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Profiles", func() {
+	var (
+		homeDir string
+		config  *Config
+	)
+
+	BeforeEach(func() {
+		homeDir = setup()
+		setConfig(homeDir, `{
+			"ConfigVersion": 3,
+			"Target": "https://api.default.com",
+			"AccessToken": "default-token",
+			"UAAOAuthClient": "cf",
+			"ColorEnabled": "true",
+			"ActiveProfile": "staging",
+			"Profiles": {
+				"staging": {
+					"Target": "https://api.staging.com",
+					"AccessToken": "staging-token",
+					"UAAOAuthClient": "cf",
+					"SSLDisabled": true,
+					"OrganizationFields": {"GUID": "staging-org-guid", "Name": "staging-org"}
+				},
+				"production": {
+					"Target": "https://api.production.com",
+					"UAAOAuthClient": "some-client",
+					"UAAOAuthClientSecret": "some-secret"
+				}
+			}
+		}`)
+	})
+
+	AfterEach(func() {
+		teardown(homeDir)
+	})
+
+	readConfigFile := func() CFConfig {
+		file, err := ioutil.ReadFile(filepath.Join(homeDir, ".cf", "config.json"))
+		Expect(err).ToNot(HaveOccurred())
+
+		var writtenConfig CFConfig
+		err = json.Unmarshal(file, &writtenConfig)
+		Expect(err).ToNot(HaveOccurred())
+		return writtenConfig
+	}
+
+	Describe("LoadConfig", func() {
+		Context("when no profile is passed", func() {
+			It("uses the active profile", func() {
+				var err error
+				config, err = LoadConfig()
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(config.ProfileName()).To(Equal("staging"))
+				Expect(config.Target()).To(Equal("https://api.staging.com"))
+				Expect(config.AccessToken()).To(Equal("staging-token"))
+				Expect(config.SkipSSLValidation()).To(BeTrue())
+				Expect(config.TargetedOrganization().Name).To(Equal("staging-org"))
+				Expect(config.ColorEnabled()).To(Equal(ColorEnabled))
+			})
+		})
+
+		Context("when $CF_PROFILE is set", func() {
+			BeforeEach(func() {
+				os.Setenv("CF_PROFILE", "production")
+			})
+
+			AfterEach(func() {
+				os.Unsetenv("CF_PROFILE")
+			})
+
+			It("uses that profile", func() {
+				var err error
+				config, err = LoadConfig()
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(config.ProfileName()).To(Equal("production"))
+				Expect(config.Target()).To(Equal("https://api.production.com"))
+				Expect(config.UAAOAuthClient()).To(Equal("some-client"))
+				Expect(config.UAAOAuthClientSecret()).To(Equal("some-secret"))
+			})
+
+			Context("when the --profile flag is passed", func() {
+				It("uses the flag's profile", func() {
+					var err error
+					config, err = LoadConfig(FlagOverride{Profile: "default"})
+					Expect(err).ToNot(HaveOccurred())
+
+					Expect(config.ProfileName()).To(Equal(DefaultProfileName))
+					Expect(config.Target()).To(Equal("https://api.default.com"))
+				})
+			})
+		})
+
+		Context("when the profile does not exist", func() {
+			It("returns a ProfileNotFoundError", func() {
+				_, err := LoadConfig(FlagOverride{Profile: "some-profile"})
+				Expect(err).To(MatchError(ProfileNotFoundError{Name: "some-profile"}))
+			})
+		})
+	})
+
+	Describe("WriteConfig", func() {
+		BeforeEach(func() {
+			var err error
+			config, err = LoadConfig()
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("stores changes in the profile in use and leaves the default profile alone", func() {
+			config.SetAccessToken("new-staging-token")
+			Expect(WriteConfig(config)).To(Succeed())
+
+			writtenConfig := readConfigFile()
+			Expect(writtenConfig.Target).To(Equal("https://api.default.com"))
+			Expect(writtenConfig.AccessToken).To(Equal("default-token"))
+			Expect(writtenConfig.ActiveProfile).To(Equal("staging"))
+			Expect(writtenConfig.Profiles["staging"].AccessToken).To(Equal("new-staging-token"))
+			Expect(writtenConfig.Profiles["production"].Target).To(Equal("https://api.production.com"))
+		})
+	})
+
+	Describe("Profiles", func() {
+		BeforeEach(func() {
+			var err error
+			config, err = LoadConfig()
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("summarizes every profile with the default profile first", func() {
+			Expect(config.Profiles()).To(Equal([]ProfileInfo{
+				{Name: "default", Target: "https://api.default.com"},
+				{Name: "production", Target: "https://api.production.com"},
+				{Name: "staging", Target: "https://api.staging.com", Organization: "staging-org", Current: true},
+			}))
+		})
+	})
+
+	Describe("CreateProfile", func() {
+		BeforeEach(func() {
+			var err error
+			config, err = LoadConfig()
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("adds an empty profile with the default UAA client", func() {
+			Expect(config.CreateProfile("development")).To(Succeed())
+			Expect(WriteConfig(config)).To(Succeed())
+
+			Expect(readConfigFile().Profiles["development"]).To(Equal(Profile{
+				UAAOAuthClient:       DefaultUAAOAuthClient,
+				UAAOAuthClientSecret: DefaultUAAOAuthClientSecret,
+			}))
+			Expect(config.ProfileName()).To(Equal("staging"))
+		})
+
+		It("does not overwrite existing profiles", func() {
+			Expect(config.CreateProfile("production")).To(MatchError(ProfileAlreadyExistsError{Name: "production"}))
+			Expect(config.CreateProfile("default")).To(MatchError(ProfileAlreadyExistsError{Name: "default"}))
+		})
+	})
+
+	Describe("SwitchProfile", func() {
+		BeforeEach(func() {
+			var err error
+			config, err = LoadConfig()
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("makes the profile active and uses its settings", func() {
+			config.SetAccessToken("new-staging-token")
+			Expect(config.SwitchProfile("production")).To(Succeed())
+
+			Expect(config.ProfileName()).To(Equal("production"))
+			Expect(config.Target()).To(Equal("https://api.production.com"))
+
+			Expect(WriteConfig(config)).To(Succeed())
+			writtenConfig := readConfigFile()
+			Expect(writtenConfig.ActiveProfile).To(Equal("production"))
+			Expect(writtenConfig.Profiles["staging"].AccessToken).To(Equal("new-staging-token"))
+			Expect(writtenConfig.Target).To(Equal("https://api.default.com"))
+		})
+
+		It("can switch back to the default profile", func() {
+			Expect(config.SwitchProfile("default")).To(Succeed())
+			Expect(config.Target()).To(Equal("https://api.default.com"))
+
+			Expect(WriteConfig(config)).To(Succeed())
+			writtenConfig := readConfigFile()
+			Expect(writtenConfig.ActiveProfile).To(BeEmpty())
+			Expect(writtenConfig.Target).To(Equal("https://api.default.com"))
+			Expect(writtenConfig.Profiles["staging"].Target).To(Equal("https://api.staging.com"))
+		})
+
+		It("returns a ProfileNotFoundError for unknown profiles", func() {
+			Expect(config.SwitchProfile("some-profile")).To(MatchError(ProfileNotFoundError{Name: "some-profile"}))
+			Expect(config.ProfileName()).To(Equal("staging"))
+		})
+	})
+
+	Describe("RenameProfile", func() {
+		BeforeEach(func() {
+			var err error
+			config, err = LoadConfig()
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("renames the profile and keeps it active", func() {
+			config.SetAccessToken("new-staging-token")
+			Expect(config.RenameProfile("staging", "qa")).To(Succeed())
+			Expect(config.ProfileName()).To(Equal("qa"))
+
+			Expect(WriteConfig(config)).To(Succeed())
+			writtenConfig := readConfigFile()
+			Expect(writtenConfig.ActiveProfile).To(Equal("qa"))
+			Expect(writtenConfig.Profiles).ToNot(HaveKey("staging"))
+			Expect(writtenConfig.Profiles["qa"].AccessToken).To(Equal("new-staging-token"))
+		})
+
+		It("returns errors for invalid renames", func() {
+			Expect(config.RenameProfile("default", "qa")).To(MatchError(DefaultProfileError{}))
+			Expect(config.RenameProfile("some-profile", "qa")).To(MatchError(ProfileNotFoundError{Name: "some-profile"}))
+			Expect(config.RenameProfile("staging", "production")).To(MatchError(ProfileAlreadyExistsError{Name: "production"}))
+		})
+	})
+
+	Describe("DeleteProfile", func() {
+		BeforeEach(func() {
+			var err error
+			config, err = LoadConfig()
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("removes the profile", func() {
+			Expect(config.DeleteProfile("production")).To(Succeed())
+			Expect(WriteConfig(config)).To(Succeed())
+			Expect(readConfigFile().Profiles).ToNot(HaveKey("production"))
+		})
+
+		Context("when the profile is active", func() {
+			It("makes the default profile active", func() {
+				Expect(config.DeleteProfile("staging")).To(Succeed())
+				Expect(config.ProfileName()).To(Equal(DefaultProfileName))
+				Expect(config.Target()).To(Equal("https://api.default.com"))
+
+				Expect(WriteConfig(config)).To(Succeed())
+				writtenConfig := readConfigFile()
+				Expect(writtenConfig.ActiveProfile).To(BeEmpty())
+				Expect(writtenConfig.Profiles).ToNot(HaveKey("staging"))
+				Expect(writtenConfig.Target).To(Equal("https://api.default.com"))
+			})
+		})
+
+		It("returns errors for invalid deletes", func() {
+			Expect(config.DeleteProfile("default")).To(MatchError(DefaultProfileError{}))
+			Expect(config.DeleteProfile("some-profile")).To(MatchError(ProfileNotFoundError{Name: "some-profile"}))
+		})
+	})
+})
@@ -24,7 +24,15 @@ func decodeUserFromJWT(accessToken string) (User, error) {
 	}
 
 	claims := token.Claims()
+
+	// Tokens issued with the client_credentials grant do not have a user, so
+	// the client is used instead.
+	name, ok := claims.Get("user_name").(string)
+	if !ok {
+		name, _ = claims.Get("client_id").(string)
+	}
+
 	return User{
-		Name: claims.Get("user_name").(string),
+		Name: name,
 	}, nil
 }
@@ -57,6 +57,12 @@ func (d *downloader) DownloadFile(url string) (int64, string, error) {
 			d.filename = getFilenameFromURL(url)
 		}
 
+		// the name comes from the server, so keep it inside saveDir
+		d.filename = filepath.Base(d.filename)
+		if d.filename == "." || d.filename == ".." || d.filename == string(filepath.Separator) {
+			d.filename = "download"
+		}
+
 		f, err := os.Create(filepath.Join(d.saveDir, d.filename))
 		if err != nil {
 			return 0, "", err
@@ -0,0 +1,213 @@
+// Package responsecache stores Cloud Controller GET responses on disk so that
+// they can be revalidated with conditional requests instead of being fetched
+// again.
+package responsecache
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// DefaultTTL is how long the root and info documents are served from the
+// cache without asking the Cloud Controller.
+const DefaultTTL = 5 * time.Minute
+
+// ttlPaths are the documents that rarely change and do not support
+// conditional requests, so they are cached for a fixed time instead.
+var ttlPaths = []string{"/", "/v2/info", "/v3"}
+
+// Entry is a cached response.
+type Entry struct {
+	URL          string      `json:"url"`
+	ETag         string      `json:"etag,omitempty"`
+	LastModified string      `json:"last_modified,omitempty"`
+	Header       http.Header `json:"header"`
+	Body         []byte      `json:"body"`
+	StoredAt     time.Time   `json:"stored_at"`
+}
+
+// SetConditions adds the headers that ask the server to only send the
+// response when it differs from the entry.
+func (entry Entry) SetConditions(header http.Header) {
+	if entry.ETag != "" {
+		header.Set("If-None-Match", entry.ETag)
+	}
+	if entry.LastModified != "" {
+		header.Set("If-Modified-Since", entry.LastModified)
+	}
+}
+
+// Cache is a directory of cached responses.
+type Cache struct {
+	dir string
+	ttl time.Duration
+}
+
+// New returns a Cache that stores responses in dir. Responses for the root
+// and info documents are reused for ttl without revalidation.
+func New(dir string, ttl time.Duration) *Cache {
+	return &Cache{
+		dir: dir,
+		ttl: ttl,
+	}
+}
+
+// Dir returns the directory in cacheDir that responses from target are
+// cached in for user. Every target and user combination has its own
+// directory.
+func Dir(cacheDir string, target string, user string) string {
+	sum := sha1.Sum([]byte(target + "\n" + user))
+	return filepath.Join(cacheDir, hex.EncodeToString(sum[:]))
+}
+
+// Load returns the cached response for requestURL, and whether it can be used
+// without revalidating it.
+func (cache *Cache) Load(requestURL *url.URL) (entry Entry, found bool, fresh bool) {
+	raw, err := ioutil.ReadFile(cache.entryPath(requestURL.String()))
+	if err != nil {
+		return Entry{}, false, false
+	}
+
+	if err := json.Unmarshal(raw, &entry); err != nil || entry.URL != requestURL.String() {
+		return Entry{}, false, false
+	}
+
+	fresh = isTTLPath(requestURL) && time.Since(entry.StoredAt) < cache.ttl
+	return entry, true, fresh
+}
+
+// Refresh records that the server confirmed the entry is still current.
+func (cache *Cache) Refresh(entry Entry) {
+	entry.StoredAt = time.Now()
+	cache.write(entry)
+}
+
+// Store caches the response to a GET of requestURL when it can be
+// revalidated or is one of the info documents. Warnings are not stored, as
+// they were already shown when the response was first received.
+func (cache *Cache) Store(requestURL *url.URL, response *http.Response, body []byte) {
+	if !isCacheable(requestURL, response) {
+		return
+	}
+
+	header := http.Header{}
+	for key, values := range response.Header {
+		if key != http.CanonicalHeaderKey("X-Cf-Warnings") {
+			header[key] = values
+		}
+	}
+
+	cache.write(Entry{
+		URL:          requestURL.String(),
+		ETag:         response.Header.Get("ETag"),
+		LastModified: response.Header.Get("Last-Modified"),
+		Header:       header,
+		Body:         body,
+		StoredAt:     time.Now(),
+	})
+}
+
+// Invalidate removes the cached responses that a request to modify
+// requestURL may have made stale. As a change to one resource can show up in
+// many others (a new route is listed in the app's routes and the space
+// summary), every response from the same server is removed except the info
+// documents.
+func (cache *Cache) Invalidate(requestURL *url.URL) {
+	files, err := ioutil.ReadDir(cache.dir)
+	if err != nil {
+		return
+	}
+
+	for _, file := range files {
+		if !strings.HasSuffix(file.Name(), ".json") {
+			continue
+		}
+
+		entryPath := filepath.Join(cache.dir, file.Name())
+		raw, err := ioutil.ReadFile(entryPath)
+		if err != nil {
+			continue
+		}
+
+		var entry Entry
+		if err := json.Unmarshal(raw, &entry); err != nil {
+			os.Remove(entryPath)
+			continue
+		}
+
+		entryURL, err := url.Parse(entry.URL)
+		if err != nil || entryURL.Host != requestURL.Host || isTTLPath(entryURL) {
+			continue
+		}
+		os.Remove(entryPath)
+	}
+}
+
+// write stores the entry on disk. Failing to write to the cache does not fail
+// the request, so errors are ignored.
+func (cache *Cache) write(entry Entry) {
+	raw, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+
+	if err := os.MkdirAll(cache.dir, 0700); err != nil {
+		return
+	}
+
+	tempFile, err := ioutil.TempFile(cache.dir, "entry")
+	if err != nil {
+		return
+	}
+	_, err = tempFile.Write(raw)
+	tempFile.Close()
+	if err == nil {
+		err = os.Rename(tempFile.Name(), cache.entryPath(entry.URL))
+	}
+	if err != nil {
+		os.Remove(tempFile.Name())
+	}
+}
+
+func (cache *Cache) entryPath(rawURL string) string {
+	sum := sha1.Sum([]byte(rawURL))
+	return filepath.Join(cache.dir, hex.EncodeToString(sum[:])+".json")
+}
+
+func isCacheable(requestURL *url.URL, response *http.Response) bool {
+	if response.StatusCode != http.StatusOK {
+		return false
+	}
+	if strings.Contains(response.Header.Get("Cache-Control"), "no-store") {
+		return false
+	}
+
+	return isTTLPath(requestURL) ||
+		response.Header.Get("ETag") != "" ||
+		response.Header.Get("Last-Modified") != ""
+}
+
+func isTTLPath(requestURL *url.URL) bool {
+	requestPath := requestURL.Path
+	if requestPath == "" {
+		requestPath = "/"
+	}
+	if requestPath != "/" {
+		requestPath = strings.TrimSuffix(requestPath, "/")
+	}
+
+	for _, ttlPath := range ttlPaths {
+		if requestPath == ttlPath {
+			return true
+		}
+	}
+	return false
+}
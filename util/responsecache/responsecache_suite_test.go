@@ -0,0 +1,13 @@
+package responsecache_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestResponseCache(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Response Cache Suite")
+}
@@ -0,0 +1,89 @@
+package responsecache
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+)
+
+// Transport is an http.RoundTripper that serves GET requests from a Cache
+// when possible and stores cacheable responses. Other requests are passed
+// through and invalidate the cache.
+type Transport struct {
+	Cache     *Cache
+	Transport http.RoundTripper
+}
+
+// RoundTrip makes a single HTTP exchange, using the cache for GET requests.
+func (transport *Transport) RoundTrip(request *http.Request) (*http.Response, error) {
+	if request.Method != http.MethodGet {
+		response, err := transport.Transport.RoundTrip(request)
+		transport.Cache.Invalidate(request.URL)
+		return response, err
+	}
+
+	entry, found, fresh := transport.Cache.Load(request.URL)
+	if fresh {
+		return entryResponse(request, entry), nil
+	}
+
+	if found {
+		// A RoundTripper must not modify the request it was given.
+		conditionalRequest := new(http.Request)
+		*conditionalRequest = *request
+		conditionalRequest.Header = http.Header{}
+		for key, values := range request.Header {
+			conditionalRequest.Header[key] = values
+		}
+		entry.SetConditions(conditionalRequest.Header)
+		request = conditionalRequest
+	}
+
+	response, err := transport.Transport.RoundTrip(request)
+	if err != nil {
+		return nil, err
+	}
+
+	if found && response.StatusCode == http.StatusNotModified {
+		response.Body.Close()
+		transport.Cache.Refresh(entry)
+		cachedResponse := entryResponse(request, entry)
+		for _, warning := range response.Header[http.CanonicalHeaderKey("X-Cf-Warnings")] {
+			cachedResponse.Header.Add("X-Cf-Warnings", warning)
+		}
+		return cachedResponse, nil
+	}
+
+	if response.StatusCode != http.StatusOK {
+		return response, nil
+	}
+
+	body, err := ioutil.ReadAll(response.Body)
+	response.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	response.Body = ioutil.NopCloser(bytes.NewBuffer(body))
+
+	transport.Cache.Store(request.URL, response, body)
+	return response, nil
+}
+
+func entryResponse(request *http.Request, entry Entry) *http.Response {
+	header := http.Header{}
+	for key, values := range entry.Header {
+		header[key] = values
+	}
+
+	return &http.Response{
+		StatusCode:    http.StatusOK,
+		Status:        "200 OK",
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          ioutil.NopCloser(bytes.NewBuffer(entry.Body)),
+		ContentLength: int64(len(entry.Body)),
+		Request:       request,
+	}
+}
@@ -0,0 +1,109 @@
+package responsecache_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"os"
+	"time"
+
+	. "code.cloudfoundry.org/cli/util/responsecache"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Transport", func() {
+	var (
+		server   *Server
+		cacheDir string
+		client   *http.Client
+	)
+
+	get := func(path string) (*http.Response, string) {
+		response, err := client.Get(server.URL() + path)
+		Expect(err).ToNot(HaveOccurred())
+		defer response.Body.Close()
+		body, err := ioutil.ReadAll(response.Body)
+		Expect(err).ToNot(HaveOccurred())
+		return response, string(body)
+	}
+
+	BeforeEach(func() {
+		server = NewServer()
+
+		var err error
+		cacheDir, err = ioutil.TempDir("", "cf-response-cache")
+		Expect(err).ToNot(HaveOccurred())
+
+		client = &http.Client{Transport: &Transport{
+			Cache:     New(cacheDir, time.Minute),
+			Transport: http.DefaultTransport,
+		}}
+	})
+
+	AfterEach(func() {
+		server.Close()
+		os.RemoveAll(cacheDir)
+	})
+
+	It("revalidates cached responses and serves them when they have not changed", func() {
+		server.AppendHandlers(
+			RespondWith(http.StatusOK, `{"name":"some-app"}`, http.Header{
+				"Etag":          {`"some-etag"`},
+				"X-Cf-Warnings": {"first-warning"},
+			}),
+			CombineHandlers(
+				VerifyHeaderKV("If-None-Match", `"some-etag"`),
+				RespondWith(http.StatusNotModified, nil, http.Header{"X-Cf-Warnings": {"second-warning"}}),
+			),
+		)
+
+		_, body := get("/v2/apps/some-app-guid")
+		Expect(body).To(Equal(`{"name":"some-app"}`))
+
+		response, body := get("/v2/apps/some-app-guid")
+		Expect(response.StatusCode).To(Equal(http.StatusOK))
+		Expect(body).To(Equal(`{"name":"some-app"}`))
+		Expect(response.Header["X-Cf-Warnings"]).To(ConsistOf("second-warning"))
+		Expect(server.ReceivedRequests()).To(HaveLen(2))
+	})
+
+	It("serves the info document from the cache until the TTL expires", func() {
+		server.AppendHandlers(
+			RespondWith(http.StatusOK, `{"api_version":"2.69.0"}`),
+		)
+
+		get("/v2/info")
+		_, body := get("/v2/info")
+		Expect(body).To(Equal(`{"api_version":"2.69.0"}`))
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+	})
+
+	It("invalidates every cached response but the info documents on a write", func() {
+		server.AppendHandlers(
+			RespondWith(http.StatusOK, `{}`),
+			RespondWith(http.StatusOK, `{"resources":[]}`, http.Header{"Etag": {`"apps-etag"`}}),
+			VerifyRequest(http.MethodPost, "/v2/routes"),
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/v2/apps"),
+				func(_ http.ResponseWriter, request *http.Request) {
+					Expect(request.Header.Get("If-None-Match")).To(BeEmpty())
+				},
+				RespondWith(http.StatusOK, `{"resources":[{}]}`),
+			),
+		)
+
+		get("/v2/info")
+		get("/v2/apps")
+
+		response, err := client.Post(server.URL()+"/v2/routes", "application/json", nil)
+		Expect(err).ToNot(HaveOccurred())
+		response.Body.Close()
+
+		_, body := get("/v2/apps")
+		Expect(body).To(Equal(`{"resources":[{}]}`))
+
+		get("/v2/info")
+		Expect(server.ReceivedRequests()).To(HaveLen(4))
+	})
+})
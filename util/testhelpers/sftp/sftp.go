@@ -0,0 +1,35 @@
+package sftp
+
+import (
+	"io"
+
+	. "github.com/onsi/gomega"
+	"github.com/pkg/sftp"
+)
+
+type pipe struct {
+	io.Reader
+	io.WriteCloser
+}
+
+// NewClient returns an SFTP client connected through pipes to an SFTP server
+// that serves the local file system, and a function that closes it.
+func NewClient() (*sftp.Client, func()) {
+	clientReader, serverWriter := io.Pipe()
+	serverReader, clientWriter := io.Pipe()
+
+	server, err := sftp.NewServer(pipe{Reader: serverReader, WriteCloser: serverWriter})
+	Expect(err).NotTo(HaveOccurred())
+	// like sftp-server, hang up once the client has closed its end
+	go func() {
+		_ = server.Serve()
+		_ = server.Close()
+	}()
+
+	client, err := sftp.NewClientPipe(clientReader, clientWriter)
+	Expect(err).NotTo(HaveOccurred())
+
+	return client, func() {
+		_ = client.Close()
+	}
+}
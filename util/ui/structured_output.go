@@ -0,0 +1,199 @@
+package ui
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"gopkg.in/yaml.v2"
+)
+
+// structuredOutput collects everything a command displays while the UI is in
+// a structured output mode so that it can be written out as one document once
+// the command has finished.
+type structuredOutput struct {
+	Messages []structuredFields   `json:"messages,omitempty" yaml:"messages,omitempty"`
+	Fields   structuredFields     `json:"fields,omitempty" yaml:"fields,omitempty"`
+	Tables   [][]structuredFields `json:"tables,omitempty" yaml:"tables,omitempty"`
+	Error    string               `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// structuredField is a single key/value pair in the structured output.
+type structuredField struct {
+	Key   string
+	Value interface{}
+}
+
+// structuredFields is an ordered set of key/value pairs. The order in which
+// the fields were displayed is preserved in both JSON and YAML.
+type structuredFields []structuredField
+
+// MarshalJSON encodes the fields as a JSON object, preserving their order.
+func (fields structuredFields) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString("{")
+	for i, field := range fields {
+		if i > 0 {
+			buffer.WriteString(",")
+		}
+		key, err := json.Marshal(field.Key)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(field.Value)
+		if err != nil {
+			return nil, err
+		}
+		buffer.Write(key)
+		buffer.WriteString(":")
+		buffer.Write(value)
+	}
+	buffer.WriteString("}")
+	return buffer.Bytes(), nil
+}
+
+// MarshalYAML encodes the fields as a YAML mapping, preserving their order.
+func (fields structuredFields) MarshalYAML() (interface{}, error) {
+	mapSlice := yaml.MapSlice{}
+	for _, field := range fields {
+		mapSlice = append(mapSlice, yaml.MapItem{Key: field.Key, Value: field.Value})
+	}
+	return mapSlice, nil
+}
+
+// newTableRows converts a table whose first row is the header into one set of
+// fields per row, keyed by the header columns. A row takes its values from
+// the matching rawValues row when there is one. Otherwise each column has a
+// single type, so that a column only holds numbers when every one of its
+// cells is a number.
+func newTableRows(table [][]string, rawValues [][]interface{}) []structuredFields {
+	rows := []structuredFields{}
+	if len(table) == 0 {
+		return rows
+	}
+
+	header := table[0]
+	columnTypes := structuredColumnTypes(table[1:])
+	for r, row := range table[1:] {
+		fields := structuredFields{}
+		for i, cell := range row {
+			key := strconv.Itoa(i)
+			if i < len(header) {
+				key = structuredKey(header[i])
+			}
+
+			var value interface{}
+			if r < len(rawValues) && i < len(rawValues[r]) {
+				value = rawValues[r][i]
+			} else {
+				value = structuredValue(cell, columnTypes[i])
+			}
+			fields = append(fields, structuredField{Key: key, Value: value})
+		}
+		rows = append(rows, fields)
+	}
+	return rows
+}
+
+// newMessage converts displayed text and the values that were substituted
+// into it into a set of fields.
+func newMessage(text string, templateValues map[string]interface{}) structuredFields {
+	message := structuredFields{{Key: "text", Value: text}}
+
+	keys := make([]string, 0, len(templateValues))
+	for key := range templateValues {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		message = append(message, structuredField{Key: structuredKey(key), Value: templateValues[key]})
+	}
+	return message
+}
+
+// structuredKey converts display headers ("start time", "API endpoint:") and
+// template keys ("TaskSequenceID") into snake_case keys ("start_time",
+// "api_endpoint", "task_sequence_id").
+func structuredKey(name string) string {
+	runes := []rune(strings.TrimSpace(name))
+	var key []rune
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			if len(key) > 0 && key[len(key)-1] != '_' {
+				key = append(key, '_')
+			}
+			continue
+		}
+
+		if unicode.IsUpper(r) && i > 0 && len(key) > 0 && key[len(key)-1] != '_' {
+			previous := runes[i-1]
+			nextIsLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(previous) || unicode.IsDigit(previous) || (unicode.IsUpper(previous) && nextIsLower) {
+				key = append(key, '_')
+			}
+		}
+		key = append(key, unicode.ToLower(r))
+	}
+	return strings.Trim(string(key), "_")
+}
+
+// structuredType is the type of the values in a table column.
+type structuredType int
+
+const (
+	structuredUnknown structuredType = iota
+	structuredString
+	structuredInt
+	structuredBool
+)
+
+// structuredColumnTypes returns the type of each column of the given rows. A
+// column is an integer or boolean column when all of its cells are integers
+// or booleans, and a string column otherwise.
+func structuredColumnTypes(rows [][]string) []structuredType {
+	var columnTypes []structuredType
+	for _, row := range rows {
+		for len(columnTypes) < len(row) {
+			columnTypes = append(columnTypes, structuredUnknown)
+		}
+		for i, cell := range row {
+			cellType := structuredCellType(cell)
+			if columnTypes[i] == structuredUnknown {
+				columnTypes[i] = cellType
+			} else if columnTypes[i] != cellType {
+				columnTypes[i] = structuredString
+			}
+		}
+	}
+	return columnTypes
+}
+
+func structuredCellType(cell string) structuredType {
+	if cell == "true" || cell == "false" {
+		return structuredBool
+	}
+
+	if value, err := strconv.Atoi(cell); err == nil && strconv.Itoa(value) == cell {
+		return structuredInt
+	}
+
+	return structuredString
+}
+
+// structuredValue returns the value of a displayed table cell as the type of
+// its column.
+func structuredValue(cell string, columnType structuredType) interface{} {
+	switch columnType {
+	case structuredBool:
+		return cell == "true"
+	case structuredInt:
+		value, _ := strconv.Atoi(cell)
+		return value
+	default:
+		return cell
+	}
+}
@@ -0,0 +1,230 @@
+package ui_test
+
+import (
+	"errors"
+
+	"code.cloudfoundry.org/cli/util/configv3"
+	. "code.cloudfoundry.org/cli/util/ui"
+	"code.cloudfoundry.org/cli/util/ui/uifakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+)
+
+var _ = Describe("Structured Output", func() {
+	var (
+		ui         *UI
+		fakeConfig *uifakes.FakeConfig
+		out        *Buffer
+		errBuff    *Buffer
+	)
+
+	BeforeEach(func() {
+		fakeConfig = new(uifakes.FakeConfig)
+		fakeConfig.ColorEnabledReturns(configv3.ColorEnabled)
+		out = NewBuffer()
+		errBuff = NewBuffer()
+	})
+
+	JustBeforeEach(func() {
+		var err error
+		ui, err = NewUI(fakeConfig)
+		Expect(err).NotTo(HaveOccurred())
+
+		ui.Out = out
+		ui.Err = errBuff
+	})
+
+	Context("when the output format is JSON", func() {
+		BeforeEach(func() {
+			fakeConfig.OutputFormatReturns(configv3.OutputJSON)
+		})
+
+		It("does not display anything until flushed", func() {
+			ui.DisplayTextWithFlavor("Getting tasks for app {{.AppName}}...", map[string]interface{}{
+				"AppName": "some-app",
+			})
+			ui.DisplayOK()
+			ui.DisplayNewline()
+			Expect(out.Contents()).To(BeEmpty())
+		})
+
+		It("displays tables as typed rows keyed by the header", func() {
+			err := ui.DisplayTable("", [][]string{
+				{"id", "name", "start time", "ready"},
+				{"3", "task-3", "Mon, 01 Jan 0001", "true"},
+				{"4", "task-4", "Tue, 02 Jan 0001", "false"},
+			}, 3)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ui.Flush()).To(Succeed())
+
+			Expect(out.Contents()).To(MatchJSON(`{
+				"tables": [[
+					{"id": 3, "name": "task-3", "start_time": "Mon, 01 Jan 0001", "ready": true},
+					{"id": 4, "name": "task-4", "start_time": "Tue, 02 Jan 0001", "ready": false}
+				]]
+			}`))
+		})
+
+		It("displays every cell of a column as a string when any of them is not a number", func() {
+			err := ui.DisplayTable("", [][]string{
+				{"name", "instances", "memory"},
+				{"app-1", "1", "1"},
+				{"app-2", "03", "1G"},
+			}, 3)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ui.Flush()).To(Succeed())
+
+			Expect(out.Contents()).To(MatchJSON(`{
+				"tables": [[
+					{"name": "app-1", "instances": "1", "memory": "1"},
+					{"name": "app-2", "instances": "03", "memory": "1G"}
+				]]
+			}`))
+		})
+
+		It("displays table rows with their raw values when they are given", func() {
+			err := ui.DisplayTable("", [][]string{
+				{"id", "state", "command"},
+				{"3", "Running", "[hidden]"},
+			}, 3, []interface{}{3, "RUNNING", ""})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ui.Flush()).To(Succeed())
+
+			Expect(out.Contents()).To(MatchJSON(`{
+				"tables": [[
+					{"id": 3, "state": "RUNNING", "command": ""}
+				]]
+			}`))
+		})
+
+		It("displays key value tables and pairs as fields", func() {
+			err := ui.DisplayKeyValueTable("", [][]string{
+				{"API endpoint:", "https://api.example.com"},
+				{"API version:", "2.69.0"},
+			}, 3)
+			Expect(err).ToNot(HaveOccurred())
+			ui.DisplayPair("Current User", "{{.Name}}", map[string]interface{}{
+				"Name": "some-user",
+			})
+			Expect(ui.Flush()).To(Succeed())
+
+			Expect(out.Contents()).To(MatchJSON(`{
+				"fields": {
+					"api_endpoint": "https://api.example.com",
+					"api_version": "2.69.0",
+					"current_user": "some-user"
+				}
+			}`))
+		})
+
+		Context("when the locale is not English", func() {
+			BeforeEach(func() {
+				fakeConfig.LocaleReturns("fr-FR")
+			})
+
+			It("keys key value tables by the untranslated attributes and uses the raw values when they are given", func() {
+				err := ui.DisplayKeyValueTable("", [][]string{
+					{"API endpoint:", "https://api.example.com"},
+					{"scopes:", "cloud_controller.read, openid"},
+				}, 3, "https://api.example.com", []string{"cloud_controller.read", "openid"})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(ui.Flush()).To(Succeed())
+
+				Expect(out.Contents()).To(MatchJSON(`{
+					"fields": {
+						"api_endpoint": "https://api.example.com",
+						"scopes": ["cloud_controller.read", "openid"]
+					}
+				}`))
+			})
+		})
+
+		It("displays text with the substituted values as messages", func() {
+			ui.DisplayText("Task {{.TaskSequenceID}} has been submitted successfully for execution.", map[string]interface{}{
+				"TaskSequenceID": 3,
+			})
+			Expect(ui.Flush()).To(Succeed())
+
+			Expect(out.Contents()).To(MatchJSON(`{
+				"messages": [{
+					"text": "Task 3 has been submitted successfully for execution.",
+					"task_sequence_id": 3
+				}]
+			}`))
+		})
+
+		It("preserves the order of the fields", func() {
+			ui.DisplayPair("zebra", "1")
+			ui.DisplayPair("aardvark", "2")
+			Expect(ui.Flush()).To(Succeed())
+
+			Expect(string(out.Contents())).To(MatchRegexp(`(?s)"zebra".*"aardvark"`))
+		})
+
+		It("sends warnings to UI.Err and not the document", func() {
+			ui.DisplayWarnings([]string{"warning-1"})
+			ui.DisplayWarning("warning-2")
+			Expect(ui.Flush()).To(Succeed())
+
+			Expect(errBuff).To(Say("warning-1\nwarning-2"))
+			Expect(out.Contents()).To(MatchJSON(`{}`))
+		})
+
+		It("records errors in the document instead of displaying FAILED", func() {
+			ui.DisplayError(errors.New("I am an error"))
+			Expect(ui.Flush()).To(Succeed())
+
+			Expect(errBuff).To(Say("I am an error"))
+			Expect(out).ToNot(Say("FAILED"))
+			Expect(out.Contents()).To(MatchJSON(`{"error": "I am an error"}`))
+		})
+	})
+
+	Context("when the output format is YAML", func() {
+		BeforeEach(func() {
+			fakeConfig.OutputFormatReturns(configv3.OutputYAML)
+		})
+
+		It("displays the document as YAML", func() {
+			err := ui.DisplayTable("", [][]string{
+				{"id", "name"},
+				{"1", "some-task"},
+			}, 3)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ui.Flush()).To(Succeed())
+
+			Expect(string(out.Contents())).To(Equal("tables:\n- - id: 1\n    name: some-task\n"))
+		})
+	})
+
+	Context("when the output format is text", func() {
+		It("does not display anything when flushed", func() {
+			Expect(ui.Flush()).To(Succeed())
+			Expect(out.Contents()).To(BeEmpty())
+		})
+
+		It("displays key value tables as a table", func() {
+			err := ui.DisplayKeyValueTable("", [][]string{
+				{"API endpoint:", "https://api.example.com"},
+				{"API version:", "2.69.0"},
+			}, 3)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(Say("API endpoint:   https://api.example.com\nAPI version:    2.69.0\n"))
+		})
+
+		Context("when the locale is not English", func() {
+			BeforeEach(func() {
+				fakeConfig.LocaleReturns("fr-FR")
+			})
+
+			It("translates the attributes of key value tables and displays the formatted values", func() {
+				err := ui.DisplayKeyValueTable("", [][]string{
+					{"API endpoint:", "https://api.example.com"},
+				}, 3, "raw-value")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(out).To(Say("Noeud final d'API :   https://api.example.com\n"))
+			})
+		})
+	})
+})
@@ -6,6 +6,7 @@
 package ui
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
 	"os"
@@ -16,6 +17,7 @@ import (
 	"github.com/fatih/color"
 	"github.com/nicksnyder/go-i18n/i18n"
 	"github.com/vito/go-interact/interact"
+	"gopkg.in/yaml.v2"
 )
 
 const (
@@ -36,6 +38,8 @@ type Config interface {
 	ColorEnabled() configv3.ColorSetting
 	// Locale is the language to translate the output to
 	Locale() string
+	// OutputFormat is the format commands display their results in
+	OutputFormat() configv3.OutputFormat
 }
 
 //go:generate counterfeiter . TranslatableError
@@ -59,6 +63,9 @@ type UI struct {
 
 	colorEnabled configv3.ColorSetting
 	translate    i18n.TranslateFunc
+
+	outputFormat configv3.OutputFormat
+	structured   *structuredOutput
 }
 
 // NewUI will return a UI object where Out is set to STDOUT, In is set to
@@ -69,13 +76,21 @@ func NewUI(c Config) (*UI, error) {
 		return nil, err
 	}
 
-	return &UI{
+	ui := &UI{
 		In:           os.Stdin,
 		Out:          color.Output,
 		Err:          os.Stderr,
 		colorEnabled: c.ColorEnabled(),
 		translate:    translateFunc,
-	}, nil
+		outputFormat: c.OutputFormat(),
+	}
+
+	if ui.outputFormat == configv3.OutputJSON || ui.outputFormat == configv3.OutputYAML {
+		ui.colorEnabled = configv3.ColorDisabled
+		ui.structured = new(structuredOutput)
+	}
+
+	return ui, nil
 }
 
 // NewTestUI will return a UI object where Out, In, and Err are customizable,
@@ -97,32 +112,75 @@ func (ui *UI) TranslateText(template string, templateValues ...map[string]interf
 	return ui.translate(template, getFirstSet(templateValues))
 }
 
-// DisplayOK outputs a bold green translated "OK" to UI.Out.
+// DisplayOK outputs a bold green translated "OK" to UI.Out. Nothing is
+// displayed in structured output mode.
 func (ui *UI) DisplayOK() {
+	if ui.structured != nil {
+		return
+	}
 	fmt.Fprintf(ui.Out, "%s\n", ui.addFlavor(ui.TranslateText("OK"), green, true))
 }
 
-// DisplayNewline outputs a newline to UI.Out.
+// DisplayNewline outputs a newline to UI.Out. Nothing is displayed in
+// structured output mode.
 func (ui *UI) DisplayNewline() {
+	if ui.structured != nil {
+		return
+	}
 	fmt.Fprintf(ui.Out, "\n")
 }
 
 // DisplayBoolPrompt outputs the prompt and waits for user input. It only
 // allows for a boolean response. A default boolean response can be set with
-// defaultResponse.
+// defaultResponse. In structured output mode the prompt is written to UI.Err
+// so that it does not end up in the document.
 func (ui *UI) DisplayBoolPrompt(prompt string, defaultResponse bool) (bool, error) {
 	response := defaultResponse
+	err := ui.newInteraction(prompt).Resolve(&response)
+	return response, err
+}
+
+// DisplayTextPrompt outputs the translated prompt and waits for user input.
+// In structured output mode the prompt is written to UI.Err.
+func (ui *UI) DisplayTextPrompt(prompt string) (string, error) {
+	var response string
+	err := ui.newInteraction(ui.TranslateText(prompt)).Resolve(interact.Required(&response))
+	return response, err
+}
+
+// DisplayPasswordPrompt outputs the translated prompt and waits for user
+// input without echoing it. In structured output mode the prompt is written to
+// UI.Err.
+func (ui *UI) DisplayPasswordPrompt(prompt string) (string, error) {
+	var response interact.Password
+	err := ui.newInteraction(ui.TranslateText(prompt)).Resolve(interact.Required(&response))
+	return string(response), err
+}
+
+func (ui *UI) newInteraction(prompt string) interact.Interaction {
 	interactivePrompt := interact.NewInteraction(fmt.Sprintf("%s%s", prompt, ui.addFlavor(">>", cyan, true)))
 	interactivePrompt.Input = ui.In
 	interactivePrompt.Output = ui.Out
-	err := interactivePrompt.Resolve(&response)
-	return response, err
+	if ui.structured != nil {
+		interactivePrompt.Output = ui.Err
+	}
+	return interactivePrompt
 }
 
 // DisplayTable outputs a matrix of strings as a table to UI.Out. Prefix will
 // be prepended to each row and padding adds the specified number of spaces
 // between columns.
-func (ui *UI) DisplayTable(prefix string, table [][]string, padding int) error {
+//
+// In structured output mode the first row is used as the header, and every
+// other row is recorded as a set of fields keyed by the header columns. The
+// values of a row are taken from the matching rawValues row when one is
+// given, so that they are not formatted for display.
+func (ui *UI) DisplayTable(prefix string, table [][]string, padding int, rawValues ...[]interface{}) error {
+	if ui.structured != nil {
+		ui.structured.Tables = append(ui.structured.Tables, newTableRows(table, rawValues))
+		return nil
+	}
+
 	tw := tabwriter.NewWriter(ui.Out, 0, 1, padding, ' ', 0)
 	for _, row := range table {
 		fmt.Fprint(tw, prefix)
@@ -131,29 +189,91 @@ func (ui *UI) DisplayTable(prefix string, table [][]string, padding int) error {
 	return tw.Flush()
 }
 
+// DisplayKeyValueTable outputs a matrix of strings, where each row is an
+// attribute followed by its value, as a table to UI.Out. The attributes are
+// translated before being displayed.
+//
+// In structured output mode every row is recorded as a field keyed by the
+// untranslated attribute instead. The value of a row is taken from the
+// matching rawValues entry when one is given, so that it is not formatted for
+// display.
+func (ui *UI) DisplayKeyValueTable(prefix string, table [][]string, padding int, rawValues ...interface{}) error {
+	if ui.structured != nil {
+		for i, row := range table {
+			if len(row) < 2 {
+				continue
+			}
+
+			field := structuredField{Key: structuredKey(row[0])}
+			if i < len(rawValues) {
+				field.Value = rawValues[i]
+			} else {
+				field.Value = structuredValue(row[1], structuredCellType(row[1]))
+			}
+			ui.structured.Fields = append(ui.structured.Fields, field)
+		}
+		return nil
+	}
+
+	translatedTable := make([][]string, 0, len(table))
+	for _, row := range table {
+		translatedRow := append([]string{}, row...)
+		if len(translatedRow) > 0 {
+			translatedRow[0] = ui.TranslateText(translatedRow[0])
+		}
+		translatedTable = append(translatedTable, translatedRow)
+	}
+	return ui.DisplayTable(prefix, translatedTable, padding)
+}
+
 // DisplayText translates the template, substitutes in templateValues, and
 // outputs the result to ui.Out. Only the first map in templateValues is used.
+// In structured output mode the text and templateValues are recorded as a
+// message.
 func (ui *UI) DisplayText(template string, templateValues ...map[string]interface{}) {
+	if ui.structured != nil {
+		ui.structured.Messages = append(ui.structured.Messages, newMessage(ui.TranslateText(template, templateValues...), getFirstSet(templateValues)))
+		return
+	}
 	fmt.Fprintf(ui.Out, "%s\n", ui.TranslateText(template, templateValues...))
 }
 
 // DisplayPair translates the attribute, translates the template, substitutes
 // templateValues into the template, and outputs the pair to ui.Out. Only the
-// first map in templateValues is used.
+// first map in templateValues is used. In structured output mode the pair is
+// recorded as a field.
 func (ui *UI) DisplayPair(attribute string, template string, templateValues ...map[string]interface{}) {
+	if ui.structured != nil {
+		ui.structured.Fields = append(ui.structured.Fields, structuredField{
+			Key:   structuredKey(attribute),
+			Value: ui.TranslateText(template, templateValues...),
+		})
+		return
+	}
 	fmt.Fprintf(ui.Out, "%s: %s\n", ui.TranslateText(attribute), ui.TranslateText(template, templateValues...))
 }
 
 // DisplayHelpHeader translates the header, bolds and adds the default color to
-// the header, and outputs the result to ui.Out.
+// the header, and outputs the result to ui.Out. In structured output mode the
+// header is recorded as a message.
 func (ui *UI) DisplayHelpHeader(text string) {
+	if ui.structured != nil {
+		ui.DisplayText(text)
+		return
+	}
 	fmt.Fprintf(ui.Out, "%s\n", ui.addFlavor(ui.TranslateText(text), defaultFgColor, true))
 }
 
 // DisplayTextWithFlavor translates the template, bolds and adds cyan color to
 // templateValues, substitutes templateValues into the template, and outputs
-// the result to ui.Out. Only the first map in templateValues is used.
+// the result to ui.Out. Only the first map in templateValues is used. In
+// structured output mode it behaves like DisplayText.
 func (ui *UI) DisplayTextWithFlavor(template string, templateValues ...map[string]interface{}) {
+	if ui.structured != nil {
+		ui.DisplayText(template, templateValues...)
+		return
+	}
+
 	firstTemplateValues := getFirstSet(templateValues)
 	for key, value := range firstTemplateValues {
 		firstTemplateValues[key] = ui.addFlavor(fmt.Sprint(value), cyan, true)
@@ -167,7 +287,8 @@ func (ui *UI) DisplayWarning(template string, templateValues ...map[string]inter
 	fmt.Fprintf(ui.Err, "%s\n", ui.TranslateText(template, templateValues...))
 }
 
-// DisplayWarnings translates the warnings and outputs to ui.Err.
+// DisplayWarnings translates the warnings and outputs to ui.Err. Warnings are
+// never part of the structured output document.
 func (ui *UI) DisplayWarnings(warnings []string) {
 	for _, warning := range warnings {
 		fmt.Fprintf(ui.Err, "%s\n", ui.TranslateText(warning))
@@ -176,7 +297,8 @@ func (ui *UI) DisplayWarnings(warnings []string) {
 
 // DisplayError outputs the translated error message to ui.Err if the error
 // satisfies TranslatableError, otherwise it outputs the original error message
-// to ui.Err. It also outputs "FAILED" in bold red to ui.Out.
+// to ui.Err. It also outputs "FAILED" in bold red to ui.Out. In structured
+// output mode the error is recorded in the document instead of "FAILED".
 func (ui *UI) DisplayError(err error) {
 	var errMsg string
 	if translatableError, ok := err.(TranslatableError); ok {
@@ -185,9 +307,41 @@ func (ui *UI) DisplayError(err error) {
 		errMsg = err.Error()
 	}
 	fmt.Fprintf(ui.Err, "%s\n", errMsg)
+
+	if ui.structured != nil {
+		ui.structured.Error = errMsg
+		return
+	}
 	fmt.Fprintf(ui.Out, "%s\n", ui.addFlavor(ui.TranslateText("FAILED"), red, true))
 }
 
+// Flush writes everything recorded in structured output mode to ui.Out as a
+// single JSON or YAML document. It does nothing in text mode.
+func (ui *UI) Flush() error {
+	if ui.structured == nil {
+		return nil
+	}
+
+	var (
+		document []byte
+		err      error
+	)
+	switch ui.outputFormat {
+	case configv3.OutputJSON:
+		document, err = json.MarshalIndent(ui.structured, "", "  ")
+		document = append(document, '\n')
+	case configv3.OutputYAML:
+		document, err = yaml.Marshal(ui.structured)
+	}
+	if err != nil {
+		return err
+	}
+
+	ui.structured = new(structuredOutput)
+	_, err = ui.Out.Write(document)
+	return err
+}
+
 // addFlavor adds the provided text color and bold style to the text.
 func (ui *UI) addFlavor(text string, textColor color.Attribute, isBold bool) string {
 	colorPrinter := color.New(textColor)
@@ -89,6 +89,34 @@ var _ = Describe("UI", func() {
 		})
 	})
 
+	Describe("DisplayTextPrompt", func() {
+		BeforeEach(func() {
+			ui.In = NewBuffer()
+			ui.In.(*Buffer).Write([]byte("some-input\n"))
+		})
+
+		It("displays the prompt and returns the input", func() {
+			response, err := ui.DisplayTextPrompt("some-prompt")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(response).To(Equal("some-input"))
+			Expect(ui.Out).To(Say("some-prompt\x1b\\[36;1m>>\x1b\\[0m"))
+		})
+	})
+
+	Describe("DisplayPasswordPrompt", func() {
+		BeforeEach(func() {
+			ui.In = NewBuffer()
+			ui.In.(*Buffer).Write([]byte("some-password\n"))
+		})
+
+		It("displays the prompt and returns the input", func() {
+			response, err := ui.DisplayPasswordPrompt("some-prompt")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(response).To(Equal("some-password"))
+			Expect(ui.Out).To(Say("some-prompt\x1b\\[36;1m>>\x1b\\[0m"))
+		})
+	})
+
 	Describe("DisplayBoolPrompt", func() {
 		var inBuffer *Buffer
 